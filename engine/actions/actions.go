@@ -10,36 +10,210 @@ type Action interface {
 	IsEnd() bool
 }
 
+// CancellableAction est une action qui doit être prévenue de son annulation (pour libérer ses ressources)
+type CancellableAction interface {
+	Action
+	Cancel()
+}
+
+// cancelAction annule une action si elle peut l'être
+func cancelAction(action Action) {
+	if cancellable, ok := action.(CancellableAction); ok {
+		cancellable.Cancel()
+	}
+}
+
+// ActionHandle permet de piloter une action ajoutée au moteur d'actions (pause, reprise, annulation, vitesse)
+type ActionHandle struct {
+	action Action
+	// tags permettent d'identifier un groupe d'actions (nom, cible de l'action, ...)
+	tags []any
+	// speed est la vitesse d'exécution de l'action (1 = vitesse normale)
+	speed     float64
+	paused    bool
+	cancelled bool
+	// remainderInMs est la partie non entière du temps écoulé mis à l'échelle
+	remainderInMs float64
+}
+
+// Action retourne l'action pilotée
+func (handle *ActionHandle) Action() Action {
+	return handle.action
+}
+
+// Tags retourne les tags de l'action
+func (handle *ActionHandle) Tags() []any {
+	return handle.tags
+}
+
+// HasTag indique si l'action porte le tag indiqué
+func (handle *ActionHandle) HasTag(tag any) bool {
+	return slices.Contains(handle.tags, tag)
+}
+
+func (handle *ActionHandle) Pause() {
+	handle.paused = true
+}
+
+func (handle *ActionHandle) Resume() {
+	handle.paused = false
+}
+
+func (handle *ActionHandle) IsPaused() bool {
+	return handle.paused
+}
+
+// Cancel annule l'action (elle sera retirée du moteur sans être exécutée à nouveau)
+func (handle *ActionHandle) Cancel() {
+	if !handle.cancelled {
+		handle.cancelled = true
+		if !handle.action.IsEnd() {
+			cancelAction(handle.action)
+		}
+	}
+}
+
+func (handle *ActionHandle) IsCancelled() bool {
+	return handle.cancelled
+}
+
+// IsEnd indique si l'action est terminée ou annulée
+func (handle *ActionHandle) IsEnd() bool {
+	return handle.cancelled || handle.action.IsEnd()
+}
+
+func (handle *ActionHandle) Speed() float64 {
+	return handle.speed
+}
+
+// SetSpeed modifie la vitesse d'exécution de l'action (1 = vitesse normale)
+func (handle *ActionHandle) SetSpeed(speed float64) {
+	handle.speed = max(speed, 0)
+}
+
+// ActionEngine exécute les actions à chaque traitement
 type ActionEngine struct {
-	actions []Action
+	handles []*ActionHandle
+	// speed est la vitesse d'exécution de toutes les actions (1 = vitesse normale)
+	speed float64
+	// speedDefined indique si la vitesse a été positionnée (vitesse normale sinon)
+	speedDefined bool
 }
 
+// Actions retourne les actions en cours
 func (engine *ActionEngine) Actions() []Action {
-	if engine.actions == nil {
-		engine.actions = make([]Action, 0)
+	actions := make([]Action, 0, len(engine.handles))
+	for _, handle := range engine.handles {
+		actions = append(actions, handle.action)
 	}
-	return engine.actions
+	return actions
 }
 
-func (engine *ActionEngine) Add(action Action) {
-	engine.actions = append(engine.Actions(), action)
+// Handles retourne les poignées des actions en cours
+func (engine *ActionEngine) Handles() []*ActionHandle {
+	return engine.handles
 }
 
+// Add ajoute une action et retourne sa poignée
+func (engine *ActionEngine) Add(action Action) *ActionHandle {
+	return engine.AddWithTags(action)
+}
+
+// AddWithTags ajoute une action avec des tags (valeurs comparables : nom, pointeur vers la cible, ...)
+func (engine *ActionEngine) AddWithTags(action Action, tags ...any) *ActionHandle {
+	handle := &ActionHandle{
+		action: action,
+		tags:   tags,
+		speed:  1,
+	}
+	engine.handles = append(engine.handles, handle)
+	return handle
+}
+
+// Clear annule toutes les actions
 func (engine *ActionEngine) Clear() {
-	engine.actions = make([]Action, 0)
+	for _, handle := range engine.handles {
+		handle.Cancel()
+	}
+	engine.handles = make([]*ActionHandle, 0)
+}
+
+// Cancel annule l'action indiquée
+func (engine *ActionEngine) Cancel(action Action) {
+	for _, handle := range engine.handles {
+		if handle.action == action {
+			handle.Cancel()
+		}
+	}
+}
+
+// CancelTag annule toutes les actions portant le tag indiqué
+func (engine *ActionEngine) CancelTag(tag any) {
+	for _, handle := range engine.handles {
+		if handle.HasTag(tag) {
+			handle.Cancel()
+		}
+	}
+}
+
+// PauseTag met en pause toutes les actions portant le tag indiqué
+func (engine *ActionEngine) PauseTag(tag any) {
+	for _, handle := range engine.handles {
+		if handle.HasTag(tag) {
+			handle.Pause()
+		}
+	}
+}
+
+// ResumeTag reprend toutes les actions portant le tag indiqué
+func (engine *ActionEngine) ResumeTag(tag any) {
+	for _, handle := range engine.handles {
+		if handle.HasTag(tag) {
+			handle.Resume()
+		}
+	}
+}
+
+// Speed retourne la vitesse d'exécution de toutes les actions
+func (engine *ActionEngine) Speed() float64 {
+	if !engine.speedDefined {
+		return 1
+	}
+	return engine.speed
+}
+
+// SetSpeed modifie la vitesse d'exécution de toutes les actions (ralenti, accéléré, ...)
+func (engine *ActionEngine) SetSpeed(speed float64) {
+	engine.speed = max(speed, 0)
+	engine.speedDefined = true
 }
 
 func (engine *ActionEngine) Execute(timer *engine.Timer) {
-	// Traiter les actions
-	toRemove := make([]Action, 0)
-	for _, action := range engine.Actions() {
-		action.Execute(timer)
-		if action.IsEnd() { // Stocker les actions finies
-			toRemove = append(toRemove, action)
+	// Traiter les actions (les actions ajoutées pendant le traitement sont traitées au prochain passage)
+	handles := engine.handles
+	for _, handle := range handles {
+		if handle.paused || handle.IsEnd() {
+			continue
+		}
+		speed := handle.speed * engine.Speed()
+		if speed == 1 {
+			handle.action.Execute(timer)
+		} else {
+			scaledTimer := scaleTimer(timer, speed, &handle.remainderInMs)
+			handle.action.Execute(&scaledTimer)
 		}
 	}
-	// Enlever les actions finies
-	engine.actions = slices.DeleteFunc(engine.actions, func(elt Action) bool {
-		return slices.Contains(toRemove, elt)
+	// Enlever les actions finies ou annulées
+	engine.handles = slices.DeleteFunc(engine.handles, func(handle *ActionHandle) bool {
+		return handle.IsEnd()
 	})
 }
+
+// scaleTimer retourne un timer dont le temps écoulé est multiplié par la vitesse (la partie non entière est conservée pour le prochain passage)
+func scaleTimer(timer *engine.Timer, speed float64, remainderInMs *float64) engine.Timer {
+	scaledElapsed := float64(timer.ElapsedTime())*speed + *remainderInMs
+	elapsed := int64(scaledElapsed)
+	*remainderInMs = scaledElapsed - float64(elapsed)
+	previous := engine.BuildTimerAt(timer.PreviousTime())
+	return previous.NextTimerAt(timer.PreviousTime() + elapsed)
+}
@@ -0,0 +1,180 @@
+package actions
+
+import (
+	"fmt"
+	"ogl46/engine"
+	"slices"
+	"testing"
+)
+
+func TestActionEngine_CancelTag(t *testing.T) {
+	actionEngine := ActionEngine{}
+	target := &struct{ name string }{"target"}
+	actionEngine.AddWithTags(BuildWaitAction(1000), target)
+	other := actionEngine.Add(BuildWaitAction(1000))
+	timer := engine.BuildTimerAt(0)
+
+	actionEngine.CancelTag(target)
+	executeDuring(&actionEngine, &timer, 1, 10)
+	if len(actionEngine.Actions()) != 1 || actionEngine.Handles()[0] != other {
+		t.Fatalf("CancelTag() actions=%d, want only not tagged action", len(actionEngine.Actions()))
+	}
+}
+
+func TestActionEngine_PauseAndSpeed(t *testing.T) {
+	actionEngine := ActionEngine{}
+	value := float32(0)
+	handle := actionEngine.Add(BuildFloatTween(func() float32 { return value }, func(v float32) { value = v }, 100, 1000, Linear))
+	timer := engine.BuildTimerAt(0)
+
+	handle.Pause()
+	executeDuring(&actionEngine, &timer, 1, 100)
+	if value != 0 {
+		t.Fatalf("Execute() value=%v while paused, want 0", value)
+	}
+	handle.Resume()
+	handle.SetSpeed(0.5)
+	actionEngine.SetSpeed(0.5)
+	executeDuring(&actionEngine, &timer, 4, 100) // 4 x 100 ms x 0.25 = 100 ms
+	if value != 10 {
+		t.Fatalf("Execute() value=%v with speed 0.25, want 10", value)
+	}
+}
+
+func TestParallelAction(t *testing.T) {
+	all := BuildParallelAction(PARALLEL_ALL)
+	all.Add(BuildWaitAction(100))
+	all.Add(BuildWaitAction(200))
+	any := BuildParallelAction(PARALLEL_ANY)
+	any.Add(BuildWaitAction(100))
+	any.Add(BuildWaitAction(200))
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(all, &timer, 1, 150)
+	any.Execute(&timer)
+	if all.IsEnd() || !any.IsEnd() {
+		t.Fatalf("Execute() all.IsEnd()=%v / any.IsEnd()=%v, want false / true", all.IsEnd(), any.IsEnd())
+	}
+	executeDuring(all, &timer, 1, 100)
+	if !all.IsEnd() {
+		t.Fatalf("Execute() all.IsEnd()=false, want true")
+	}
+}
+
+func TestRepeatAction(t *testing.T) {
+	count := 0
+	action := BuildRepeatAction(func() Action { return BuildExecuteAction(func() { count++ }) }, 3)
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 10, 10)
+	if count != 3 || !action.IsEnd() {
+		t.Fatalf("Execute() count=%d / IsEnd()=%v, want count=3 / IsEnd()=true", count, action.IsEnd())
+	}
+}
+
+func TestWaitUntilAction(t *testing.T) {
+	ready := false
+	action := BuildWaitUntilAction(func() bool { return ready })
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 2, 10)
+	if action.IsEnd() {
+		t.Fatalf("IsEnd()=true, want false")
+	}
+	ready = true
+	executeDuring(action, &timer, 1, 10)
+	if !action.IsEnd() {
+		t.Fatalf("IsEnd()=false, want true")
+	}
+}
+
+func TestCoroutineAction(t *testing.T) {
+	steps := make([]string, 0)
+	value := float32(0)
+	action := BuildCoroutineAction(func(co *Coroutine) {
+		steps = append(steps, "start")
+		co.Wait(100)
+		steps = append(steps, "waited")
+		co.Await(BuildFloatTween(func() float32 { return value }, func(v float32) { value = v }, 10, 50, Linear))
+		steps = append(steps, "tweened")
+	})
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 1, 10)
+	if len(steps) != 1 {
+		t.Fatalf("Execute() steps=%v, want [start]", steps)
+	}
+	executeDuring(action, &timer, 10, 10)
+	if len(steps) != 2 || action.IsEnd() {
+		t.Fatalf("Execute() steps=%v / IsEnd()=%v, want [start waited] / IsEnd()=false", steps, action.IsEnd())
+	}
+	executeDuring(action, &timer, 5, 10)
+	if len(steps) != 3 || value != 10 || !action.IsEnd() {
+		t.Fatalf("Execute() steps=%v / value=%v / IsEnd()=%v, want [start waited tweened] / 10 / true", steps, value, action.IsEnd())
+	}
+}
+
+func TestCoroutineAction_Cancel(t *testing.T) {
+	released := false
+	actionEngine := ActionEngine{}
+	actionEngine.Add(BuildCoroutineAction(func(co *Coroutine) {
+		defer func() { released = true }()
+		for co.Yield() {
+		}
+	}))
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(&actionEngine, &timer, 3, 10)
+	actionEngine.Clear()
+	if !released {
+		t.Fatalf("Clear() coroutine is not released")
+	}
+}
+
+func TestCoroutineAction_CancelResumePoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		function func(co *Coroutine) bool
+	}{
+		{"yield", func(co *Coroutine) bool {
+			for co.Yield() {
+			}
+			return false
+		}},
+		{"wait", func(co *Coroutine) bool { return co.Wait(1000) }},
+		{"wait until", func(co *Coroutine) bool { return co.WaitUntil(func() bool { return false }) }},
+		{"await", func(co *Coroutine) bool { return co.Await(BuildWaitAction(1000)) }},
+	}
+	for _, test := range tests {
+		steps := make([]string, 0)
+		action := BuildCoroutineAction(func(co *Coroutine) {
+			steps = append(steps, "start")
+			if !test.function(co) {
+				steps = append(steps, fmt.Sprintf("cancelled=%v", co.Cancelled()))
+				return
+			}
+			steps = append(steps, "resumed")
+		})
+		timer := engine.BuildTimerAt(0)
+
+		executeDuring(action, &timer, 3, 10)
+		action.Cancel()
+		if !slices.Equal(steps, []string{"start", "cancelled=true"}) || !action.IsEnd() {
+			t.Fatalf("%s: Cancel() steps=%v / IsEnd()=%v, want [start cancelled=true] / true", test.name, steps, action.IsEnd())
+		}
+	}
+}
+
+func TestCoroutineAction_CancelBeforeStart(t *testing.T) {
+	started := false
+	action := BuildCoroutineAction(func(co *Coroutine) {
+		started = true
+	})
+	timer := engine.BuildTimerAt(0)
+
+	action.Cancel()
+	executeDuring(action, &timer, 1, 10)
+	if started || !action.IsEnd() {
+		t.Fatalf("Cancel() started=%v / IsEnd()=%v, want started=false / IsEnd()=true", started, action.IsEnd())
+	}
+}
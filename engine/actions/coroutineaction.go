@@ -0,0 +1,169 @@
+package actions
+
+import (
+	"ogl46/engine"
+)
+
+// Coroutine permet à une fonction de coroutine de rendre la main au moteur d'actions (points de reprise)
+//
+// Les points de reprise retournent faux quand la coroutine est annulée : la fonction de coroutine doit alors se terminer.
+type Coroutine struct {
+	// resume transmet le timer de la frame à la coroutine pour la reprendre
+	resume chan *engine.Timer
+	// yield indique au moteur que la coroutine a rendu la main (ou qu'elle est terminée)
+	yield chan struct{}
+	// cancel est fermé quand la coroutine est annulée
+	cancel chan struct{}
+	// timer est le timer de la frame en cours
+	timer *engine.Timer
+	// cancelled indique que la coroutine est annulée (vérifié à chaque point de reprise)
+	cancelled bool
+	// done indique que la fonction de coroutine est terminée
+	done bool
+	// panicValue est la valeur d'une "panic" de la fonction de coroutine (à propager au moteur)
+	panicValue any
+}
+
+// Timer retourne le timer de la frame en cours
+func (co *Coroutine) Timer() *engine.Timer {
+	return co.timer
+}
+
+// Cancelled indique si la coroutine est annulée
+func (co *Coroutine) Cancelled() bool {
+	return co.cancelled
+}
+
+// Yield rend la main au moteur jusqu'à la prochaine frame (faux si la coroutine est annulée)
+func (co *Coroutine) Yield() bool {
+	if co.cancelled {
+		return false
+	}
+	co.yield <- struct{}{}
+	return co.waitResume()
+}
+
+// waitResume attend la reprise de la coroutine par le moteur ou son annulation (faux si la coroutine est annulée)
+func (co *Coroutine) waitResume() bool {
+	select {
+	case timer := <-co.resume:
+		co.timer = timer
+		return true
+	case <-co.cancel:
+		co.cancelled = true
+		return false
+	}
+}
+
+// Wait attend la durée indiquée en millisecondes (faux si la coroutine est annulée)
+func (co *Coroutine) Wait(waitTimeInMs int64) bool {
+	for waitTimeInMs > 0 {
+		if !co.Yield() {
+			return false
+		}
+		waitTimeInMs -= co.timer.ElapsedTime()
+	}
+	return true
+}
+
+// WaitUntil attend que la condition soit vraie, vérifiée à chaque frame (faux si la coroutine est annulée)
+func (co *Coroutine) WaitUntil(predicate func() bool) bool {
+	for !predicate() {
+		if !co.Yield() {
+			return false
+		}
+	}
+	return true
+}
+
+// Await exécute une action à chaque frame jusqu'à sa fin (faux si la coroutine est annulée, l'action est alors annulée)
+func (co *Coroutine) Await(action Action) bool {
+	if action == nil {
+		return !co.cancelled
+	}
+	for {
+		action.Execute(co.timer)
+		if action.IsEnd() {
+			return true
+		}
+		if !co.Yield() {
+			cancelAction(action)
+			return false
+		}
+	}
+}
+
+// CoroutineAction exécute une fonction écrite de manière linéaire ("déplacer, attendre 2s, jouer un son, ...")
+// en la reprenant à chaque frame depuis son dernier point de reprise (Yield, Wait, WaitUntil, Await).
+// Une fonction annulée est reprise une dernière fois : ses points de reprise retournent faux et elle doit se terminer.
+//
+// La fonction est exécutée dans une goroutine, mais jamais en même temps que le moteur d'actions :
+// elle peut donc modifier l'état du jeu sans synchronisation. Une coroutine non terminée doit être annulée
+// (Cancel, ActionHandle.Cancel ou ActionEngine.Clear à la libération du stage) pour libérer sa goroutine.
+type CoroutineAction struct {
+	function  func(co *Coroutine)
+	coroutine *Coroutine
+	end       bool
+}
+
+func BuildCoroutineAction(function func(co *Coroutine)) *CoroutineAction {
+	return &CoroutineAction{
+		function: function,
+		end:      function == nil,
+	}
+}
+
+// start démarre la goroutine de la coroutine (en attente de la première reprise)
+func (action *CoroutineAction) start() {
+	co := &Coroutine{
+		resume: make(chan *engine.Timer),
+		yield:  make(chan struct{}),
+		cancel: make(chan struct{}),
+	}
+	action.coroutine = co
+	go func() {
+		defer func() {
+			co.panicValue = recover()
+			co.done = true
+			co.yield <- struct{}{}
+		}()
+		// Une coroutine annulée avant sa première reprise n'exécute pas sa fonction
+		if co.waitResume() {
+			action.function(co)
+		}
+	}()
+}
+
+func (action *CoroutineAction) Execute(timer *engine.Timer) {
+	if action.end {
+		return
+	}
+	if action.coroutine == nil {
+		action.start()
+	}
+	// Reprendre la coroutine et attendre qu'elle rende la main
+	action.coroutine.resume <- timer
+	<-action.coroutine.yield
+	if action.coroutine.done {
+		action.end = true
+		if action.coroutine.panicValue != nil {
+			panic(action.coroutine.panicValue)
+		}
+	}
+}
+
+// Cancel reprend la coroutine une dernière fois (ses points de reprise retournent faux) et attend la fin de sa goroutine
+func (action *CoroutineAction) Cancel() {
+	if action.end {
+		return
+	}
+	action.end = true
+	if action.coroutine != nil && !action.coroutine.done {
+		close(action.coroutine.cancel)
+		<-action.coroutine.yield
+	}
+}
+
+func (action *CoroutineAction) IsEnd() bool {
+	return action.end
+}
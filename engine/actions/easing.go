@@ -0,0 +1,176 @@
+package actions
+
+import (
+	"math"
+)
+
+// EasingFunc transforme une progression linéaire (entre 0 et 1) en progression "adoucie".
+type EasingFunc func(progress float64) float64
+
+// Constantes des fonctions "back" et "elastic"
+const (
+	easingBackOvershoot   = 1.70158
+	easingBackInOutFactor = easingBackOvershoot * 1.525
+	easingElasticPeriod   = 2 * math.Pi / 3
+	easingElasticInOut    = 2 * math.Pi / 4.5
+)
+
+// Linear ne modifie pas la progression
+func Linear(progress float64) float64 {
+	return progress
+}
+
+func EaseInQuad(progress float64) float64 {
+	return progress * progress
+}
+
+func EaseOutQuad(progress float64) float64 {
+	return 1 - (1-progress)*(1-progress)
+}
+
+func EaseInOutQuad(progress float64) float64 {
+	if progress < 0.5 {
+		return 2 * progress * progress
+	}
+	return 1 - math.Pow(-2*progress+2, 2)/2
+}
+
+func EaseInCubic(progress float64) float64 {
+	return progress * progress * progress
+}
+
+func EaseOutCubic(progress float64) float64 {
+	return 1 - math.Pow(1-progress, 3)
+}
+
+func EaseInOutCubic(progress float64) float64 {
+	if progress < 0.5 {
+		return 4 * progress * progress * progress
+	}
+	return 1 - math.Pow(-2*progress+2, 3)/2
+}
+
+func EaseInSine(progress float64) float64 {
+	return 1 - math.Cos(progress*math.Pi/2)
+}
+
+func EaseOutSine(progress float64) float64 {
+	return math.Sin(progress * math.Pi / 2)
+}
+
+func EaseInOutSine(progress float64) float64 {
+	return -(math.Cos(math.Pi*progress) - 1) / 2
+}
+
+func EaseInBack(progress float64) float64 {
+	return (easingBackOvershoot+1)*progress*progress*progress - easingBackOvershoot*progress*progress
+}
+
+func EaseOutBack(progress float64) float64 {
+	return 1 + (easingBackOvershoot+1)*math.Pow(progress-1, 3) + easingBackOvershoot*math.Pow(progress-1, 2)
+}
+
+func EaseInOutBack(progress float64) float64 {
+	if progress < 0.5 {
+		return (math.Pow(2*progress, 2) * ((easingBackInOutFactor+1)*2*progress - easingBackInOutFactor)) / 2
+	}
+	return (math.Pow(2*progress-2, 2)*((easingBackInOutFactor+1)*(progress*2-2)+easingBackInOutFactor) + 2) / 2
+}
+
+func EaseInElastic(progress float64) float64 {
+	if progress <= 0 || progress >= 1 {
+		return progress
+	}
+	return -math.Pow(2, 10*progress-10) * math.Sin((progress*10-10.75)*easingElasticPeriod)
+}
+
+func EaseOutElastic(progress float64) float64 {
+	if progress <= 0 || progress >= 1 {
+		return progress
+	}
+	return math.Pow(2, -10*progress)*math.Sin((progress*10-0.75)*easingElasticPeriod) + 1
+}
+
+func EaseInOutElastic(progress float64) float64 {
+	if progress <= 0 || progress >= 1 {
+		return progress
+	}
+	if progress < 0.5 {
+		return -(math.Pow(2, 20*progress-10) * math.Sin((20*progress-11.125)*easingElasticInOut)) / 2
+	}
+	return (math.Pow(2, -20*progress+10)*math.Sin((20*progress-11.125)*easingElasticInOut))/2 + 1
+}
+
+func EaseInBounce(progress float64) float64 {
+	return 1 - EaseOutBounce(1-progress)
+}
+
+func EaseOutBounce(progress float64) float64 {
+	const n1, d1 = 7.5625, 2.75
+	switch {
+	case progress < 1/d1:
+		return n1 * progress * progress
+	case progress < 2/d1:
+		progress -= 1.5 / d1
+		return n1*progress*progress + 0.75
+	case progress < 2.5/d1:
+		progress -= 2.25 / d1
+		return n1*progress*progress + 0.9375
+	default:
+		progress -= 2.625 / d1
+		return n1*progress*progress + 0.984375
+	}
+}
+
+func EaseInOutBounce(progress float64) float64 {
+	if progress < 0.5 {
+		return (1 - EaseOutBounce(1-2*progress)) / 2
+	}
+	return (1 + EaseOutBounce(2*progress-1)) / 2
+}
+
+// CubicBezier retourne une fonction d'adoucissement définie par une courbe de Bézier cubique
+// passant par (0, 0) et (1, 1) avec les points de contrôle (x1, y1) et (x2, y2) (comme "cubic-bezier" en CSS).
+func CubicBezier(x1, y1, x2, y2 float64) EasingFunc {
+	x1 = min(max(x1, 0), 1)
+	x2 = min(max(x2, 0), 1)
+	bezier := func(t, p1, p2 float64) float64 {
+		return 3*(1-t)*(1-t)*t*p1 + 3*(1-t)*t*t*p2 + t*t*t
+	}
+	bezierDerivative := func(t, p1, p2 float64) float64 {
+		return 3*(1-t)*(1-t)*p1 + 6*(1-t)*t*(p2-p1) + 3*t*t*(1-p2)
+	}
+	return func(progress float64) float64 {
+		if progress <= 0 || progress >= 1 {
+			return progress
+		}
+		// Trouver t tel que x(t) = progress (Newton puis dichotomie si besoin)
+		t := progress
+		for iter := 0; iter < 8; iter++ {
+			delta := bezier(t, x1, x2) - progress
+			if math.Abs(delta) < 1e-7 {
+				return bezier(t, y1, y2)
+			}
+			derivative := bezierDerivative(t, x1, x2)
+			if math.Abs(derivative) < 1e-6 {
+				break
+			}
+			t -= delta / derivative
+		}
+		low, high := 0., 1.
+		t = progress
+		for iter := 0; iter < 50; iter++ {
+			x := bezier(t, x1, x2)
+			if math.Abs(x-progress) < 1e-7 {
+				break
+			}
+			if x < progress {
+				low = t
+			} else {
+				high = t
+			}
+			t = (low + high) / 2
+		}
+		return bezier(t, y1, y2)
+	}
+}
@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEasing_Bounds(t *testing.T) {
+	easings := map[string]EasingFunc{
+		"Linear":           Linear,
+		"EaseInQuad":       EaseInQuad,
+		"EaseOutQuad":      EaseOutQuad,
+		"EaseInOutQuad":    EaseInOutQuad,
+		"EaseInCubic":      EaseInCubic,
+		"EaseOutCubic":     EaseOutCubic,
+		"EaseInOutCubic":   EaseInOutCubic,
+		"EaseInSine":       EaseInSine,
+		"EaseOutSine":      EaseOutSine,
+		"EaseInOutSine":    EaseInOutSine,
+		"EaseInBack":       EaseInBack,
+		"EaseOutBack":      EaseOutBack,
+		"EaseInOutBack":    EaseInOutBack,
+		"EaseInElastic":    EaseInElastic,
+		"EaseOutElastic":   EaseOutElastic,
+		"EaseInOutElastic": EaseInOutElastic,
+		"EaseInBounce":     EaseInBounce,
+		"EaseOutBounce":    EaseOutBounce,
+		"EaseInOutBounce":  EaseInOutBounce,
+		"CubicBezier":      CubicBezier(0.25, 0.1, 0.25, 1),
+	}
+	for name, easing := range easings {
+		if value := easing(0); math.Abs(value) > 1e-6 {
+			t.Fatalf("%s(0)=%v, want 0", name, value)
+		}
+		if value := easing(1); math.Abs(value-1) > 1e-6 {
+			t.Fatalf("%s(1)=%v, want 1", name, value)
+		}
+	}
+}
+
+func TestEasing_Values(t *testing.T) {
+	if value := EaseInQuad(0.5); math.Abs(value-0.25) > 1e-9 {
+		t.Fatalf("EaseInQuad(0.5)=%v, want 0.25", value)
+	}
+	if value := EaseOutCubic(0.5); math.Abs(value-0.875) > 1e-9 {
+		t.Fatalf("EaseOutCubic(0.5)=%v, want 0.875", value)
+	}
+	if value := EaseInBack(0.5); value >= 0 {
+		t.Fatalf("EaseInBack(0.5)=%v, want negative value (overshoot)", value)
+	}
+	if value := EaseOutBounce(0.5); math.Abs(value-0.765625) > 1e-9 {
+		t.Fatalf("EaseOutBounce(0.5)=%v, want 0.765625", value)
+	}
+}
+
+func TestCubicBezier(t *testing.T) {
+	linear := CubicBezier(0.25, 0.25, 0.75, 0.75)
+	for _, progress := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+		if value := linear(progress); math.Abs(value-progress) > 1e-5 {
+			t.Fatalf("CubicBezier(linear)(%v)=%v, want %v", progress, value, progress)
+		}
+	}
+	easeIn := CubicBezier(0.42, 0, 1, 1)
+	if value := easeIn(0.5); value >= 0.5 {
+		t.Fatalf("CubicBezier(ease-in)(0.5)=%v, want value < 0.5", value)
+	}
+}
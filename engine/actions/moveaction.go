@@ -36,7 +36,7 @@ func (action *MoveAction) Execute(timer *engine.Timer) {
 	distance := action.speed * timeInS
 	pos := *action.computePosition
 	var newPos mgl32.Vec2
-	if action.target.Sub(pos).LenSqr() <= distance {
+	if action.target.Sub(pos).Len() <= distance {
 		newPos = action.target
 		action.end = true
 	} else {
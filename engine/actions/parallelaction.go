@@ -0,0 +1,83 @@
+package actions
+
+import (
+	"ogl46/engine"
+)
+
+// ParallelMode indique quand une action parallèle est terminée
+type ParallelMode uint8
+
+const (
+	// PARALLEL_ALL indique que l'action parallèle est terminée quand toutes les actions sont terminées
+	PARALLEL_ALL ParallelMode = iota
+	// PARALLEL_ANY indique que l'action parallèle est terminée dès qu'une action est terminée (les autres sont abandonnées)
+	PARALLEL_ANY
+)
+
+type ParallelAction struct {
+	actions []Action
+	mode    ParallelMode
+	end     bool
+}
+
+func BuildParallelAction(mode ParallelMode) *ParallelAction {
+	return &ParallelAction{
+		actions: make([]Action, 0),
+		mode:    mode,
+	}
+}
+
+func (action *ParallelAction) Add(newAction Action) {
+	if newAction != nil && !newAction.IsEnd() {
+		action.actions = append(action.actions, newAction)
+	}
+}
+
+func (action *ParallelAction) Clear() {
+	action.actions = make([]Action, 0)
+}
+
+func (action *ParallelAction) Execute(timer *engine.Timer) {
+	if action.IsEnd() {
+		return
+	}
+	running := 0
+	for _, currentAction := range action.actions {
+		if currentAction.IsEnd() {
+			continue
+		}
+		currentAction.Execute(timer)
+		if currentAction.IsEnd() {
+			if action.mode == PARALLEL_ANY {
+				action.end = true
+			}
+		} else {
+			running++
+		}
+	}
+	if running == 0 {
+		action.end = true
+	} else if action.end {
+		// Mode PARALLEL_ANY : abandonner les actions restantes
+		action.cancelRunning()
+	}
+}
+
+// Cancel annule les actions en cours
+func (action *ParallelAction) Cancel() {
+	action.end = true
+	action.cancelRunning()
+}
+
+// cancelRunning annule les actions non terminées
+func (action *ParallelAction) cancelRunning() {
+	for _, currentAction := range action.actions {
+		if !currentAction.IsEnd() {
+			cancelAction(currentAction)
+		}
+	}
+}
+
+func (action *ParallelAction) IsEnd() bool {
+	return action.end || len(action.actions) == 0
+}
@@ -0,0 +1,65 @@
+package actions
+
+import (
+	"ogl46/engine"
+)
+
+// RepeatAction répète une action (construite à nouveau à chaque répétition)
+type RepeatAction struct {
+	build   func() Action
+	current Action
+	// times est le nombre d'exécutions (REPEAT_FOREVER pour ne jamais s'arrêter)
+	times int
+	// count est le nombre d'exécutions démarrées
+	count int
+	end   bool
+}
+
+// BuildRepeatAction construit une action répétée "times" fois (REPEAT_FOREVER pour ne jamais s'arrêter).
+// La fonction "build" est appelée pour construire l'action à chaque répétition.
+func BuildRepeatAction(build func() Action, times int) *RepeatAction {
+	return &RepeatAction{
+		build: build,
+		times: times,
+		end:   build == nil || times == 0,
+	}
+}
+
+// Count retourne le nombre d'exécutions démarrées
+func (action *RepeatAction) Count() int {
+	return action.count
+}
+
+func (action *RepeatAction) Execute(timer *engine.Timer) {
+	if action.end {
+		return
+	}
+	if action.current == nil || action.current.IsEnd() {
+		if action.times != REPEAT_FOREVER && action.count >= action.times {
+			action.end = true
+			return
+		}
+		action.current = action.build()
+		action.count++
+		if action.current == nil {
+			action.end = true
+			return
+		}
+	}
+	action.current.Execute(timer)
+	if action.current.IsEnd() && action.times != REPEAT_FOREVER && action.count >= action.times {
+		action.end = true
+	}
+}
+
+func (action *RepeatAction) IsEnd() bool {
+	return action.end
+}
+
+// Cancel annule la répétition en cours
+func (action *RepeatAction) Cancel() {
+	action.end = true
+	if action.current != nil && !action.current.IsEnd() {
+		cancelAction(action.current)
+	}
+}
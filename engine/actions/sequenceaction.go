@@ -43,3 +43,13 @@ func (action *SequenceAction) Execute(timer *engine.Timer) {
 func (action *SequenceAction) IsEnd() bool {
 	return action.currentIndex >= len(action.actions)
 }
+
+// Cancel annule l'action en cours et les actions restantes
+func (action *SequenceAction) Cancel() {
+	for idx := action.currentIndex; idx < len(action.actions); idx++ {
+		if action.actions[idx] != nil && !action.actions[idx].IsEnd() {
+			cancelAction(action.actions[idx])
+		}
+	}
+	action.currentIndex = len(action.actions)
+}
@@ -0,0 +1,179 @@
+package actions
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+)
+
+// REPEAT_FOREVER indique qu'une action doit être répétée indéfiniment
+const REPEAT_FOREVER = -1
+
+// InterpolateFunc calcule une valeur intermédiaire entre deux valeurs (ratio entre 0 et 1, ou en dehors avec certains adoucissements)
+type InterpolateFunc[T any] func(from T, to T, ratio float64) T
+
+// TweenAction anime une propriété d'une valeur de départ vers une valeur cible sur une durée
+type TweenAction[T any] struct {
+	getValue    func() T
+	setValue    func(T)
+	interpolate InterpolateFunc[T]
+	easing      EasingFunc
+
+	// from est la valeur de départ (récupérée au démarrage de l'animation)
+	from *T
+	// to est la valeur cible
+	to T
+	// durationInMs est la durée d'un cycle de l'animation
+	durationInMs int64
+	// delayInMs est le temps d'attente avant de démarrer l'animation
+	delayInMs int64
+	// times est le nombre de cycles, comme pour RepeatAction (0 ou 1 pour un seul cycle, REPEAT_FOREVER pour ne jamais s'arrêter)
+	times int
+	// yoyo indique si l'animation repart en arrière à chaque répétition
+	yoyo bool
+	// onComplete est appelée à la fin de l'animation
+	onComplete func()
+
+	// elapsedInMs est le temps écoulé dans le cycle en cours
+	elapsedInMs int64
+	// cycle est le numéro du cycle en cours
+	cycle int
+	end   bool
+}
+
+// BuildTweenAction construit une animation de propriété avec une fonction d'interpolation
+func BuildTweenAction[T any](getValue func() T, setValue func(T), interpolate InterpolateFunc[T], target T, durationInMs int64, easing EasingFunc) *TweenAction[T] {
+	if easing == nil {
+		easing = Linear
+	}
+	return &TweenAction[T]{
+		getValue:     getValue,
+		setValue:     setValue,
+		interpolate:  interpolate,
+		easing:       easing,
+		to:           target,
+		durationInMs: max(durationInMs, 0),
+	}
+}
+
+// BuildFloatTween construit une animation d'un nombre
+func BuildFloatTween(getValue func() float32, setValue func(float32), target float32, durationInMs int64, easing EasingFunc) *TweenAction[float32] {
+	return BuildTweenAction(getValue, setValue, InterpolateFloat, target, durationInMs, easing)
+}
+
+// BuildVec2Tween construit une animation d'un vecteur 2D (position, dimension, ...)
+func BuildVec2Tween(getValue func() mgl32.Vec2, setValue func(mgl32.Vec2), target mgl32.Vec2, durationInMs int64, easing EasingFunc) *TweenAction[mgl32.Vec2] {
+	return BuildTweenAction(getValue, setValue, InterpolateVec2, target, durationInMs, easing)
+}
+
+// BuildVec3Tween construit une animation d'un vecteur 3D
+func BuildVec3Tween(getValue func() mgl32.Vec3, setValue func(mgl32.Vec3), target mgl32.Vec3, durationInMs int64, easing EasingFunc) *TweenAction[mgl32.Vec3] {
+	return BuildTweenAction(getValue, setValue, InterpolateVec3, target, durationInMs, easing)
+}
+
+// BuildColorTween construit une animation d'une couleur
+func BuildColorTween(getValue func() graphic.Color, setValue func(graphic.Color), target graphic.Color, durationInMs int64, easing EasingFunc) *TweenAction[graphic.Color] {
+	return BuildTweenAction(getValue, setValue, InterpolateColor, target, durationInMs, easing)
+}
+
+// BuildAngleTween construit une animation d'un angle
+func BuildAngleTween(getValue func() graphic.Angle, setValue func(graphic.Angle), target graphic.Angle, durationInMs int64, easing EasingFunc) *TweenAction[graphic.Angle] {
+	return BuildTweenAction(getValue, setValue, InterpolateAngle, target, durationInMs, easing)
+}
+
+func InterpolateFloat(from float32, to float32, ratio float64) float32 {
+	return from + (to-from)*float32(ratio)
+}
+
+func InterpolateVec2(from mgl32.Vec2, to mgl32.Vec2, ratio float64) mgl32.Vec2 {
+	return from.Add(to.Sub(from).Mul(float32(ratio)))
+}
+
+func InterpolateVec3(from mgl32.Vec3, to mgl32.Vec3, ratio float64) mgl32.Vec3 {
+	return from.Add(to.Sub(from).Mul(float32(ratio)))
+}
+
+func InterpolateColor(from graphic.Color, to graphic.Color, ratio float64) graphic.Color {
+	return graphic.Color(mgl32.Vec4(from).Add(mgl32.Vec4(to).Sub(mgl32.Vec4(from)).Mul(float32(ratio))))
+}
+
+func InterpolateAngle(from graphic.Angle, to graphic.Angle, ratio float64) graphic.Angle {
+	return from + (to-from)*graphic.Angle(ratio)
+}
+
+// SetDelay indique le temps d'attente avant de démarrer l'animation
+func (action *TweenAction[T]) SetDelay(delayInMs int64) *TweenAction[T] {
+	action.delayInMs = delayInMs
+	return action
+}
+
+// SetRepeat indique le nombre total de cycles, comme pour BuildRepeatAction (REPEAT_FOREVER pour ne jamais s'arrêter)
+func (action *TweenAction[T]) SetRepeat(times int) *TweenAction[T] {
+	action.times = times
+	return action
+}
+
+// SetYoyo indique si l'animation repart en arrière à chaque répétition
+func (action *TweenAction[T]) SetYoyo(yoyo bool) *TweenAction[T] {
+	action.yoyo = yoyo
+	return action
+}
+
+// OnComplete indique la fonction à appeler à la fin de l'animation
+func (action *TweenAction[T]) OnComplete(function func()) *TweenAction[T] {
+	action.onComplete = function
+	return action
+}
+
+func (action *TweenAction[T]) Execute(timer *engine.Timer) {
+	if action.end {
+		return
+	}
+	elapsed := timer.ElapsedTime()
+	// Attendre avant de démarrer
+	if action.delayInMs > 0 {
+		if elapsed < action.delayInMs {
+			action.delayInMs -= elapsed
+			return
+		}
+		elapsed -= action.delayInMs
+		action.delayInMs = 0
+	}
+	// Récupérer la valeur de départ au démarrage
+	if action.from == nil {
+		from := action.getValue()
+		action.from = &from
+	}
+	action.elapsedInMs += elapsed
+	// Passer les cycles terminés
+	for action.elapsedInMs >= action.durationInMs {
+		action.elapsedInMs -= action.durationInMs
+		if action.times != REPEAT_FOREVER && action.cycle+1 >= action.times {
+			action.setValue(action.valueAt(1))
+			action.end = true
+			if action.onComplete != nil {
+				action.onComplete()
+			}
+			return
+		}
+		action.cycle++
+		if action.durationInMs == 0 {
+			// Une animation sans durée et répétée indéfiniment ne peut pas avancer
+			action.elapsedInMs = 0
+			break
+		}
+	}
+	action.setValue(action.valueAt(float64(action.elapsedInMs) / float64(max(action.durationInMs, 1))))
+}
+
+// valueAt retourne la valeur pour la progression indiquée dans le cycle en cours
+func (action *TweenAction[T]) valueAt(progress float64) T {
+	if action.yoyo && action.cycle%2 == 1 {
+		progress = 1 - progress
+	}
+	return action.interpolate(*action.from, action.to, action.easing(progress))
+}
+
+func (action *TweenAction[T]) IsEnd() bool {
+	return action.end
+}
@@ -0,0 +1,134 @@
+package actions
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"ogl46/engine"
+	"testing"
+)
+
+// executeDuring exécute une action par pas de temps constants
+func executeDuring(action interface{ Execute(timer *engine.Timer) }, timer *engine.Timer, steps int, stepInMs int64) {
+	for step := 0; step < steps; step++ {
+		*timer = timer.NextTimerAt(timer.CurrentTime() + stepInMs)
+		action.Execute(timer)
+	}
+}
+
+func TestTweenAction_Linear(t *testing.T) {
+	value := float32(0)
+	action := BuildFloatTween(func() float32 { return value }, func(v float32) { value = v }, 100, 1000, Linear)
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 1, 250)
+	if math.Abs(float64(value-25)) > 1e-4 {
+		t.Fatalf("Execute() value=%v, want 25", value)
+	}
+	executeDuring(action, &timer, 2, 250)
+	if math.Abs(float64(value-75)) > 1e-4 {
+		t.Fatalf("Execute() value=%v, want 75", value)
+	}
+	if action.IsEnd() {
+		t.Fatalf("IsEnd()=true, want false")
+	}
+	executeDuring(action, &timer, 1, 300)
+	if value != 100 || !action.IsEnd() {
+		t.Fatalf("Execute() value=%v / IsEnd()=%v, want value=100 / IsEnd()=true", value, action.IsEnd())
+	}
+}
+
+func TestTweenAction_DelayAndComplete(t *testing.T) {
+	position := mgl32.Vec2{0, 0}
+	completed := 0
+	action := BuildVec2Tween(func() mgl32.Vec2 { return position }, func(v mgl32.Vec2) { position = v },
+		mgl32.Vec2{10, 20}, 100, EaseInQuad).SetDelay(50).OnComplete(func() { completed++ })
+	timer := engine.BuildTimerAt(1000)
+
+	executeDuring(action, &timer, 1, 40)
+	if position != (mgl32.Vec2{0, 0}) {
+		t.Fatalf("Execute() position=%v during delay, want [0 0]", position)
+	}
+	executeDuring(action, &timer, 1, 60) // 10 ms d'attente restants + 50 ms d'animation
+	if !position.ApproxEqual(mgl32.Vec2{2.5, 5}) {
+		t.Fatalf("Execute() position=%v, want [2.5 5]", position)
+	}
+	executeDuring(action, &timer, 2, 50)
+	if position != (mgl32.Vec2{10, 20}) || completed != 1 {
+		t.Fatalf("Execute() position=%v / completed=%d, want position=[10 20] / completed=1", position, completed)
+	}
+	executeDuring(action, &timer, 1, 50)
+	if completed != 1 {
+		t.Fatalf("Execute() completed=%d after end, want 1", completed)
+	}
+}
+
+func TestTweenAction_YoyoRepeat(t *testing.T) {
+	value := float32(0)
+	action := BuildFloatTween(func() float32 { return value }, func(v float32) { value = v }, 10, 100, Linear).
+		SetRepeat(2).SetYoyo(true)
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 1, 150)
+	if math.Abs(float64(value-5)) > 1e-4 {
+		t.Fatalf("Execute() value=%v in yoyo cycle, want 5", value)
+	}
+	executeDuring(action, &timer, 1, 50)
+	if value != 0 || !action.IsEnd() {
+		t.Fatalf("Execute() value=%v / IsEnd()=%v, want value=0 / IsEnd()=true", value, action.IsEnd())
+	}
+}
+
+func TestTweenAction_RepeatTimes(t *testing.T) {
+	// Même sémantique que BuildRepeatAction : nombre total de cycles
+	tests := []struct {
+		times    int
+		duration int
+	}{
+		{0, 100},
+		{1, 100},
+		{2, 200},
+		{3, 300},
+	}
+	for _, test := range tests {
+		value := float32(0)
+		action := BuildFloatTween(func() float32 { return value }, func(v float32) { value = v }, 10, 100, Linear).
+			SetRepeat(test.times)
+		timer := engine.BuildTimerAt(0)
+
+		executeDuring(action, &timer, test.duration/10-1, 10)
+		if action.IsEnd() {
+			t.Fatalf("SetRepeat(%d): IsEnd()=true after %d ms, want false", test.times, test.duration-10)
+		}
+		executeDuring(action, &timer, 1, 10)
+		if !action.IsEnd() {
+			t.Fatalf("SetRepeat(%d): IsEnd()=false after %d ms, want true", test.times, test.duration)
+		}
+	}
+}
+
+func TestTweenAction_RepeatForever(t *testing.T) {
+	value := float32(0)
+	action := BuildFloatTween(func() float32 { return value }, func(v float32) { value = v }, 10, 100, Linear).
+		SetRepeat(REPEAT_FOREVER)
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 100, 70)
+	if action.IsEnd() {
+		t.Fatalf("IsEnd()=true, want false")
+	}
+}
+
+func TestMoveAction_ReachTarget(t *testing.T) {
+	position := mgl32.Vec2{0, 0}
+	action := BuildMoveAction(func() mgl32.Vec2 { return position }, func(v mgl32.Vec2) { position = v }, mgl32.Vec2{10, 0}, 10)
+	timer := engine.BuildTimerAt(0)
+
+	executeDuring(action, &timer, 1, 500)
+	if action.IsEnd() || !position.ApproxEqual(mgl32.Vec2{5, 0}) {
+		t.Fatalf("Execute() position=%v / IsEnd()=%v, want position=[5 0] / IsEnd()=false", position, action.IsEnd())
+	}
+	executeDuring(action, &timer, 1, 500)
+	if !action.IsEnd() || position != (mgl32.Vec2{10, 0}) {
+		t.Fatalf("Execute() position=%v / IsEnd()=%v, want position=[10 0] / IsEnd()=true", position, action.IsEnd())
+	}
+}
@@ -0,0 +1,28 @@
+package actions
+
+import (
+	"ogl46/engine"
+)
+
+// WaitUntilAction attend qu'une condition soit vraie
+type WaitUntilAction struct {
+	predicate func() bool
+	end       bool
+}
+
+func BuildWaitUntilAction(predicate func() bool) Action {
+	return &WaitUntilAction{
+		predicate: predicate,
+		end:       predicate == nil,
+	}
+}
+
+func (action *WaitUntilAction) Execute(timer *engine.Timer) {
+	if !action.end && action.predicate() {
+		action.end = true
+	}
+}
+
+func (action *WaitUntilAction) IsEnd() bool {
+	return action.end
+}
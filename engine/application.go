@@ -6,6 +6,7 @@ import (
 	"ogl46/engine/assetsmngr"
 	"ogl46/engine/graphic"
 	"ogl46/engine/input"
+	"ogl46/engine/postprocess"
 )
 
 type Application struct {
@@ -21,12 +22,19 @@ type Application struct {
 	window input.Window
 	// Renderer 2D
 	renderer2d graphic.Renderer2d
+	// Chaîne de post-traitement (nil = rendu direct dans la fenêtre)
+	postProcessing *postprocess.Chain
 
 	// Managers pour les ressources
-	musicManager   *assetsmngr.MusicManager
-	soundManager   *assetsmngr.SoundManager
-	textureManager *assetsmngr.TextureManager
-	fontManager    *assetsmngr.FontManager
+	musicManager          *assetsmngr.MusicManager
+	soundManager          *assetsmngr.SoundManager
+	textureManager        *assetsmngr.TextureManager
+	fontManager           *assetsmngr.FontManager
+	themeManager          *assetsmngr.ThemeManager
+	spriteSheetManager    *assetsmngr.SpriteSheetManager
+	tileMapManager        *assetsmngr.TileMapManager
+	particleEffectManager *assetsmngr.ParticleEffectManager
+	shaderManager         *assetsmngr.ShaderManager
 }
 
 func (app *Application) Window() *input.Window {
@@ -81,11 +89,21 @@ func (app *Application) initialize() {
 	app.soundManager = assetsmngr.NewSoundManager()
 	app.textureManager = assetsmngr.NewTextureManager()
 	app.fontManager = assetsmngr.NewFontManager()
+	app.themeManager = assetsmngr.NewThemeManager()
+	app.spriteSheetManager = assetsmngr.NewSpriteSheetManager()
+	app.tileMapManager = assetsmngr.NewTileMapManager()
+	app.particleEffectManager = assetsmngr.NewParticleEffectManager()
+	app.shaderManager = assetsmngr.NewShaderManager()
 	app.stage.Initialize(app)
 }
 
 func (app *Application) release() {
 	app.stage.Release(app)
+	app.shaderManager.ReleaseAll()
+	app.particleEffectManager.ReleaseAll()
+	app.tileMapManager.ReleaseAll()
+	app.spriteSheetManager.ReleaseAll()
+	app.themeManager.ReleaseAll()
 	app.fontManager.ReleaseAll()
 	app.textureManager.ReleaseAll()
 	app.soundManager.ReleaseAll()
@@ -100,13 +118,26 @@ func (app *Application) processStage(timer *Timer) {
 		app.stage.Execute(app, timer)
 	}
 
-	// Affichage
+	// Affichage (dans la chaîne de post-traitement si elle existe, ses effets sont appliqués avant l'affichage)
+	postProcessing := app.postProcessing
+	if postProcessing != nil {
+		postProcessing.Update(timer.ElapsedTime())
+		postProcessing.Begin()
+	}
 	app.window.Clear()
 	app.stage.Display(app, timer)
+	if postProcessing != nil {
+		postProcessing.End()
+	}
 	app.window.Swap()
 
 	// Traitement des évènements
 	app.window.ProcessEvents(func(event input.Event) {
+		if windowEvent, ok := event.(*input.WindowEvent); ok && windowEvent.Action() == input.WINDOW_RESIZED && app.postProcessing != nil {
+			if err := app.postProcessing.Resize(int32(windowEvent.Width()), int32(windowEvent.Heigth())); err != nil {
+				log.Printf("failed to resize post-processing chain: %v", err)
+			}
+		}
 		app.stage.ProcessEvent(app, event, timer)
 	})
 }
@@ -145,6 +176,18 @@ func (app *Application) SetStage(stage Stage) {
 	}
 }
 
+// PostProcessing retourne la chaîne de post-traitement (nil si le rendu est direct)
+func (app *Application) PostProcessing() *postprocess.Chain {
+	return app.postProcessing
+}
+
+// SetPostProcessing définit la chaîne de post-traitement appliquée à chaque affichage (nil = rendu direct)
+//
+//	La chaîne est redimensionnée avec la fenêtre, elle doit être libérée par celui qui l'a créée.
+func (app *Application) SetPostProcessing(chain *postprocess.Chain) {
+	app.postProcessing = chain
+}
+
 func (app *Application) MusicManager() *assetsmngr.MusicManager {
 	return app.musicManager
 }
@@ -161,6 +204,26 @@ func (app *Application) FontManager() *assetsmngr.FontManager {
 	return app.fontManager
 }
 
+func (app *Application) ThemeManager() *assetsmngr.ThemeManager {
+	return app.themeManager
+}
+
+func (app *Application) SpriteSheetManager() *assetsmngr.SpriteSheetManager {
+	return app.spriteSheetManager
+}
+
+func (app *Application) TileMapManager() *assetsmngr.TileMapManager {
+	return app.tileMapManager
+}
+
+func (app *Application) ParticleEffectManager() *assetsmngr.ParticleEffectManager {
+	return app.particleEffectManager
+}
+
+func (app *Application) ShaderManager() *assetsmngr.ShaderManager {
+	return app.shaderManager
+}
+
 func (app *Application) VSync() bool {
 	return app.window.VSync()
 }
@@ -0,0 +1,44 @@
+package assetsmngr
+
+import (
+	"fmt"
+	"ogl46/engine/particles"
+)
+
+// ParticleEffectManager permet de gérer le chargement des descriptions d'effets de particules
+type ParticleEffectManager struct {
+	// Manager est une instance d'asset manager pour gérer les ressources
+	Manager[particles.EffectConfig]
+}
+
+func NewParticleEffectManager() *ParticleEffectManager {
+	return &ParticleEffectManager{
+		Manager: CreateManager[particles.EffectConfig](),
+	}
+}
+
+func (particleEffectManager *ParticleEffectManager) RegisterParticleEffectFromFile(name string, filename string) {
+	particleEffectManager.Manager.Register(name,
+		func() (*particles.EffectConfig, error) {
+			config, err := particles.LoadEffectConfigFromFile(filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' particle effect from file '%s'.\n - %w", name, filename, err)
+			}
+			return config, nil
+		},
+		func(config *particles.EffectConfig) {
+		})
+}
+
+func (particleEffectManager *ParticleEffectManager) RegisterParticleEffectFromBytes(name string, content []byte) {
+	particleEffectManager.Manager.Register(name,
+		func() (*particles.EffectConfig, error) {
+			config, err := particles.LoadEffectConfigFromBytes(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' particle effect from byte array.\n - %w", name, err)
+			}
+			return config, nil
+		},
+		func(config *particles.EffectConfig) {
+		})
+}
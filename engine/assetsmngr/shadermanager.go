@@ -0,0 +1,114 @@
+package assetsmngr
+
+import (
+	"errors"
+	"fmt"
+	"ogl46/engine/ogl"
+	"os"
+)
+
+// ShaderManager permet de gérer le chargement et la libération des programmes de shaders
+//
+//	Les directives #include sont résolues parmi les sources enregistrées (RegisterSource), puis dans les fichiers.
+type ShaderManager struct {
+	// Manager est une instance d'asset manager pour gérer les ressources
+	Manager[ogl.ShaderProgram]
+	// sources contient les sources enregistrées en mémoire (fichiers embarqués, code partagé...)
+	sources map[string]string
+	// preprocessor prépare les sources (includes, defines) et garde en cache les variantes
+	preprocessor *ogl.ShaderPreprocessor
+}
+
+func NewShaderManager() *ShaderManager {
+	shaderManager := &ShaderManager{
+		Manager: CreateManager[ogl.ShaderProgram](),
+		sources: make(map[string]string),
+	}
+	shaderManager.preprocessor = ogl.NewShaderPreprocessor(shaderManager.loadSource)
+	return shaderManager
+}
+
+// RegisterSource enregistre une source en mémoire, utilisable comme shader ou par #include
+func (shaderManager *ShaderManager) RegisterSource(name string, content string) {
+	shaderManager.sources[name] = content
+	shaderManager.preprocessor.Invalidate()
+}
+
+// RegisterShaderProgramFromFiles enregistre un programme (vertex et fragment shaders) avec ses defines (nil sans defines)
+func (shaderManager *ShaderManager) RegisterShaderProgramFromFiles(name string, vertexFilename string, fragmentFilename string, defines ogl.ShaderDefines) {
+	shaderManager.Manager.Register(name,
+		func() (*ogl.ShaderProgram, error) {
+			program, err := shaderManager.preprocessor.BuildProgram(vertexFilename, fragmentFilename, defines)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' shader program from files '%s' and '%s'.\n - %w", name, vertexFilename, fragmentFilename, err)
+			}
+			return program, nil
+		},
+		func(program *ogl.ShaderProgram) {
+			if program != nil {
+				program.Delete()
+			}
+		})
+}
+
+// RegisterComputeProgramFromFile enregistre un programme de calcul (compute shader) avec ses defines (nil sans defines)
+func (shaderManager *ShaderManager) RegisterComputeProgramFromFile(name string, filename string, defines ogl.ShaderDefines) {
+	shaderManager.Manager.Register(name,
+		func() (*ogl.ShaderProgram, error) {
+			program, err := shaderManager.preprocessor.BuildComputeProgram(filename, defines)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' compute program from file '%s'.\n - %w", name, filename, err)
+			}
+			return program, nil
+		},
+		func(program *ogl.ShaderProgram) {
+			if program != nil {
+				program.Delete()
+			}
+		})
+}
+
+// GetVariant retourne la variante d'un programme pour des defines (compilée au premier appel puis gardée en cache)
+func (shaderManager *ShaderManager) GetVariant(vertexFilename string, fragmentFilename string, defines ogl.ShaderDefines) (*ogl.ShaderProgram, error) {
+	name := variantName(vertexFilename, fragmentFilename, defines)
+	if !shaderManager.IsRegister(name) {
+		shaderManager.RegisterShaderProgramFromFiles(name, vertexFilename, fragmentFilename, defines)
+	}
+	return shaderManager.Get(name)
+}
+
+// Reload relit les sources et reconstruit sur place les programmes chargés : les programmes déjà récupérés par Get
+// restent valides. Un programme dont la reconstruction échoue est conservé tel quel (l'erreur est retournée).
+func (shaderManager *ShaderManager) Reload() error {
+	shaderManager.preprocessor.Invalidate()
+	var errs []error
+	for _, information := range shaderManager.assetInformations {
+		if information.Asset == nil || information.Load == nil {
+			continue
+		}
+		program, err := information.Load()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reload '%s' shader program\n - %w", information.Name, err))
+			continue
+		}
+		information.Asset.Replace(program)
+	}
+	return errors.Join(errs...)
+}
+
+// loadSource retourne le contenu d'une source enregistrée ou d'un fichier
+func (shaderManager *ShaderManager) loadSource(name string) (string, error) {
+	if content, found := shaderManager.sources[name]; found {
+		return content, nil
+	}
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// variantName retourne le nom sous lequel une variante est enregistrée
+func variantName(vertexFilename string, fragmentFilename string, defines ogl.ShaderDefines) string {
+	return fmt.Sprintf("%s|%s|%s", vertexFilename, fragmentFilename, defines.Key())
+}
@@ -0,0 +1,50 @@
+package assetsmngr
+
+import (
+	"fmt"
+	"ogl46/engine/graphic"
+)
+
+// SpriteSheetManager permet de gérer le chargement et la libération des planches de sprites
+type SpriteSheetManager struct {
+	// Manager est une instance d'asset manager pour gérer les ressources
+	Manager[graphic.SpriteSheet]
+}
+
+func NewSpriteSheetManager() *SpriteSheetManager {
+	return &SpriteSheetManager{
+		Manager: CreateManager[graphic.SpriteSheet](),
+	}
+}
+
+func (spriteSheetManager *SpriteSheetManager) RegisterSpriteSheetFromAsepriteFile(name string, filename string) {
+	spriteSheetManager.Manager.Register(name,
+		func() (*graphic.SpriteSheet, error) {
+			sheet, err := graphic.LoadSpriteSheetFromAsepriteFile(filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' sprite sheet from Aseprite file '%s'.\n - %w", name, filename, err)
+			}
+			return sheet, nil
+		},
+		func(sheet *graphic.SpriteSheet) {
+			if sheet != nil {
+				sheet.Release()
+			}
+		})
+}
+
+func (spriteSheetManager *SpriteSheetManager) RegisterSpriteSheetFromAsepriteBytes(name string, content []byte) {
+	spriteSheetManager.Manager.Register(name,
+		func() (*graphic.SpriteSheet, error) {
+			sheet, err := graphic.LoadSpriteSheetFromAsepriteBytes(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' sprite sheet from Aseprite byte array.\n - %w", name, err)
+			}
+			return sheet, nil
+		},
+		func(sheet *graphic.SpriteSheet) {
+			if sheet != nil {
+				sheet.Release()
+			}
+		})
+}
@@ -0,0 +1,50 @@
+package assetsmngr
+
+import (
+	"fmt"
+	"ogl46/engine/graphic"
+)
+
+// ThemeManager permet de gérer le chargement et la libération des thèmes
+type ThemeManager struct {
+	// Manager est une instance d'asset manager pour gérer les ressources
+	Manager[graphic.Theme]
+}
+
+func NewThemeManager() *ThemeManager {
+	return &ThemeManager{
+		Manager: CreateManager[graphic.Theme](),
+	}
+}
+
+func (themeManager *ThemeManager) RegisterThemeFromFile(name string, filename string) {
+	themeManager.Manager.Register(name,
+		func() (*graphic.Theme, error) {
+			theme, err := graphic.LoadThemeFromFile(filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' theme from file '%s'.\n - %w", name, filename, err)
+			}
+			return theme, nil
+		},
+		func(theme *graphic.Theme) {
+			if theme != nil {
+				theme.Release()
+			}
+		})
+}
+
+func (themeManager *ThemeManager) RegisterThemeFromBytes(name string, content []byte) {
+	themeManager.Manager.Register(name,
+		func() (*graphic.Theme, error) {
+			theme, err := graphic.LoadThemeFromBytes(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' theme from byte array.\n - %w", name, err)
+			}
+			return theme, nil
+		},
+		func(theme *graphic.Theme) {
+			if theme != nil {
+				theme.Release()
+			}
+		})
+}
@@ -0,0 +1,52 @@
+package assetsmngr
+
+import (
+	"fmt"
+	"ogl46/engine/graphic"
+)
+
+// TileMapManager permet de gérer le chargement et la libération des cartes de tuiles (éditeur Tiled)
+type TileMapManager struct {
+	// Manager est une instance d'asset manager pour gérer les ressources
+	Manager[graphic.TileMap]
+}
+
+func NewTileMapManager() *TileMapManager {
+	return &TileMapManager{
+		Manager: CreateManager[graphic.TileMap](),
+	}
+}
+
+// RegisterTileMapFromFile enregistre une carte ".tmx", ".tmj" ou ".json" (tilesets externes et images relatifs au fichier)
+func (tileMapManager *TileMapManager) RegisterTileMapFromFile(name string, filename string) {
+	tileMapManager.Manager.Register(name,
+		func() (*graphic.TileMap, error) {
+			tileMap, err := graphic.LoadTileMapFromFile(filename)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' tile map from file '%s'.\n - %w", name, filename, err)
+			}
+			return tileMap, nil
+		},
+		func(tileMap *graphic.TileMap) {
+			if tileMap != nil {
+				tileMap.Release()
+			}
+		})
+}
+
+// RegisterTileMapFromBytes enregistre une carte (tilesets externes et images relatifs au répertoire indiqué)
+func (tileMapManager *TileMapManager) RegisterTileMapFromBytes(name string, content []byte, format graphic.TileMapFormat, directory string) {
+	tileMapManager.Manager.Register(name,
+		func() (*graphic.TileMap, error) {
+			tileMap, err := graphic.LoadTileMapFromBytes(content, format, directory)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load '%s' tile map from byte array.\n - %w", name, err)
+			}
+			return tileMap, nil
+		},
+		func(tileMap *graphic.TileMap) {
+			if tileMap != nil {
+				tileMap.Release()
+			}
+		})
+}
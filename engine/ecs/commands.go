@@ -0,0 +1,59 @@
+package ecs
+
+// CommandBuffer enregistre des modifications du monde pour les appliquer plus tard (après le parcours d'une requête)
+type CommandBuffer struct {
+	commands []func(world *World)
+}
+
+// Do enregistre une modification quelconque du monde
+func (buffer *CommandBuffer) Do(command func(world *World)) {
+	buffer.commands = append(buffer.commands, command)
+}
+
+// Create enregistre la création d'une entité (la fonction "build" ajoute ses composants)
+func (buffer *CommandBuffer) Create(build func(world *World, entity Entity)) {
+	buffer.Do(func(world *World) {
+		entity := world.CreateEntity()
+		if build != nil {
+			build(world, entity)
+		}
+	})
+}
+
+// Destroy enregistre la destruction d'une entité
+func (buffer *CommandBuffer) Destroy(entity Entity) {
+	buffer.Do(func(world *World) {
+		world.DestroyEntity(entity)
+	})
+}
+
+// Len retourne le nombre de modifications en attente
+func (buffer *CommandBuffer) Len() int {
+	return len(buffer.commands)
+}
+
+// Apply applique les modifications enregistrées (dans l'ordre d'enregistrement)
+func (buffer *CommandBuffer) Apply(world *World) {
+	// Les modifications peuvent en enregistrer d'autres : elles sont appliquées dans la foulée
+	for len(buffer.commands) > 0 {
+		commands := buffer.commands
+		buffer.commands = nil
+		for _, command := range commands {
+			command(world)
+		}
+	}
+}
+
+// DeferAdd enregistre l'ajout d'un composant à une entité
+func DeferAdd[T any](buffer *CommandBuffer, entity Entity, component T) {
+	buffer.Do(func(world *World) {
+		AddComponent(world, entity, component)
+	})
+}
+
+// DeferRemove enregistre le retrait d'un composant d'une entité
+func DeferRemove[T any](buffer *CommandBuffer, entity Entity) {
+	buffer.Do(func(world *World) {
+		RemoveComponent[T](world, entity)
+	})
+}
@@ -0,0 +1,24 @@
+package ecs
+
+// Entity identifie une entité du monde (index + génération)
+//
+// L'index d'une entité détruite est réutilisé par une nouvelle entité avec une génération différente :
+// une entité détruite n'est donc jamais confondue avec une nouvelle entité.
+type Entity uint64
+
+// NO_ENTITY est une entité invalide (jamais retournée par le monde)
+const NO_ENTITY Entity = 0
+
+func buildEntity(index uint32, generation uint32) Entity {
+	return Entity(uint64(generation)<<32 | uint64(index))
+}
+
+// Index retourne l'index de l'entité (position dans les tableaux du monde)
+func (entity Entity) Index() uint32 {
+	return uint32(entity)
+}
+
+// Generation retourne la génération de l'entité
+func (entity Entity) Generation() uint32 {
+	return uint32(entity >> 32)
+}
@@ -0,0 +1,160 @@
+package ecs
+
+// Filter est un critère supplémentaire sur les entités d'une requête
+type Filter func(entity Entity) bool
+
+// With retourne un filtre ne gardant que les entités ayant un composant (sans le récupérer)
+func With[T any](world *World) Filter {
+	storage := StorageOf[T](world)
+	return storage.Has
+}
+
+// Without retourne un filtre excluant les entités ayant un composant
+func Without[T any](world *World) Filter {
+	storage := StorageOf[T](world)
+	return func(entity Entity) bool {
+		return !storage.Has(entity)
+	}
+}
+
+// accept indique si l'entité respecte tous les filtres
+func accept(filters []Filter, entity Entity) bool {
+	for _, filter := range filters {
+		if !filter(entity) {
+			return false
+		}
+	}
+	return true
+}
+
+// smallest retourne les entités du plus petit stockage (les entités à parcourir)
+func smallest(storages ...componentStorage) []Entity {
+	var entities []Entity
+	for index, storage := range storages {
+		if index == 0 || storage.Len() < len(entities) {
+			entities = storage.(interface{ Entities() []Entity }).Entities()
+		}
+	}
+	return entities
+}
+
+// Query1 parcourt les entités ayant un composant A
+//
+// Les requêtes parcourent les entités de la fin vers le début : l'entité en cours peut être détruite
+// (ou perdre ses composants) pendant le parcours. Les autres modifications passent par World.Commands.
+type Query1[A any] struct {
+	a       *Storage[A]
+	filters []Filter
+}
+
+func NewQuery1[A any](world *World, filters ...Filter) *Query1[A] {
+	return &Query1[A]{
+		a:       StorageOf[A](world),
+		filters: filters,
+	}
+}
+
+// Each appelle la fonction pour chaque entité de la requête
+func (query *Query1[A]) Each(function func(entity Entity, a *A)) {
+	for position := len(query.a.entities) - 1; position >= 0; position-- {
+		if position >= len(query.a.entities) {
+			// Plusieurs entités ont été retirées pendant le parcours
+			continue
+		}
+		entity := query.a.entities[position]
+		if accept(query.filters, entity) {
+			function(entity, &query.a.components[position])
+		}
+	}
+}
+
+// Count retourne le nombre d'entités de la requête
+func (query *Query1[A]) Count() int {
+	count := 0
+	query.Each(func(Entity, *A) { count++ })
+	return count
+}
+
+// Query2 parcourt les entités ayant les composants A et B
+type Query2[A any, B any] struct {
+	a       *Storage[A]
+	b       *Storage[B]
+	filters []Filter
+}
+
+func NewQuery2[A any, B any](world *World, filters ...Filter) *Query2[A, B] {
+	return &Query2[A, B]{
+		a:       StorageOf[A](world),
+		b:       StorageOf[B](world),
+		filters: filters,
+	}
+}
+
+// Each appelle la fonction pour chaque entité de la requête
+func (query *Query2[A, B]) Each(function func(entity Entity, a *A, b *B)) {
+	entities := smallest(query.a, query.b)
+	for position := len(entities) - 1; position >= 0; position-- {
+		entity := entities[position]
+		a := query.a.Get(entity)
+		if a == nil {
+			continue
+		}
+		b := query.b.Get(entity)
+		if b == nil || !accept(query.filters, entity) {
+			continue
+		}
+		function(entity, a, b)
+	}
+}
+
+// Count retourne le nombre d'entités de la requête
+func (query *Query2[A, B]) Count() int {
+	count := 0
+	query.Each(func(Entity, *A, *B) { count++ })
+	return count
+}
+
+// Query3 parcourt les entités ayant les composants A, B et C
+type Query3[A any, B any, C any] struct {
+	a       *Storage[A]
+	b       *Storage[B]
+	c       *Storage[C]
+	filters []Filter
+}
+
+func NewQuery3[A any, B any, C any](world *World, filters ...Filter) *Query3[A, B, C] {
+	return &Query3[A, B, C]{
+		a:       StorageOf[A](world),
+		b:       StorageOf[B](world),
+		c:       StorageOf[C](world),
+		filters: filters,
+	}
+}
+
+// Each appelle la fonction pour chaque entité de la requête
+func (query *Query3[A, B, C]) Each(function func(entity Entity, a *A, b *B, c *C)) {
+	entities := smallest(query.a, query.b, query.c)
+	for position := len(entities) - 1; position >= 0; position-- {
+		entity := entities[position]
+		a := query.a.Get(entity)
+		if a == nil {
+			continue
+		}
+		b := query.b.Get(entity)
+		if b == nil {
+			continue
+		}
+		c := query.c.Get(entity)
+		if c == nil || !accept(query.filters, entity) {
+			continue
+		}
+		function(entity, a, b, c)
+	}
+}
+
+// Count retourne le nombre d'entités de la requête
+func (query *Query3[A, B, C]) Count() int {
+	count := 0
+	query.Each(func(Entity, *A, *B, *C) { count++ })
+	return count
+}
@@ -0,0 +1,226 @@
+package ecs
+
+import (
+	"fmt"
+	"ogl46/engine"
+	"slices"
+)
+
+// System traite les entités du monde (déplacement, collisions, dessin, ...)
+type System interface {
+	Run(world *World, timer *engine.Timer)
+}
+
+// SystemFunc permet d'utiliser une fonction comme système
+type SystemFunc func(world *World, timer *engine.Timer)
+
+func (function SystemFunc) Run(world *World, timer *engine.Timer) {
+	function(world, timer)
+}
+
+// RunCriteria indique si un système doit être exécuté
+type RunCriteria func(world *World, timer *engine.Timer) bool
+
+// RunEvery retourne un critère d'exécution à intervalle régulier (en millisecondes)
+func RunEvery(intervalInMs int64) RunCriteria {
+	var elapsedInMs int64
+	return func(world *World, timer *engine.Timer) bool {
+		elapsedInMs += timer.ElapsedTime()
+		if elapsedInMs < intervalInMs {
+			return false
+		}
+		elapsedInMs -= intervalInMs
+		return true
+	}
+}
+
+// SystemEntry est un système d'un ordonnancement avec ses contraintes d'ordre et ses critères d'exécution
+type SystemEntry struct {
+	name     string
+	system   System
+	after    []string
+	before   []string
+	criteria []RunCriteria
+	enabled  bool
+	schedule *Schedule
+}
+
+// Name retourne le nom du système
+func (entry *SystemEntry) Name() string {
+	return entry.name
+}
+
+// After indique que le système doit être exécuté après les systèmes indiqués
+func (entry *SystemEntry) After(names ...string) *SystemEntry {
+	entry.after = append(entry.after, names...)
+	entry.schedule.order = nil
+	return entry
+}
+
+// Before indique que le système doit être exécuté avant les systèmes indiqués
+func (entry *SystemEntry) Before(names ...string) *SystemEntry {
+	entry.before = append(entry.before, names...)
+	entry.schedule.order = nil
+	return entry
+}
+
+// RunIf ajoute un critère d'exécution (le système n'est exécuté que si tous ses critères sont vrais)
+func (entry *SystemEntry) RunIf(criteria RunCriteria) *SystemEntry {
+	entry.criteria = append(entry.criteria, criteria)
+	return entry
+}
+
+func (entry *SystemEntry) Enabled() bool {
+	return entry.enabled
+}
+
+// SetEnabled active ou désactive le système
+func (entry *SystemEntry) SetEnabled(enabled bool) {
+	entry.enabled = enabled
+}
+
+// Schedule exécute des systèmes dans un ordre respectant leurs contraintes (ordre d'ajout sinon)
+//
+// Les modifications différées du monde (World.Commands) sont appliquées après chaque système.
+type Schedule struct {
+	entries []*SystemEntry
+	// order est l'ordre d'exécution calculé (nil si à recalculer)
+	order []*SystemEntry
+}
+
+// NewSchedule construit un ordonnancement vide
+func NewSchedule() *Schedule {
+	return &Schedule{
+		entries: make([]*SystemEntry, 0),
+	}
+}
+
+// Add ajoute un système (remplace le système de même nom)
+func (schedule *Schedule) Add(name string, system System) *SystemEntry {
+	schedule.Remove(name)
+	entry := &SystemEntry{
+		name:     name,
+		system:   system,
+		enabled:  true,
+		schedule: schedule,
+	}
+	schedule.entries = append(schedule.entries, entry)
+	schedule.order = nil
+	return entry
+}
+
+// AddFunc ajoute une fonction comme système
+func (schedule *Schedule) AddFunc(name string, function func(world *World, timer *engine.Timer)) *SystemEntry {
+	return schedule.Add(name, SystemFunc(function))
+}
+
+// Remove retire un système
+func (schedule *Schedule) Remove(name string) {
+	schedule.entries = slices.DeleteFunc(schedule.entries, func(entry *SystemEntry) bool {
+		return entry.name == name
+	})
+	schedule.order = nil
+}
+
+// System retourne un système par son nom (nil si absent)
+func (schedule *Schedule) System(name string) *SystemEntry {
+	for _, entry := range schedule.entries {
+		if entry.name == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Order retourne les noms des systèmes dans l'ordre d'exécution
+func (schedule *Schedule) Order() ([]string, error) {
+	if err := schedule.Build(); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(schedule.order))
+	for _, entry := range schedule.order {
+		names = append(names, entry.name)
+	}
+	return names, nil
+}
+
+// Build calcule l'ordre d'exécution (erreur si un système est inconnu ou si les contraintes forment un cycle)
+func (schedule *Schedule) Build() error {
+	if schedule.order != nil {
+		return nil
+	}
+	indexes := make(map[string]int, len(schedule.entries))
+	for index, entry := range schedule.entries {
+		indexes[entry.name] = index
+	}
+	// successors[i] contient les systèmes à exécuter après le système i
+	successors := make([][]int, len(schedule.entries))
+	predecessorCounts := make([]int, len(schedule.entries))
+	addConstraint := func(first, second int) {
+		successors[first] = append(successors[first], second)
+		predecessorCounts[second]++
+	}
+	for index, entry := range schedule.entries {
+		for _, name := range entry.after {
+			other, found := indexes[name]
+			if !found {
+				return fmt.Errorf("system '%s' must run after unknown system '%s'", entry.name, name)
+			}
+			addConstraint(other, index)
+		}
+		for _, name := range entry.before {
+			other, found := indexes[name]
+			if !found {
+				return fmt.Errorf("system '%s' must run before unknown system '%s'", entry.name, name)
+			}
+			addConstraint(index, other)
+		}
+	}
+	// Tri topologique (à contraintes égales, l'ordre d'ajout est conservé)
+	order := make([]*SystemEntry, 0, len(schedule.entries))
+	done := make([]bool, len(schedule.entries))
+	for len(order) < len(schedule.entries) {
+		next := -1
+		for index := range schedule.entries {
+			if !done[index] && predecessorCounts[index] == 0 {
+				next = index
+				break
+			}
+		}
+		if next < 0 {
+			return fmt.Errorf("systems ordering constraints contain a cycle")
+		}
+		done[next] = true
+		order = append(order, schedule.entries[next])
+		for _, successor := range successors[next] {
+			predecessorCounts[successor]--
+		}
+	}
+	schedule.order = order
+	return nil
+}
+
+// Run exécute les systèmes actifs dont les critères d'exécution sont vrais
+func (schedule *Schedule) Run(world *World, timer *engine.Timer) error {
+	if err := schedule.Build(); err != nil {
+		return fmt.Errorf("failed to run systems\n - %w", err)
+	}
+	for _, entry := range schedule.order {
+		if !entry.enabled || !entry.shouldRun(world, timer) {
+			continue
+		}
+		entry.system.Run(world, timer)
+		world.commands.Apply(world)
+	}
+	return nil
+}
+
+// shouldRun vérifie les critères d'exécution du système
+func (entry *SystemEntry) shouldRun(world *World, timer *engine.Timer) bool {
+	for _, criteria := range entry.criteria {
+		if !criteria(world, timer) {
+			return false
+		}
+	}
+	return true
+}
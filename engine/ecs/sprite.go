@@ -0,0 +1,105 @@
+package ecs
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+	"slices"
+)
+
+// Transform est la position, l'échelle et la rotation d'une entité
+type Transform struct {
+	Position mgl32.Vec2
+	Scale    mgl32.Vec2
+	Rotation graphic.Angle
+}
+
+// BuildTransform construit une transformation sans échelle ni rotation
+func BuildTransform(position mgl32.Vec2) Transform {
+	return Transform{
+		Position: position,
+		Scale:    mgl32.Vec2{1, 1},
+	}
+}
+
+// Sprite est l'image dessinée à la position d'une entité (avec un composant Transform)
+type Sprite struct {
+	Texture *graphic.Texture
+	// Source est la zone de la texture à dessiner (toute la texture si la zone est vide)
+	Source graphic.Rectangle
+	// Size est la dimension du sprite (dimension de la zone source si elle est nulle)
+	Size mgl32.Vec2
+	// Origin est le point du sprite placé à la position de l'entité et centre de rotation
+	// (ratio relatif à la dimension du sprite : 0 = à gauche ou en haut / 1 = à droite ou en bas)
+	Origin mgl32.Vec2
+	Color  graphic.Color
+	// Layer est la couche du sprite (les couches basses sont dessinées en premier)
+	Layer   int
+	Visible bool
+}
+
+// BuildSprite construit un sprite visible affichant toute la texture, centré sur la position de l'entité
+func BuildSprite(texture *graphic.Texture) Sprite {
+	return Sprite{
+		Texture: texture,
+		Origin:  mgl32.Vec2{0.5, 0.5},
+		Color:   graphic.White,
+		Visible: true,
+	}
+}
+
+// spriteToDraw est un sprite à dessiner (trié par couche)
+type spriteToDraw struct {
+	sprite    *Sprite
+	transform *Transform
+}
+
+// SpriteRenderer dessine les entités ayant un composant Sprite et un composant Transform
+type SpriteRenderer struct {
+	renderer *graphic.Renderer2d
+	query    *Query2[Sprite, Transform]
+	// sprites est réutilisé d'un dessin à l'autre (pas d'allocation à chaque frame)
+	sprites []spriteToDraw
+}
+
+// NewSpriteRenderer construit un système de dessin des sprites (le rendu doit être démarré par Renderer2d.Begin)
+func NewSpriteRenderer(world *World, renderer *graphic.Renderer2d) *SpriteRenderer {
+	return &SpriteRenderer{
+		renderer: renderer,
+		query:    NewQuery2[Sprite, Transform](world),
+		sprites:  make([]spriteToDraw, 0),
+	}
+}
+
+func (spriteRenderer *SpriteRenderer) Run(world *World, timer *engine.Timer) {
+	spriteRenderer.sprites = spriteRenderer.sprites[:0]
+	spriteRenderer.query.Each(func(entity Entity, sprite *Sprite, transform *Transform) {
+		if sprite.Visible && sprite.Texture != nil {
+			spriteRenderer.sprites = append(spriteRenderer.sprites, spriteToDraw{sprite: sprite, transform: transform})
+		}
+	})
+	// Les requêtes parcourent les entités de la fin vers le début : conserver l'ordre de création à couche égale
+	slices.Reverse(spriteRenderer.sprites)
+	slices.SortStableFunc(spriteRenderer.sprites, func(first, second spriteToDraw) int {
+		return first.sprite.Layer - second.sprite.Layer
+	})
+	for _, toDraw := range spriteRenderer.sprites {
+		DrawSprite(spriteRenderer.renderer, toDraw.sprite, toDraw.transform)
+	}
+}
+
+// DrawSprite dessine un sprite avec sa transformation
+func DrawSprite(renderer *graphic.Renderer2d, sprite *Sprite, transform *Transform) {
+	source := sprite.Source
+	if source.Width() == 0 || source.Height() == 0 {
+		source = sprite.Texture.Rectangle()
+	}
+	size := sprite.Size
+	if size.X() == 0 && size.Y() == 0 {
+		size = source.Dim()
+	}
+	size = mgl32.Vec2{size.X() * transform.Scale.X(), size.Y() * transform.Scale.Y()}
+	origin := mgl32.Vec2{sprite.Origin.X() * size.X(), sprite.Origin.Y() * size.Y()}
+	renderer.DrawSpriteExWithRotateAndColor(sprite.Texture, source.Pos(), source.Dim(),
+		transform.Position.Sub(origin), size, origin, transform.Rotation, sprite.Color)
+}
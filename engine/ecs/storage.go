@@ -0,0 +1,116 @@
+package ecs
+
+// componentStorage est la partie non typée d'un stockage de composants (utilisée par le monde)
+type componentStorage interface {
+	Has(entity Entity) bool
+	Remove(entity Entity)
+	Len() int
+	Clear()
+}
+
+// Storage stocke les composants d'un type dans un "sparse set" :
+// les composants sont contigus en mémoire (parcours rapide) et retrouvés en temps constant à partir de l'entité.
+type Storage[T any] struct {
+	// sparse contient, par index d'entité, la position du composant + 1 (0 si l'entité n'a pas de composant)
+	sparse []int32
+	// entities contient les entités, dans l'ordre des composants
+	entities []Entity
+	// components contient les composants
+	components []T
+}
+
+// NewStorage construit un stockage de composants vide
+func NewStorage[T any]() *Storage[T] {
+	return &Storage[T]{
+		sparse:     make([]int32, 0),
+		entities:   make([]Entity, 0),
+		components: make([]T, 0),
+	}
+}
+
+// position retourne la position du composant de l'entité (-1 si l'entité n'a pas de composant)
+func (storage *Storage[T]) position(entity Entity) int {
+	index := int(entity.Index())
+	if index >= len(storage.sparse) {
+		return -1
+	}
+	position := int(storage.sparse[index]) - 1
+	if position < 0 || storage.entities[position] != entity {
+		return -1
+	}
+	return position
+}
+
+// Has indique si l'entité a un composant
+func (storage *Storage[T]) Has(entity Entity) bool {
+	return storage.position(entity) >= 0
+}
+
+// Get retourne le composant de l'entité (nil si l'entité n'a pas de composant)
+//
+// Le pointeur n'est valide que jusqu'au prochain ajout ou retrait de composant dans le stockage.
+func (storage *Storage[T]) Get(entity Entity) *T {
+	position := storage.position(entity)
+	if position < 0 {
+		return nil
+	}
+	return &storage.components[position]
+}
+
+// Set ajoute ou remplace le composant de l'entité
+func (storage *Storage[T]) Set(entity Entity, component T) {
+	if position := storage.position(entity); position >= 0 {
+		storage.components[position] = component
+		return
+	}
+	index := int(entity.Index())
+	if index >= len(storage.sparse) {
+		storage.sparse = append(storage.sparse, make([]int32, index+1-len(storage.sparse))...)
+	}
+	storage.entities = append(storage.entities, entity)
+	storage.components = append(storage.components, component)
+	storage.sparse[index] = int32(len(storage.entities))
+}
+
+// Remove retire le composant de l'entité (le dernier composant prend sa place)
+func (storage *Storage[T]) Remove(entity Entity) {
+	position := storage.position(entity)
+	if position < 0 {
+		return
+	}
+	last := len(storage.entities) - 1
+	if position != last {
+		lastEntity := storage.entities[last]
+		storage.entities[position] = lastEntity
+		storage.components[position] = storage.components[last]
+		storage.sparse[lastEntity.Index()] = int32(position + 1)
+	}
+	var zero T
+	storage.components[last] = zero
+	storage.entities = storage.entities[:last]
+	storage.components = storage.components[:last]
+	storage.sparse[entity.Index()] = 0
+}
+
+// Len retourne le nombre de composants
+func (storage *Storage[T]) Len() int {
+	return len(storage.entities)
+}
+
+// Entities retourne les entités ayant un composant (dans l'ordre des composants, ne pas modifier)
+func (storage *Storage[T]) Entities() []Entity {
+	return storage.entities
+}
+
+// Components retourne les composants (dans l'ordre des entités)
+func (storage *Storage[T]) Components() []T {
+	return storage.components
+}
+
+// Clear retire tous les composants
+func (storage *Storage[T]) Clear() {
+	clear(storage.sparse)
+	clear(storage.components)
+	storage.entities = storage.entities[:0]
+	storage.components = storage.components[:0]
+}
@@ -0,0 +1,160 @@
+package ecs
+
+import (
+	"reflect"
+)
+
+// World contient les entités, leurs composants (un stockage par type de composant) et les ressources partagées
+type World struct {
+	// generations contient la génération courante de chaque index d'entité
+	generations []uint32
+	// alive indique si l'entité de chaque index est vivante
+	alive []bool
+	// freeIndexes contient les index des entités détruites (réutilisés par les nouvelles entités)
+	freeIndexes []uint32
+	// count est le nombre d'entités vivantes
+	count int
+
+	// storages contient les stockages de composants par type de composant
+	storages map[reflect.Type]componentStorage
+	// storageList contient les stockages dans l'ordre de création (pour la destruction des entités)
+	storageList []componentStorage
+
+	// resources contient les ressources partagées par les systèmes (une par type)
+	resources map[reflect.Type]any
+
+	// commands contient les modifications différées (appliquées après chaque système)
+	commands CommandBuffer
+}
+
+// NewWorld construit un monde vide
+func NewWorld() *World {
+	return &World{
+		generations: make([]uint32, 0),
+		alive:       make([]bool, 0),
+		freeIndexes: make([]uint32, 0),
+		storages:    make(map[reflect.Type]componentStorage),
+		storageList: make([]componentStorage, 0),
+		resources:   make(map[reflect.Type]any),
+	}
+}
+
+// CreateEntity crée une nouvelle entité (sans composant)
+func (world *World) CreateEntity() Entity {
+	world.count++
+	if freeCount := len(world.freeIndexes); freeCount > 0 {
+		index := world.freeIndexes[freeCount-1]
+		world.freeIndexes = world.freeIndexes[:freeCount-1]
+		world.alive[index] = true
+		return buildEntity(index, world.generations[index])
+	}
+	index := uint32(len(world.generations))
+	world.generations = append(world.generations, 1)
+	world.alive = append(world.alive, true)
+	return buildEntity(index, 1)
+}
+
+// DestroyEntity détruit une entité et retire tous ses composants
+func (world *World) DestroyEntity(entity Entity) {
+	if !world.IsAlive(entity) {
+		return
+	}
+	for _, storage := range world.storageList {
+		storage.Remove(entity)
+	}
+	index := entity.Index()
+	world.alive[index] = false
+	world.generations[index]++
+	if world.generations[index] == 0 {
+		// La génération 0 est réservée à NO_ENTITY
+		world.generations[index] = 1
+	}
+	world.freeIndexes = append(world.freeIndexes, index)
+	world.count--
+}
+
+// IsAlive indique si l'entité existe toujours
+func (world *World) IsAlive(entity Entity) bool {
+	index := int(entity.Index())
+	return index < len(world.generations) && world.alive[index] && world.generations[index] == entity.Generation()
+}
+
+// EntityCount retourne le nombre d'entités vivantes
+func (world *World) EntityCount() int {
+	return world.count
+}
+
+// Clear détruit toutes les entités et retire toutes les ressources
+func (world *World) Clear() {
+	for _, storage := range world.storageList {
+		storage.Clear()
+	}
+	for index := range world.generations {
+		if world.alive[index] {
+			world.DestroyEntity(buildEntity(uint32(index), world.generations[index]))
+		}
+	}
+	clear(world.resources)
+	world.commands.commands = world.commands.commands[:0]
+}
+
+// Commands retourne les modifications différées du monde
+//
+// Les entités ne doivent pas être créées / détruites (et les composants ajoutés / retirés) pendant le parcours d'une
+// requête, sauf pour l'entité en cours : ces modifications sont enregistrées puis appliquées après le système.
+func (world *World) Commands() *CommandBuffer {
+	return &world.commands
+}
+
+// typeOf retourne le type d'un composant ou d'une ressource
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// StorageOf retourne le stockage des composants d'un type (créé au besoin)
+func StorageOf[T any](world *World) *Storage[T] {
+	componentType := typeOf[T]()
+	if storage, found := world.storages[componentType]; found {
+		return storage.(*Storage[T])
+	}
+	storage := NewStorage[T]()
+	world.storages[componentType] = storage
+	world.storageList = append(world.storageList, storage)
+	return storage
+}
+
+// AddComponent ajoute (ou remplace) un composant à une entité vivante
+func AddComponent[T any](world *World, entity Entity, component T) {
+	if world.IsAlive(entity) {
+		StorageOf[T](world).Set(entity, component)
+	}
+}
+
+// GetComponent retourne le composant d'une entité (nil si absent)
+func GetComponent[T any](world *World, entity Entity) *T {
+	return StorageOf[T](world).Get(entity)
+}
+
+// HasComponent indique si une entité a un composant
+func HasComponent[T any](world *World, entity Entity) bool {
+	return StorageOf[T](world).Has(entity)
+}
+
+// RemoveComponent retire un composant d'une entité
+func RemoveComponent[T any](world *World, entity Entity) {
+	StorageOf[T](world).Remove(entity)
+}
+
+// SetResource enregistre une ressource partagée (une seule ressource par type)
+func SetResource[T any](world *World, resource *T) {
+	world.resources[typeOf[T]()] = resource
+}
+
+// Resource retourne une ressource partagée (nil si absente)
+func Resource[T any](world *World) *T {
+	resource, found := world.resources[typeOf[T]()]
+	if !found {
+		return nil
+	}
+	return resource.(*T)
+}
@@ -0,0 +1,80 @@
+package ecs
+
+import (
+	"ogl46/engine"
+	"testing"
+)
+
+const benchmarkEntityCount = 100_000
+
+// buildBenchmarkWorld construit un monde de 100k entités (une sur deux est mobile)
+func buildBenchmarkWorld() *World {
+	world := NewWorld()
+	for index := 0; index < benchmarkEntityCount; index++ {
+		entity := world.CreateEntity()
+		AddComponent(world, entity, position{float32(index), 0})
+		if index%2 == 0 {
+			AddComponent(world, entity, velocity{1, 1})
+		}
+	}
+	return world
+}
+
+func BenchmarkWorld_Create100k(b *testing.B) {
+	for iteration := 0; iteration < b.N; iteration++ {
+		buildBenchmarkWorld()
+	}
+}
+
+func BenchmarkQuery1_Each100k(b *testing.B) {
+	world := buildBenchmarkWorld()
+	query := NewQuery1[position](world)
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		query.Each(func(entity Entity, position *position) {
+			position.x++
+		})
+	}
+}
+
+func BenchmarkQuery2_Each100k(b *testing.B) {
+	world := buildBenchmarkWorld()
+	query := NewQuery2[position, velocity](world)
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		query.Each(func(entity Entity, position *position, velocity *velocity) {
+			position.x += velocity.x
+			position.y += velocity.y
+		})
+	}
+}
+
+func BenchmarkSchedule_Run100k(b *testing.B) {
+	world := buildBenchmarkWorld()
+	query := NewQuery2[position, velocity](world)
+	schedule := NewSchedule()
+	schedule.AddFunc("move", func(world *World, timer *engine.Timer) {
+		query.Each(func(entity Entity, position *position, velocity *velocity) {
+			position.x += velocity.x * float32(timer.ElapsedTime())
+		})
+	})
+	schedule.AddFunc("respawn", func(world *World, timer *engine.Timer) {
+		// Détruire et recréer 1% des entités à chaque frame
+		for index := 0; index < benchmarkEntityCount/100; index++ {
+			entity := StorageOf[position](world).Entities()[index]
+			world.Commands().Destroy(entity)
+			world.Commands().Create(func(world *World, entity Entity) {
+				AddComponent(world, entity, position{})
+				AddComponent(world, entity, velocity{1, 1})
+			})
+		}
+	}).After("move")
+	timer := engine.BuildTimerAt(0)
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		timer = timer.NextTimerAt(timer.CurrentTime() + 16)
+		if err := schedule.Run(world, &timer); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ecs
+
+import (
+	"ogl46/engine"
+	"testing"
+)
+
+type position struct{ x, y float32 }
+type velocity struct{ x, y float32 }
+type frozen struct{}
+
+func TestWorld_Entities(t *testing.T) {
+	world := NewWorld()
+	first := world.CreateEntity()
+	second := world.CreateEntity()
+	world.DestroyEntity(first)
+	if world.IsAlive(first) || !world.IsAlive(second) || world.EntityCount() != 1 {
+		t.Fatalf("DestroyEntity() alive=(%v, %v) count=%d, want (false, true) 1", world.IsAlive(first), world.IsAlive(second), world.EntityCount())
+	}
+	// L'index est réutilisé avec une nouvelle génération
+	third := world.CreateEntity()
+	if third.Index() != first.Index() || third == first || world.IsAlive(first) {
+		t.Fatalf("CreateEntity() = %v, want index %d with a new generation", third, first.Index())
+	}
+}
+
+func TestWorld_Components(t *testing.T) {
+	world := NewWorld()
+	entities := make([]Entity, 0)
+	for index := 0; index < 5; index++ {
+		entity := world.CreateEntity()
+		AddComponent(world, entity, position{float32(index), 0})
+		entities = append(entities, entity)
+	}
+	RemoveComponent[position](world, entities[1])
+	world.DestroyEntity(entities[3])
+	if HasComponent[position](world, entities[1]) || GetComponent[position](world, entities[3]) != nil {
+		t.Fatalf("RemoveComponent() / DestroyEntity() kept components")
+	}
+	for _, index := range []int{0, 2, 4} {
+		if component := GetComponent[position](world, entities[index]); component == nil || component.x != float32(index) {
+			t.Fatalf("GetComponent(%d) = %v, want x=%d", index, component, index)
+		}
+	}
+	// Une entité détruite ne reçoit plus de composant
+	AddComponent(world, entities[3], position{})
+	if StorageOf[position](world).Len() != 3 {
+		t.Fatalf("StorageOf().Len() = %d, want 3", StorageOf[position](world).Len())
+	}
+}
+
+func TestQuery_Each(t *testing.T) {
+	world := NewWorld()
+	for index := 0; index < 10; index++ {
+		entity := world.CreateEntity()
+		AddComponent(world, entity, position{})
+		if index%2 == 0 {
+			AddComponent(world, entity, velocity{1, 2})
+		}
+		if index%4 == 0 {
+			AddComponent(world, entity, frozen{})
+		}
+	}
+	query := NewQuery2[position, velocity](world, Without[frozen](world))
+	if count := query.Count(); count != 2 {
+		t.Fatalf("Count() = %d, want 2", count)
+	}
+	// L'entité en cours peut être détruite pendant le parcours
+	visited := 0
+	NewQuery1[position](world).Each(func(entity Entity, position *position) {
+		visited++
+		world.DestroyEntity(entity)
+	})
+	if visited != 10 || world.EntityCount() != 0 {
+		t.Fatalf("Each() visited=%d count=%d, want 10 0", visited, world.EntityCount())
+	}
+}
+
+func TestSchedule_Run(t *testing.T) {
+	world := NewWorld()
+	schedule := NewSchedule()
+	calls := make([]string, 0)
+	record := func(name string) func(*World, *engine.Timer) {
+		return func(*World, *engine.Timer) { calls = append(calls, name) }
+	}
+	schedule.AddFunc("render", record("render"))
+	schedule.AddFunc("physics", record("physics")).Before("render")
+	schedule.AddFunc("input", record("input")).Before("physics")
+	schedule.AddFunc("spawn", func(world *World, timer *engine.Timer) {
+		world.Commands().Create(nil)
+	}).After("input").RunIf(RunEvery(100))
+
+	timer := engine.BuildTimerAt(0)
+	for _, currentTime := range []int64{50, 100, 150, 200} {
+		timer = timer.NextTimerAt(currentTime)
+		if err := schedule.Run(world, &timer); err != nil {
+			t.Fatalf("Run() error=%v", err)
+		}
+	}
+	order, _ := schedule.Order()
+	if want := []string{"input", "physics", "render", "spawn"}; len(order) != len(want) || order[0] != want[0] || order[1] != want[1] || order[2] != want[2] || order[3] != want[3] {
+		t.Fatalf("Order() = %v, want %v", order, want)
+	}
+	if world.EntityCount() != 2 {
+		t.Fatalf("Run() entities=%d, want 2 (spawn every 100 ms)", world.EntityCount())
+	}
+
+	schedule.System("input").After("render")
+	if err := schedule.Run(world, &timer); err == nil {
+		t.Fatalf("Run() error=nil, want cycle error")
+	}
+}
@@ -0,0 +1,94 @@
+package ecs
+
+import (
+	"log/slog"
+	"ogl46/engine"
+	"ogl46/engine/input"
+)
+
+// WorldStage est un stage exécutant les systèmes d'un monde
+//
+// Les systèmes de traitement (ExecuteSchedule) sont exécutés à chaque traitement, les systèmes de dessin
+// (DisplaySchedule) entre Renderer2d.Begin et Renderer2d.End. Un SpriteRenderer est ajouté aux systèmes de dessin
+// sous le nom SPRITE_RENDERER_SYSTEM.
+type WorldStage struct {
+	world           *World
+	executeSchedule *Schedule
+	displaySchedule *Schedule
+
+	// OnInitialize est appelée à l'initialisation du stage (création des entités et des systèmes)
+	OnInitialize func(app *engine.Application, stage *WorldStage) error
+	// OnRelease est appelée à la libération du stage
+	OnRelease func(app *engine.Application, stage *WorldStage)
+	// OnEvent est appelée pour chaque évènement (la fermeture de la fenêtre arrête l'application si elle est absente)
+	OnEvent func(app *engine.Application, stage *WorldStage, event input.Event, timer *engine.Timer)
+}
+
+// SPRITE_RENDERER_SYSTEM est le nom du système de dessin des sprites
+const SPRITE_RENDERER_SYSTEM = "sprite-renderer"
+
+// NewWorldStage construit un stage avec un monde vide
+func NewWorldStage() *WorldStage {
+	return &WorldStage{
+		world:           NewWorld(),
+		executeSchedule: NewSchedule(),
+		displaySchedule: NewSchedule(),
+	}
+}
+
+func (stage *WorldStage) World() *World {
+	return stage.world
+}
+
+// ExecuteSchedule retourne les systèmes exécutés à chaque traitement
+func (stage *WorldStage) ExecuteSchedule() *Schedule {
+	return stage.executeSchedule
+}
+
+// DisplaySchedule retourne les systèmes exécutés à chaque dessin
+func (stage *WorldStage) DisplaySchedule() *Schedule {
+	return stage.displaySchedule
+}
+
+func (stage *WorldStage) Initialize(app *engine.Application) error {
+	if stage.displaySchedule.System(SPRITE_RENDERER_SYSTEM) == nil {
+		stage.displaySchedule.Add(SPRITE_RENDERER_SYSTEM, NewSpriteRenderer(stage.world, app.Renderer2d()))
+	}
+	if stage.OnInitialize != nil {
+		return stage.OnInitialize(app, stage)
+	}
+	return nil
+}
+
+func (stage *WorldStage) Release(app *engine.Application) {
+	if stage.OnRelease != nil {
+		stage.OnRelease(app, stage)
+	}
+	stage.world.Clear()
+}
+
+func (stage *WorldStage) Display(app *engine.Application, timer *engine.Timer) {
+	width, height := app.Size()
+	app.Renderer2d().Begin(float32(width), float32(height))
+	defer app.Renderer2d().End()
+	if err := stage.displaySchedule.Run(stage.world, timer); err != nil {
+		slog.Error("failed to display world", "error", err)
+	}
+}
+
+func (stage *WorldStage) Execute(app *engine.Application, timer *engine.Timer) {
+	if err := stage.executeSchedule.Run(stage.world, timer); err != nil {
+		slog.Error("failed to execute world", "error", err)
+	}
+}
+
+func (stage *WorldStage) ProcessEvent(app *engine.Application, event input.Event, timer *engine.Timer) {
+	if stage.OnEvent != nil {
+		stage.OnEvent(app, stage, event, timer)
+		stage.world.commands.Apply(stage.world)
+		return
+	}
+	if event.Source() == input.WINDOW && event.(*input.WindowEvent).Action() == input.WINDOW_CLOSED {
+		app.Stop()
+	}
+}
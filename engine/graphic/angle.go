@@ -50,3 +50,24 @@ func (angle Angle) Mod360() Angle {
 		return AngleInDegree(angleInDeg360)
 	}
 }
+
+// Normalize180 normalise l'angle entre -180° (exclu) et 180° (inclus)
+func (angle Angle) Normalize180() Angle {
+	normalized := math.Mod(angle.Radian(), 2*math.Pi)
+	if normalized <= -math.Pi {
+		normalized += 2 * math.Pi
+	} else if normalized > math.Pi {
+		normalized -= 2 * math.Pi
+	}
+	return Angle(normalized)
+}
+
+// ShortestDifference retourne la rotation la plus courte pour aller de l'angle vers l'angle cible (entre -180° et 180°)
+func (angle Angle) ShortestDifference(target Angle) Angle {
+	return (target - angle).Normalize180()
+}
+
+// Lerp interpole l'angle vers l'angle cible par le chemin le plus court (ratio entre 0 et 1)
+func (angle Angle) Lerp(target Angle, ratio float64) Angle {
+	return angle + angle.ShortestDifference(target)*Angle(ratio)
+}
@@ -0,0 +1,34 @@
+package graphic
+
+import (
+	"math"
+	"testing"
+)
+
+func assertDegree(t *testing.T, name string, angle Angle, want float64) {
+	t.Helper()
+	if math.Abs(angle.Degree()-want) > 1e-9 {
+		t.Fatalf("%s = %v°, want %v°", name, angle.Degree(), want)
+	}
+}
+
+func TestAngle_Normalize180(t *testing.T) {
+	for _, test := range []struct{ angle, want float64 }{
+		{0, 0}, {190, -170}, {-190, 170}, {180, 180}, {-180, 180}, {720 + 45, 45}, {-540, 180},
+	} {
+		assertDegree(t, "Normalize180()", AngleInDegree(test.angle).Normalize180(), test.want)
+	}
+}
+
+func TestAngle_ShortestDifference(t *testing.T) {
+	assertDegree(t, "ShortestDifference(350 -> 10)", AngleInDegree(350).ShortestDifference(AngleInDegree(10)), 20)
+	assertDegree(t, "ShortestDifference(10 -> 350)", AngleInDegree(10).ShortestDifference(AngleInDegree(350)), -20)
+	assertDegree(t, "ShortestDifference(0 -> 90)", AngleInDegree(0).ShortestDifference(AngleInDegree(90)), 90)
+}
+
+func TestAngle_Lerp(t *testing.T) {
+	// Passer par 0° plutôt que par 180°
+	assertDegree(t, "Lerp(350 -> 10, 0.5)", AngleInDegree(350).Lerp(AngleInDegree(10), 0.5).Mod360(), 0)
+	assertDegree(t, "Lerp(0 -> 90, 0.25)", AngleInDegree(0).Lerp(AngleInDegree(90), 0.25), 22.5)
+	assertDegree(t, "Lerp(170 -> -170, 1)", AngleInDegree(170).Lerp(AngleInDegree(-170), 1), 190)
+}
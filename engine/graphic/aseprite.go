@@ -0,0 +1,151 @@
+package graphic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+)
+
+// asepriteDefaultClip is the clip name used when Aseprite export has no tag.
+const asepriteDefaultClip = "default"
+
+// asepriteRect is a rectangle in Aseprite export.
+type asepriteRect struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	W float32 `json:"w"`
+	H float32 `json:"h"`
+}
+
+// asepriteFrame is a frame in Aseprite export.
+type asepriteFrame struct {
+	Frame    asepriteRect `json:"frame"`
+	Duration int64        `json:"duration"`
+}
+
+// asepriteTag is a frame tag (animation) in Aseprite export.
+type asepriteTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+	Repeat    string `json:"repeat"`
+}
+
+// asepriteExport is an Aseprite JSON export ("frames" is an array or a hash).
+type asepriteExport struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		Image     string        `json:"image"`
+		FrameTags []asepriteTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// LoadSpriteSheetFromAsepriteFile loads clips from an Aseprite JSON export file.
+func LoadSpriteSheetFromAsepriteFile(filename string) (*SpriteSheet, error) {
+	slog.Debug("sprite sheet creation from Aseprite file", "filename", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Aseprite file '%s'\n - %w", filename, err)
+	}
+	sheet, err := LoadSpriteSheetFromAsepriteBytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sprite sheet from Aseprite file '%s'\n - %w", filename, err)
+	}
+	return sheet, nil
+}
+
+// LoadSpriteSheetFromAsepriteBytes loads clips from an Aseprite JSON export.
+//
+//	Each frame tag becomes a clip ("forward", "reverse", "pingpong" and "pingpong_reverse" directions are supported,
+//	a tag repeated once is played once). Without tag, all frames are in "default" clip.
+func LoadSpriteSheetFromAsepriteBytes(content []byte) (*SpriteSheet, error) {
+	slog.Debug("sprite sheet creation from Aseprite byte array")
+	export := asepriteExport{}
+	if err := json.Unmarshal(content, &export); err != nil {
+		return nil, fmt.Errorf("failed to decode Aseprite export\n - %w", err)
+	}
+	frames, err := decodeAsepriteFrames(export.Frames)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("Aseprite export has no frame")
+	}
+
+	sheet := BuildSpriteSheet()
+	sheet.Image = export.Meta.Image
+	tags := export.Meta.FrameTags
+	if len(tags) == 0 {
+		tags = []asepriteTag{{Name: asepriteDefaultClip, From: 0, To: len(frames) - 1, Direction: "forward"}}
+	}
+	for _, tag := range tags {
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("Aseprite '%s' tag has invalid frame range [%d, %d] (%d frames)", tag.Name, tag.From, tag.To, len(frames))
+		}
+		clip := &AnimationClip{
+			Name:   tag.Name,
+			Frames: make([]AnimationFrame, 0, tag.To-tag.From+1),
+			Mode:   ANIMATION_LOOP,
+		}
+		for _, frame := range frames[tag.From : tag.To+1] {
+			clip.Frames = append(clip.Frames, AnimationFrame{
+				Region:   BuildRectangle(frame.Frame.X, frame.Frame.Y, frame.Frame.W, frame.Frame.H),
+				Duration: frame.Duration,
+			})
+		}
+		switch tag.Direction {
+		case "", "forward":
+		case "reverse":
+			slices.Reverse(clip.Frames)
+		case "pingpong":
+			clip.Mode = ANIMATION_PING_PONG
+		case "pingpong_reverse":
+			slices.Reverse(clip.Frames)
+			clip.Mode = ANIMATION_PING_PONG
+		default:
+			return nil, fmt.Errorf("Aseprite '%s' tag has unsupported '%s' direction", tag.Name, tag.Direction)
+		}
+		if tag.Repeat == "1" && clip.Mode == ANIMATION_LOOP {
+			clip.Mode = ANIMATION_ONCE
+		}
+		sheet.AddClip(clip)
+	}
+	return sheet, nil
+}
+
+// decodeAsepriteFrames decodes frames (array or hash, hash order is kept).
+func decodeAsepriteFrames(raw json.RawMessage) ([]asepriteFrame, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("Aseprite export has no 'frames' field")
+	}
+	if raw[0] == '[' {
+		frames := make([]asepriteFrame, 0)
+		if err := json.Unmarshal(raw, &frames); err != nil {
+			return nil, fmt.Errorf("failed to decode Aseprite frames\n - %w", err)
+		}
+		return frames, nil
+	}
+	// Hash : conserver l'ordre des clés
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("failed to decode Aseprite frames\n - %w", err)
+	}
+	frames := make([]asepriteFrame, 0)
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Aseprite frames\n - %w", err)
+		}
+		frame := asepriteFrame{}
+		if err := decoder.Decode(&frame); err != nil {
+			return nil, fmt.Errorf("failed to decode Aseprite '%v' frame\n - %w", key, err)
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
@@ -0,0 +1,18 @@
+package graphic
+
+import (
+	"ogl46/engine/ogl"
+)
+
+// BlendMode defines how drawn pixels are mixed with pixels already drawn (see ogl.BlendMode).
+type BlendMode = ogl.BlendMode
+
+// List of blend modes
+const (
+	BLEND_NONE          = ogl.BLEND_NONE
+	BLEND_ALPHA         = ogl.BLEND_ALPHA
+	BLEND_PREMULTIPLIED = ogl.BLEND_PREMULTIPLIED
+	BLEND_ADDITIVE      = ogl.BLEND_ADDITIVE
+	BLEND_MULTIPLY      = ogl.BLEND_MULTIPLY
+	BLEND_SCREEN        = ogl.BLEND_SCREEN
+)
@@ -0,0 +1,216 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"math/rand"
+)
+
+// Camera2d is a 2D camera: it shows the part of the world around its position in a screen viewport.
+//
+//	Several cameras with different viewports can be used to split screen (one Renderer2d.BeginWithCamera / End per camera).
+type Camera2d struct {
+	// Position est le point du monde affiché au centre de la vue
+	Position mgl32.Vec2
+	// Zoom est le facteur d'agrandissement (1 = un pixel du monde par pixel de l'écran)
+	Zoom float32
+	// Rotation est la rotation de la caméra (sens inverse des aiguilles d'une montre à l'écran)
+	Rotation Angle
+	// Viewport est la zone de l'écran où la caméra est rendue (coordonnées écran)
+	Viewport Rectangle
+
+	// target retourne la position suivie (nil si la caméra ne suit rien)
+	target func() mgl32.Vec2
+	// followSpeed est la vitesse de rattrapage de la cible (par seconde, 0 = immédiat)
+	followSpeed float32
+	// deadZone est la dimension (en pixels écran) de la zone centrale où la cible peut bouger sans déplacer la caméra
+	deadZone mgl32.Vec2
+
+	// bounds est la zone du monde que la caméra ne doit pas quitter
+	bounds    Rectangle
+	hasBounds bool
+
+	// shakeIntensity est l'amplitude maximale du tremblement (en pixels écran)
+	shakeIntensity float32
+	// shakeDurationInMs et shakeRemainingInMs sont la durée totale et restante du tremblement
+	shakeDurationInMs  int64
+	shakeRemainingInMs int64
+	// shakeOffset est le décalage courant dû au tremblement (coordonnées monde)
+	shakeOffset mgl32.Vec2
+}
+
+// NewCamera2d creates a camera rendered in viewport and centered on the viewport center.
+func NewCamera2d(viewport Rectangle) *Camera2d {
+	return &Camera2d{
+		Position: mgl32.Vec2{viewport.Width() / 2, viewport.Height() / 2},
+		Zoom:     1.,
+		Viewport: viewport,
+	}
+}
+
+// Follow makes the camera follow a position (target is called at each update).
+func (camera *Camera2d) Follow(target func() mgl32.Vec2) {
+	camera.target = target
+}
+
+// StopFollow stops following target.
+func (camera *Camera2d) StopFollow() {
+	camera.target = nil
+}
+
+// SetFollowSpeed defines how fast camera catches up with target (per second, 0 = immediately).
+func (camera *Camera2d) SetFollowSpeed(speed float32) {
+	camera.followSpeed = max(speed, 0)
+}
+
+// SetDeadZone defines the central zone (screen pixels) where target can move without moving camera.
+func (camera *Camera2d) SetDeadZone(width, height float32) {
+	camera.deadZone = mgl32.Vec2{max(width, 0), max(height, 0)}
+}
+
+// SetBounds defines the world zone camera cannot leave.
+func (camera *Camera2d) SetBounds(bounds Rectangle) {
+	camera.bounds = bounds
+	camera.hasBounds = true
+}
+
+// ClearBounds removes world bounds.
+func (camera *Camera2d) ClearBounds() {
+	camera.hasBounds = false
+}
+
+// Shake shakes camera (intensity is maximum offset in screen pixels, it decreases until the end of shake).
+func (camera *Camera2d) Shake(intensity float32, durationInMs int64) {
+	camera.shakeIntensity = intensity
+	camera.shakeDurationInMs = durationInMs
+	camera.shakeRemainingInMs = durationInMs
+}
+
+// IsShaking indicates if camera is shaking.
+func (camera *Camera2d) IsShaking() bool {
+	return camera.shakeRemainingInMs > 0
+}
+
+// Update moves camera toward followed target, clamps it in bounds and updates shake.
+func (camera *Camera2d) Update(elapsedTime int64) {
+	if camera.target != nil {
+		camera.Position = camera.Position.Add(camera.followOffset(camera.target(), elapsedTime))
+	}
+	if camera.hasBounds {
+		camera.clampInBounds()
+	}
+	camera.updateShake(elapsedTime)
+}
+
+// CenterOn moves camera on position immediately (bounds are applied).
+func (camera *Camera2d) CenterOn(position mgl32.Vec2) {
+	camera.Position = position
+	if camera.hasBounds {
+		camera.clampInBounds()
+	}
+}
+
+// followOffset returns camera move to follow target.
+func (camera *Camera2d) followOffset(target mgl32.Vec2, elapsedTime int64) mgl32.Vec2 {
+	// La cible doit rester dans la zone morte (centrée sur la caméra)
+	halfDeadZone := camera.deadZone.Mul(0.5 / camera.EffectiveZoom())
+	delta := target.Sub(camera.Position)
+	offset := mgl32.Vec2{}
+	for axis := 0; axis < 2; axis++ {
+		if delta[axis] > halfDeadZone[axis] {
+			offset[axis] = delta[axis] - halfDeadZone[axis]
+		} else if delta[axis] < -halfDeadZone[axis] {
+			offset[axis] = delta[axis] + halfDeadZone[axis]
+		}
+	}
+	if camera.followSpeed == 0 {
+		return offset
+	}
+	// Rattrapage exponentiel (indépendant de la fréquence d'affichage)
+	factor := 1 - float32(math.Exp(-float64(camera.followSpeed)*float64(elapsedTime)/1000.))
+	return offset.Mul(factor)
+}
+
+// clampInBounds keeps visible zone in bounds (camera is centered on bounds if they are smaller than visible zone).
+func (camera *Camera2d) clampInBounds() {
+	halfView := mgl32.Vec2{camera.Viewport.Width(), camera.Viewport.Height()}.Mul(0.5 / camera.EffectiveZoom())
+	for axis := 0; axis < 2; axis++ {
+		minimum := camera.bounds[axis] + halfView[axis]
+		maximum := camera.bounds[axis] + camera.bounds[axis+2] - halfView[axis]
+		if minimum > maximum {
+			camera.Position[axis] = camera.bounds[axis] + camera.bounds[axis+2]/2
+		} else {
+			camera.Position[axis] = mgl32.Clamp(camera.Position[axis], minimum, maximum)
+		}
+	}
+}
+
+// updateShake computes shake offset.
+func (camera *Camera2d) updateShake(elapsedTime int64) {
+	if camera.shakeRemainingInMs <= 0 {
+		camera.shakeOffset = mgl32.Vec2{}
+		return
+	}
+	camera.shakeRemainingInMs = max(camera.shakeRemainingInMs-elapsedTime, 0)
+	if camera.shakeRemainingInMs == 0 || camera.shakeDurationInMs <= 0 {
+		camera.shakeOffset = mgl32.Vec2{}
+		return
+	}
+	amplitude := camera.shakeIntensity * float32(camera.shakeRemainingInMs) / float32(camera.shakeDurationInMs) / camera.EffectiveZoom()
+	camera.shakeOffset = mgl32.Vec2{(rand.Float32()*2 - 1) * amplitude, (rand.Float32()*2 - 1) * amplitude}
+}
+
+// EffectiveZoom returns the zoom used for rendering (1 if Zoom is not positive).
+func (camera *Camera2d) EffectiveZoom() float32 {
+	if camera.Zoom <= 0 {
+		return 1
+	}
+	return camera.Zoom
+}
+
+// ViewMatrix returns the transformation from world coordinates to viewport coordinates (origin at viewport top left corner).
+func (camera *Camera2d) ViewMatrix() mgl32.Mat4 {
+	center := camera.Position.Add(camera.shakeOffset)
+	view := mgl32.Translate3D(camera.Viewport.Width()/2, camera.Viewport.Height()/2, 0.)
+	if camera.Rotation != 0 {
+		view = view.Mul4(mgl32.HomogRotate3DZ(float32(camera.Rotation)))
+	}
+	view = view.Mul4(mgl32.Scale3D(camera.EffectiveZoom(), camera.EffectiveZoom(), 1.))
+	return view.Mul4(mgl32.Translate3D(-center.X(), -center.Y(), 0.))
+}
+
+// WorldToScreen converts a world position to a screen position.
+func (camera *Camera2d) WorldToScreen(position mgl32.Vec2) mgl32.Vec2 {
+	viewPosition := camera.ViewMatrix().Mul4x1(mgl32.Vec4{position.X(), position.Y(), 0., 1.})
+	return mgl32.Vec2{viewPosition.X() + camera.Viewport.X(), viewPosition.Y() + camera.Viewport.Y()}
+}
+
+// ScreenToWorld converts a screen position (mouse for example) to a world position.
+func (camera *Camera2d) ScreenToWorld(position mgl32.Vec2) mgl32.Vec2 {
+	worldPosition := camera.ViewMatrix().Inv().Mul4x1(mgl32.Vec4{
+		position.X() - camera.Viewport.X(), position.Y() - camera.Viewport.Y(), 0., 1.,
+	})
+	return mgl32.Vec2{worldPosition.X(), worldPosition.Y()}
+}
+
+// InViewport indicates if a screen position is in camera viewport.
+func (camera *Camera2d) InViewport(position mgl32.Vec2) bool {
+	return camera.Viewport.In(position)
+}
+
+// VisibleRect returns the world zone visible by camera (bounding box if camera is rotated).
+//
+//	It can be used to cull what is drawn (tile maps for example).
+func (camera *Camera2d) VisibleRect() Rectangle {
+	inverse := camera.ViewMatrix().Inv()
+	minimum := mgl32.Vec2{float32(math.Inf(1)), float32(math.Inf(1))}
+	maximum := mgl32.Vec2{float32(math.Inf(-1)), float32(math.Inf(-1))}
+	for _, corner := range []mgl32.Vec2{
+		{0, 0}, {camera.Viewport.Width(), 0}, {0, camera.Viewport.Height()}, {camera.Viewport.Width(), camera.Viewport.Height()},
+	} {
+		world := inverse.Mul4x1(mgl32.Vec4{corner.X(), corner.Y(), 0., 1.})
+		minimum = mgl32.Vec2{min(minimum.X(), world.X()), min(minimum.Y(), world.Y())}
+		maximum = mgl32.Vec2{max(maximum.X(), world.X()), max(maximum.Y(), world.Y())}
+	}
+	return BuildRectFromPosAndDim(minimum, maximum.Sub(minimum))
+}
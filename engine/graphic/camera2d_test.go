@@ -0,0 +1,115 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"testing"
+)
+
+func TestCamera2dConversion(t *testing.T) {
+	camera := NewCamera2d(BuildRectangle(100, 50, 200, 100))
+	camera.Position = mgl32.Vec2{500, 300}
+	camera.Zoom = 2
+	if screen := camera.WorldToScreen(mgl32.Vec2{510, 305}); !screen.ApproxEqual(mgl32.Vec2{220, 110}) {
+		t.Fatalf("WorldToScreen() = %v, want (220, 110)", screen)
+	}
+	if world := camera.ScreenToWorld(mgl32.Vec2{220, 110}); !world.ApproxEqualThreshold(mgl32.Vec2{510, 305}, 1e-3) {
+		t.Fatalf("ScreenToWorld() = %v, want (510, 305)", world)
+	}
+	if visible := camera.VisibleRect(); !mgl32.Vec4(visible).ApproxEqualThreshold(mgl32.Vec4{450, 275, 100, 50}, 1e-3) {
+		t.Fatalf("VisibleRect() = %v, want [450 275 100 50]", visible)
+	}
+
+	camera.Zoom = 1
+	camera.Rotation = math.Pi / 2
+	world := camera.ScreenToWorld(camera.WorldToScreen(mgl32.Vec2{520, 290}))
+	if !world.ApproxEqualThreshold(mgl32.Vec2{520, 290}, 1e-3) {
+		t.Fatalf("ScreenToWorld(WorldToScreen()) = %v, want (520, 290)", world)
+	}
+	// Caméra tournée d'un quart de tour : la zone visible est "couchée"
+	if visible := camera.VisibleRect(); !mgl32.Vec4(visible).ApproxEqualThreshold(mgl32.Vec4{450, 200, 100, 200}, 1e-3) {
+		t.Fatalf("VisibleRect() = %v, want [450 200 100 200]", visible)
+	}
+}
+
+func TestCamera2dFollow(t *testing.T) {
+	camera := NewCamera2d(BuildRectangle(0, 0, 200, 100))
+	target := mgl32.Vec2{100, 50}
+	camera.Follow(func() mgl32.Vec2 { return target })
+	camera.SetDeadZone(40, 20)
+
+	// La cible reste dans la zone morte : la caméra ne bouge pas
+	target = mgl32.Vec2{115, 55}
+	camera.Update(16)
+	if camera.Position != (mgl32.Vec2{100, 50}) {
+		t.Fatalf("Position = %v, want (100, 50) (target in dead zone)", camera.Position)
+	}
+	// La cible sort de la zone morte : elle est ramenée au bord de la zone
+	target = mgl32.Vec2{150, 30}
+	camera.Update(16)
+	if camera.Position != (mgl32.Vec2{130, 40}) {
+		t.Fatalf("Position = %v, want (130, 40)", camera.Position)
+	}
+
+	// Rattrapage progressif
+	camera.SetDeadZone(0, 0)
+	camera.SetFollowSpeed(5)
+	target = mgl32.Vec2{230, 40}
+	camera.Update(100)
+	if x := camera.Position.X(); x <= 130 || x >= 230 {
+		t.Fatalf("Position.X() = %v, want between 130 and 230", x)
+	}
+	for step := 0; step < 100; step++ {
+		camera.Update(100)
+	}
+	if !camera.Position.ApproxEqualThreshold(target, 1e-2) {
+		t.Fatalf("Position = %v, want %v", camera.Position, target)
+	}
+}
+
+func TestCamera2dBounds(t *testing.T) {
+	camera := NewCamera2d(BuildRectangle(0, 0, 200, 100))
+	camera.SetBounds(BuildRectangle(0, 0, 1000, 80))
+	camera.CenterOn(mgl32.Vec2{20, 500})
+	// x : limité à la demi-largeur de la vue, y : carte moins haute que la vue => centrée
+	if camera.Position != (mgl32.Vec2{100, 40}) {
+		t.Fatalf("Position = %v, want (100, 40)", camera.Position)
+	}
+	camera.Zoom = 2
+	camera.CenterOn(mgl32.Vec2{990, 0})
+	if camera.Position != (mgl32.Vec2{950, 25}) {
+		t.Fatalf("Position = %v, want (950, 25)", camera.Position)
+	}
+	camera.ClearBounds()
+	camera.CenterOn(mgl32.Vec2{-10, -10})
+	if camera.Position != (mgl32.Vec2{-10, -10}) {
+		t.Fatalf("Position = %v, want (-10, -10) without bounds", camera.Position)
+	}
+}
+
+func TestCamera2dShake(t *testing.T) {
+	camera := NewCamera2d(BuildRectangle(0, 0, 200, 100))
+	center := camera.WorldToScreen(camera.Position)
+	camera.Shake(10, 100)
+	for elapsed := 0; elapsed < 90; elapsed += 10 {
+		camera.Update(10)
+		offset := camera.WorldToScreen(camera.Position).Sub(center)
+		if math.Abs(float64(offset.X())) > 10 || math.Abs(float64(offset.Y())) > 10 {
+			t.Fatalf("shake offset = %v, want at most 10 pixels", offset)
+		}
+	}
+	camera.Update(20)
+	if camera.IsShaking() || camera.WorldToScreen(camera.Position) != center {
+		t.Fatalf("camera still shaking after shake duration")
+	}
+}
+
+func TestCamera2dEffectiveZoom(t *testing.T) {
+	camera := NewCamera2d(BuildRectangle(0, 0, 200, 100))
+	for _, test := range []struct{ zoom, want float32 }{{2, 2}, {0.5, 0.5}, {0, 1}, {-3, 1}} {
+		camera.Zoom = test.zoom
+		if got := camera.EffectiveZoom(); got != test.want {
+			t.Fatalf("EffectiveZoom() with Zoom=%v = %v, want %v", test.zoom, got, test.want)
+		}
+	}
+}
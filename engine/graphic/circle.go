@@ -0,0 +1,40 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Circle is a 2D circle.
+type Circle struct {
+	Center mgl32.Vec2
+	Radius float32
+}
+
+// BuildCircle function builds and returns a circle.
+func BuildCircle(center mgl32.Vec2, radius float32) Circle {
+	return Circle{Center: center, Radius: radius}
+}
+
+// Contains returns true if the point is inside the circle.
+func (circle Circle) Contains(point mgl32.Vec2) bool {
+	offset := point.Sub(circle.Center)
+	return offset.Dot(offset) <= circle.Radius*circle.Radius
+}
+
+// IntersectsCircle returns true if both circles overlap.
+func (circle Circle) IntersectsCircle(other Circle) bool {
+	offset := other.Center.Sub(circle.Center)
+	radius := circle.Radius + other.Radius
+	return offset.Dot(offset) < radius*radius
+}
+
+// IntersectsRect returns true if the circle overlaps the rectangle.
+func (circle Circle) IntersectsRect(rect Rectangle) bool {
+	offset := circle.Center.Sub(rect.ClosestPoint(circle.Center))
+	return offset.Dot(offset) < circle.Radius*circle.Radius
+}
+
+// Bounds returns the smallest rectangle containing the circle.
+func (circle Circle) Bounds() Rectangle {
+	return Rectangle{circle.Center.X() - circle.Radius, circle.Center.Y() - circle.Radius, 2 * circle.Radius, 2 * circle.Radius}
+}
@@ -0,0 +1,137 @@
+package graphic
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"log/slog"
+)
+
+// Formats S3TC sRGB (extension EXT_texture_sRGB, absents du profil core)
+const (
+	glCompressedSrgbAlphaS3tcDxt1 = 0x8C4D
+	glCompressedSrgbAlphaS3tcDxt3 = 0x8C4E
+	glCompressedSrgbAlphaS3tcDxt5 = 0x8C4F
+)
+
+// FORMAT_COMPRESSED is the format of textures built from a CompressedImage (see TextureOptions.Format).
+const FORMAT_COMPRESSED TextureFormat = "compressed"
+
+// compressedBlock is the block size of a compressed format.
+type compressedBlock struct {
+	width  int
+	height int
+	// size est la taille d'un bloc en octets
+	size int
+}
+
+// compressedBlocks contains the block size by OpenGL compressed format
+var compressedBlocks = map[uint32]compressedBlock{
+	gl.COMPRESSED_RGB_S3TC_DXT1_EXT:       {4, 4, 8},
+	gl.COMPRESSED_RGBA_S3TC_DXT1_EXT:      {4, 4, 8},
+	gl.COMPRESSED_RGBA_S3TC_DXT3_EXT:      {4, 4, 16},
+	gl.COMPRESSED_RGBA_S3TC_DXT5_EXT:      {4, 4, 16},
+	glCompressedSrgbAlphaS3tcDxt1:         {4, 4, 8},
+	glCompressedSrgbAlphaS3tcDxt3:         {4, 4, 16},
+	glCompressedSrgbAlphaS3tcDxt5:         {4, 4, 16},
+	gl.COMPRESSED_RED_RGTC1:               {4, 4, 8},
+	gl.COMPRESSED_SIGNED_RED_RGTC1:        {4, 4, 8},
+	gl.COMPRESSED_RG_RGTC2:                {4, 4, 16},
+	gl.COMPRESSED_SIGNED_RG_RGTC2:         {4, 4, 16},
+	gl.COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT: {4, 4, 16},
+	gl.COMPRESSED_RGB_BPTC_SIGNED_FLOAT:   {4, 4, 16},
+	gl.COMPRESSED_RGBA_BPTC_UNORM:         {4, 4, 16},
+	gl.COMPRESSED_SRGB_ALPHA_BPTC_UNORM:   {4, 4, 16},
+}
+
+// astcBlockSizes contains ASTC block sizes (in OpenGL format order)
+var astcBlockSizes = [][2]int{
+	{4, 4}, {5, 4}, {5, 5}, {6, 5}, {6, 6}, {8, 5}, {8, 6}, {8, 8}, {10, 5}, {10, 6}, {10, 8}, {10, 10}, {12, 10}, {12, 12},
+}
+
+func init() {
+	for index, size := range astcBlockSizes {
+		compressedBlocks[gl.COMPRESSED_RGBA_ASTC_4x4_KHR+uint32(index)] = compressedBlock{size[0], size[1], 16}
+		compressedBlocks[gl.COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR+uint32(index)] = compressedBlock{size[0], size[1], 16}
+	}
+}
+
+// compressedLevelSize returns the size in bytes of a width x height level.
+func compressedLevelSize(block compressedBlock, width, height int) int {
+	return ((width + block.width - 1) / block.width) * ((height + block.height - 1) / block.height) * block.size
+}
+
+// CompressedImage is a block compressed image (BCn or ASTC) uploaded as is on the GPU.
+type CompressedImage struct {
+	// InternalFormat est le format compressé OpenGL (gl.COMPRESSED_RGBA_S3TC_DXT5_EXT par exemple)
+	InternalFormat uint32
+	Width          int
+	Height         int
+	// Levels contient les données de chaque niveau de mipmap (niveau 0 en premier)
+	Levels [][]byte
+}
+
+// Validate checks format and level sizes.
+func (img *CompressedImage) Validate() error {
+	block, found := compressedBlocks[img.InternalFormat]
+	if !found {
+		return fmt.Errorf("unsupported 0x%X compressed format", img.InternalFormat)
+	}
+	if img.Width <= 0 || img.Height <= 0 {
+		return fmt.Errorf("invalid %dx%d compressed image size", img.Width, img.Height)
+	}
+	if len(img.Levels) == 0 {
+		return fmt.Errorf("compressed image has no data")
+	}
+	if maxLevels := mipmapLevels(int32(img.Width), int32(img.Height), true); int32(len(img.Levels)) > maxLevels {
+		return fmt.Errorf("compressed image has %d levels (%d at most)", len(img.Levels), maxLevels)
+	}
+	width, height := img.Width, img.Height
+	for level, data := range img.Levels {
+		if size := compressedLevelSize(block, width, height); len(data) < size {
+			return fmt.Errorf("truncated compressed image level %d (%d bytes, %d expected)", level, len(data), size)
+		}
+		width, height = max(width/2, 1), max(height/2, 1)
+	}
+	return nil
+}
+
+// NewTextureFromCompressedImage returns a texture built from compressed data (without decompression).
+//
+//	Format and Mipmaps options are ignored: the texture uses the image levels (FILTER_TRILINEAR
+//	falls back to FILTER_LINEAR without mipmaps). Texture cannot be updated or read back.
+func NewTextureFromCompressedImage(img *CompressedImage, options TextureOptions) (*Texture, error) {
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	options.Format = FORMAT_COMPRESSED
+	options = options.normalized()
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	options.Mipmaps = len(img.Levels) > 1
+	if options.Filter == FILTER_TRILINEAR && !options.Mipmaps {
+		options.Filter = FILTER_LINEAR
+	}
+	block := compressedBlocks[img.InternalFormat]
+
+	var handle uint32
+	gl.CreateTextures(gl.TEXTURE_2D, 1, &handle)
+	gl.TextureStorage2D(handle, int32(len(img.Levels)), img.InternalFormat, int32(img.Width), int32(img.Height))
+	width, height := img.Width, img.Height
+	for level, data := range img.Levels {
+		size := compressedLevelSize(block, width, height)
+		gl.CompressedTextureSubImage2D(handle, int32(level), 0, 0, int32(width), int32(height), img.InternalFormat, int32(size), gl.Ptr(data))
+		width, height = max(width/2, 1), max(height/2, 1)
+	}
+	texture := &Texture{
+		handle:  handle,
+		width:   int32(img.Width),
+		height:  int32(img.Height),
+		options: options,
+	}
+	texture.applyOptions()
+
+	slog.Debug("compressed texture info", "handle", handle, "width", img.Width, "height", img.Height, "levels", len(img.Levels))
+
+	return texture, nil
+}
@@ -0,0 +1,168 @@
+package graphic
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"image"
+	"image/color"
+	"log/slog"
+	"math"
+)
+
+// CubeFace is a cube map face (in OpenGL layer order).
+type CubeFace int
+
+// List of cube map faces
+const (
+	CUBE_FACE_POSITIVE_X CubeFace = iota
+	CUBE_FACE_NEGATIVE_X
+	CUBE_FACE_POSITIVE_Y
+	CUBE_FACE_NEGATIVE_Y
+	CUBE_FACE_POSITIVE_Z
+	CUBE_FACE_NEGATIVE_Z
+)
+
+// CubeMap is a cube map texture (samplerCube in shaders), skyboxes or environment maps for example.
+type CubeMap struct {
+	layeredTexture
+}
+
+// NewCubeMapFromImages returns a cube map from six square faces of the same size (see CubeFace for the order).
+//
+//	Seamless filtering between faces is enabled.
+func NewCubeMapFromImages(faces [6]image.Image, options TextureOptions) (*CubeMap, error) {
+	if faces[0] != nil && faces[0].Bounds().Dx() != faces[0].Bounds().Dy() {
+		return nil, fmt.Errorf("cube map faces must be square (%dx%d)", faces[0].Bounds().Dx(), faces[0].Bounds().Dy())
+	}
+	for face, img := range faces {
+		if img == nil {
+			return nil, fmt.Errorf("cube map face #%d is missing", face)
+		}
+	}
+	texture, err := newLayeredTexture(gl.TEXTURE_CUBE_MAP, faces[:], options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cube map\n - %w", err)
+	}
+	gl.Enable(gl.TEXTURE_CUBE_MAP_SEAMLESS)
+	return &CubeMap{layeredTexture: *texture}, nil
+}
+
+// LoadCubeMapFromFiles returns a cube map from six face image files (see CubeFace for the order).
+func LoadCubeMapFromFiles(filenames [6]string, options TextureOptions) (*CubeMap, error) {
+	slog.Debug("cube map creation from files", "filenames", filenames)
+	images, err := loadImagesFromFiles(filenames[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cube map\n - %w", err)
+	}
+	return NewCubeMapFromImages([6]image.Image(images), options)
+}
+
+// NewCubeMapFromEquirectangular returns a cube map with size x size faces from an equirectangular panorama.
+func NewCubeMapFromEquirectangular(panorama image.Image, size int, options TextureOptions) (*CubeMap, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid %d cube map size", size)
+	}
+	if panorama == nil {
+		return nil, fmt.Errorf("cube map panorama is missing")
+	}
+	if panorama.Bounds().Empty() {
+		return nil, fmt.Errorf("cannot build cube map from empty panorama")
+	}
+	faces := equirectangularToCubeFaces(panorama, size)
+	// Les faces sont flottantes : le format par défaut est celui du panorama
+	if options.Format == "" {
+		options.Format = options.withImageFormat(panorama).normalized().Format
+	}
+	var images [6]image.Image
+	for face := range faces {
+		images[face] = faces[face]
+	}
+	return NewCubeMapFromImages(images, options)
+}
+
+// LoadCubeMapFromEquirectangularFile returns a cube map from an equirectangular panorama file (HDR for example).
+func LoadCubeMapFromEquirectangularFile(filename string, size int, options TextureOptions) (*CubeMap, error) {
+	slog.Debug("cube map creation from equirectangular file", "filename", filename, "size", size)
+	panorama, err := LoadImageFromFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cube map\n - %w", err)
+	}
+	return NewCubeMapFromEquirectangular(panorama, size, options)
+}
+
+// Size returns the size of a face.
+func (cubeMap *CubeMap) Size() int32 {
+	return cubeMap.width
+}
+
+// UpdateFace replaces a face with img (same size as the faces).
+func (cubeMap *CubeMap) UpdateFace(face CubeFace, img image.Image) error {
+	return cubeMap.updateLayer(int(face), img)
+}
+
+// cubeFaceDirection returns the direction of the (u, v) point of a face (u and v in [-1, 1], v from top to bottom).
+func cubeFaceDirection(face CubeFace, u, v float32) mgl32.Vec3 {
+	switch face {
+	case CUBE_FACE_POSITIVE_X:
+		return mgl32.Vec3{1, -v, -u}
+	case CUBE_FACE_NEGATIVE_X:
+		return mgl32.Vec3{-1, -v, u}
+	case CUBE_FACE_POSITIVE_Y:
+		return mgl32.Vec3{u, 1, v}
+	case CUBE_FACE_NEGATIVE_Y:
+		return mgl32.Vec3{u, -1, -v}
+	case CUBE_FACE_POSITIVE_Z:
+		return mgl32.Vec3{u, -v, 1}
+	default:
+		return mgl32.Vec3{-u, -v, -1}
+	}
+}
+
+// equirectangularToCubeFaces projects a panorama on the six faces of a cube (bilinear sampling).
+//
+//	The panorama center (longitude 0) is in the -Z direction and its top is +Y.
+func equirectangularToCubeFaces(panorama image.Image, size int) [6]*FloatImage {
+	bounds := panorama.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	floatPanorama, isFloat := panorama.(*FloatImage)
+	pixel := func(x, y int) [4]float32 {
+		// Répétition horizontale, bords verticaux étendus
+		x = ((x % width) + width) % width
+		y = min(max(y, 0), height-1)
+		if isFloat {
+			r, g, b, a := floatPanorama.FloatAt(bounds.Min.X+x, bounds.Min.Y+y)
+			return [4]float32{r, g, b, a}
+		}
+		c := color.NRGBA64Model.Convert(panorama.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA64)
+		return [4]float32{float32(c.R) / 0xffff, float32(c.G) / 0xffff, float32(c.B) / 0xffff, float32(c.A) / 0xffff}
+	}
+
+	var faces [6]*FloatImage
+	for face := range faces {
+		faces[face] = NewFloatImage(image.Rect(0, 0, size, size))
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				u := 2*(float32(x)+0.5)/float32(size) - 1
+				v := 2*(float32(y)+0.5)/float32(size) - 1
+				direction := cubeFaceDirection(CubeFace(face), u, v).Normalize()
+				longitude := math.Atan2(float64(direction.X()), -float64(direction.Z()))
+				latitude := math.Asin(float64(direction.Y()))
+				// Coordonnées dans le panorama puis interpolation bilinéaire
+				sourceX := (longitude/(2*math.Pi)+0.5)*float64(width) - 0.5
+				sourceY := (0.5-latitude/math.Pi)*float64(height) - 0.5
+				x0, y0 := int(math.Floor(sourceX)), int(math.Floor(sourceY))
+				fx, fy := float32(sourceX-float64(x0)), float32(sourceY-float64(y0))
+				p00, p10, p01, p11 := pixel(x0, y0), pixel(x0+1, y0), pixel(x0, y0+1), pixel(x0+1, y0+1)
+				var result [4]float32
+				for component := range result {
+					top := p00[component]*(1-fx) + p10[component]*fx
+					bottom := p01[component]*(1-fx) + p11[component]*fx
+					result[component] = top*(1-fy) + bottom*fy
+				}
+				faces[face].SetFloat(x, y, result[0], result[1], result[2], result[3])
+			}
+		}
+	}
+	return faces
+}
@@ -0,0 +1,69 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"image"
+	"testing"
+)
+
+func TestCubeFaceDirection(t *testing.T) {
+	for _, test := range []struct {
+		face CubeFace
+		want mgl32.Vec3
+	}{
+		{CUBE_FACE_POSITIVE_X, mgl32.Vec3{1, 0, 0}},
+		{CUBE_FACE_NEGATIVE_X, mgl32.Vec3{-1, 0, 0}},
+		{CUBE_FACE_POSITIVE_Y, mgl32.Vec3{0, 1, 0}},
+		{CUBE_FACE_NEGATIVE_Y, mgl32.Vec3{0, -1, 0}},
+		{CUBE_FACE_POSITIVE_Z, mgl32.Vec3{0, 0, 1}},
+		{CUBE_FACE_NEGATIVE_Z, mgl32.Vec3{0, 0, -1}},
+	} {
+		if direction := cubeFaceDirection(test.face, 0, 0); direction != test.want {
+			t.Fatalf("cubeFaceDirection(%d, 0, 0) = %v, want %v", test.face, direction, test.want)
+		}
+	}
+	// Le haut de la face +X est vers +Y
+	if direction := cubeFaceDirection(CUBE_FACE_POSITIVE_X, 0, -1); direction.Y() != 1 {
+		t.Fatalf("cubeFaceDirection(+X, 0, -1) = %v, want +Y component", direction)
+	}
+}
+
+func TestEquirectangularToCubeFaces(t *testing.T) {
+	// Rouge = colonne, vert = ligne
+	panorama := NewFloatImage(image.Rect(0, 0, 8, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 8; x++ {
+			panorama.SetFloat(x, y, float32(x), float32(y), 0, 1)
+		}
+	}
+	faces := equirectangularToCubeFaces(panorama, 1)
+	for _, test := range []struct {
+		face         CubeFace
+		wantR, wantG float32
+	}{
+		// -Z est au centre du panorama, +X un quart plus loin
+		{CUBE_FACE_NEGATIVE_Z, 3.5, 1.5},
+		{CUBE_FACE_POSITIVE_X, 5.5, 1.5},
+		{CUBE_FACE_NEGATIVE_X, 1.5, 1.5},
+	} {
+		r, g, _, a := faces[test.face].FloatAt(0, 0)
+		if mgl32.Abs(r-test.wantR) > 1e-4 || mgl32.Abs(g-test.wantG) > 1e-4 || a != 1 {
+			t.Fatalf("face %d center = %v, %v, %v, want %v, %v, 1", test.face, r, g, a, test.wantR, test.wantG)
+		}
+	}
+	// Les pôles prennent la première et la dernière ligne
+	if _, g, _, _ := faces[CUBE_FACE_POSITIVE_Y].FloatAt(0, 0); g != 0 {
+		t.Fatalf("+Y face green = %v, want 0", g)
+	}
+	if _, g, _, _ := faces[CUBE_FACE_NEGATIVE_Y].FloatAt(0, 0); g != 3 {
+		t.Fatalf("-Y face green = %v, want 3", g)
+	}
+}
+
+func TestNewCubeMapFromEquirectangular_EmptyPanorama(t *testing.T) {
+	for _, panorama := range []image.Image{nil, NewFloatImage(image.Rect(0, 0, 0, 0)), image.NewRGBA(image.Rect(0, 0, 16, 0))} {
+		if _, err := NewCubeMapFromEquirectangular(panorama, 4, TextureOptions{}); err == nil {
+			t.Fatalf("NewCubeMapFromEquirectangular() with %T panorama = nil error, want error", panorama)
+		}
+	}
+}
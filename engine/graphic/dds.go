@@ -0,0 +1,142 @@
+package graphic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"io"
+)
+
+// Tailles et drapeaux DDS
+const (
+	ddsHeaderSize      = 128
+	ddsDx10HeaderSize  = 20
+	ddsMipmapCountFlag = 0x20000
+	ddsFourCCFlag      = 0x4
+	ddsCubemapFlag     = 0x200
+	ddsDx10CubemapFlag = 0x4
+)
+
+// ddsFourCCFormats contains OpenGL formats by DDS four character code
+var ddsFourCCFormats = map[string]uint32{
+	"DXT1": gl.COMPRESSED_RGBA_S3TC_DXT1_EXT,
+	"DXT3": gl.COMPRESSED_RGBA_S3TC_DXT3_EXT,
+	"DXT5": gl.COMPRESSED_RGBA_S3TC_DXT5_EXT,
+	"ATI1": gl.COMPRESSED_RED_RGTC1,
+	"BC4U": gl.COMPRESSED_RED_RGTC1,
+	"BC4S": gl.COMPRESSED_SIGNED_RED_RGTC1,
+	"ATI2": gl.COMPRESSED_RG_RGTC2,
+	"BC5U": gl.COMPRESSED_RG_RGTC2,
+	"BC5S": gl.COMPRESSED_SIGNED_RG_RGTC2,
+}
+
+// ddsDxgiFormats contains OpenGL formats by DXGI format (DX10 extended header)
+var ddsDxgiFormats = map[uint32]uint32{
+	70: gl.COMPRESSED_RGBA_S3TC_DXT1_EXT, // BC1_TYPELESS
+	71: gl.COMPRESSED_RGBA_S3TC_DXT1_EXT, // BC1_UNORM
+	72: glCompressedSrgbAlphaS3tcDxt1,    // BC1_UNORM_SRGB
+	73: gl.COMPRESSED_RGBA_S3TC_DXT3_EXT, // BC2_TYPELESS
+	74: gl.COMPRESSED_RGBA_S3TC_DXT3_EXT, // BC2_UNORM
+	75: glCompressedSrgbAlphaS3tcDxt3,    // BC2_UNORM_SRGB
+	76: gl.COMPRESSED_RGBA_S3TC_DXT5_EXT, // BC3_TYPELESS
+	77: gl.COMPRESSED_RGBA_S3TC_DXT5_EXT, // BC3_UNORM
+	78: glCompressedSrgbAlphaS3tcDxt5,    // BC3_UNORM_SRGB
+	79: gl.COMPRESSED_RED_RGTC1,          // BC4_TYPELESS
+	80: gl.COMPRESSED_RED_RGTC1,          // BC4_UNORM
+	81: gl.COMPRESSED_SIGNED_RED_RGTC1,   // BC4_SNORM
+	82: gl.COMPRESSED_RG_RGTC2,           // BC5_TYPELESS
+	83: gl.COMPRESSED_RG_RGTC2,           // BC5_UNORM
+	84: gl.COMPRESSED_SIGNED_RG_RGTC2,    // BC5_SNORM
+	95: gl.COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT,
+	96: gl.COMPRESSED_RGB_BPTC_SIGNED_FLOAT,
+	97: gl.COMPRESSED_RGBA_BPTC_UNORM,       // BC7_TYPELESS
+	98: gl.COMPRESSED_RGBA_BPTC_UNORM,       // BC7_UNORM
+	99: gl.COMPRESSED_SRGB_ALPHA_BPTC_UNORM, // BC7_UNORM_SRGB
+}
+
+// decodeDDS decodes a DirectDraw Surface 2D texture with block compressed data (BC1 to BC7).
+func decodeDDS(reader io.Reader) (*CompressedImage, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < ddsHeaderSize || string(content[:4]) != "DDS " || binary.LittleEndian.Uint32(content[4:]) != 124 {
+		return nil, fmt.Errorf("invalid DDS header")
+	}
+	height := int(binary.LittleEndian.Uint32(content[12:]))
+	width := int(binary.LittleEndian.Uint32(content[16:]))
+	// Le nombre de niveaux n'est valide qu'avec le drapeau DDSD_MIPMAPCOUNT (et borné par la dimension de l'image)
+	levelCount := 1
+	if binary.LittleEndian.Uint32(content[8:])&ddsMipmapCountFlag != 0 {
+		levelCount = max(int(binary.LittleEndian.Uint32(content[28:])), 1)
+		levelCount = min(levelCount, int(mipmapLevels(int32(width), int32(height), true)))
+	}
+	pixelFormatFlags := binary.LittleEndian.Uint32(content[80:])
+	fourCC := string(content[84:88])
+	if binary.LittleEndian.Uint32(content[112:])&ddsCubemapFlag != 0 {
+		return nil, fmt.Errorf("unsupported DDS cube map")
+	}
+	if pixelFormatFlags&ddsFourCCFlag == 0 {
+		return nil, fmt.Errorf("unsupported uncompressed DDS texture")
+	}
+
+	img := &CompressedImage{Width: width, Height: height}
+	offset := ddsHeaderSize
+	if fourCC == "DX10" {
+		if len(content) < ddsHeaderSize+ddsDx10HeaderSize {
+			return nil, fmt.Errorf("truncated DDS DX10 header")
+		}
+		dxgiFormat := binary.LittleEndian.Uint32(content[128:])
+		dimension := binary.LittleEndian.Uint32(content[132:])
+		miscFlags := binary.LittleEndian.Uint32(content[136:])
+		arraySize := binary.LittleEndian.Uint32(content[140:])
+		if dimension != 3 || miscFlags&ddsDx10CubemapFlag != 0 || arraySize > 1 {
+			return nil, fmt.Errorf("unsupported DDS texture (only 2D textures are supported)")
+		}
+		format, found := ddsDxgiFormats[dxgiFormat]
+		if !found {
+			return nil, fmt.Errorf("unsupported DDS DXGI format %d", dxgiFormat)
+		}
+		img.InternalFormat = format
+		offset += ddsDx10HeaderSize
+	} else {
+		format, found := ddsFourCCFormats[fourCC]
+		if !found {
+			return nil, fmt.Errorf("unsupported DDS '%s' format", fourCC)
+		}
+		img.InternalFormat = format
+	}
+
+	levels, err := splitCompressedLevels(content[offset:], img.InternalFormat, width, height, levelCount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DDS data\n - %w", err)
+	}
+	img.Levels = levels
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// splitCompressedLevels cuts consecutive mipmap levels.
+func splitCompressedLevels(data []byte, format uint32, width, height int, levelCount int) ([][]byte, error) {
+	block, found := compressedBlocks[format]
+	if !found {
+		return nil, fmt.Errorf("unsupported 0x%X compressed format", format)
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid %dx%d image size", width, height)
+	}
+	levels := make([][]byte, 0, levelCount)
+	offset := 0
+	for level := 0; level < levelCount; level++ {
+		size := compressedLevelSize(block, width, height)
+		if offset+size > len(data) {
+			return nil, fmt.Errorf("truncated level %d", level)
+		}
+		levels = append(levels, data[offset:offset+size])
+		offset += size
+		width, height = max(width/2, 1), max(height/2, 1)
+	}
+	return levels, nil
+}
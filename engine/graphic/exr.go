@@ -0,0 +1,317 @@
+package graphic
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"slices"
+)
+
+// Compressions OpenEXR supportées
+const (
+	exrNoCompression   = 0
+	exrRleCompression  = 1
+	exrZipsCompression = 2
+	exrZipCompression  = 3
+)
+
+// Types de composantes OpenEXR
+const (
+	exrUint  = 0
+	exrHalf  = 1
+	exrFloat = 2
+)
+
+// exrChannel is an OpenEXR channel description.
+type exrChannel struct {
+	name      string
+	pixelType int32
+}
+
+// size returns the size of a channel value in bytes.
+func (channel exrChannel) size() int {
+	if channel.pixelType == exrHalf {
+		return 2
+	}
+	return 4
+}
+
+// decodeEXR decodes an OpenEXR image as a FloatImage.
+//
+//	Only single part scanline images without subsampling are supported, uncompressed or with RLE, ZIPS or ZIP
+//	compression. R, G, B and A channels are read (Y for luminance images, missing alpha is 1).
+func decodeEXR(reader io.Reader) (image.Image, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < 8 || binary.LittleEndian.Uint32(content) != 20000630 {
+		return nil, fmt.Errorf("invalid OpenEXR header")
+	}
+	version := binary.LittleEndian.Uint32(content[4:])
+	if version&0x200 != 0 || version&0x1000 != 0 || version&0x800 != 0 {
+		return nil, fmt.Errorf("unsupported OpenEXR file (tiled, multi-part or deep data)")
+	}
+
+	// Attributs de l'entête
+	var channels []exrChannel
+	compression := -1
+	var window [4]int32
+	position := 8
+	readString := func() (string, error) {
+		end := bytes.IndexByte(content[position:], 0)
+		if end < 0 {
+			return "", fmt.Errorf("truncated OpenEXR header")
+		}
+		value := string(content[position : position+end])
+		position += end + 1
+		return value, nil
+	}
+	for {
+		name, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			break
+		}
+		if _, err := readString(); err != nil {
+			return nil, err
+		}
+		if position+4 > len(content) {
+			return nil, fmt.Errorf("truncated OpenEXR header")
+		}
+		size := int(binary.LittleEndian.Uint32(content[position:]))
+		position += 4
+		if size < 0 || position+size > len(content) {
+			return nil, fmt.Errorf("truncated OpenEXR header")
+		}
+		value := content[position : position+size]
+		position += size
+		switch name {
+		case "channels":
+			if channels, err = decodeEXRChannels(value); err != nil {
+				return nil, err
+			}
+		case "compression":
+			if len(value) > 0 {
+				compression = int(value[0])
+			}
+		case "dataWindow":
+			if len(value) < 16 {
+				return nil, fmt.Errorf("invalid OpenEXR data window")
+			}
+			for index := range window {
+				window[index] = int32(binary.LittleEndian.Uint32(value[index*4:]))
+			}
+		}
+	}
+	if len(channels) == 0 {
+		return nil, fmt.Errorf("OpenEXR image has no channel")
+	}
+	linesPerBlock := 1
+	switch compression {
+	case exrNoCompression, exrRleCompression, exrZipsCompression:
+	case exrZipCompression:
+		linesPerBlock = 16
+	default:
+		return nil, fmt.Errorf("unsupported OpenEXR compression %d", compression)
+	}
+	width, height := int(window[2]-window[0])+1, int(window[3]-window[1])+1
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid %dx%d OpenEXR image size", width, height)
+	}
+	lineSize := 0
+	for _, channel := range channels {
+		lineSize += channel.size() * width
+	}
+
+	// Table des blocs puis blocs de lignes
+	img := NewFloatImage(image.Rect(0, 0, width, height))
+	blockCount := (height + linesPerBlock - 1) / linesPerBlock
+	if position+blockCount*8 > len(content) {
+		return nil, fmt.Errorf("truncated OpenEXR offset table")
+	}
+	for block := 0; block < blockCount; block++ {
+		offset := binary.LittleEndian.Uint64(content[position+block*8:])
+		if offset > uint64(len(content)-8) {
+			return nil, fmt.Errorf("invalid OpenEXR block offset")
+		}
+		y := int(int32(binary.LittleEndian.Uint32(content[offset:]))) - int(window[1])
+		size := int(binary.LittleEndian.Uint32(content[offset+4:]))
+		if y < 0 || y >= height || size < 0 || int(offset)+8+size > len(content) {
+			return nil, fmt.Errorf("invalid OpenEXR block %d", block)
+		}
+		lines := min(linesPerBlock, height-y)
+		data, err := decompressEXRBlock(content[int(offset)+8:int(offset)+8+size], compression, lines*lineSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress OpenEXR block %d\n - %w", block, err)
+		}
+		for line := 0; line < lines; line++ {
+			decodeEXRLine(img, y+line, data[line*lineSize:(line+1)*lineSize], channels, width)
+		}
+	}
+	return img, nil
+}
+
+// decodeEXRChannels decodes the channel list attribute (channels are sorted by name).
+func decodeEXRChannels(value []byte) ([]exrChannel, error) {
+	var channels []exrChannel
+	for position := 0; position < len(value) && value[position] != 0; {
+		end := bytes.IndexByte(value[position:], 0)
+		if end < 0 || position+end+1+16 > len(value) {
+			return nil, fmt.Errorf("invalid OpenEXR channel list")
+		}
+		channel := exrChannel{name: string(value[position : position+end])}
+		position += end + 1
+		channel.pixelType = int32(binary.LittleEndian.Uint32(value[position:]))
+		xSampling := binary.LittleEndian.Uint32(value[position+8:])
+		ySampling := binary.LittleEndian.Uint32(value[position+12:])
+		position += 16
+		if channel.pixelType < exrUint || channel.pixelType > exrFloat {
+			return nil, fmt.Errorf("invalid OpenEXR '%s' channel type %d", channel.name, channel.pixelType)
+		}
+		if xSampling != 1 || ySampling != 1 {
+			return nil, fmt.Errorf("unsupported OpenEXR '%s' channel subsampling", channel.name)
+		}
+		channels = append(channels, channel)
+	}
+	slices.SortFunc(channels, func(first, second exrChannel) int {
+		return bytes.Compare([]byte(first.name), []byte(second.name))
+	})
+	return channels, nil
+}
+
+// decompressEXRBlock returns uncompressed block data (data is stored uncompressed when compression does not help).
+func decompressEXRBlock(data []byte, compression int, size int) ([]byte, error) {
+	if compression == exrNoCompression || len(data) == size {
+		if len(data) < size {
+			return nil, fmt.Errorf("truncated data")
+		}
+		return data, nil
+	}
+	var predicted []byte
+	if compression == exrRleCompression {
+		for position := 0; position < len(data) && len(predicted) < size; {
+			count := int(int8(data[position]))
+			position++
+			if count < 0 {
+				// Suite brute de -count octets
+				if position-count > len(data) {
+					return nil, fmt.Errorf("truncated RLE data")
+				}
+				predicted = append(predicted, data[position:position-count]...)
+				position -= count
+			} else {
+				// Octet répété count+1 fois
+				if position >= len(data) {
+					return nil, fmt.Errorf("truncated RLE data")
+				}
+				for ; count >= 0; count-- {
+					predicted = append(predicted, data[position])
+				}
+				position++
+			}
+		}
+	} else {
+		zipReader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		predicted, err = io.ReadAll(io.LimitReader(zipReader, int64(size)))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(predicted) != size {
+		return nil, fmt.Errorf("invalid uncompressed size %d (%d expected)", len(predicted), size)
+	}
+	// Prédicteur : chaque octet est stocké comme la différence avec le précédent
+	for index := 1; index < len(predicted); index++ {
+		predicted[index] = predicted[index-1] + predicted[index] - 128
+	}
+	// Entrelacement : les octets pairs sont dans la première moitié, les impairs dans la seconde
+	uncompressed := make([]byte, size)
+	half := (size + 1) / 2
+	for index := range uncompressed {
+		if index%2 == 0 {
+			uncompressed[index] = predicted[index/2]
+		} else {
+			uncompressed[index] = predicted[half+index/2]
+		}
+	}
+	return uncompressed, nil
+}
+
+// decodeEXRLine copies a scanline (all values of each channel one after the other) into the image.
+func decodeEXRLine(img *FloatImage, y int, data []byte, channels []exrChannel, width int) {
+	luminance := !slices.ContainsFunc(channels, func(channel exrChannel) bool {
+		return channel.name == "R" || channel.name == "G" || channel.name == "B"
+	})
+	hasAlpha := slices.ContainsFunc(channels, func(channel exrChannel) bool { return channel.name == "A" })
+	if !hasAlpha {
+		for x := 0; x < width; x++ {
+			img.Pix[img.PixOffset(x, y)+3] = 1
+		}
+	}
+	position := 0
+	for _, channel := range channels {
+		components := []int{}
+		switch channel.name {
+		case "R":
+			components = []int{0}
+		case "G":
+			components = []int{1}
+		case "B":
+			components = []int{2}
+		case "A":
+			components = []int{3}
+		case "Y":
+			if luminance {
+				components = []int{0, 1, 2}
+			}
+		}
+		for x := 0; x < width; x++ {
+			var value float32
+			switch channel.pixelType {
+			case exrHalf:
+				value = halfToFloat(binary.LittleEndian.Uint16(data[position:]))
+			case exrFloat:
+				value = math.Float32frombits(binary.LittleEndian.Uint32(data[position:]))
+			default:
+				value = float32(binary.LittleEndian.Uint32(data[position:]))
+			}
+			position += channel.size()
+			offset := img.PixOffset(x, y)
+			for _, component := range components {
+				img.Pix[offset+component] = value
+			}
+		}
+	}
+}
+
+// halfToFloat converts a 16 bits IEEE 754 float.
+func halfToFloat(half uint16) float32 {
+	sign := uint32(half>>15) << 31
+	exponent := uint32(half>>10) & 0x1f
+	mantissa := uint32(half) & 0x3ff
+	switch {
+	case exponent == 0 && mantissa == 0:
+		return math.Float32frombits(sign)
+	case exponent == 0:
+		// Nombre dénormalisé
+		value := float32(mantissa) / (1 << 24)
+		if sign != 0 {
+			return -value
+		}
+		return value
+	case exponent == 0x1f:
+		// Infini ou NaN
+		return math.Float32frombits(sign | 0x7f800000 | mantissa<<13)
+	}
+	return math.Float32frombits(sign | (exponent+112)<<23 | mantissa<<13)
+}
@@ -0,0 +1,100 @@
+package graphic
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// FloatImage is an image with float32 R, G, B, A components (non premultiplied, not clamped to [0, 1]).
+//
+//	It is returned by high dynamic range decoders (HDR, EXR) and uploaded without loss in float texture formats.
+type FloatImage struct {
+	// Pix contient les composantes R, G, B, A des pixels, ligne par ligne
+	Pix []float32
+	// Stride est le nombre de composantes d'une ligne
+	Stride int
+	Rect   image.Rectangle
+}
+
+// NewFloatImage returns a transparent black float image.
+func NewFloatImage(rectangle image.Rectangle) *FloatImage {
+	return &FloatImage{
+		Pix:    make([]float32, rectangle.Dx()*rectangle.Dy()*4),
+		Stride: rectangle.Dx() * 4,
+		Rect:   rectangle,
+	}
+}
+
+// ColorModel returns the model used by At (components are clamped to [0, 1]).
+func (img *FloatImage) ColorModel() color.Model {
+	return color.NRGBA64Model
+}
+
+// Bounds returns the image bounds.
+func (img *FloatImage) Bounds() image.Rectangle {
+	return img.Rect
+}
+
+// At returns the pixel color clamped to [0, 1] (see FloatAt for the float components).
+func (img *FloatImage) At(x, y int) color.Color {
+	r, g, b, a := img.FloatAt(x, y)
+	component := func(value float32) uint16 {
+		return uint16(math.Round(float64(min(max(value, 0), 1)) * 0xffff))
+	}
+	return color.NRGBA64{R: component(r), G: component(g), B: component(b), A: component(a)}
+}
+
+// FloatAt returns the pixel float components (0 outside image bounds).
+func (img *FloatImage) FloatAt(x, y int) (r, g, b, a float32) {
+	if !(image.Point{X: x, Y: y}).In(img.Rect) {
+		return 0, 0, 0, 0
+	}
+	offset := img.PixOffset(x, y)
+	return img.Pix[offset], img.Pix[offset+1], img.Pix[offset+2], img.Pix[offset+3]
+}
+
+// SetFloat changes the pixel float components.
+func (img *FloatImage) SetFloat(x, y int, r, g, b, a float32) {
+	if !(image.Point{X: x, Y: y}).In(img.Rect) {
+		return
+	}
+	offset := img.PixOffset(x, y)
+	img.Pix[offset], img.Pix[offset+1], img.Pix[offset+2], img.Pix[offset+3] = r, g, b, a
+}
+
+// SubImage returns the part of the image inside rectangle (pixels are shared).
+func (img *FloatImage) SubImage(rectangle image.Rectangle) image.Image {
+	rectangle = rectangle.Intersect(img.Rect)
+	if rectangle.Empty() {
+		return &FloatImage{}
+	}
+	return &FloatImage{
+		Pix:    img.Pix[img.PixOffset(rectangle.Min.X, rectangle.Min.Y):],
+		Stride: img.Stride,
+		Rect:   rectangle,
+	}
+}
+
+// PixOffset returns the index of the pixel first component in Pix.
+func (img *FloatImage) PixOffset(x, y int) int {
+	return (y-img.Rect.Min.Y)*img.Stride + (x-img.Rect.Min.X)*4
+}
+
+// components returns the image components row by row (4 per pixel, or the luminance for 1 channel).
+func (img *FloatImage) components(channels int) []float32 {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	components := make([]float32, 0, width*height*channels)
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		offset := img.PixOffset(img.Rect.Min.X, y)
+		row := img.Pix[offset : offset+width*4]
+		if channels == 4 {
+			components = append(components, row...)
+			continue
+		}
+		for index := 0; index < len(row); index += 4 {
+			components = append(components, 0.299*row[index]+0.587*row[index+1]+0.114*row[index+2])
+		}
+	}
+	return components
+}
@@ -0,0 +1,103 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"testing"
+)
+
+func TestCircle(t *testing.T) {
+	circle := BuildCircle(mgl32.Vec2{0, 0}, 5)
+	if !circle.Contains(mgl32.Vec2{3, 4}) || circle.Contains(mgl32.Vec2{4, 4}) {
+		t.Fatalf("Contains() inconsistent results")
+	}
+	if !circle.IntersectsCircle(BuildCircle(mgl32.Vec2{9, 0}, 5)) || circle.IntersectsCircle(BuildCircle(mgl32.Vec2{10, 0}, 5)) {
+		t.Fatalf("IntersectsCircle() inconsistent results")
+	}
+	if !circle.IntersectsRect(BuildRectangle(4, -1, 5, 2)) || circle.IntersectsRect(BuildRectangle(4, 4, 5, 5)) {
+		t.Fatalf("IntersectsRect() inconsistent results")
+	}
+	if bounds := circle.Bounds(); bounds != BuildRectangle(-5, -5, 10, 10) {
+		t.Fatalf("Bounds() = %v, want [-5 -5 10 10]", bounds)
+	}
+}
+
+func TestSegment(t *testing.T) {
+	segment := BuildSegment(mgl32.Vec2{0, 0}, mgl32.Vec2{10, 0})
+	if point := segment.ClosestPoint(mgl32.Vec2{15, 5}); point != (mgl32.Vec2{10, 0}) {
+		t.Fatalf("ClosestPoint() = %v, want (10, 0)", point)
+	}
+	if distance := segment.Distance(mgl32.Vec2{5, 3}); distance != 3 {
+		t.Fatalf("Distance() = %v, want 3", distance)
+	}
+	point, found := segment.Intersection(BuildSegment(mgl32.Vec2{5, -5}, mgl32.Vec2{5, 5}))
+	if !found || point != (mgl32.Vec2{5, 0}) {
+		t.Fatalf("Intersection() = %v, %v, want (5, 0), true", point, found)
+	}
+	if _, found = segment.Intersection(BuildSegment(mgl32.Vec2{0, 1}, mgl32.Vec2{10, 1})); found {
+		t.Fatalf("Intersection() of parallel segments found=true, want false")
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	// Polygone concave en forme de "L"
+	polygon := Polygon{{0, 0}, {10, 0}, {10, 5}, {5, 5}, {5, 10}, {0, 10}}
+	if !polygon.Contains(mgl32.Vec2{2, 8}) || polygon.Contains(mgl32.Vec2{8, 8}) {
+		t.Fatalf("Contains() inconsistent results")
+	}
+	if area := polygon.Area(); area != 75 {
+		t.Fatalf("Area() = %v, want 75", area)
+	}
+	if polygon.IsConvex() {
+		t.Fatalf("IsConvex() = true, want false")
+	}
+	square := BuildRectangle(0, 0, 10, 10)
+	if !square.Polygon().IsConvex() || square.Polygon().Centroid() != (mgl32.Vec2{5, 5}) {
+		t.Fatalf("rectangle Polygon() is not a convex polygon centered on (5, 5)")
+	}
+	if bounds := polygon.Bounds(); bounds != BuildRectangle(0, 0, 10, 10) {
+		t.Fatalf("Bounds() = %v, want [0 0 10 10]", bounds)
+	}
+}
+
+func TestRay(t *testing.T) {
+	ray := BuildRay(mgl32.Vec2{0, 0}, mgl32.Vec2{2, 0})
+	if distance, hit := ray.IntersectRect(BuildRectangle(5, -1, 2, 2)); !hit || distance != 5 {
+		t.Fatalf("IntersectRect() = %v, %v, want 5, true", distance, hit)
+	}
+	if _, hit := ray.IntersectRect(BuildRectangle(5, 2, 2, 2)); hit {
+		t.Fatalf("IntersectRect() hit=true, want false")
+	}
+	if distance, hit := ray.IntersectCircle(BuildCircle(mgl32.Vec2{10, 0}, 2)); !hit || distance != 8 {
+		t.Fatalf("IntersectCircle() = %v, %v, want 8, true", distance, hit)
+	}
+	if _, hit := ray.IntersectCircle(BuildCircle(mgl32.Vec2{-10, 0}, 2)); hit {
+		t.Fatalf("IntersectCircle() behind the ray hit=true, want false")
+	}
+	if distance, hit := ray.IntersectSegment(BuildSegment(mgl32.Vec2{3, -1}, mgl32.Vec2{3, 1})); !hit || distance != 3 {
+		t.Fatalf("IntersectSegment() = %v, %v, want 3, true", distance, hit)
+	}
+	triangle := Polygon{{4, -2}, {8, 0}, {4, 2}}
+	if distance, hit := ray.IntersectPolygon(triangle); !hit || distance != 4 {
+		t.Fatalf("IntersectPolygon() = %v, %v, want 4, true", distance, hit)
+	}
+}
+
+func TestSeparatingAxisTest(t *testing.T) {
+	first := BuildRectangle(0, 0, 10, 10)
+	second := BuildRectangle(8, 2, 10, 10)
+	translation, overlap := SeparatingAxisTest(first.Polygon(), second.Polygon())
+	if !overlap || !translation.ApproxEqual(mgl32.Vec2{2, 0}) {
+		t.Fatalf("SeparatingAxisTest() = %v, %v, want (2, 0), true", translation, overlap)
+	}
+	far := BuildRectangle(20, 0, 10, 10)
+	if _, overlap = SeparatingAxisTest(first.Polygon(), far.Polygon()); overlap {
+		t.Fatalf("SeparatingAxisTest() of distant polygons overlap=true, want false")
+	}
+	translation, overlap = SeparatingAxisTestCircle(first.Polygon(), BuildCircle(mgl32.Vec2{5, 12}, 3))
+	if !overlap || !translation.ApproxEqual(mgl32.Vec2{0, 1}) {
+		t.Fatalf("SeparatingAxisTestCircle() = %v, %v, want (0, 1), true", translation, overlap)
+	}
+	if _, overlap = SeparatingAxisTestCircle(first.Polygon(), BuildCircle(mgl32.Vec2{13, 13}, 3)); overlap {
+		t.Fatalf("SeparatingAxisTestCircle() near a corner overlap=true, want false")
+	}
+}
@@ -0,0 +1,115 @@
+package graphic
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+)
+
+// GIF_CLIP is the name of the clip built from an animated GIF.
+const GIF_CLIP = "default"
+
+// gifDefaultDelay est la durée d'une frame sans délai (en millisecondes, comme les navigateurs)
+const gifDefaultDelay = 100
+
+// LoadAnimatedGifFromFile loads all frames of an animated GIF file.
+//
+//	Frames are composed (disposal methods are applied) and laid out in a grid on the returned texture.
+//	The sprite sheet contains the GIF_CLIP clip (looped forever, the number of times given by the GIF, or played once).
+func LoadAnimatedGifFromFile(filename string, options TextureOptions) (*Texture, *SpriteSheet, error) {
+	slog.Debug("animated GIF creation from file", "filename", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load GIF file '%s'\n - %w", filename, err)
+	}
+	texture, sheet, err := LoadAnimatedGifFromBytes(content, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build animation from GIF file '%s'\n - %w", filename, err)
+	}
+	return texture, sheet, nil
+}
+
+// LoadAnimatedGifFromBytes loads all frames of an animated GIF (see LoadAnimatedGifFromFile).
+func LoadAnimatedGifFromBytes(content []byte, options TextureOptions) (*Texture, *SpriteSheet, error) {
+	img, clip, err := decodeGifAnimation(bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, err
+	}
+	texture, err := NewTextureFromImageWithOptions(img, options)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create texture from GIF frames\n - %w", err)
+	}
+	sheet := BuildSpriteSheet()
+	sheet.AddClip(clip)
+	return texture, sheet, nil
+}
+
+// decodeGifAnimation composes GIF frames in a grid image and returns the matching clip.
+func decodeGifAnimation(reader io.Reader) (*image.NRGBA, *AnimationClip, error) {
+	animation, err := gif.DecodeAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode GIF\n - %w", err)
+	}
+	if len(animation.Image) == 0 {
+		return nil, nil, fmt.Errorf("GIF has no frame")
+	}
+	width, height := animation.Config.Width, animation.Config.Height
+	if width == 0 || height == 0 {
+		bounds := animation.Image[0].Bounds()
+		width, height = bounds.Max.X, bounds.Max.Y
+	}
+	// Grille presque carrée pour ne pas dépasser la taille maximale d'une texture
+	columns := int(math.Ceil(math.Sqrt(float64(len(animation.Image)))))
+	rows := (len(animation.Image) + columns - 1) / columns
+	grid := image.NewNRGBA(image.Rect(0, 0, columns*width, rows*height))
+
+	clip := &AnimationClip{
+		Name:   GIF_CLIP,
+		Frames: make([]AnimationFrame, 0, len(animation.Image)),
+		Mode:   ANIMATION_LOOP,
+	}
+	// LoopCount : -1 = une seule lecture, 0 = sans fin, n = n répétitions après la première lecture
+	if animation.LoopCount < 0 {
+		clip.Mode = ANIMATION_ONCE
+	} else if animation.LoopCount > 0 {
+		clip.Plays = animation.LoopCount + 1
+	}
+	canvas := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for index, frame := range animation.Image {
+		var disposal byte
+		if index < len(animation.Disposal) {
+			disposal = animation.Disposal[index]
+		}
+		var previous *image.NRGBA
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewNRGBA(canvas.Bounds())
+			copy(previous.Pix, canvas.Pix)
+		}
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		region := image.Rect(index%columns*width, index/columns*height, (index%columns+1)*width, (index/columns+1)*height)
+		draw.Draw(grid, region, canvas, image.Point{}, draw.Src)
+		duration := int64(gifDefaultDelay)
+		if index < len(animation.Delay) && animation.Delay[index] > 1 {
+			duration = int64(animation.Delay[index]) * 10
+		}
+		clip.Frames = append(clip.Frames, AnimationFrame{
+			Region:   BuildRectangle(float32(region.Min.X), float32(region.Min.Y), float32(width), float32(height)),
+			Duration: duration,
+		})
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return grid, clip, nil
+}
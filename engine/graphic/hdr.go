@@ -0,0 +1,141 @@
+package graphic
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strings"
+)
+
+// decodeHDR decodes a Radiance RGBE (.hdr) image as a FloatImage.
+//
+//	Only the standard "-Y height +X width" orientation and the RGBE format (not XYZE) are supported.
+func decodeHDR(reader io.Reader) (image.Image, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	buffered := bufio.NewReader(bytes.NewReader(content))
+	magic, err := buffered.ReadString('\n')
+	if err != nil || (!strings.HasPrefix(magic, "#?RADIANCE") && !strings.HasPrefix(magic, "#?RGBE")) {
+		return nil, fmt.Errorf("invalid Radiance HDR header")
+	}
+	headerSize := len(magic)
+	// Entête : variables jusqu'à une ligne vide
+	for {
+		line, err := buffered.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("truncated Radiance HDR header")
+		}
+		headerSize += len(line)
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if format, found := strings.CutPrefix(line, "FORMAT="); found && format != "32-bit_rle_rgbe" {
+			return nil, fmt.Errorf("unsupported '%s' Radiance HDR format", format)
+		}
+	}
+	resolution, err := buffered.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("truncated Radiance HDR header")
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(resolution, "-Y %d +X %d", &height, &width); err != nil {
+		return nil, fmt.Errorf("unsupported Radiance HDR resolution '%s'", strings.TrimSpace(resolution))
+	}
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid %dx%d Radiance HDR image size", width, height)
+	}
+	// Les données doivent contenir toutes les lignes avant d'allouer l'image
+	dataSize := len(content) - headerSize - len(resolution)
+	if width > dataSize || height > dataSize/hdrMinScanlineSize(width) {
+		return nil, fmt.Errorf("truncated Radiance HDR image data")
+	}
+
+	img := NewFloatImage(image.Rect(0, 0, width, height))
+	scanline := make([]byte, width*4)
+	for y := 0; y < height; y++ {
+		if err := readHDRScanline(buffered, scanline, width); err != nil {
+			return nil, fmt.Errorf("failed to read Radiance HDR scanline %d\n - %w", y, err)
+		}
+		for x := 0; x < width; x++ {
+			r, g, b := rgbeToFloat(scanline[x*4], scanline[x*4+1], scanline[x*4+2], scanline[x*4+3])
+			img.SetFloat(x, y, r, g, b, 1)
+		}
+	}
+	return img, nil
+}
+
+// hdrMinScanlineSize returns the smallest size of an encoded scanline of width pixels.
+func hdrMinScanlineSize(width int) int {
+	if width < 8 || width > 0x7fff {
+		// Ligne non compressée
+		return width * 4
+	}
+	// Entête puis, pour chaque composante, des séquences répétées de 127 pixels au plus (2 octets)
+	return 4 + 4*2*((width+126)/127)
+}
+
+// readHDRScanline reads a scanline (RGBE components of each pixel), flat or run length encoded by component.
+func readHDRScanline(reader *bufio.Reader, scanline []byte, width int) error {
+	if _, err := io.ReadFull(reader, scanline[:4]); err != nil {
+		return err
+	}
+	if width < 8 || width > 0x7fff || scanline[0] != 2 || scanline[1] != 2 || scanline[2]&0x80 != 0 {
+		// Ligne non compressée
+		_, err := io.ReadFull(reader, scanline[4:])
+		return err
+	}
+	if int(scanline[2])<<8|int(scanline[3]) != width {
+		return fmt.Errorf("invalid scanline width")
+	}
+	// Chaque composante est encodée séparément : séquences répétées (> 128) ou brutes
+	components := make([]byte, width)
+	for component := 0; component < 4; component++ {
+		for x := 0; x < width; {
+			count, err := reader.ReadByte()
+			if err != nil {
+				return err
+			}
+			if count > 128 {
+				repeat := int(count) - 128
+				value, err := reader.ReadByte()
+				if err != nil {
+					return err
+				}
+				if x+repeat > width {
+					return fmt.Errorf("invalid run length")
+				}
+				for ; repeat > 0; repeat-- {
+					components[x] = value
+					x++
+				}
+			} else {
+				if count == 0 || x+int(count) > width {
+					return fmt.Errorf("invalid run length")
+				}
+				if _, err := io.ReadFull(reader, components[x:x+int(count)]); err != nil {
+					return err
+				}
+				x += int(count)
+			}
+		}
+		for x := 0; x < width; x++ {
+			scanline[x*4+component] = components[x]
+		}
+	}
+	return nil
+}
+
+// rgbeToFloat converts a RGBE pixel (shared exponent) to float components.
+func rgbeToFloat(r, g, b, exponent byte) (float32, float32, float32) {
+	if exponent == 0 {
+		return 0, 0, 0
+	}
+	factor := float32(math.Ldexp(1, int(exponent)-(128+8)))
+	return float32(r) * factor, float32(g) * factor, float32(b) * factor
+}
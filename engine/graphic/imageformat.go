@@ -1,11 +1,18 @@
 package graphic
 
 import (
+	"fmt"
+	"golang.org/x/image/bmp"
+	"golang.org/x/image/webp"
 	"image"
 	"image/gif"
 	"image/jpeg"
 	"image/png"
 	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
 // ImageFormat is an image file supported format
@@ -17,6 +24,16 @@ const (
 	JPG              = ".jpg"
 	GIF              = ".gif"
 	PNG              = ".png"
+	WEBP             = ".webp"
+	BMP              = ".bmp"
+	TGA              = ".tga"
+	QOI              = ".qoi"
+	// HDR et EXR sont des images flottantes (voir FloatImage)
+	HDR = ".hdr"
+	EXR = ".exr"
+	// DDS et KTX2 contiennent des textures compressées (voir CompressedImage)
+	DDS  = ".dds"
+	KTX2 = ".ktx2"
 )
 
 // decoders contains image decoder by supported format
@@ -25,4 +42,48 @@ var imageDecoders = map[ImageFormat]func(reader io.Reader) (image.Image, error){
 	JPG:  jpeg.Decode,
 	GIF:  gif.Decode,
 	PNG:  png.Decode,
+	WEBP: webp.Decode,
+	BMP:  bmp.Decode,
+	TGA:  decodeTGA,
+	QOI:  decodeQOI,
+	HDR:  decodeHDR,
+	EXR:  decodeEXR,
+}
+
+// compressedImageDecoders contains compressed texture decoder by supported format
+var compressedImageDecoders = map[ImageFormat]func(reader io.Reader) (*CompressedImage, error){
+	DDS:  decodeDDS,
+	KTX2: decodeKTX2,
+}
+
+// supportedImageFormats returns the list of supported formats (for error messages).
+func supportedImageFormats() string {
+	formats := make([]string, 0, len(imageDecoders)+len(compressedImageDecoders))
+	for format := range imageDecoders {
+		formats = append(formats, "'"+string(format)+"'")
+	}
+	for format := range compressedImageDecoders {
+		formats = append(formats, "'"+string(format)+"'")
+	}
+	slices.Sort(formats)
+	return strings.Join(formats, ", ")
+}
+
+// LoadImageFromFile decodes an image file (the format is given by the file extension, compressed formats are excluded).
+func LoadImageFromFile(filename string) (image.Image, error) {
+	extension := ImageFormat(strings.ToLower(filepath.Ext(filename)))
+	decode, found := imageDecoders[extension]
+	if !found {
+		return nil, fmt.Errorf("cannot load image from '%s' file (unsupported '%s' extension)", filename, extension)
+	}
+	fileReader, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image from file '%s'\n - %w", filename, err)
+	}
+	defer fileReader.Close()
+	img, err := decode(fileReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image from file '%s'\n - %w", filename, err)
+	}
+	return img, nil
 }
@@ -0,0 +1,367 @@
+package graphic
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestDecodeTGA(t *testing.T) {
+	// 2x2, 24 bits, RLE, lignes de bas en haut
+	header := make([]byte, tgaHeaderSize)
+	header[2] = tgaRleTrueColor
+	binary.LittleEndian.PutUint16(header[12:], 2)
+	binary.LittleEndian.PutUint16(header[14:], 2)
+	header[16] = 24
+	data := []byte{0x81, 0, 0, 255, 0x01, 0, 255, 0, 255, 0, 0}
+	img, err := decodeTGA(bytes.NewReader(append(header, data...)))
+	if err != nil {
+		t.Fatalf("decodeTGA() error = %v", err)
+	}
+	for _, test := range []struct {
+		x, y int
+		want color.NRGBA
+	}{
+		{0, 1, color.NRGBA{R: 255, A: 255}},
+		{1, 1, color.NRGBA{R: 255, A: 255}},
+		{0, 0, color.NRGBA{G: 255, A: 255}},
+		{1, 0, color.NRGBA{B: 255, A: 255}},
+	} {
+		if got := img.At(test.x, test.y); got != test.want {
+			t.Fatalf("decodeTGA() pixel (%d, %d) = %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+	if _, err := decodeTGA(bytes.NewReader(append(header, data[:6]...))); err == nil {
+		t.Fatalf("decodeTGA() with truncated data = nil error, want error")
+	}
+}
+
+func TestDecodeQOI(t *testing.T) {
+	content := []byte("qoif")
+	content = binary.BigEndian.AppendUint32(content, 2)
+	content = binary.BigEndian.AppendUint32(content, 2)
+	content = append(content, 4, 0)
+	// rouge, répétition, différence (+1, -2, 0), index du rouge
+	content = append(content, qoiOpRGB, 255, 0, 0, qoiOpRun, 0x72, 50)
+	content = append(content, 0, 0, 0, 0, 0, 0, 0, 1)
+	img, err := decodeQOI(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("decodeQOI() error = %v", err)
+	}
+	red := color.NRGBA{R: 255, A: 255}
+	for _, test := range []struct {
+		x, y int
+		want color.NRGBA
+	}{
+		{0, 0, red},
+		{1, 0, red},
+		{0, 1, color.NRGBA{R: 0, G: 254, B: 0, A: 255}},
+		{1, 1, red},
+	} {
+		if got := img.At(test.x, test.y); got != test.want {
+			t.Fatalf("decodeQOI() pixel (%d, %d) = %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+// buildTGAHeader builds a TGA header (with a color map if colorMapLength > 0).
+func buildTGAHeader(imageType byte, width, height uint16, depth byte, colorMapLength uint16, colorMapDepth byte) []byte {
+	header := make([]byte, tgaHeaderSize)
+	if colorMapLength > 0 {
+		header[1] = 1
+	}
+	header[2] = imageType
+	binary.LittleEndian.PutUint16(header[5:], colorMapLength)
+	header[7] = colorMapDepth
+	binary.LittleEndian.PutUint16(header[12:], width)
+	binary.LittleEndian.PutUint16(header[14:], height)
+	header[16] = depth
+	return header
+}
+
+func TestDecodeImage_InvalidData(t *testing.T) {
+	qoiHeader := func(width, height uint32) []byte {
+		content := []byte("qoif")
+		content = binary.BigEndian.AppendUint32(content, width)
+		content = binary.BigEndian.AppendUint32(content, height)
+		return append(content, 4, 0)
+	}
+	tests := []struct {
+		name    string
+		decoder func(reader io.Reader) (image.Image, error)
+		content []byte
+	}{
+		{"TGA 8 bits color map", decodeTGA, append(buildTGAHeader(tgaColorMapped, 1, 1, 8, 2, 8), 0, 0, 0)},
+		{"TGA 0 bit color map", decodeTGA, append(buildTGAHeader(tgaColorMapped, 1, 1, 8, 2, 0), 0)},
+		{"TGA truncated color map", decodeTGA, append(buildTGAHeader(tgaColorMapped, 1, 1, 8, 2, 24), 0, 0, 0, 0)},
+		{"TGA huge RLE image", decodeTGA, append(buildTGAHeader(tgaRleTrueColor, 0xffff, 0xffff, 24, 0, 0), 0xff, 0, 0, 0)},
+		{"QOI size overflow", decodeQOI, append(qoiHeader(0xffffffff, 0xffffffff), 0, 0, 0, 0, 0, 0, 0, 1)},
+		{"QOI huge image", decodeQOI, append(qoiHeader(20000, 20000), qoiOpRun|61, 0, 0, 0, 0, 0, 0, 0, 1)},
+		{"HDR huge flat image", decodeHDR, []byte("#?RADIANCE\n\n-Y 100000 +X 100000\n\x80\x40\x00\x81")},
+		{"HDR huge RLE image", decodeHDR, []byte("#?RADIANCE\n\n-Y 1000000000 +X 8\n\x02\x02\x00\x08")},
+	}
+	for _, test := range tests {
+		if _, err := test.decoder(bytes.NewReader(test.content)); err == nil {
+			t.Fatalf("%s: decoder returns no error, want error", test.name)
+		}
+	}
+}
+
+func TestDecodeHDR(t *testing.T) {
+	// Ligne non compressée
+	content := []byte("#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 2\n")
+	content = append(content, 128, 64, 0, 129, 0, 0, 0, 0)
+	img, err := decodeHDR(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("decodeHDR() error = %v", err)
+	}
+	if r, g, b, a := img.(*FloatImage).FloatAt(0, 0); r != 1 || g != 0.5 || b != 0 || a != 1 {
+		t.Fatalf("decodeHDR() pixel (0, 0) = %v, %v, %v, %v, want 1, 0.5, 0, 1", r, g, b, a)
+	}
+
+	// Ligne compressée (8 pixels identiques)
+	content = []byte("#?RGBE\n\n-Y 1 +X 8\n")
+	content = append(content, 2, 2, 0, 8)
+	for _, value := range []byte{128, 128, 128, 130} {
+		content = append(content, 128+8, value)
+	}
+	img, err = decodeHDR(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("decodeHDR() with RLE error = %v", err)
+	}
+	if r, _, _, _ := img.(*FloatImage).FloatAt(7, 0); r != 2 {
+		t.Fatalf("decodeHDR() with RLE pixel (7, 0) red = %v, want 2", r)
+	}
+}
+
+// buildEXR builds a single block 2x1 OpenEXR image with B, G, R half channels.
+func buildEXR(compression byte, block []byte) []byte {
+	attribute := func(content []byte, name, kind string, value []byte) []byte {
+		content = append(content, name...)
+		content = append(content, 0)
+		content = append(content, kind...)
+		content = append(content, 0)
+		content = binary.LittleEndian.AppendUint32(content, uint32(len(value)))
+		return append(content, value...)
+	}
+	var channels []byte
+	for _, name := range []string{"B", "G", "R"} {
+		channels = append(channels, name...)
+		channels = append(channels, 0)
+		channels = binary.LittleEndian.AppendUint32(channels, exrHalf)
+		channels = append(channels, 0, 0, 0, 0)
+		channels = binary.LittleEndian.AppendUint32(channels, 1)
+		channels = binary.LittleEndian.AppendUint32(channels, 1)
+	}
+	channels = append(channels, 0)
+	window := make([]byte, 16)
+	binary.LittleEndian.PutUint32(window[8:], 1)
+
+	content := binary.LittleEndian.AppendUint32(nil, 20000630)
+	content = binary.LittleEndian.AppendUint32(content, 2)
+	content = attribute(content, "channels", "chlist", channels)
+	content = attribute(content, "compression", "compression", []byte{compression})
+	content = attribute(content, "dataWindow", "box2i", window)
+	content = append(content, 0)
+	content = binary.LittleEndian.AppendUint64(content, uint64(len(content)+8))
+	content = binary.LittleEndian.AppendUint32(content, 0)
+	content = binary.LittleEndian.AppendUint32(content, uint32(len(block)))
+	return append(content, block...)
+}
+
+func TestDecodeEXR(t *testing.T) {
+	// B = 0.5, 0 ; G = 2, 0 ; R = 1, -2
+	raw := []byte{0x00, 0x38, 0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x3c, 0x00, 0xc0}
+
+	// Compression ZIPS : entrelacement, prédicteur puis zlib
+	half := (len(raw) + 1) / 2
+	interleaved := make([]byte, len(raw))
+	for index, value := range raw {
+		if index%2 == 0 {
+			interleaved[index/2] = value
+		} else {
+			interleaved[half+index/2] = value
+		}
+	}
+	predicted := make([]byte, len(raw))
+	predicted[0] = interleaved[0]
+	for index := 1; index < len(raw); index++ {
+		predicted[index] = interleaved[index] - interleaved[index-1] + 128
+	}
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write(predicted)
+	writer.Close()
+
+	for _, test := range []struct {
+		name        string
+		compression byte
+		block       []byte
+	}{
+		{"none", exrNoCompression, raw},
+		{"zips", exrZipsCompression, compressed.Bytes()},
+	} {
+		img, err := decodeEXR(bytes.NewReader(buildEXR(test.compression, test.block)))
+		if err != nil {
+			t.Fatalf("decodeEXR(%s) error = %v", test.name, err)
+		}
+		floatImage := img.(*FloatImage)
+		if r, g, b, a := floatImage.FloatAt(0, 0); r != 1 || g != 2 || b != 0.5 || a != 1 {
+			t.Fatalf("decodeEXR(%s) pixel (0, 0) = %v, %v, %v, %v, want 1, 2, 0.5, 1", test.name, r, g, b, a)
+		}
+		if r, _, _, _ := floatImage.FloatAt(1, 0); r != -2 {
+			t.Fatalf("decodeEXR(%s) pixel (1, 0) red = %v, want -2", test.name, r)
+		}
+	}
+}
+
+func TestHalfToFloat(t *testing.T) {
+	for _, test := range []struct {
+		half uint16
+		want float32
+	}{
+		{0x3c00, 1},
+		{0xc000, -2},
+		{0x3555, 0.33325195},
+		{0x0001, 1. / (1 << 24)},
+		{0x7c00, float32(math.Inf(1))},
+	} {
+		if got := halfToFloat(test.half); got != test.want {
+			t.Fatalf("halfToFloat(0x%04x) = %v, want %v", test.half, got, test.want)
+		}
+	}
+}
+
+func TestDecodeDDS(t *testing.T) {
+	header := make([]byte, ddsHeaderSize)
+	copy(header, "DDS ")
+	binary.LittleEndian.PutUint32(header[4:], 124)
+	binary.LittleEndian.PutUint32(header[8:], ddsMipmapCountFlag)
+	binary.LittleEndian.PutUint32(header[12:], 8)
+	binary.LittleEndian.PutUint32(header[16:], 8)
+	binary.LittleEndian.PutUint32(header[28:], 2)
+	binary.LittleEndian.PutUint32(header[80:], ddsFourCCFlag)
+	copy(header[84:], "DXT5")
+	img, err := decodeDDS(bytes.NewReader(append(header, make([]byte, 64+16)...)))
+	if err != nil {
+		t.Fatalf("decodeDDS() error = %v", err)
+	}
+	if img.InternalFormat != gl.COMPRESSED_RGBA_S3TC_DXT5_EXT || len(img.Levels) != 2 || len(img.Levels[0]) != 64 || len(img.Levels[1]) != 16 {
+		t.Fatalf("decodeDDS() = format 0x%X, %d levels, want DXT5 with 64 and 16 bytes levels", img.InternalFormat, len(img.Levels))
+	}
+	if _, err := decodeDDS(bytes.NewReader(append(header, make([]byte, 70)...))); err == nil {
+		t.Fatalf("decodeDDS() with truncated data = nil error, want error")
+	}
+
+	// Nombre de niveaux ignoré sans le drapeau DDSD_MIPMAPCOUNT
+	binary.LittleEndian.PutUint32(header[8:], 0)
+	if img, err := decodeDDS(bytes.NewReader(append(header, make([]byte, 64+16)...))); err != nil || len(img.Levels) != 1 {
+		t.Fatalf("decodeDDS() without mipmap count flag = %v, want 1 level", err)
+	}
+	// Nombre de niveaux borné par la dimension de l'image (8, 4, 2 et 1 pixels)
+	binary.LittleEndian.PutUint32(header[8:], ddsMipmapCountFlag)
+	binary.LittleEndian.PutUint32(header[28:], 0xFFFFFFFF)
+	if img, err := decodeDDS(bytes.NewReader(append(header, make([]byte, 64+16*3)...))); err != nil || len(img.Levels) != 4 {
+		t.Fatalf("decodeDDS() with huge mipmap count = %v, want 4 levels", err)
+	}
+}
+
+func TestDecodeKTX2(t *testing.T) {
+	content := []byte(ktx2Identifier)
+	for _, value := range []uint32{157, 1, 8, 4, 0, 0, 1, 1, 0} {
+		content = binary.LittleEndian.AppendUint32(content, value)
+	}
+	content = append(content, make([]byte, ktx2HeaderSize-len(content))...)
+	content = binary.LittleEndian.AppendUint64(content, ktx2HeaderSize+24)
+	content = binary.LittleEndian.AppendUint64(content, 32)
+	content = binary.LittleEndian.AppendUint64(content, 32)
+	content = append(content, make([]byte, 32)...)
+	img, err := decodeKTX2(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("decodeKTX2() error = %v", err)
+	}
+	if img.InternalFormat != gl.COMPRESSED_RGBA_ASTC_4x4_KHR || img.Width != 8 || img.Height != 4 || len(img.Levels) != 1 {
+		t.Fatalf("decodeKTX2() = format 0x%X, %dx%d, %d levels, want ASTC 4x4, 8x4, 1 level", img.InternalFormat, img.Width, img.Height, len(img.Levels))
+	}
+	if format := ktx2Formats[184]; format != gl.COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR+13 {
+		t.Fatalf("ktx2Formats[184] = 0x%X, want ASTC 12x12 sRGB", format)
+	}
+}
+
+func TestDecodeGifAnimation(t *testing.T) {
+	palette := color.Palette{color.Transparent, color.NRGBA{R: 255, A: 255}, color.NRGBA{B: 255, A: 255}}
+	first := image.NewPaletted(image.Rect(0, 0, 2, 2), palette)
+	for index := range first.Pix {
+		first.Pix[index] = 1
+	}
+	second := image.NewPaletted(image.Rect(1, 1, 2, 2), palette)
+	second.Pix[0] = 2
+	var content bytes.Buffer
+	if err := gif.EncodeAll(&content, &gif.GIF{
+		Image:    []*image.Paletted{first, second},
+		Delay:    []int{5, 0},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalNone},
+		Config:   image.Config{ColorModel: palette, Width: 2, Height: 2},
+	}); err != nil {
+		t.Fatalf("gif.EncodeAll() error = %v", err)
+	}
+	grid, clip, err := decodeGifAnimation(&content)
+	if err != nil {
+		t.Fatalf("decodeGifAnimation() error = %v", err)
+	}
+	if grid.Bounds() != image.Rect(0, 0, 4, 2) {
+		t.Fatalf("decodeGifAnimation() grid bounds = %v, want (0,0)-(4,2)", grid.Bounds())
+	}
+	// La seconde frame est composée sur la première
+	if got, want := grid.At(2, 0), (color.NRGBA{R: 255, A: 255}); got != want {
+		t.Fatalf("decodeGifAnimation() pixel (2, 0) = %v, want %v", got, want)
+	}
+	if got, want := grid.At(3, 1), (color.NRGBA{B: 255, A: 255}); got != want {
+		t.Fatalf("decodeGifAnimation() pixel (3, 1) = %v, want %v", got, want)
+	}
+	if clip.Mode != ANIMATION_LOOP || len(clip.Frames) != 2 || clip.Frames[0].Duration != 50 || clip.Frames[1].Duration != gifDefaultDelay {
+		t.Fatalf("decodeGifAnimation() clip = %+v, want 2 looped frames of 50 and %d ms", clip, gifDefaultDelay)
+	}
+	if region := clip.Frames[1].Region; region != BuildRectangle(2, 0, 2, 2) {
+		t.Fatalf("decodeGifAnimation() second frame region = %v, want %v", region, BuildRectangle(2, 0, 2, 2))
+	}
+}
+
+func TestDecodeGifAnimation_LoopCount(t *testing.T) {
+	tests := []struct {
+		loopCount int
+		mode      AnimationMode
+		plays     int
+	}{
+		{-1, ANIMATION_ONCE, 0},
+		{0, ANIMATION_LOOP, 0},
+		{2, ANIMATION_LOOP, 3},
+	}
+	palette := color.Palette{color.Transparent, color.NRGBA{R: 255, A: 255}}
+	frame := image.NewPaletted(image.Rect(0, 0, 1, 1), palette)
+	for _, test := range tests {
+		var content bytes.Buffer
+		if err := gif.EncodeAll(&content, &gif.GIF{
+			Image:     []*image.Paletted{frame, frame},
+			Delay:     []int{0, 0},
+			LoopCount: test.loopCount,
+		}); err != nil {
+			t.Fatalf("gif.EncodeAll() error = %v", err)
+		}
+		_, clip, err := decodeGifAnimation(&content)
+		if err != nil {
+			t.Fatalf("decodeGifAnimation() error = %v", err)
+		}
+		if clip.Mode != test.mode || clip.Plays != test.plays {
+			t.Fatalf("decodeGifAnimation() with loop count %d: mode = %s, plays = %d, want %s, %d",
+				test.loopCount, clip.Mode, clip.Plays, test.mode, test.plays)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package graphic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"io"
+)
+
+// ktx2Identifier est l'identifiant au début d'un fichier KTX2
+const ktx2Identifier = "\xabKTX 20\xbb\r\n\x1a\n"
+
+// ktx2HeaderSize est la taille de l'entête et de l'index (avant la table des niveaux)
+const ktx2HeaderSize = 80
+
+// ktx2Formats contains OpenGL formats by Vulkan format
+var ktx2Formats = map[uint32]uint32{
+	131: gl.COMPRESSED_RGB_S3TC_DXT1_EXT,  // BC1_RGB_UNORM
+	132: glCompressedSrgbAlphaS3tcDxt1,    // BC1_RGB_SRGB
+	133: gl.COMPRESSED_RGBA_S3TC_DXT1_EXT, // BC1_RGBA_UNORM
+	134: glCompressedSrgbAlphaS3tcDxt1,    // BC1_RGBA_SRGB
+	135: gl.COMPRESSED_RGBA_S3TC_DXT3_EXT, // BC2_UNORM
+	136: glCompressedSrgbAlphaS3tcDxt3,    // BC2_SRGB
+	137: gl.COMPRESSED_RGBA_S3TC_DXT5_EXT, // BC3_UNORM
+	138: glCompressedSrgbAlphaS3tcDxt5,    // BC3_SRGB
+	139: gl.COMPRESSED_RED_RGTC1,          // BC4_UNORM
+	140: gl.COMPRESSED_SIGNED_RED_RGTC1,   // BC4_SNORM
+	141: gl.COMPRESSED_RG_RGTC2,           // BC5_UNORM
+	142: gl.COMPRESSED_SIGNED_RG_RGTC2,    // BC5_SNORM
+	143: gl.COMPRESSED_RGB_BPTC_UNSIGNED_FLOAT,
+	144: gl.COMPRESSED_RGB_BPTC_SIGNED_FLOAT,
+	145: gl.COMPRESSED_RGBA_BPTC_UNORM,       // BC7_UNORM
+	146: gl.COMPRESSED_SRGB_ALPHA_BPTC_UNORM, // BC7_SRGB
+}
+
+func init() {
+	// ASTC : formats 157 (4x4 UNORM) à 184 (12x12 SRGB), UNORM et SRGB en alternance
+	for index := range astcBlockSizes {
+		ktx2Formats[157+uint32(index)*2] = gl.COMPRESSED_RGBA_ASTC_4x4_KHR + uint32(index)
+		ktx2Formats[158+uint32(index)*2] = gl.COMPRESSED_SRGB8_ALPHA8_ASTC_4x4_KHR + uint32(index)
+	}
+}
+
+// decodeKTX2 decodes a KTX 2.0 2D texture with block compressed data (BCn or ASTC, without supercompression).
+func decodeKTX2(reader io.Reader) (*CompressedImage, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < ktx2HeaderSize || string(content[:12]) != ktx2Identifier {
+		return nil, fmt.Errorf("invalid KTX2 header")
+	}
+	vkFormat := binary.LittleEndian.Uint32(content[12:])
+	width := int(binary.LittleEndian.Uint32(content[20:]))
+	height := int(binary.LittleEndian.Uint32(content[24:]))
+	depth := binary.LittleEndian.Uint32(content[28:])
+	layerCount := binary.LittleEndian.Uint32(content[32:])
+	faceCount := binary.LittleEndian.Uint32(content[36:])
+	levelCount := max(int(binary.LittleEndian.Uint32(content[40:])), 1)
+	supercompression := binary.LittleEndian.Uint32(content[44:])
+	if depth > 0 || layerCount > 1 || faceCount != 1 {
+		return nil, fmt.Errorf("unsupported KTX2 texture (only 2D textures are supported)")
+	}
+	if supercompression != 0 {
+		return nil, fmt.Errorf("unsupported KTX2 supercompression scheme %d", supercompression)
+	}
+	format, found := ktx2Formats[vkFormat]
+	if !found {
+		return nil, fmt.Errorf("unsupported KTX2 Vulkan format %d", vkFormat)
+	}
+	if ktx2HeaderSize+levelCount*24 > len(content) {
+		return nil, fmt.Errorf("truncated KTX2 level index")
+	}
+
+	img := &CompressedImage{InternalFormat: format, Width: width, Height: height, Levels: make([][]byte, 0, levelCount)}
+	for level := 0; level < levelCount; level++ {
+		index := content[ktx2HeaderSize+level*24:]
+		offset := binary.LittleEndian.Uint64(index)
+		length := binary.LittleEndian.Uint64(index[8:])
+		if offset > uint64(len(content)) || length > uint64(len(content))-offset {
+			return nil, fmt.Errorf("invalid KTX2 level %d", level)
+		}
+		img.Levels = append(img.Levels, content[offset:offset+length])
+	}
+	if err := img.Validate(); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
@@ -0,0 +1,158 @@
+package graphic
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"image"
+	"log/slog"
+	"ogl46/engine/ogl"
+)
+
+// Sampler is a texture that can be bound to a texture unit (Texture, CubeMap, TextureArray or Texture3d).
+type Sampler interface {
+	// BindTextureUnit binds the texture to a texture unit (gl.TEXTURE0, gl.TEXTURE1...)
+	BindTextureUnit(textureUnit uint32)
+}
+
+// layeredTexture is an OpenGL texture made of several images of the same size
+// (cube map faces, array layers or 3D texture slices).
+type layeredTexture struct {
+	// target est le type de texture OpenGL (gl.TEXTURE_CUBE_MAP, gl.TEXTURE_2D_ARRAY ou gl.TEXTURE_3D)
+	target  uint32
+	handle  uint32
+	width   int32
+	height  int32
+	layers  int32
+	options TextureOptions
+}
+
+// newLayeredTexture creates a target texture with one image per layer.
+func newLayeredTexture(target uint32, images []image.Image, options TextureOptions) (*layeredTexture, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("cannot build texture without image")
+	}
+	bounds := images[0].Bounds()
+	for index, img := range images {
+		if img.Bounds().Dx() != bounds.Dx() || img.Bounds().Dy() != bounds.Dy() {
+			return nil, fmt.Errorf("image #%d size %dx%d differs from first image size %dx%d",
+				index, img.Bounds().Dx(), img.Bounds().Dy(), bounds.Dx(), bounds.Dy())
+		}
+	}
+	options = options.withImageFormat(images[0]).normalized()
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if options.Format == FORMAT_COMPRESSED {
+		return nil, fmt.Errorf("compressed format is not supported for layered textures")
+	}
+	width, height, layers := int32(bounds.Dx()), int32(bounds.Dy()), int32(len(images))
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("cannot build texture from empty images")
+	}
+	format := textureFormats[options.Format]
+
+	texture := &layeredTexture{target: target, width: width, height: height, layers: layers, options: options}
+	gl.CreateTextures(target, 1, &texture.handle)
+	switch target {
+	case gl.TEXTURE_CUBE_MAP:
+		gl.TextureStorage2D(texture.handle, mipmapLevels(width, height, options.Mipmaps), format.internalFormat, width, height)
+	case gl.TEXTURE_3D:
+		gl.TextureStorage3D(texture.handle, mipmapLevels(max(width, layers), height, options.Mipmaps), format.internalFormat, width, height, layers)
+	default:
+		gl.TextureStorage3D(texture.handle, mipmapLevels(width, height, options.Mipmaps), format.internalFormat, width, height, layers)
+	}
+	applyTextureOptions(texture.handle, options)
+	for layer, img := range images {
+		texture.upload(int32(layer), imageToPixels(img, format))
+	}
+	if options.Mipmaps {
+		gl.GenerateTextureMipmap(texture.handle)
+	}
+
+	slog.Debug("layered texture info", "handle", texture.handle, "width", width, "height", height, "layers", layers, "format", options.Format)
+
+	return texture, nil
+}
+
+// upload copies pixels (see imageToPixels) into a layer.
+func (texture *layeredTexture) upload(layer int32, pixels any) {
+	format := textureFormats[texture.options.Format]
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	gl.TextureSubImage3D(texture.handle, 0, 0, 0, layer, texture.width, texture.height, 1, format.pixelFormat, format.pixelType, gl.Ptr(pixels))
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
+}
+
+// updateLayer replaces a layer with img (mipmaps are regenerated).
+func (texture *layeredTexture) updateLayer(layer int, img image.Image) error {
+	if layer < 0 || layer >= int(texture.layers) {
+		return fmt.Errorf("invalid layer %d (%d layers)", layer, texture.layers)
+	}
+	if int32(img.Bounds().Dx()) != texture.width || int32(img.Bounds().Dy()) != texture.height {
+		return fmt.Errorf("image size %dx%d differs from texture size %dx%d", img.Bounds().Dx(), img.Bounds().Dy(), texture.width, texture.height)
+	}
+	texture.upload(int32(layer), imageToPixels(img, textureFormats[texture.options.Format]))
+	if texture.options.Mipmaps {
+		gl.GenerateTextureMipmap(texture.handle)
+	}
+	return nil
+}
+
+// BindTextureUnit binds the texture to a texture unit (gl.TEXTURE0, gl.TEXTURE1...).
+func (texture *layeredTexture) BindTextureUnit(textureUnit uint32) {
+	gl.BindTextureUnit(textureUnit-gl.TEXTURE0, texture.handle)
+}
+
+// BindImageUnit binds all layers to an image unit for compute shaders (image2DArray, imageCube or image3D).
+func (texture *layeredTexture) BindImageUnit(imageUnit uint32, access ogl.ImageAccess) error {
+	return ogl.BindImage(imageUnit, texture.handle, 0, true, 0, access)
+}
+
+// BindImageLayer binds one layer to an image unit for compute shaders (image2D).
+func (texture *layeredTexture) BindImageLayer(imageUnit uint32, layer int, access ogl.ImageAccess) error {
+	if layer < 0 || layer >= int(texture.layers) {
+		return fmt.Errorf("invalid layer %d (%d layers)", layer, texture.layers)
+	}
+	return ogl.BindImage(imageUnit, texture.handle, 0, false, int32(layer), access)
+}
+
+// Handle returns the OpenGL texture.
+func (texture *layeredTexture) Handle() uint32 {
+	return texture.handle
+}
+
+// Width returns the width of a layer.
+func (texture *layeredTexture) Width() int32 {
+	return texture.width
+}
+
+// Height returns the height of a layer.
+func (texture *layeredTexture) Height() int32 {
+	return texture.height
+}
+
+// Options returns the texture creation options.
+func (texture *layeredTexture) Options() TextureOptions {
+	return texture.options
+}
+
+// Release releases the OpenGL texture.
+func (texture *layeredTexture) Release() {
+	if texture.handle != 0 {
+		slog.Debug("layered texture destruction")
+		gl.DeleteTextures(1, &texture.handle)
+		texture.handle = 0
+	}
+}
+
+// loadImagesFromFiles decodes image files.
+func loadImagesFromFiles(filenames []string) ([]image.Image, error) {
+	images := make([]image.Image, 0, len(filenames))
+	for _, filename := range filenames {
+		img, err := LoadImageFromFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
@@ -0,0 +1,234 @@
+package graphic
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"log/slog"
+	"ogl46/engine/ogl"
+	"os"
+)
+
+//go:embed shader/material_grayscale.frag
+var grayscaleFragmentShader string
+
+//go:embed shader/material_dissolve.frag
+var dissolveFragmentShader string
+
+//go:embed shader/material_paletteswap.frag
+var paletteSwapFragmentShader string
+
+//go:embed shader/material_outline.frag
+var outlineFragmentShader string
+
+// materialAttributes lists the vertex attributes of the renderer vertex layout (index = location).
+var materialAttributes = []string{"position", "textPosition", "vertexColor"}
+
+// materialTexture is an additional texture used by a material.
+type materialTexture struct {
+	name    string
+	texture Sampler
+}
+
+// Material is a custom shader used to draw sprites (see Renderer2d.DrawBatchWithMaterial and DrawSpriteWithMaterial).
+//
+//	The fragment shader receives the renderer outputs:
+//	  in vec2 TexCoords;       // texture coordinates
+//	  in vec4 Color;           // sprite color
+//	  uniform sampler2D image; // sprite texture (unit 0)
+//	  out vec4 color;          // pixel color
+//	User uniforms are set through the material and sent each time it is used.
+type Material struct {
+	shaderProgram *ogl.ShaderProgram
+	// uniforms contient les valeurs des uniforms utilisateur
+	uniforms ogl.UniformValues
+	// textures contient les textures supplémentaires (unités 1, 2...)
+	textures []materialTexture
+}
+
+// NewMaterial creates a material from a fragment shader (the renderer vertex shader is used).
+func NewMaterial(fragmentSource string) (*Material, error) {
+	return NewMaterialWithVertexShader(batch2dVertexShader, fragmentSource)
+}
+
+// NewMaterialWithVertexShader creates a material from a vertex shader and a fragment shader.
+//
+//	The vertex shader must use the renderer vertex layout: 'position' (vec2, location 0),
+//	'textPosition' (vec2, location 1), 'vertexColor' (vec4, location 2) attributes and 'projection' uniform.
+func NewMaterialWithVertexShader(vertexSource, fragmentSource string) (*Material, error) {
+	slog.Debug("material creation")
+	vertexShader, err := ogl.NewShaderFromSource(vertexSource, gl.VERTEX_SHADER)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile material vertex shader\n - %w", err)
+	}
+	fragmentShader, err := ogl.NewShaderFromSource(fragmentSource, gl.FRAGMENT_SHADER)
+	if err != nil {
+		vertexShader.Delete()
+		return nil, fmt.Errorf("failed to compile material fragment shader\n - %w", err)
+	}
+	shaderProgram, err := ogl.NewShaderProgram(vertexShader, fragmentShader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link material shaders\n - %w", err)
+	}
+	if err := checkMaterialProgram(shaderProgram); err != nil {
+		shaderProgram.Delete()
+		return nil, fmt.Errorf("invalid material shaders\n - %w", err)
+	}
+	return &Material{shaderProgram: shaderProgram, uniforms: make(ogl.UniformValues)}, nil
+}
+
+// LoadMaterialFromFile creates a material from a fragment shader file.
+func LoadMaterialFromFile(filename string) (*Material, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load '%s' material file\n - %w", filename, err)
+	}
+	material, err := NewMaterial(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build material from '%s' file\n - %w", filename, err)
+	}
+	return material, nil
+}
+
+// checkMaterialProgram verifies that a linked program uses the renderer vertex layout.
+func checkMaterialProgram(shaderProgram *ogl.ShaderProgram) error {
+	for location, name := range materialAttributes {
+		actual := shaderProgram.GetAttributeLocation(name)
+		// Les attributs inutilisés sont supprimés par l'édition de liens (sauf la position, indispensable)
+		if actual < 0 && location == 0 {
+			return fmt.Errorf("'%s' attribute is missing", name)
+		}
+		if actual >= 0 && actual != int32(location) {
+			return fmt.Errorf("'%s' attribute location is %d (%d expected)", name, actual, location)
+		}
+	}
+	if !shaderProgram.HasUniform("projection") {
+		return fmt.Errorf("'projection' uniform is missing")
+	}
+	return nil
+}
+
+// NewGrayscaleMaterial creates a material that removes colors ('intensity' uniform, 1 by default).
+func NewGrayscaleMaterial() (*Material, error) {
+	material, err := NewMaterial(grayscaleFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	material.SetFloat("intensity", 1.)
+	return material, nil
+}
+
+// NewDissolveMaterial creates a material that dissolves sprites with a burning edge.
+//
+//	'progress' uniform goes from 0 (visible) to 1 (dissolved), see SetFloat.
+func NewDissolveMaterial(edgeWidth float32, edgeColor Color) (*Material, error) {
+	material, err := NewMaterial(dissolveFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	material.SetFloat("progress", 0.)
+	material.SetFloat("edgeWidth", edgeWidth)
+	material.SetColor("edgeColor", edgeColor)
+	return material, nil
+}
+
+// NewPaletteSwapMaterial creates a material that replaces colors by palette colors.
+//
+//	The red channel of the sprite texture is the color index in palette (first line of palette texture).
+func NewPaletteSwapMaterial(palette *Texture) (*Material, error) {
+	material, err := NewMaterial(paletteSwapFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	material.SetTexture("palette", palette)
+	return material, nil
+}
+
+// NewOutlineMaterial creates a material that draws an outline around opaque pixels (thickness in texels).
+//
+//	Sprites must have transparent borders (the outline is drawn inside the sprite zone).
+func NewOutlineMaterial(outlineColor Color, thickness float32) (*Material, error) {
+	material, err := NewMaterial(outlineFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	material.SetColor("outlineColor", outlineColor)
+	material.SetFloat("thickness", thickness)
+	return material, nil
+}
+
+// Release releases material shaders (textures are not released).
+func (material *Material) Release() {
+	if material.shaderProgram != nil {
+		slog.Debug("material destruction")
+		material.shaderProgram.Delete()
+		material.shaderProgram = nil
+	}
+}
+
+// SetInt sets an int uniform.
+func (material *Material) SetInt(name string, value int32) {
+	material.uniforms.SetInt(name, value)
+}
+
+// SetFloat sets a float uniform.
+func (material *Material) SetFloat(name string, value float32) {
+	material.uniforms.SetFloat(name, value)
+}
+
+// SetVec2 sets a vec2 uniform.
+func (material *Material) SetVec2(name string, value mgl32.Vec2) {
+	material.uniforms.SetVec2(name, value)
+}
+
+// SetVec3 sets a vec3 uniform.
+func (material *Material) SetVec3(name string, value mgl32.Vec3) {
+	material.uniforms.SetVec3(name, value)
+}
+
+// SetVec4 sets a vec4 uniform.
+func (material *Material) SetVec4(name string, value mgl32.Vec4) {
+	material.uniforms.SetVec4(name, value)
+}
+
+// SetColor sets a vec4 uniform from a color.
+func (material *Material) SetColor(name string, value Color) {
+	material.uniforms.SetVec4(name, mgl32.Vec4(value))
+}
+
+// SetMat4 sets a mat4 uniform.
+func (material *Material) SetMat4(name string, value mgl32.Mat4) {
+	material.uniforms.SetMat4(name, value)
+}
+
+// SetTexture sets a sampler uniform (sampler2D, samplerCube, sampler2DArray or sampler3D).
+//
+//	Textures are bound on units 1, 2... in the order they are first set.
+func (material *Material) SetTexture(name string, texture Sampler) {
+	for index := range material.textures {
+		if material.textures[index].name == name {
+			material.textures[index].texture = texture
+			return
+		}
+	}
+	material.textures = append(material.textures, materialTexture{name: name, texture: texture})
+}
+
+// Uniform returns the value of a user uniform.
+func (material *Material) Uniform(name string) (any, bool) {
+	value, found := material.uniforms[name]
+	return value, found
+}
+
+// use actives material shader and sends user uniforms and textures.
+func (material *Material) use(projection *mgl32.Mat4) {
+	shaderProgram := material.shaderProgram
+	shaderProgram.Use()
+	shaderProgram.UniformMatrix4fv("projection", projection)
+	material.uniforms.Apply(shaderProgram)
+	for index, texture := range material.textures {
+		texture.texture.BindTextureUnit(gl.TEXTURE1 + uint32(index))
+		shaderProgram.Uniform1i(texture.name, int32(index+1))
+	}
+}
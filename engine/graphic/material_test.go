@@ -0,0 +1,33 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine/ogl"
+	"testing"
+)
+
+func TestMaterialUniforms(t *testing.T) {
+	material := &Material{uniforms: make(ogl.UniformValues)}
+	material.SetFloat("progress", 0.5)
+	material.SetColor("edgeColor", White)
+	if value, found := material.Uniform("progress"); !found || value != float32(0.5) {
+		t.Fatalf("Uniform(progress) = %v, %v, want 0.5, true", value, found)
+	}
+	if value, _ := material.Uniform("edgeColor"); value != mgl32.Vec4(White) {
+		t.Fatalf("Uniform(edgeColor) = %v, want %v", value, mgl32.Vec4(White))
+	}
+	if _, found := material.Uniform("unknown"); found {
+		t.Fatalf("Uniform(unknown) found, want not found")
+	}
+}
+
+func TestMaterialTextureUnits(t *testing.T) {
+	material := &Material{uniforms: make(ogl.UniformValues)}
+	palette, other := &Texture{}, &Texture{}
+	material.SetTexture("palette", &Texture{})
+	material.SetTexture("noise", other)
+	material.SetTexture("palette", palette)
+	if len(material.textures) != 2 || material.textures[0].texture != palette || material.textures[1].texture != other {
+		t.Fatalf("SetTexture() did not keep texture units: %+v", material.textures)
+	}
+}
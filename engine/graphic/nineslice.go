@@ -0,0 +1,109 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Insets defines border sizes (left, top, right, bottom) used to slice a sprite.
+type Insets mgl32.Vec4
+
+// BuildInsets function builds and returns insets.
+func BuildInsets(left float32, top float32, right float32, bottom float32) Insets {
+	return Insets{left, top, right, bottom}
+}
+
+func (insets *Insets) Left() float32 {
+	return insets[0]
+}
+func (insets *Insets) Top() float32 {
+	return insets[1]
+}
+func (insets *Insets) Right() float32 {
+	return insets[2]
+}
+func (insets *Insets) Bottom() float32 {
+	return insets[3]
+}
+
+// Scale returns insets multiplied by horizontal and vertical ratios.
+func (insets *Insets) Scale(ratio mgl32.Vec2) Insets {
+	return Insets{insets[0] * ratio.X(), insets[1] * ratio.Y(), insets[2] * ratio.X(), insets[3] * ratio.Y()}
+}
+
+// Apply returns the rectangle reduced by insets.
+func (insets *Insets) Apply(rect Rectangle) Rectangle {
+	return Rectangle{
+		rect.X() + insets.Left(), rect.Y() + insets.Top(),
+		max(rect.Width()-insets.Left()-insets.Right(), 0), max(rect.Height()-insets.Top()-insets.Bottom(), 0),
+	}
+}
+
+// SliceMode indicates how a sprite is sliced when it is stretched.
+type SliceMode string
+
+// List of supported slice modes
+const (
+	// STRETCH stretches the whole sprite
+	STRETCH SliceMode = "stretch"
+	// NINE_SLICE keeps corners and stretches borders and center
+	NINE_SLICE SliceMode = "nine"
+	// THREE_SLICE_HORIZONTAL keeps left and right parts and stretches the middle part
+	THREE_SLICE_HORIZONTAL SliceMode = "three-horizontal"
+	// THREE_SLICE_VERTICAL keeps top and bottom parts and stretches the middle part
+	THREE_SLICE_VERTICAL SliceMode = "three-vertical"
+)
+
+// SlicePart is a part of a sliced sprite (texture zone and screen zone).
+type SlicePart struct {
+	Source Rectangle
+	Target Rectangle
+}
+
+// ComputeNineSlices splits source and target rectangles in (up to) 9 parts.
+//
+//	sourceInsets define borders on texture.
+//	targetInsets define borders on screen (reduced if target rectangle is too small).
+//	Empty parts are not returned.
+func ComputeNineSlices(source Rectangle, sourceInsets Insets, target Rectangle, targetInsets Insets) []SlicePart {
+	// Reduce borders if target zone is too small
+	if horizontal := targetInsets.Left() + targetInsets.Right(); horizontal > target.Width() && horizontal > 0 {
+		ratio := target.Width() / horizontal
+		targetInsets[0] *= ratio
+		targetInsets[2] *= ratio
+	}
+	if vertical := targetInsets.Top() + targetInsets.Bottom(); vertical > target.Height() && vertical > 0 {
+		ratio := target.Height() / vertical
+		targetInsets[1] *= ratio
+		targetInsets[3] *= ratio
+	}
+
+	sourceColumns := sliceAxis(source.X(), source.Width(), sourceInsets.Left(), sourceInsets.Right())
+	sourceRows := sliceAxis(source.Y(), source.Height(), sourceInsets.Top(), sourceInsets.Bottom())
+	targetColumns := sliceAxis(target.X(), target.Width(), targetInsets.Left(), targetInsets.Right())
+	targetRows := sliceAxis(target.Y(), target.Height(), targetInsets.Top(), targetInsets.Bottom())
+
+	parts := make([]SlicePart, 0, 9)
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			if sourceColumns[column][1] <= 0 || sourceRows[row][1] <= 0 ||
+				targetColumns[column][1] <= 0 || targetRows[row][1] <= 0 {
+				continue
+			}
+			parts = append(parts, SlicePart{
+				Source: Rectangle{sourceColumns[column][0], sourceRows[row][0], sourceColumns[column][1], sourceRows[row][1]},
+				Target: Rectangle{targetColumns[column][0], targetRows[row][0], targetColumns[column][1], targetRows[row][1]},
+			})
+		}
+	}
+	return parts
+}
+
+// sliceAxis splits a segment in 3 parts (position and length of each part).
+func sliceAxis(position, length, before, after float32) [3][2]float32 {
+	middle := max(length-before-after, 0)
+	return [3][2]float32{
+		{position, before},
+		{position + before, middle},
+		{position + before + middle, after},
+	}
+}
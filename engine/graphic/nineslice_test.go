@@ -0,0 +1,104 @@
+package graphic
+
+import (
+	"testing"
+)
+
+func TestComputeNineSlices(t *testing.T) {
+	source := BuildRectangle(0, 0, 30, 30)
+	insets := BuildInsets(10, 10, 10, 10)
+	parts := ComputeNineSlices(source, insets, BuildRectangle(100, 200, 100, 50), insets)
+	want := []SlicePart{
+		// Coins et bords supérieurs
+		{BuildRectangle(0, 0, 10, 10), BuildRectangle(100, 200, 10, 10)},
+		{BuildRectangle(10, 0, 10, 10), BuildRectangle(110, 200, 80, 10)},
+		{BuildRectangle(20, 0, 10, 10), BuildRectangle(190, 200, 10, 10)},
+		// Bords latéraux et centre
+		{BuildRectangle(0, 10, 10, 10), BuildRectangle(100, 210, 10, 30)},
+		{BuildRectangle(10, 10, 10, 10), BuildRectangle(110, 210, 80, 30)},
+		{BuildRectangle(20, 10, 10, 10), BuildRectangle(190, 210, 10, 30)},
+		// Coins et bords inférieurs
+		{BuildRectangle(0, 20, 10, 10), BuildRectangle(100, 240, 10, 10)},
+		{BuildRectangle(10, 20, 10, 10), BuildRectangle(110, 240, 80, 10)},
+		{BuildRectangle(20, 20, 10, 10), BuildRectangle(190, 240, 10, 10)},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("ComputeNineSlices() returns %d parts, want %d", len(parts), len(want))
+	}
+	for index := range want {
+		if parts[index] != want[index] {
+			t.Fatalf("ComputeNineSlices() part %d = %v, want %v", index, parts[index], want[index])
+		}
+	}
+}
+
+func TestComputeNineSlices_Undersized(t *testing.T) {
+	source := BuildRectangle(0, 0, 30, 30)
+	insets := BuildInsets(10, 10, 10, 10)
+	tests := []struct {
+		name   string
+		target Rectangle
+		want   []SlicePart
+	}{
+		{
+			// Bordures réduites à la moitié de la cible, plus de bord ni de centre
+			name:   "smaller than borders",
+			target: BuildRectangle(0, 0, 10, 10),
+			want: []SlicePart{
+				{BuildRectangle(0, 0, 10, 10), BuildRectangle(0, 0, 5, 5)},
+				{BuildRectangle(20, 0, 10, 10), BuildRectangle(5, 0, 5, 5)},
+				{BuildRectangle(0, 20, 10, 10), BuildRectangle(0, 5, 5, 5)},
+				{BuildRectangle(20, 20, 10, 10), BuildRectangle(5, 5, 5, 5)},
+			},
+		},
+		{
+			// Seule la hauteur est trop petite : colonnes conservées, pas de ligne centrale
+			name:   "too short",
+			target: BuildRectangle(0, 0, 40, 10),
+			want: []SlicePart{
+				{BuildRectangle(0, 0, 10, 10), BuildRectangle(0, 0, 10, 5)},
+				{BuildRectangle(10, 0, 10, 10), BuildRectangle(10, 0, 20, 5)},
+				{BuildRectangle(20, 0, 10, 10), BuildRectangle(30, 0, 10, 5)},
+				{BuildRectangle(0, 20, 10, 10), BuildRectangle(0, 5, 10, 5)},
+				{BuildRectangle(10, 20, 10, 10), BuildRectangle(10, 5, 20, 5)},
+				{BuildRectangle(20, 20, 10, 10), BuildRectangle(30, 5, 10, 5)},
+			},
+		},
+		{
+			name:   "empty target",
+			target: BuildRectangle(0, 0, 0, 0),
+			want:   []SlicePart{},
+		},
+	}
+	for _, test := range tests {
+		parts := ComputeNineSlices(source, insets, test.target, insets)
+		if len(parts) != len(test.want) {
+			t.Fatalf("ComputeNineSlices() %s returns %d parts (%v), want %d", test.name, len(parts), parts, len(test.want))
+		}
+		for index := range test.want {
+			if parts[index] != test.want[index] {
+				t.Fatalf("ComputeNineSlices() %s part %d = %v, want %v", test.name, index, parts[index], test.want[index])
+			}
+		}
+	}
+}
+
+func TestComputeNineSlices_ThreeSlices(t *testing.T) {
+	// Sans bordure verticale : une seule ligne de 3 parties
+	source := BuildRectangle(0, 0, 30, 10)
+	insets := BuildInsets(10, 0, 10, 0)
+	parts := ComputeNineSlices(source, insets, BuildRectangle(0, 0, 60, 20), insets)
+	want := []SlicePart{
+		{BuildRectangle(0, 0, 10, 10), BuildRectangle(0, 0, 10, 20)},
+		{BuildRectangle(10, 0, 10, 10), BuildRectangle(10, 0, 40, 20)},
+		{BuildRectangle(20, 0, 10, 10), BuildRectangle(50, 0, 10, 20)},
+	}
+	if len(parts) != len(want) {
+		t.Fatalf("ComputeNineSlices() returns %d parts, want %d", len(parts), len(want))
+	}
+	for index := range want {
+		if parts[index] != want[index] {
+			t.Fatalf("ComputeNineSlices() part %d = %v, want %v", index, parts[index], want[index])
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Polygon is a 2D polygon (list of vertices, the last vertex is connected to the first one).
+type Polygon []mgl32.Vec2
+
+// Contains returns true if the point is inside the polygon (convex or concave).
+func (polygon Polygon) Contains(point mgl32.Vec2) bool {
+	inside := false
+	for index, current := range polygon {
+		previous := polygon[(index+len(polygon)-1)%len(polygon)]
+		// Count the crossings of a horizontal ray starting at the point
+		if (current.Y() > point.Y()) != (previous.Y() > point.Y()) {
+			crossingX := current.X() + (point.Y()-current.Y())*(previous.X()-current.X())/(previous.Y()-current.Y())
+			if point.X() < crossingX {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// SignedArea returns the area of the polygon (positive for counter-clockwise vertices in a y-up frame).
+func (polygon Polygon) SignedArea() float32 {
+	area := float32(0)
+	for index, vertex := range polygon {
+		area += cross2d(vertex, polygon[(index+1)%len(polygon)])
+	}
+	return area / 2
+}
+
+// Area returns the area of the polygon.
+func (polygon Polygon) Area() float32 {
+	return mgl32.Abs(polygon.SignedArea())
+}
+
+// Centroid returns the center of mass of the polygon.
+func (polygon Polygon) Centroid() mgl32.Vec2 {
+	area := polygon.SignedArea()
+	if area == 0 {
+		centroid := mgl32.Vec2{}
+		for _, vertex := range polygon {
+			centroid = centroid.Add(vertex)
+		}
+		return centroid.Mul(1 / float32(max(len(polygon), 1)))
+	}
+	centroid := mgl32.Vec2{}
+	for index, first := range polygon {
+		second := polygon[(index+1)%len(polygon)]
+		centroid = centroid.Add(first.Add(second).Mul(cross2d(first, second)))
+	}
+	return centroid.Mul(1 / (6 * area))
+}
+
+// IsConvex returns true if the polygon is convex.
+func (polygon Polygon) IsConvex() bool {
+	if len(polygon) < 3 {
+		return false
+	}
+	sign := 0
+	for index, vertex := range polygon {
+		next := polygon[(index+1)%len(polygon)]
+		afterNext := polygon[(index+2)%len(polygon)]
+		turn := cross2d(next.Sub(vertex), afterNext.Sub(next))
+		switch {
+		case turn > 0 && sign < 0, turn < 0 && sign > 0:
+			return false
+		case turn > 0:
+			sign = 1
+		case turn < 0:
+			sign = -1
+		}
+	}
+	return sign != 0
+}
+
+// Bounds returns the smallest rectangle containing the polygon.
+func (polygon Polygon) Bounds() Rectangle {
+	if len(polygon) == 0 {
+		return Rectangle{}
+	}
+	minimum, maximum := polygon[0], polygon[0]
+	for _, vertex := range polygon[1:] {
+		minimum = mgl32.Vec2{min(minimum.X(), vertex.X()), min(minimum.Y(), vertex.Y())}
+		maximum = mgl32.Vec2{max(maximum.X(), vertex.X()), max(maximum.Y(), vertex.Y())}
+	}
+	return BuildRectFromPosAndDim(minimum, maximum.Sub(minimum))
+}
+
+// Translate returns the polygon moved by the offset.
+func (polygon Polygon) Translate(offset mgl32.Vec2) Polygon {
+	translated := make(Polygon, len(polygon))
+	for index, vertex := range polygon {
+		translated[index] = vertex.Add(offset)
+	}
+	return translated
+}
+
+// Edges returns the sides of the polygon.
+func (polygon Polygon) Edges() []Segment {
+	edges := make([]Segment, len(polygon))
+	for index, vertex := range polygon {
+		edges[index] = Segment{Start: vertex, End: polygon[(index+1)%len(polygon)]}
+	}
+	return edges
+}
+
+// project returns the interval covered by the polygon on the axis.
+func (polygon Polygon) project(axis mgl32.Vec2) (float32, float32) {
+	minimum, maximum := float32(math.MaxFloat32), float32(-math.MaxFloat32)
+	for _, vertex := range polygon {
+		projection := vertex.Dot(axis)
+		minimum = min(minimum, projection)
+		maximum = max(maximum, projection)
+	}
+	return minimum, maximum
+}
+
+// axes returns the normalized normals of the sides of the polygon.
+func (polygon Polygon) axes() []mgl32.Vec2 {
+	axes := make([]mgl32.Vec2, 0, len(polygon))
+	for index, vertex := range polygon {
+		edge := polygon[(index+1)%len(polygon)].Sub(vertex)
+		if edge.Len() > 0 {
+			axes = append(axes, mgl32.Vec2{-edge.Y(), edge.X()}.Normalize())
+		}
+	}
+	return axes
+}
+
+// SeparatingAxisTest tests two convex polygons with the separating axis theorem.
+// It returns true if they overlap, with the minimum translation vector to move the second polygon out of the first one.
+func SeparatingAxisTest(first Polygon, second Polygon) (mgl32.Vec2, bool) {
+	return separatingAxisTest(first, second, append(first.axes(), second.axes()...))
+}
+
+// SeparatingAxisTestCircle tests a convex polygon and a circle with the separating axis theorem.
+// It returns true if they overlap, with the minimum translation vector to move the circle out of the polygon.
+func SeparatingAxisTestCircle(polygon Polygon, circle Circle) (mgl32.Vec2, bool) {
+	if len(polygon) == 0 {
+		return mgl32.Vec2{}, false
+	}
+	// The polygon axes and the axis from the closest vertex to the circle center
+	closest := polygon[0]
+	for _, vertex := range polygon[1:] {
+		if vertex.Sub(circle.Center).Len() < closest.Sub(circle.Center).Len() {
+			closest = vertex
+		}
+	}
+	axes := polygon.axes()
+	if axis := circle.Center.Sub(closest); axis.Len() > 0 {
+		axes = append(axes, axis.Normalize())
+	}
+	bestOverlap := float32(math.MaxFloat32)
+	bestAxis := mgl32.Vec2{}
+	for _, axis := range axes {
+		polygonMin, polygonMax := polygon.project(axis)
+		center := circle.Center.Dot(axis)
+		overlap := min(polygonMax-(center-circle.Radius), (center+circle.Radius)-polygonMin)
+		if overlap <= 0 {
+			return mgl32.Vec2{}, false
+		}
+		if overlap < bestOverlap {
+			bestOverlap, bestAxis = overlap, axis
+		}
+	}
+	return orientAxis(bestAxis, polygon.Centroid(), circle.Center).Mul(bestOverlap), true
+}
+
+func separatingAxisTest(first Polygon, second Polygon, axes []mgl32.Vec2) (mgl32.Vec2, bool) {
+	if len(first) == 0 || len(second) == 0 {
+		return mgl32.Vec2{}, false
+	}
+	bestOverlap := float32(math.MaxFloat32)
+	bestAxis := mgl32.Vec2{}
+	for _, axis := range axes {
+		firstMin, firstMax := first.project(axis)
+		secondMin, secondMax := second.project(axis)
+		overlap := min(firstMax-secondMin, secondMax-firstMin)
+		if overlap <= 0 {
+			return mgl32.Vec2{}, false
+		}
+		if overlap < bestOverlap {
+			bestOverlap, bestAxis = overlap, axis
+		}
+	}
+	return orientAxis(bestAxis, first.Centroid(), second.Centroid()).Mul(bestOverlap), true
+}
+
+// orientAxis returns the axis pointing from the first position to the second one.
+func orientAxis(axis mgl32.Vec2, from mgl32.Vec2, to mgl32.Vec2) mgl32.Vec2 {
+	if to.Sub(from).Dot(axis) < 0 {
+		return axis.Mul(-1)
+	}
+	return axis
+}
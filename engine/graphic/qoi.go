@@ -0,0 +1,105 @@
+package graphic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+)
+
+// Opérations QOI
+const (
+	qoiOpIndex = 0x00
+	qoiOpDiff  = 0x40
+	qoiOpLuma  = 0x80
+	qoiOpRun   = 0xc0
+	qoiOpRGB   = 0xfe
+	qoiOpRGBA  = 0xff
+	qoiMask    = 0xc0
+)
+
+// qoiHeaderSize est la taille de l'entête d'un fichier QOI
+const qoiHeaderSize = 14
+
+// qoiMaxPixels est le nombre maximal de pixels d'une image QOI (limite de la spécification)
+const qoiMaxPixels = 400_000_000
+
+// qoiMaxRun est le nombre maximal de pixels codés par une opération (répétition)
+const qoiMaxRun = 62
+
+// decodeQOI decodes a "Quite OK Image" image.
+func decodeQOI(reader io.Reader) (image.Image, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < qoiHeaderSize || string(content[:4]) != "qoif" {
+		return nil, fmt.Errorf("invalid QOI header")
+	}
+	width := int(binary.BigEndian.Uint32(content[4:]))
+	height := int(binary.BigEndian.Uint32(content[8:]))
+	channels := content[12]
+	if width == 0 || height == 0 || width > qoiMaxPixels/height {
+		return nil, fmt.Errorf("invalid %dx%d QOI image size", width, height)
+	}
+	if channels != 3 && channels != 4 {
+		return nil, fmt.Errorf("invalid QOI channel count %d", channels)
+	}
+	// Chaque opération occupe au moins un octet : les données doivent suffire avant d'allouer l'image
+	if (len(content)-qoiHeaderSize)*qoiMaxRun < width*height {
+		return nil, fmt.Errorf("truncated QOI image data")
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	var index [64]color.NRGBA
+	pixel := color.NRGBA{A: 255}
+	position, run := qoiHeaderSize, 0
+	for offset := 0; offset < len(img.Pix); offset += 4 {
+		if run > 0 {
+			run--
+		} else {
+			if position >= len(content) {
+				return nil, fmt.Errorf("truncated QOI image data")
+			}
+			op := content[position]
+			position++
+			switch {
+			case op == qoiOpRGB || op == qoiOpRGBA:
+				size := 3
+				if op == qoiOpRGBA {
+					size = 4
+				}
+				if position+size > len(content) {
+					return nil, fmt.Errorf("truncated QOI image data")
+				}
+				pixel.R, pixel.G, pixel.B = content[position], content[position+1], content[position+2]
+				if op == qoiOpRGBA {
+					pixel.A = content[position+3]
+				}
+				position += size
+			case op&qoiMask == qoiOpIndex:
+				pixel = index[op]
+			case op&qoiMask == qoiOpDiff:
+				pixel.R += (op>>4)&0x03 - 2
+				pixel.G += (op>>2)&0x03 - 2
+				pixel.B += op&0x03 - 2
+			case op&qoiMask == qoiOpLuma:
+				if position >= len(content) {
+					return nil, fmt.Errorf("truncated QOI image data")
+				}
+				next := content[position]
+				position++
+				greenDiff := op&0x3f - 32
+				pixel.R += greenDiff - 8 + (next>>4)&0x0f
+				pixel.G += greenDiff
+				pixel.B += greenDiff - 8 + next&0x0f
+			default:
+				run = int(op & 0x3f)
+			}
+			index[(int(pixel.R)*3+int(pixel.G)*5+int(pixel.B)*7+int(pixel.A)*11)%64] = pixel
+		}
+		img.Pix[offset], img.Pix[offset+1], img.Pix[offset+2], img.Pix[offset+3] = pixel.R, pixel.G, pixel.B, pixel.A
+	}
+	return img, nil
+}
@@ -0,0 +1,97 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Ray is a 2D half-line.
+type Ray struct {
+	Origin mgl32.Vec2
+	// Direction is the normalized direction of the ray.
+	Direction mgl32.Vec2
+}
+
+// BuildRay function builds and returns a ray (the direction is normalized).
+func BuildRay(origin mgl32.Vec2, direction mgl32.Vec2) Ray {
+	if direction.Len() > 0 {
+		direction = direction.Normalize()
+	}
+	return Ray{Origin: origin, Direction: direction}
+}
+
+// PointAt returns the point of the ray at the distance.
+func (ray Ray) PointAt(distance float32) mgl32.Vec2 {
+	return ray.Origin.Add(ray.Direction.Mul(distance))
+}
+
+// IntersectRect returns the distance to the first hit of the rectangle (0 if the origin is inside).
+func (ray Ray) IntersectRect(rect Rectangle) (float32, bool) {
+	// Slab method
+	near, far := float32(0), float32(math.MaxFloat32)
+	for axis := 0; axis < 2; axis++ {
+		origin, direction := ray.Origin[axis], ray.Direction[axis]
+		low, high := rect[axis], rect[axis]+rect[axis+2]
+		if direction == 0 {
+			if origin < low || origin > high {
+				return 0, false
+			}
+			continue
+		}
+		first, second := (low-origin)/direction, (high-origin)/direction
+		if first > second {
+			first, second = second, first
+		}
+		near, far = max(near, first), min(far, second)
+		if near > far {
+			return 0, false
+		}
+	}
+	return near, true
+}
+
+// IntersectCircle returns the distance to the first hit of the circle (0 if the origin is inside).
+func (ray Ray) IntersectCircle(circle Circle) (float32, bool) {
+	offset := ray.Origin.Sub(circle.Center)
+	b := offset.Dot(ray.Direction)
+	c := offset.Dot(offset) - circle.Radius*circle.Radius
+	if c <= 0 {
+		return 0, true
+	}
+	discriminant := b*b - c
+	if b > 0 || discriminant < 0 {
+		return 0, false
+	}
+	return -b - float32(math.Sqrt(float64(discriminant))), true
+}
+
+// IntersectSegment returns the distance to the segment.
+func (ray Ray) IntersectSegment(segment Segment) (float32, bool) {
+	segmentDirection := segment.End.Sub(segment.Start)
+	denominator := cross2d(ray.Direction, segmentDirection)
+	if denominator == 0 {
+		return 0, false
+	}
+	offset := segment.Start.Sub(ray.Origin)
+	distance := cross2d(offset, segmentDirection) / denominator
+	ratio := cross2d(offset, ray.Direction) / denominator
+	if distance < 0 || ratio < 0 || ratio > 1 {
+		return 0, false
+	}
+	return distance, true
+}
+
+// IntersectPolygon returns the distance to the first hit of the polygon sides (0 if the origin is inside).
+func (ray Ray) IntersectPolygon(polygon Polygon) (float32, bool) {
+	if polygon.Contains(ray.Origin) {
+		return 0, true
+	}
+	closest := float32(math.MaxFloat32)
+	found := false
+	for _, edge := range polygon.Edges() {
+		if distance, hit := ray.IntersectSegment(edge); hit && distance < closest {
+			closest, found = distance, true
+		}
+	}
+	return closest, found
+}
@@ -61,3 +61,99 @@ func (rect *Rectangle) In(pos mgl32.Vec2) bool {
 	return pos.X() >= rect.X() && pos.X() <= rect.X()+rect.Width() &&
 		pos.Y() >= rect.Y() && pos.Y() <= rect.Y()+rect.Height()
 }
+
+// Right returns the x coordinate of the right side.
+func (rect *Rectangle) Right() float32 {
+	return rect[0] + rect[2]
+}
+
+// Bottom returns the y coordinate of the bottom side.
+func (rect *Rectangle) Bottom() float32 {
+	return rect[1] + rect[3]
+}
+
+// Center returns the center of the rectangle.
+func (rect *Rectangle) Center() mgl32.Vec2 {
+	return mgl32.Vec2{rect[0] + rect[2]/2, rect[1] + rect[3]/2}
+}
+
+// IsEmpty returns true if the rectangle has no area.
+func (rect *Rectangle) IsEmpty() bool {
+	return rect[2] <= 0 || rect[3] <= 0
+}
+
+// Normalize returns the same rectangle with positive width and height.
+func (rect *Rectangle) Normalize() Rectangle {
+	normalized := *rect
+	if normalized[2] < 0 {
+		normalized[0] += normalized[2]
+		normalized[2] = -normalized[2]
+	}
+	if normalized[3] < 0 {
+		normalized[1] += normalized[3]
+		normalized[3] = -normalized[3]
+	}
+	return normalized
+}
+
+// ContainsRect returns true if the other rectangle is entirely inside the rectangle.
+func (rect *Rectangle) ContainsRect(other Rectangle) bool {
+	return other.X() >= rect.X() && other.Right() <= rect.Right() &&
+		other.Y() >= rect.Y() && other.Bottom() <= rect.Bottom()
+}
+
+// Intersects returns true if the rectangles overlap (touching sides do not overlap).
+func (rect *Rectangle) Intersects(other Rectangle) bool {
+	return rect.X() < other.Right() && other.X() < rect.Right() &&
+		rect.Y() < other.Bottom() && other.Y() < rect.Bottom()
+}
+
+// Intersection returns the overlapping part of both rectangles (false if they do not overlap).
+func (rect *Rectangle) Intersection(other Rectangle) (Rectangle, bool) {
+	if !rect.Intersects(other) {
+		return Rectangle{}, false
+	}
+	left := max(rect.X(), other.X())
+	top := max(rect.Y(), other.Y())
+	return Rectangle{left, top, min(rect.Right(), other.Right()) - left, min(rect.Bottom(), other.Bottom()) - top}, true
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+func (rect *Rectangle) Union(other Rectangle) Rectangle {
+	left := min(rect.X(), other.X())
+	top := min(rect.Y(), other.Y())
+	return Rectangle{left, top, max(rect.Right(), other.Right()) - left, max(rect.Bottom(), other.Bottom()) - top}
+}
+
+// Inset returns the rectangle shrunk by the same amount on each side (grown if the amount is negative).
+func (rect *Rectangle) Inset(amount float32) Rectangle {
+	return rect.InsetBy(Insets{amount, amount, amount, amount})
+}
+
+// InsetBy returns the rectangle shrunk by the insets (the size never becomes negative).
+func (rect *Rectangle) InsetBy(insets Insets) Rectangle {
+	return insets.Apply(*rect)
+}
+
+// Translate returns the rectangle moved by the offset.
+func (rect *Rectangle) Translate(offset mgl32.Vec2) Rectangle {
+	return Rectangle{rect.X() + offset.X(), rect.Y() + offset.Y(), rect.Width(), rect.Height()}
+}
+
+// ClosestPoint returns the point of the rectangle closest to the position.
+func (rect *Rectangle) ClosestPoint(pos mgl32.Vec2) mgl32.Vec2 {
+	return mgl32.Vec2{
+		mgl32.Clamp(pos.X(), rect.X(), rect.Right()),
+		mgl32.Clamp(pos.Y(), rect.Y(), rect.Bottom()),
+	}
+}
+
+// Polygon returns the corners of the rectangle (top-left, top-right, bottom-right, bottom-left).
+func (rect *Rectangle) Polygon() Polygon {
+	return Polygon{
+		{rect.X(), rect.Y()},
+		{rect.Right(), rect.Y()},
+		{rect.Right(), rect.Bottom()},
+		{rect.X(), rect.Bottom()},
+	}
+}
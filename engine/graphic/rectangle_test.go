@@ -0,0 +1,61 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"testing"
+)
+
+func TestRectangle_Intersection(t *testing.T) {
+	rect := BuildRectangle(0, 0, 10, 10)
+	intersection, found := rect.Intersection(BuildRectangle(5, 5, 10, 10))
+	if !found || intersection != BuildRectangle(5, 5, 5, 5) {
+		t.Fatalf("Intersection() = %v, %v, want [5 5 5 5], true", intersection, found)
+	}
+	if _, found = rect.Intersection(BuildRectangle(10, 0, 5, 5)); found {
+		t.Fatalf("Intersection() of touching rectangles found=true, want false")
+	}
+}
+
+func TestRectangle_Union(t *testing.T) {
+	rect := BuildRectangle(0, 0, 10, 10)
+	if union := rect.Union(BuildRectangle(20, -5, 5, 5)); union != BuildRectangle(0, -5, 25, 15) {
+		t.Fatalf("Union() = %v, want [0 -5 25 15]", union)
+	}
+}
+
+func TestRectangle_ContainsRect(t *testing.T) {
+	rect := BuildRectangle(0, 0, 10, 10)
+	if !rect.ContainsRect(BuildRectangle(2, 2, 8, 8)) || rect.ContainsRect(BuildRectangle(2, 2, 9, 8)) {
+		t.Fatalf("ContainsRect() inconsistent results")
+	}
+}
+
+func TestRectangle_Inset(t *testing.T) {
+	rect := BuildRectangle(0, 0, 10, 20)
+	if inset := rect.Inset(2); inset != BuildRectangle(2, 2, 6, 16) {
+		t.Fatalf("Inset(2) = %v, want [2 2 6 16]", inset)
+	}
+	if inset := rect.InsetBy(BuildInsets(1, 2, 3, 4)); inset != BuildRectangle(1, 2, 6, 14) {
+		t.Fatalf("InsetBy() = %v, want [1 2 6 14]", inset)
+	}
+	if inset := rect.Inset(8); inset.Width() != 0 || !inset.IsEmpty() {
+		t.Fatalf("Inset(8) = %v, want empty rectangle", inset)
+	}
+}
+
+func TestRectangle_Normalize(t *testing.T) {
+	rect := BuildRectangle(10, 10, -5, -10)
+	if normalized := rect.Normalize(); normalized != BuildRectangle(5, 0, 5, 10) {
+		t.Fatalf("Normalize() = %v, want [5 0 5 10]", normalized)
+	}
+}
+
+func TestRectangle_ClosestPoint(t *testing.T) {
+	rect := BuildRectangle(0, 0, 10, 10)
+	if point := rect.ClosestPoint(mgl32.Vec2{15, 5}); point != (mgl32.Vec2{10, 5}) {
+		t.Fatalf("ClosestPoint() = %v, want (10, 5)", point)
+	}
+	if center := rect.Center(); center != (mgl32.Vec2{5, 5}) {
+		t.Fatalf("Center() = %v, want (5, 5)", center)
+	}
+}
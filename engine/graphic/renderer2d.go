@@ -37,16 +37,30 @@ type Renderer2d struct {
 	vao uint32
 	//  square vbo (Vertex Buffer Object) handler.
 	vbo uint32
-
-	// State before 2D view (to restore state after 2d rendering)
-	keepPreviousStateBlend     bool
-	keepPreviousStateDepthTest bool
+	// batch draws many sprites with few draw calls.
+	batch spriteBatch
+	// projection is the current projection (world or screen coordinates to OpenGL coordinates).
+	projection mgl32.Mat4
+
+	// Camera used by current rendering (nil without camera)
+	camera *Camera2d
+	// Viewport before camera rendering
+	previousViewport [4]int32
+
+	// Framebuffer used by current rendering (nil to render in window)
+	framebuffer *ogl.Framebuffer
+	// Framebuffer and viewport before framebuffer rendering
+	previousFramebuffer uint32
+	framebufferViewport [4]int32
 }
 
 // Initialize renderer (create vertex array and shader).
 func (renderer *Renderer2d) Initialize() error {
 	renderer.initializeVao()
-	return renderer.initializeShader()
+	if err := renderer.initializeShader(); err != nil {
+		return err
+	}
+	return renderer.batch.initialize()
 }
 
 // initializeShader compiles vertex shader and fragment et creates shader program.
@@ -100,6 +114,7 @@ func (renderer *Renderer2d) initializeVao() {
 
 // Release renderer (release VAO and shader).
 func (renderer *Renderer2d) Release() {
+	renderer.batch.release()
 	renderer.releaseVao()
 	if renderer.shaderProgram != nil {
 		renderer.releaseShader()
@@ -122,37 +137,123 @@ func (renderer *Renderer2d) releaseVao() {
 }
 
 // Begin method initializes 2D rendering (active shader and OpenGL states)
+//
+//	Render states are saved and restored by End (alpha blending, no depth test, no culling).
 func (renderer *Renderer2d) Begin(width, height float32) {
-	// Disable depth test
-	renderer.keepPreviousStateDepthTest = gl.IsEnabled(gl.DEPTH_TEST)
-	if renderer.keepPreviousStateDepthTest {
-		gl.Disable(gl.DEPTH_TEST)
-	}
-
-	// Active transparence
-	renderer.keepPreviousStateBlend = gl.IsEnabled(gl.BLEND)
-	if !renderer.keepPreviousStateBlend {
-		gl.Enable(gl.BLEND)
-		gl.BlendFunc(gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA)
-	}
+	states := ogl.GlobalStateCache()
+	states.Push()
+	state := ogl.DefaultRenderState2d()
+	// Conserver la découpe et le stencil (masques définis avant le rendu 2D)
+	state.Scissor = states.Current().Scissor
+	state.Stencil = states.Current().Stencil
+	states.Apply(state)
 
 	// Active shader
 	renderer.shaderProgram.Use()
 
 	// Initialize orthogonal projection
-	projection2d := mgl32.Ortho2D(0., width, height, 0.)
-	renderer.shaderProgram.UniformMatrix4fv("projection", &projection2d)
+	renderer.setProjection(mgl32.Ortho2D(0., width, height, 0.))
+}
+
+// BeginWithCamera method initializes 2D rendering through a camera (active shader and OpenGL states).
+//
+//	width and height are the screen size: rendering is limited to camera viewport and
+//	positions are world positions (camera position, zoom and rotation are applied).
+func (renderer *Renderer2d) BeginWithCamera(camera *Camera2d, width, height float32) {
+	renderer.Begin(width, height)
+	renderer.camera = camera
+
+	// Limiter le rendu à la zone de la caméra (le viewport OpenGL a son origine en bas à gauche)
+	gl.GetIntegerv(gl.VIEWPORT, &renderer.previousViewport[0])
+	scaleX := float32(renderer.previousViewport[2]) / width
+	scaleY := float32(renderer.previousViewport[3]) / height
+	viewport := camera.Viewport
+	x := renderer.previousViewport[0] + int32(viewport.X()*scaleX)
+	y := renderer.previousViewport[1] + int32((height-viewport.Bottom())*scaleY)
+	if renderer.framebuffer != nil {
+		// Le rendu dans un framebuffer est inversé verticalement (voir setProjection)
+		y = renderer.previousViewport[1] + int32(viewport.Y()*scaleY)
+	}
+	viewportWidth, viewportHeight := int32(viewport.Width()*scaleX), int32(viewport.Height()*scaleY)
+	gl.Viewport(x, y, viewportWidth, viewportHeight)
+	ogl.GlobalStateCache().SetScissor(ogl.ScissorState{Enabled: true, Rectangle: [4]int32{x, y, viewportWidth, viewportHeight}})
+
+	// Projection sur la zone de la caméra et transformation monde => caméra
+	renderer.setProjection(mgl32.Ortho2D(0., viewport.Width(), viewport.Height(), 0.).Mul4(camera.ViewMatrix()))
+}
+
+// BeginOnFramebuffer method initializes 2D rendering in a framebuffer (framebuffer size is the screen size).
+//
+//	Framebuffer color attachments can then be drawn like loaded textures (see NewTextureFromFramebuffer).
+func (renderer *Renderer2d) BeginOnFramebuffer(framebuffer *ogl.Framebuffer) {
+	renderer.BeginOnFramebufferWithCamera(framebuffer, nil)
+}
+
+// BeginOnFramebufferWithCamera method initializes 2D rendering in a framebuffer through a camera (nil = no camera).
+//
+//	Camera viewport is relative to framebuffer.
+func (renderer *Renderer2d) BeginOnFramebufferWithCamera(framebuffer *ogl.Framebuffer, camera *Camera2d) {
+	renderer.framebuffer = framebuffer
+	renderer.previousFramebuffer = ogl.BoundFramebuffer()
+	gl.GetIntegerv(gl.VIEWPORT, &renderer.framebufferViewport[0])
+	framebuffer.Bind()
+	gl.Viewport(0, 0, framebuffer.Width(), framebuffer.Height())
+
+	width, height := float32(framebuffer.Width()), float32(framebuffer.Height())
+	if camera != nil {
+		renderer.BeginWithCamera(camera, width, height)
+		return
+	}
+	renderer.Begin(width, height)
+	// La découpe de l'écran ne s'applique pas au framebuffer
+	ogl.GlobalStateCache().SetScissor(ogl.ScissorState{})
+}
+
+// setProjection sends projection to sprite shader.
+//
+//	Framebuffer rendering is flipped vertically: OpenGL textures have their origin at bottom left,
+//	so framebuffer textures are then drawn like loaded textures (first line at top).
+func (renderer *Renderer2d) setProjection(projection mgl32.Mat4) {
+	if renderer.framebuffer != nil {
+		projection = mgl32.Scale3D(1., -1., 1.).Mul4(projection)
+	}
+	renderer.projection = projection
+	renderer.shaderProgram.UniformMatrix4fv("projection", &renderer.projection)
+}
+
+// Framebuffer returns framebuffer used by current rendering (nil when rendering in window).
+func (renderer *Renderer2d) Framebuffer() *ogl.Framebuffer {
+	return renderer.framebuffer
+}
+
+// Camera returns camera used by current rendering (nil without camera).
+func (renderer *Renderer2d) Camera() *Camera2d {
+	return renderer.camera
 }
 
 // End method finalizes 2D rendering (restore OpenGL states)
 func (renderer *Renderer2d) End() {
 	renderer.shaderProgram.Unuse()
-	if renderer.keepPreviousStateDepthTest {
-		gl.Enable(gl.DEPTH_TEST)
+	if renderer.camera != nil {
+		gl.Viewport(renderer.previousViewport[0], renderer.previousViewport[1], renderer.previousViewport[2], renderer.previousViewport[3])
+		renderer.camera = nil
 	}
-	if !renderer.keepPreviousStateBlend {
-		gl.Disable(gl.BLEND)
+	if renderer.framebuffer != nil {
+		ogl.BindFramebufferHandle(renderer.previousFramebuffer)
+		gl.Viewport(renderer.framebufferViewport[0], renderer.framebufferViewport[1], renderer.framebufferViewport[2], renderer.framebufferViewport[3])
+		renderer.framebuffer = nil
 	}
+	ogl.GlobalStateCache().Pop()
+}
+
+// BlendMode returns the current blend mode.
+func (renderer *Renderer2d) BlendMode() BlendMode {
+	return ogl.GlobalStateCache().Current().Blend
+}
+
+// SetBlendMode changes the blend mode of the next draws (the blend mode is restored by End).
+func (renderer *Renderer2d) SetBlendMode(mode BlendMode) {
+	ogl.GlobalStateCache().SetBlend(mode)
 }
 
 // DrawSprite draws a picture
@@ -234,6 +335,49 @@ func (renderer *Renderer2d) DrawSpriteExWithRotateAndColor(texture *Texture, sou
 	gl.BindVertexArray(0)
 }
 
+// DrawNineSlice draws part of texture in target zone without stretching corners (borders keep texture size).
+func (renderer *Renderer2d) DrawNineSlice(texture *Texture, sourceRectangle Rectangle, insets Insets, targetRectangle Rectangle, color Color) {
+	renderer.DrawNineSliceEx(texture, sourceRectangle, insets, targetRectangle, insets, color)
+}
+
+// DrawNineSliceEx draws part of texture in target zone without stretching corners.
+//
+//	sourceInsets définissent les bordures au niveau de la texture.
+//	targetInsets définissent les bordures à l'écran.
+func (renderer *Renderer2d) DrawNineSliceEx(texture *Texture, sourceRectangle Rectangle, sourceInsets Insets,
+	targetRectangle Rectangle, targetInsets Insets, color Color) {
+	for _, part := range ComputeNineSlices(sourceRectangle, sourceInsets, targetRectangle, targetInsets) {
+		renderer.DrawSpriteFromRectWithColor(texture, part.Source, part.Target, color)
+	}
+}
+
+// DrawThreeSliceHorizontal draws part of texture in target zone without stretching left and right parts.
+func (renderer *Renderer2d) DrawThreeSliceHorizontal(texture *Texture, sourceRectangle Rectangle, left, right float32, targetRectangle Rectangle, color Color) {
+	renderer.DrawNineSlice(texture, sourceRectangle, BuildInsets(left, 0, right, 0), targetRectangle, color)
+}
+
+// DrawThreeSliceVertical draws part of texture in target zone without stretching top and bottom parts.
+func (renderer *Renderer2d) DrawThreeSliceVertical(texture *Texture, sourceRectangle Rectangle, top, bottom float32, targetRectangle Rectangle, color Color) {
+	renderer.DrawNineSlice(texture, sourceRectangle, BuildInsets(0, top, 0, bottom), targetRectangle, color)
+}
+
+// DrawSliced draws part of texture in target zone according to slice mode.
+func (renderer *Renderer2d) DrawSliced(texture *Texture, mode SliceMode, sourceRectangle Rectangle, sourceInsets Insets,
+	targetRectangle Rectangle, targetInsets Insets, color Color) {
+	switch mode {
+	case NINE_SLICE:
+		renderer.DrawNineSliceEx(texture, sourceRectangle, sourceInsets, targetRectangle, targetInsets, color)
+	case THREE_SLICE_HORIZONTAL:
+		renderer.DrawNineSliceEx(texture, sourceRectangle, BuildInsets(sourceInsets.Left(), 0, sourceInsets.Right(), 0),
+			targetRectangle, BuildInsets(targetInsets.Left(), 0, targetInsets.Right(), 0), color)
+	case THREE_SLICE_VERTICAL:
+		renderer.DrawNineSliceEx(texture, sourceRectangle, BuildInsets(0, sourceInsets.Top(), 0, sourceInsets.Bottom()),
+			targetRectangle, BuildInsets(0, targetInsets.Top(), 0, targetInsets.Bottom()), color)
+	default:
+		renderer.DrawSpriteFromRectWithColor(texture, sourceRectangle, targetRectangle, color)
+	}
+}
+
 // DrawText dessine un texte à la position indiquée
 func (renderer *Renderer2d) DrawText(font *Font, text string, position mgl32.Vec2, color Color) {
 	renderer.DrawTextEx(font, text, position, mgl32.Vec2{1., 1.}, color)
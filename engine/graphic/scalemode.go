@@ -0,0 +1,47 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// ScaleMode defines how a virtual resolution (low resolution rendering) is upscaled to the screen.
+type ScaleMode string
+
+// List of scale modes
+const (
+	// SCALE_NONE dessine directement à l'écran avec un ratio fractionnaire (pas de résolution virtuelle)
+	SCALE_NONE ScaleMode = "none"
+	// SCALE_INTEGER agrandit d'un facteur entier (pixels nets), la zone restante forme des bandes noires
+	SCALE_INTEGER ScaleMode = "integer"
+	// SCALE_FIT agrandit au plus grand facteur possible (plus proche voisin, facteur éventuellement fractionnaire)
+	SCALE_FIT ScaleMode = "fit"
+)
+
+// IsValid indicates if mode is a known scale mode.
+func (mode ScaleMode) IsValid() bool {
+	switch mode {
+	case SCALE_NONE, SCALE_INTEGER, SCALE_FIT:
+		return true
+	}
+	return false
+}
+
+// ComputeScaledZone returns the screen zone where a virtual screen is drawn and its scale factor.
+//
+//	The zone is centered in screen (letterboxing) and aligned on screen pixels (position and size are rounded).
+//	With SCALE_INTEGER, a screen smaller than the virtual screen uses the SCALE_FIT factor.
+func ComputeScaledZone(virtualSize mgl32.Vec2, screen Rectangle, mode ScaleMode) (Rectangle, float32) {
+	if virtualSize.X() <= 0 || virtualSize.Y() <= 0 {
+		return screen, 1
+	}
+	scale := min(screen.Width()/virtualSize.X(), screen.Height()/virtualSize.Y())
+	if mode == SCALE_INTEGER && scale >= 1 {
+		scale = float32(math.Floor(float64(scale)))
+	}
+	width := float32(math.Round(float64(virtualSize.X() * scale)))
+	height := float32(math.Round(float64(virtualSize.Y() * scale)))
+	x := screen.X() + float32(math.Round(float64(screen.Width()-width)/2))
+	y := screen.Y() + float32(math.Round(float64(screen.Height()-height)/2))
+	return Rectangle{x, y, width, height}, scale
+}
@@ -0,0 +1,32 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"testing"
+)
+
+func TestComputeScaledZone(t *testing.T) {
+	virtualSize := mgl32.Vec2{320, 180}
+	for _, test := range []struct {
+		screen    Rectangle
+		mode      ScaleMode
+		wantZone  Rectangle
+		wantScale float32
+	}{
+		// 1280x720 : facteur entier exact
+		{Rectangle{0, 0, 1280, 720}, SCALE_INTEGER, Rectangle{0, 0, 1280, 720}, 4},
+		// 1366x768 : facteur 4 avec bandes noires
+		{Rectangle{0, 0, 1366, 768}, SCALE_INTEGER, Rectangle{43, 24, 1280, 720}, 4},
+		// 1366x768 : facteur fractionnaire, zone arrondie au pixel
+		{Rectangle{0, 0, 1366, 768}, SCALE_FIT, Rectangle{1, 0, 1365, 768}, 768. / 180.},
+		// écran plus petit que la résolution virtuelle
+		{Rectangle{0, 0, 160, 90}, SCALE_INTEGER, Rectangle{0, 0, 160, 90}, 0.5},
+		// zone décalée
+		{Rectangle{100, 50, 640, 400}, SCALE_INTEGER, Rectangle{100, 70, 640, 360}, 2},
+	} {
+		zone, scale := ComputeScaledZone(virtualSize, test.screen, test.mode)
+		if zone != test.wantZone || scale != test.wantScale {
+			t.Fatalf("ComputeScaledZone(%v, %s) = %v, %v, want %v, %v", test.screen, test.mode, zone, scale, test.wantZone, test.wantScale)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Segment is a 2D line segment.
+type Segment struct {
+	Start mgl32.Vec2
+	End   mgl32.Vec2
+}
+
+// BuildSegment function builds and returns a line segment.
+func BuildSegment(start mgl32.Vec2, end mgl32.Vec2) Segment {
+	return Segment{Start: start, End: end}
+}
+
+// Length returns the length of the segment.
+func (segment Segment) Length() float32 {
+	return segment.End.Sub(segment.Start).Len()
+}
+
+// ClosestPoint returns the point of the segment closest to the position.
+func (segment Segment) ClosestPoint(pos mgl32.Vec2) mgl32.Vec2 {
+	direction := segment.End.Sub(segment.Start)
+	lengthSquared := direction.Dot(direction)
+	if lengthSquared == 0 {
+		return segment.Start
+	}
+	ratio := mgl32.Clamp(pos.Sub(segment.Start).Dot(direction)/lengthSquared, 0, 1)
+	return segment.Start.Add(direction.Mul(ratio))
+}
+
+// Distance returns the distance between the position and the segment.
+func (segment Segment) Distance(pos mgl32.Vec2) float32 {
+	return pos.Sub(segment.ClosestPoint(pos)).Len()
+}
+
+// Intersection returns the intersection point of both segments (false if they do not cross or are parallel).
+func (segment Segment) Intersection(other Segment) (mgl32.Vec2, bool) {
+	direction := segment.End.Sub(segment.Start)
+	otherDirection := other.End.Sub(other.Start)
+	denominator := cross2d(direction, otherDirection)
+	if denominator == 0 {
+		return mgl32.Vec2{}, false
+	}
+	offset := other.Start.Sub(segment.Start)
+	ratio := cross2d(offset, otherDirection) / denominator
+	otherRatio := cross2d(offset, direction) / denominator
+	if ratio < 0 || ratio > 1 || otherRatio < 0 || otherRatio > 1 {
+		return mgl32.Vec2{}, false
+	}
+	return segment.Start.Add(direction.Mul(ratio)), true
+}
+
+// cross2d returns the z component of the cross product of two 2D vectors.
+func cross2d(first mgl32.Vec2, second mgl32.Vec2) float32 {
+	return first.X()*second.Y() - first.Y()*second.X()
+}
@@ -0,0 +1,13 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+in vec4 Color;     // Sprite color
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Texture
+
+void main()
+{
+    // mix sprite color and texture color
+    color = texture(image, TexCoords) * Color;
+}
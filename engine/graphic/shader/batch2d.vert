@@ -0,0 +1,17 @@
+#version 460 core
+
+layout (location = 0) in vec2 position;     // Vertex Array - Position
+layout (location = 1) in vec2 textPosition; // Vertex Array - Texture coordinates (in)
+layout (location = 2) in vec4 vertexColor;  // Vertex Array - Color
+
+out vec2 TexCoords; // Texture coordinates (out)
+out vec4 Color;     // Sprite color (out)
+
+uniform mat4 projection; // Orthogonal projection for 2d rendering
+
+void main()
+{
+    gl_Position = projection * vec4(position.xy, 0.0, 1.0);
+    TexCoords = textPosition;
+    Color = vertexColor;
+}
@@ -0,0 +1,30 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+in vec4 Color;     // Sprite color
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Texture
+uniform float progress;  // Dissolve progress (0 = visible, 1 = dissolved)
+uniform float edgeWidth; // Burning edge width (in progress unit)
+uniform vec4 edgeColor;  // Burning edge color
+
+// noise returns a pseudo random value in [0, 1] for a texel
+float noise(vec2 texel)
+{
+    return fract(sin(dot(texel, vec2(12.9898, 78.233))) * 43758.5453);
+}
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords) * Color;
+    float value = noise(floor(TexCoords * vec2(textureSize(image, 0))));
+    if (value < progress) {
+        discard;
+    }
+    if (value < progress + edgeWidth) {
+        pixel.rgb = edgeColor.rgb;
+        pixel.a *= edgeColor.a;
+    }
+    color = pixel;
+}
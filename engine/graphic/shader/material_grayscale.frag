@@ -0,0 +1,16 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+in vec4 Color;     // Sprite color
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Texture
+uniform float intensity; // Grayscale intensity (0 = original colors, 1 = gray)
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords) * Color;
+    // luminance (Rec. 709)
+    float gray = dot(pixel.rgb, vec3(0.2126, 0.7152, 0.0722));
+    color = vec4(mix(pixel.rgb, vec3(gray), intensity), pixel.a);
+}
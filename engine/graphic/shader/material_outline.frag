@@ -0,0 +1,28 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+in vec4 Color;     // Sprite color
+out vec4 color;    // Pixel color
+
+uniform sampler2D image;     // Texture
+uniform vec4 outlineColor;   // Outline color
+uniform float thickness;     // Outline thickness (in texels)
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords) * Color;
+    vec2 texel = thickness / vec2(textureSize(image, 0));
+    // maximum transparency of neighbours
+    float neighbours = 0.0;
+    neighbours = max(neighbours, texture(image, TexCoords + vec2(texel.x, 0.0)).a);
+    neighbours = max(neighbours, texture(image, TexCoords - vec2(texel.x, 0.0)).a);
+    neighbours = max(neighbours, texture(image, TexCoords + vec2(0.0, texel.y)).a);
+    neighbours = max(neighbours, texture(image, TexCoords - vec2(0.0, texel.y)).a);
+    neighbours = max(neighbours, texture(image, TexCoords + texel).a);
+    neighbours = max(neighbours, texture(image, TexCoords - texel).a);
+    neighbours = max(neighbours, texture(image, TexCoords + vec2(texel.x, -texel.y)).a);
+    neighbours = max(neighbours, texture(image, TexCoords + vec2(-texel.x, texel.y)).a);
+    // outline is drawn around opaque pixels
+    float outline = (1.0 - pixel.a) * neighbours * outlineColor.a;
+    color = vec4(mix(pixel.rgb, outlineColor.rgb, outline), max(pixel.a, outline));
+}
@@ -0,0 +1,17 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+in vec4 Color;     // Sprite color
+out vec4 color;    // Pixel color
+
+uniform sampler2D image;   // Texture (red channel is the palette index)
+uniform sampler2D palette; // Palette (one color per texel on first line)
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords);
+    float index = floor(pixel.r * 255.0 + 0.5);
+    float paletteWidth = float(textureSize(palette, 0).x);
+    vec4 paletteColor = texture(palette, vec2((index + 0.5) / paletteWidth, 0.5));
+    color = vec4(paletteColor.rgb, paletteColor.a * pixel.a) * Color;
+}
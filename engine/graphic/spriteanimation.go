@@ -0,0 +1,263 @@
+package graphic
+
+import (
+	"fmt"
+)
+
+// AnimationMode indicates how an animation clip is played.
+type AnimationMode string
+
+// List of supported animation modes
+const (
+	// ANIMATION_LOOP plays clip frames in a loop
+	ANIMATION_LOOP AnimationMode = "loop"
+	// ANIMATION_PING_PONG plays clip frames forward then backward in a loop
+	ANIMATION_PING_PONG AnimationMode = "ping-pong"
+	// ANIMATION_ONCE plays clip frames once and stops on last frame
+	ANIMATION_ONCE AnimationMode = "once"
+)
+
+// AnimationFrame is a frame of an animation clip.
+type AnimationFrame struct {
+	// Region est la zone de la texture à afficher
+	Region Rectangle
+	// Duration est la durée de la frame (en millisecondes)
+	Duration int64
+	// Events sont les évènements déclenchés quand la frame est affichée
+	Events []string
+}
+
+// AnimationClip is a named list of frames.
+type AnimationClip struct {
+	// Name est le nom du clip
+	Name string
+	// Frames sont les frames du clip
+	Frames []AnimationFrame
+	// Mode est le mode de lecture du clip
+	Mode AnimationMode
+	// Plays est le nombre de lectures en mode ANIMATION_LOOP (0 = en boucle sans fin)
+	Plays int
+}
+
+// BuildAnimationClip builds a clip from texture regions (all frames have the same duration).
+func BuildAnimationClip(name string, mode AnimationMode, frameDuration int64, regions ...Rectangle) *AnimationClip {
+	clip := &AnimationClip{
+		Name:   name,
+		Frames: make([]AnimationFrame, 0, len(regions)),
+		Mode:   mode,
+	}
+	for _, region := range regions {
+		clip.Frames = append(clip.Frames, AnimationFrame{Region: region, Duration: frameDuration})
+	}
+	return clip
+}
+
+// AddFrameEvent adds an event triggered when frame is displayed.
+func (clip *AnimationClip) AddFrameEvent(frameIndex int, event string) error {
+	if frameIndex < 0 || frameIndex >= len(clip.Frames) {
+		return fmt.Errorf("cannot add '%s' event on frame #%d of '%s' clip (%d frames)", event, frameIndex, clip.Name, len(clip.Frames))
+	}
+	clip.Frames[frameIndex].Events = append(clip.Frames[frameIndex].Events, event)
+	return nil
+}
+
+// Duration returns clip duration for one play (in milliseconds).
+func (clip *AnimationClip) Duration() int64 {
+	duration := int64(0)
+	for _, frame := range clip.Frames {
+		duration += frame.Duration
+	}
+	return duration
+}
+
+// SpriteSheet is a set of animation clips on the same texture.
+type SpriteSheet struct {
+	// Image est le nom du fichier image associé (information de l'export)
+	Image string
+	// Clips contient les clips par nom
+	Clips map[string]*AnimationClip
+}
+
+// BuildSpriteSheet builds an empty sprite sheet.
+func BuildSpriteSheet() *SpriteSheet {
+	return &SpriteSheet{
+		Clips: make(map[string]*AnimationClip),
+	}
+}
+
+// AddClip adds (or replaces) a clip.
+func (sheet *SpriteSheet) AddClip(clip *AnimationClip) {
+	sheet.Clips[clip.Name] = clip
+}
+
+// Clip returns named clip (nil if clip is not found).
+func (sheet *SpriteSheet) Clip(name string) *AnimationClip {
+	return sheet.Clips[name]
+}
+
+// Release releases sprite sheet (sprite sheet does not own texture).
+func (sheet *SpriteSheet) Release() {
+}
+
+// AnimationFrameEventFunc is called when a frame with events is displayed.
+type AnimationFrameEventFunc func(clip *AnimationClip, frameIndex int, event string)
+
+// AnimationEndFunc is called when a clip played once (or a limited number of times) is finished.
+type AnimationEndFunc func(clip *AnimationClip)
+
+// AnimationPlayer plays an animation clip according to elapsed time.
+type AnimationPlayer struct {
+	// clip est le clip en cours
+	clip *AnimationClip
+	// frameIndex est l'index de la frame courante
+	frameIndex int
+	// frameTime est le temps passé sur la frame courante (en millisecondes)
+	frameTime float64
+	// direction est le sens de lecture (1 = en avant, -1 = en arrière)
+	direction int
+	// speed est la vitesse de lecture (1 = vitesse normale)
+	speed float64
+	// paused indique si la lecture est en pause
+	paused bool
+	// plays est le nombre de lectures terminées (mode ANIMATION_LOOP)
+	plays int
+	// finished indique si la lecture est terminée (mode ANIMATION_ONCE ou nombre de lectures atteint)
+	finished bool
+
+	OnFrameEvent AnimationFrameEventFunc
+	OnEnd        AnimationEndFunc
+}
+
+// BuildAnimationPlayer builds a player without clip.
+func BuildAnimationPlayer() AnimationPlayer {
+	return AnimationPlayer{
+		direction: 1,
+		speed:     1,
+	}
+}
+
+// Play starts a clip from its first frame.
+func (player *AnimationPlayer) Play(clip *AnimationClip) {
+	player.clip = clip
+	player.frameIndex = 0
+	player.frameTime = 0
+	player.direction = 1
+	player.paused = false
+	player.plays = 0
+	player.finished = false
+	player.emitFrameEvents()
+}
+
+// Clip returns current clip (nil if no clip).
+func (player *AnimationPlayer) Clip() *AnimationClip {
+	return player.clip
+}
+
+// FrameIndex returns current frame index.
+func (player *AnimationPlayer) FrameIndex() int {
+	return player.frameIndex
+}
+
+// Frame returns current frame (nil if no clip).
+func (player *AnimationPlayer) Frame() *AnimationFrame {
+	if player.clip == nil || len(player.clip.Frames) == 0 {
+		return nil
+	}
+	return &player.clip.Frames[player.frameIndex]
+}
+
+func (player *AnimationPlayer) Speed() float64 {
+	return player.speed
+}
+
+func (player *AnimationPlayer) SetSpeed(speed float64) {
+	player.speed = max(speed, 0)
+}
+
+func (player *AnimationPlayer) Pause() {
+	player.paused = true
+}
+
+func (player *AnimationPlayer) Resume() {
+	player.paused = false
+}
+
+func (player *AnimationPlayer) Paused() bool {
+	return player.paused
+}
+
+// Finished indicates if a clip played once (or a limited number of times) is finished.
+func (player *AnimationPlayer) Finished() bool {
+	return player.finished
+}
+
+// Update moves animation forward according to elapsed time (in milliseconds).
+func (player *AnimationPlayer) Update(elapsedTime int64) {
+	if player.clip == nil || len(player.clip.Frames) == 0 || player.paused || player.finished {
+		return
+	}
+	player.frameTime += float64(elapsedTime) * player.speed
+	for !player.finished {
+		duration := float64(player.clip.Frames[player.frameIndex].Duration)
+		if duration <= 0 {
+			// Une frame sans durée bloquerait l'animation
+			duration = 1
+		}
+		if player.frameTime < duration {
+			return
+		}
+		player.frameTime -= duration
+		player.nextFrame()
+	}
+}
+
+// nextFrame moves to next frame according to clip mode.
+func (player *AnimationPlayer) nextFrame() {
+	last := len(player.clip.Frames) - 1
+	switch player.clip.Mode {
+	case ANIMATION_ONCE:
+		if player.frameIndex >= last {
+			player.finish()
+			return
+		}
+		player.frameIndex++
+	case ANIMATION_PING_PONG:
+		if last == 0 {
+			player.frameIndex = 0
+		} else {
+			if player.frameIndex+player.direction > last || player.frameIndex+player.direction < 0 {
+				player.direction = -player.direction
+			}
+			player.frameIndex += player.direction
+		}
+	default:
+		if player.frameIndex >= last && player.clip.Plays > 0 {
+			player.plays++
+			if player.plays >= player.clip.Plays {
+				player.finish()
+				return
+			}
+		}
+		player.frameIndex = (player.frameIndex + 1) % (last + 1)
+	}
+	player.emitFrameEvents()
+}
+
+// finish stops the clip on its current frame and calls end callback.
+func (player *AnimationPlayer) finish() {
+	player.finished = true
+	player.frameTime = 0
+	if player.OnEnd != nil {
+		player.OnEnd(player.clip)
+	}
+}
+
+// emitFrameEvents calls event callback for current frame events.
+func (player *AnimationPlayer) emitFrameEvents() {
+	if player.OnFrameEvent == nil || player.clip == nil || len(player.clip.Frames) == 0 {
+		return
+	}
+	for _, event := range player.clip.Frames[player.frameIndex].Events {
+		player.OnFrameEvent(player.clip, player.frameIndex, event)
+	}
+}
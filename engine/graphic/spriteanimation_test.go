@@ -0,0 +1,147 @@
+package graphic
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// buildTestClip retourne un clip de 3 frames de 100 ms
+func buildTestClip(mode AnimationMode) *AnimationClip {
+	return BuildAnimationClip("walk", mode, 100,
+		BuildRectangle(0, 0, 16, 16), BuildRectangle(16, 0, 16, 16), BuildRectangle(32, 0, 16, 16))
+}
+
+func TestAnimationPlayer_Update(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     AnimationMode
+		updates  []int64
+		want     []int
+		finished bool
+	}{
+		{"loop", ANIMATION_LOOP, []int64{50, 50, 100, 100, 100}, []int{0, 1, 2, 0, 1}, false},
+		{"loop skipping frames", ANIMATION_LOOP, []int64{250, 100}, []int{2, 0}, false},
+		{"ping-pong", ANIMATION_PING_PONG, []int64{100, 100, 100, 100, 100, 100}, []int{1, 2, 1, 0, 1, 2}, false},
+		{"once", ANIMATION_ONCE, []int64{100, 100, 100, 100}, []int{1, 2, 2, 2}, true},
+		{"once skipping end", ANIMATION_ONCE, []int64{1000}, []int{2}, true},
+	}
+	for _, test := range tests {
+		player := BuildAnimationPlayer()
+		player.Play(buildTestClip(test.mode))
+		for step, elapsedTime := range test.updates {
+			player.Update(elapsedTime)
+			if got := player.FrameIndex(); got != test.want[step] {
+				t.Fatalf("%s: FrameIndex() after update #%d = %d, want %d", test.name, step, got, test.want[step])
+			}
+		}
+		if player.Finished() != test.finished {
+			t.Fatalf("%s: Finished() = %v, want %v", test.name, player.Finished(), test.finished)
+		}
+	}
+}
+
+func TestAnimationPlayer_SpeedAndPause(t *testing.T) {
+	tests := []struct {
+		name   string
+		speed  float64
+		paused bool
+		want   int
+	}{
+		{"normal speed", 1, false, 1},
+		{"double speed", 2, false, 2},
+		{"half speed", 0.5, false, 0},
+		{"negative speed", -1, false, 0},
+		{"paused", 1, true, 0},
+	}
+	for _, test := range tests {
+		player := BuildAnimationPlayer()
+		player.Play(buildTestClip(ANIMATION_LOOP))
+		player.SetSpeed(test.speed)
+		if test.paused {
+			player.Pause()
+		}
+		player.Update(100)
+		if got := player.FrameIndex(); got != test.want {
+			t.Fatalf("%s: FrameIndex() = %d, want %d", test.name, got, test.want)
+		}
+	}
+}
+
+func TestAnimationPlayer_Events(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    AnimationMode
+		elapsed int64
+		want    []string
+		ends    int
+	}{
+		// "start" est émis par Play, puis à chaque retour sur la première frame
+		{"loop", ANIMATION_LOOP, 300, []string{"0:start", "2:hit", "0:start"}, 0},
+		{"ping-pong", ANIMATION_PING_PONG, 400, []string{"0:start", "2:hit", "0:start"}, 0},
+		{"once", ANIMATION_ONCE, 1000, []string{"0:start", "2:hit"}, 1},
+	}
+	for _, test := range tests {
+		clip := buildTestClip(test.mode)
+		if err := clip.AddFrameEvent(0, "start"); err != nil {
+			t.Fatalf("AddFrameEvent() returns error %v", err)
+		}
+		if err := clip.AddFrameEvent(2, "hit"); err != nil {
+			t.Fatalf("AddFrameEvent() returns error %v", err)
+		}
+		var events []string
+		ends := 0
+		player := BuildAnimationPlayer()
+		player.OnFrameEvent = func(clip *AnimationClip, frameIndex int, event string) {
+			events = append(events, fmt.Sprintf("%d:%s", frameIndex, event))
+		}
+		player.OnEnd = func(clip *AnimationClip) {
+			ends++
+		}
+		player.Play(clip)
+		player.Update(test.elapsed)
+		if !slices.Equal(events, test.want) {
+			t.Fatalf("%s: events = %v, want %v", test.name, events, test.want)
+		}
+		if ends != test.ends {
+			t.Fatalf("%s: OnEnd called %d times, want %d", test.name, ends, test.ends)
+		}
+	}
+}
+
+func TestAnimationClip_AddFrameEvent(t *testing.T) {
+	clip := buildTestClip(ANIMATION_LOOP)
+	for _, frameIndex := range []int{-1, 3} {
+		if err := clip.AddFrameEvent(frameIndex, "hit"); err == nil {
+			t.Fatalf("AddFrameEvent(%d) returns no error, want error", frameIndex)
+		}
+	}
+	if got := clip.Duration(); got != 300 {
+		t.Fatalf("Duration() = %d, want 300", got)
+	}
+}
+
+func TestAnimationPlayer_Plays(t *testing.T) {
+	clip := buildTestClip(ANIMATION_LOOP)
+	clip.Plays = 2
+	ends := 0
+	player := BuildAnimationPlayer()
+	player.OnEnd = func(clip *AnimationClip) {
+		ends++
+	}
+	player.Play(clip)
+	player.Update(500)
+	if player.Finished() || player.FrameIndex() != 2 {
+		t.Fatalf("after 500 ms: Finished() = %v, FrameIndex() = %d, want false, 2", player.Finished(), player.FrameIndex())
+	}
+	player.Update(100)
+	if !player.Finished() || player.FrameIndex() != 2 || ends != 1 {
+		t.Fatalf("after 600 ms: Finished() = %v, FrameIndex() = %d, OnEnd calls = %d, want true, 2, 1", player.Finished(), player.FrameIndex(), ends)
+	}
+	// Une nouvelle lecture recommence le décompte
+	player.Play(clip)
+	player.Update(300)
+	if player.Finished() || player.FrameIndex() != 0 {
+		t.Fatalf("after replay: Finished() = %v, FrameIndex() = %d, want false, 0", player.Finished(), player.FrameIndex())
+	}
+}
@@ -0,0 +1,208 @@
+package graphic
+
+import (
+	_ "embed"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"log/slog"
+	"math"
+	"ogl46/engine/ogl"
+)
+
+// batch2dVertexShader is the 2D batch vertex shader.
+//
+//go:embed shader/batch2d.vert
+var batch2dVertexShader string
+
+// batch2dFragmentShader is the 2D batch fragment shader.
+//
+//go:embed shader/batch2d.frag
+var batch2dFragmentShader string
+
+// MAX_BATCH_SPRITES is the maximum number of sprites drawn by one draw call (bigger batches use several draw calls).
+const MAX_BATCH_SPRITES = 4096
+
+// batchVertexSize is the vertex size in float32 (2 coordinates + 2 texture coordinates + 4 color components).
+const batchVertexSize = 2 + 2 + 4
+
+// BatchSprite is a sprite drawn by a batch.
+type BatchSprite struct {
+	// Source est la zone de la texture à dessiner
+	Source Rectangle
+	// Center est la position du centre du sprite
+	Center mgl32.Vec2
+	// Size est la dimension du sprite
+	Size mgl32.Vec2
+	// Rotation est la rotation autour du centre
+	Rotation Angle
+	Color    Color
+}
+
+// spriteBatch contains OpenGL objects used to draw batches of sprites.
+type spriteBatch struct {
+	shaderProgram *ogl.ShaderProgram
+	vao           uint32
+	vbo           uint32
+	ebo           uint32
+	// vertices est le tampon des sommets (réutilisé d'un appel à l'autre)
+	vertices []float32
+}
+
+// initialize creates batch shader and buffers (indices never change, vertices are updated at each draw).
+func (batch *spriteBatch) initialize() error {
+	slog.Debug("Renderer2d - 2D batch creation")
+	vertexShader, err := ogl.NewShaderFromSource(batch2dVertexShader, gl.VERTEX_SHADER)
+	if err != nil {
+		return err
+	}
+	fragmentShader, err := ogl.NewShaderFromSource(batch2dFragmentShader, gl.FRAGMENT_SHADER)
+	if err != nil {
+		vertexShader.Delete()
+		return err
+	}
+	batch.shaderProgram, err = ogl.NewShaderProgram(vertexShader, fragmentShader)
+	if err != nil {
+		return err
+	}
+
+	gl.GenVertexArrays(1, &batch.vao)
+	gl.GenBuffers(1, &batch.vbo)
+	gl.GenBuffers(1, &batch.ebo)
+	gl.BindVertexArray(batch.vao)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, batch.vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, MAX_BATCH_SPRITES*4*batchVertexSize*4, nil, gl.STREAM_DRAW) // 4 vertices by sprite, 4 = float32 size
+
+	indices := make([]uint32, 0, MAX_BATCH_SPRITES*6)
+	for sprite := uint32(0); sprite < MAX_BATCH_SPRITES; sprite++ {
+		first := sprite * 4
+		indices = append(indices, first, first+1, first+2, first+2, first+1, first+3)
+	}
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, batch.ebo)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+
+	var stride int32 = batchVertexSize * 4
+	gl.VertexAttribPointerWithOffset(0, 2, gl.FLOAT, false, stride, 0)
+	gl.EnableVertexAttribArray(0)
+	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, stride, 2*4)
+	gl.EnableVertexAttribArray(1)
+	gl.VertexAttribPointerWithOffset(2, 4, gl.FLOAT, false, stride, 4*4)
+	gl.EnableVertexAttribArray(2)
+
+	gl.BindVertexArray(0)
+	batch.vertices = make([]float32, 0, MAX_BATCH_SPRITES*4*batchVertexSize)
+	return nil
+}
+
+// release releases batch shader and buffers.
+func (batch *spriteBatch) release() {
+	if batch.vao != 0 {
+		slog.Debug("Renderer2d - 2D batch destruction")
+		gl.DeleteBuffers(1, &batch.ebo)
+		gl.DeleteBuffers(1, &batch.vbo)
+		gl.DeleteVertexArrays(1, &batch.vao)
+		batch.vao, batch.vbo, batch.ebo = 0, 0, 0
+	}
+	if batch.shaderProgram != nil {
+		batch.shaderProgram.Delete()
+		batch.shaderProgram = nil
+	}
+}
+
+// appendSprite adds sprite vertices (top left, bottom left, top right, bottom right).
+func (batch *spriteBatch) appendSprite(texture *Texture, sprite *BatchSprite) {
+	textureWidth, textureHeight := float32(texture.Width()), float32(texture.Height())
+	left, top := sprite.Source.X()/textureWidth, sprite.Source.Y()/textureHeight
+	right, bottom := (sprite.Source.X()+sprite.Source.Width())/textureWidth, (sprite.Source.Y()+sprite.Source.Height())/textureHeight
+
+	halfWidth, halfHeight := sprite.Size.X()/2, sprite.Size.Y()/2
+	// Même sens de rotation que DrawSpriteExWithRotateAndColor
+	sin, cos := float32(0), float32(1)
+	if sprite.Rotation != 0 {
+		sin64, cos64 := math.Sincos(-float64(sprite.Rotation))
+		sin, cos = float32(sin64), float32(cos64)
+	}
+	for _, corner := range [4][4]float32{
+		{-halfWidth, -halfHeight, left, top},
+		{-halfWidth, halfHeight, left, bottom},
+		{halfWidth, -halfHeight, right, top},
+		{halfWidth, halfHeight, right, bottom},
+	} {
+		batch.vertices = append(batch.vertices,
+			sprite.Center.X()+corner[0]*cos-corner[1]*sin,
+			sprite.Center.Y()+corner[0]*sin+corner[1]*cos,
+			corner[2], corner[3],
+			sprite.Color[0], sprite.Color[1], sprite.Color[2], sprite.Color[3],
+		)
+	}
+}
+
+// flush draws buffered sprites.
+func (batch *spriteBatch) flush() {
+	if len(batch.vertices) == 0 {
+		return
+	}
+	spriteCount := int32(len(batch.vertices) / (4 * batchVertexSize))
+	gl.BindVertexArray(batch.vao)
+	gl.BindBuffer(gl.ARRAY_BUFFER, batch.vbo)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(batch.vertices)*4, gl.Ptr(batch.vertices))
+	gl.DrawElementsWithOffset(gl.TRIANGLES, spriteCount*6, gl.UNSIGNED_INT, 0)
+	gl.BindVertexArray(0)
+	batch.vertices = batch.vertices[:0]
+}
+
+// DrawBatch draws many sprites of the same texture with few draw calls.
+//
+//	blend is the blend mode used by the batch (the previous blend mode is restored after drawing).
+func (renderer *Renderer2d) DrawBatch(texture *Texture, sprites []BatchSprite, blend BlendMode) {
+	renderer.DrawBatchWithMaterial(texture, sprites, blend, nil)
+}
+
+// DrawBatchWithMaterial draws many sprites of the same texture with a custom material (nil = default shader).
+func (renderer *Renderer2d) DrawBatchWithMaterial(texture *Texture, sprites []BatchSprite, blend BlendMode, material *Material) {
+	if len(sprites) == 0 {
+		return
+	}
+	batch := &renderer.batch
+	texture.Bind()
+	if material != nil {
+		material.use(&renderer.projection)
+		if material.shaderProgram.HasUniform("image") {
+			material.shaderProgram.Uniform1i("image", 0)
+		}
+		// Les textures du matériau ont changé l'unité active
+		gl.ActiveTexture(gl.TEXTURE0)
+	} else {
+		batch.shaderProgram.Use()
+		batch.shaderProgram.UniformMatrix4fv("projection", &renderer.projection)
+		batch.shaderProgram.Uniform1i("image", 0)
+	}
+	previousBlend := renderer.BlendMode()
+	renderer.SetBlendMode(blend)
+
+	for index := range sprites {
+		batch.appendSprite(texture, &sprites[index])
+		if len(batch.vertices) == cap(batch.vertices) {
+			batch.flush()
+		}
+	}
+	batch.flush()
+
+	renderer.SetBlendMode(previousBlend)
+	// Revenir au shader des sprites pour les dessins suivants
+	renderer.shaderProgram.Use()
+}
+
+// DrawSpriteWithMaterial draws part of texture in target zone with a custom material.
+//
+//	rotation is applied around target zone center.
+func (renderer *Renderer2d) DrawSpriteWithMaterial(texture *Texture, sourceRectangle Rectangle, targetRectangle Rectangle,
+	rotation Angle, color Color, material *Material) {
+	renderer.DrawBatchWithMaterial(texture, []BatchSprite{{
+		Source:   sourceRectangle,
+		Center:   targetRectangle.Center(),
+		Size:     targetRectangle.Dim(),
+		Rotation: rotation,
+		Color:    color,
+	}}, renderer.BlendMode(), material)
+}
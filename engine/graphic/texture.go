@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"github.com/go-gl/gl/v4.6-core/gl"
 	"image"
-	"image/draw"
 	"io"
 	"log/slog"
+	"ogl46/engine/ogl"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,21 +22,41 @@ type Texture struct {
 	width int32
 	// Dimension de la texture : hauteur
 	height int32
+	// framebuffer est le framebuffer propriétaire de la texture (nil pour une texture chargée)
+	framebuffer *ogl.Framebuffer
+	// options sont les options de création (filtrage, répétition, format...)
+	options TextureOptions
+}
+
+// NewTextureFromFramebuffer returns a texture drawing a framebuffer color attachment.
+//
+//	The texture stays valid when framebuffer is resized and is released with framebuffer (Release does nothing).
+func NewTextureFromFramebuffer(framebuffer *ogl.Framebuffer, attachment int) (*Texture, error) {
+	handle := framebuffer.ColorAttachment(attachment)
+	if handle == 0 {
+		return nil, fmt.Errorf("framebuffer has no color attachment %d", attachment)
+	}
+	return &Texture{handle: handle, framebuffer: framebuffer, options: DefaultTextureOptions()}, nil
 }
 
 func LoadTextureFromFile(filename string) (*Texture, error) {
+	return LoadTextureFromFileWithOptions(filename, TextureOptions{})
+}
+
+// LoadTextureFromFileWithOptions returns a texture built from an image file with creation options.
+func LoadTextureFromFileWithOptions(filename string, options TextureOptions) (*Texture, error) {
 	slog.Debug("texture creation from file %s", filename)
 	extension := ImageFormat(strings.ToLower(filepath.Ext(filename)))
-	decoder, found := imageDecoders[extension]
-	if !found {
+	if !isSupportedImageFormat(extension) {
 		return nil, fmt.Errorf("cannot build texture from '%s' file "+
-			"(unsupported '%s' extension - only '.png', '.jpeg' and '.gif' are supported)", filename, extension)
+			"(unsupported '%s' extension - supported extensions: %s)", filename, extension, supportedImageFormats())
 	}
 	fileReader, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load image from file '%s'\n - %w", filename, err)
 	}
-	texture, err := buildTexture(fileReader, decoder)
+	defer fileReader.Close()
+	texture, err := buildTexture(fileReader, extension, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build texture from file '%s'\n - %w", filename, err)
 	}
@@ -44,14 +64,18 @@ func LoadTextureFromFile(filename string) (*Texture, error) {
 }
 
 func LoadTextureFromBytes(content []byte, format ImageFormat) (*Texture, error) {
+	return LoadTextureFromBytesWithOptions(content, format, TextureOptions{})
+}
+
+// LoadTextureFromBytesWithOptions returns a texture built from encoded image data with creation options.
+func LoadTextureFromBytesWithOptions(content []byte, format ImageFormat, options TextureOptions) (*Texture, error) {
 	slog.Debug("texture creation from byte array")
-	decoder, found := imageDecoders[format]
-	if !found {
+	if !isSupportedImageFormat(format) {
 		return nil, fmt.Errorf("cannot build texture from byte array "+
-			"(unsupported '%s' format - only '.png', '.jpeg' and '.gif' are supported)", format)
+			"(unsupported '%s' format - supported formats: %s)", format, supportedImageFormats())
 	}
 	textureReader := bytes.NewReader(content)
-	texture, err := buildTexture(textureReader, decoder)
+	texture, err := buildTexture(textureReader, format, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build texture from byte array\n - %w", err)
 	}
@@ -59,38 +83,128 @@ func LoadTextureFromBytes(content []byte, format ImageFormat) (*Texture, error)
 }
 
 func NewTextureFromImage(img image.Image) (*Texture, error) {
-	rgba := image.NewRGBA(img.Bounds())
-	if rgba.Stride != rgba.Rect.Size().X*4 {
-		return nil, fmt.Errorf("unsupported image stride (stride value = %d)", rgba.Stride)
+	return NewTextureFromImageWithOptions(img, TextureOptions{})
+}
+
+// NewTextureFromImageWithOptions returns a texture built from img with creation options.
+func NewTextureFromImageWithOptions(img image.Image, options TextureOptions) (*Texture, error) {
+	options = options.withImageFormat(img).normalized()
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if options.Format == FORMAT_COMPRESSED {
+		return nil, fmt.Errorf("compressed textures are built from compressed images (see NewTextureFromCompressedImage)")
 	}
-	draw.Draw(rgba, rgba.Bounds(), img, image.Point{X: 0, Y: 0}, draw.Src)
+	width, height := int32(img.Bounds().Dx()), int32(img.Bounds().Dy())
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("cannot build texture from empty image")
+	}
+	format := textureFormats[options.Format]
 
 	var handle uint32
-	gl.Enable(gl.TEXTURE_2D)
-	gl.GenTextures(1, &handle)
-	gl.BindTexture(gl.TEXTURE_2D, handle)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
-	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
-	gl.TexImage2D(
-		gl.TEXTURE_2D,
-		0,
-		gl.RGBA,
-		int32(rgba.Rect.Size().X),
-		int32(rgba.Rect.Size().Y),
-		0,
-		gl.RGBA,
-		gl.UNSIGNED_BYTE,
-		gl.Ptr(rgba.Pix))
-
-	slog.Debug("texture info: handler=%d / size=%dx%d", handle, rgba.Rect.Size().X, rgba.Rect.Size().Y)
-
-	return &Texture{
-		handle: handle,
-		width:  int32(rgba.Rect.Size().X),
-		height: int32(rgba.Rect.Size().Y),
-	}, nil
+	gl.CreateTextures(gl.TEXTURE_2D, 1, &handle)
+	gl.TextureStorage2D(handle, mipmapLevels(width, height, options.Mipmaps), format.internalFormat, width, height)
+	texture := &Texture{
+		handle:  handle,
+		width:   width,
+		height:  height,
+		options: options,
+	}
+	texture.applyOptions()
+	texture.upload(0, 0, width, height, imageToPixels(img, format))
+
+	slog.Debug("texture info: handler=%d / size=%dx%d", handle, width, height)
+
+	return texture, nil
+}
+
+// Options returns the texture creation options.
+func (texture *Texture) Options() TextureOptions {
+	return texture.options
+}
+
+// SetFilter changes the texture filter (FILTER_TRILINEAR needs a texture created with mipmaps).
+func (texture *Texture) SetFilter(filter TextureFilter) error {
+	options := texture.options
+	options.Filter = filter
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if filter == FILTER_TRILINEAR && !options.Mipmaps {
+		return fmt.Errorf("trilinear filtering needs a texture created with mipmaps")
+	}
+	texture.options = options
+	texture.applyOptions()
+	return nil
+}
+
+// SetWrap changes the texture wrap mode.
+func (texture *Texture) SetWrap(wrap TextureWrap) error {
+	options := texture.options
+	options.Wrap = wrap
+	if err := options.validate(); err != nil {
+		return err
+	}
+	texture.options = options
+	texture.applyOptions()
+	return nil
+}
+
+// UpdateRegion replaces the texture region at (x, y) with img (mipmaps are regenerated).
+func (texture *Texture) UpdateRegion(x, y int32, img image.Image) error {
+	if texture.options.Format == FORMAT_COMPRESSED {
+		return fmt.Errorf("cannot update compressed texture")
+	}
+	width, height := int32(img.Bounds().Dx()), int32(img.Bounds().Dy())
+	if x < 0 || y < 0 || x+width > texture.Width() || y+height > texture.Height() {
+		return fmt.Errorf("region %dx%d at (%d, %d) is outside %dx%d texture", width, height, x, y, texture.Width(), texture.Height())
+	}
+	if width == 0 || height == 0 {
+		return nil
+	}
+	texture.upload(x, y, width, height, imageToPixels(img, textureFormats[texture.options.Format]))
+	return nil
+}
+
+// ToImage reads the texture content back (image.RGBA or image.Gray for 8 bits formats, FloatImage or image.Gray16 for float formats).
+func (texture *Texture) ToImage() (image.Image, error) {
+	if texture.handle == 0 {
+		return nil, fmt.Errorf("cannot read released texture")
+	}
+	if texture.options.Format == FORMAT_COMPRESSED {
+		return nil, fmt.Errorf("cannot read compressed texture")
+	}
+	format := textureFormats[texture.options.Format]
+	width, height := int(texture.Width()), int(texture.Height())
+	count := width * height * format.channels
+	var pixels any
+	var size int
+	if format.float {
+		pixels, size = make([]float32, count), count*4
+	} else {
+		pixels, size = make([]byte, count), count
+	}
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.GetTextureImage(texture.handle, 0, format.pixelFormat, format.pixelType, int32(size), gl.Ptr(pixels))
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 4)
+	return pixelsToImage(pixels, width, height, format), nil
+}
+
+// applyOptions sets texture filtering and wrapping parameters.
+func (texture *Texture) applyOptions() {
+	applyTextureOptions(texture.handle, texture.options)
+}
+
+// upload copies pixels (see imageToPixels) into the texture region and regenerates mipmaps.
+func (texture *Texture) upload(x, y, width, height int32, pixels any) {
+	format := textureFormats[texture.options.Format]
+	// Les lignes des formats à une composante ne sont pas alignées sur 4 octets
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 1)
+	gl.TextureSubImage2D(texture.handle, 0, x, y, width, height, format.pixelFormat, format.pixelType, gl.Ptr(pixels))
+	gl.PixelStorei(gl.UNPACK_ALIGNMENT, 4)
+	if texture.options.Mipmaps {
+		gl.GenerateTextureMipmap(texture.handle)
+	}
 }
 
 func (texture *Texture) Bind() {
@@ -105,13 +219,18 @@ func (texture *Texture) BindTextureUnit(textureUnit uint32) {
 	texture.unit = textureUnit
 }
 
+// BindImageUnit binds the texture to an image unit for compute shaders (image2D, 0 for the first unit).
+func (texture *Texture) BindImageUnit(imageUnit uint32, access ogl.ImageAccess) error {
+	return ogl.BindImage(imageUnit, texture.handle, 0, false, 0, access)
+}
+
 func (texture *Texture) UnBind() {
 	gl.ActiveTexture(texture.unit)
 	gl.BindTexture(gl.TEXTURE_2D, 0)
 }
 
 func (texture *Texture) Release() {
-	if texture.handle != 0 {
+	if texture.handle != 0 && texture.framebuffer == nil {
 		slog.Debug("texture destruction")
 		gl.DeleteTextures(1, &texture.handle)
 		texture.handle = 0
@@ -127,25 +246,49 @@ func (texture *Texture) SetUniform(uniformLocation int32) error {
 }
 
 func (texture *Texture) Width() int32 {
+	if texture.framebuffer != nil {
+		return texture.framebuffer.Width()
+	}
 	return texture.width
 }
 
 func (texture *Texture) Height() int32 {
+	if texture.framebuffer != nil {
+		return texture.framebuffer.Height()
+	}
 	return texture.height
 }
 
 func (texture *Texture) Rectangle() Rectangle {
-	return Rectangle{0, 0, float32(texture.width), float32(texture.height)}
+	return Rectangle{0, 0, float32(texture.Width()), float32(texture.Height())}
 }
 
-func buildTexture(imageReader io.Reader, decode func(reader io.Reader) (image.Image, error)) (*Texture, error) {
-	img, err := decode(imageReader)
+func buildTexture(imageReader io.Reader, format ImageFormat, options TextureOptions) (*Texture, error) {
+	if decode, found := compressedImageDecoders[format]; found {
+		img, err := decode(imageReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode compressed image\n - %w", err)
+		}
+		texture, err := NewTextureFromCompressedImage(img, options)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create texture from compressed image\n - %w", err)
+		}
+		return texture, nil
+	}
+	img, err := imageDecoders[format](imageReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode image\n - %w", err)
 	}
-	texture, err := NewTextureFromImage(img)
+	texture, err := NewTextureFromImageWithOptions(img, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create texture from image\n - %w", err)
 	}
 	return texture, nil
 }
+
+// isSupportedImageFormat indicates if a texture can be built from format.
+func isSupportedImageFormat(format ImageFormat) bool {
+	_, isImage := imageDecoders[format]
+	_, isCompressed := compressedImageDecoders[format]
+	return isImage || isCompressed
+}
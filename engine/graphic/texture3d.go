@@ -0,0 +1,80 @@
+package graphic
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"image"
+	"image/draw"
+	"log/slog"
+)
+
+// Texture3d is a 3D texture (sampler3D in shaders), volumetric look-up tables for example.
+type Texture3d struct {
+	layeredTexture
+}
+
+// NewTexture3dFromImages returns a 3D texture with one slice per image (all images have the same size).
+func NewTexture3dFromImages(slices []image.Image, options TextureOptions) (*Texture3d, error) {
+	texture, err := newLayeredTexture(gl.TEXTURE_3D, slices, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create 3D texture\n - %w", err)
+	}
+	return &Texture3d{layeredTexture: *texture}, nil
+}
+
+// NewTexture3dFromStrip returns a 3D texture from an horizontal strip of depth slices
+// (a 16x16x16 color look-up table is a 256x16 image for example).
+func NewTexture3dFromStrip(img image.Image, depth int, options TextureOptions) (*Texture3d, error) {
+	slices, err := splitStrip(img, depth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create 3D texture\n - %w", err)
+	}
+	return NewTexture3dFromImages(slices, options)
+}
+
+// LoadTexture3dFromFile returns a 3D texture from an image file containing an horizontal strip of depth slices.
+func LoadTexture3dFromFile(filename string, depth int, options TextureOptions) (*Texture3d, error) {
+	slog.Debug("3D texture creation from file", "filename", filename, "depth", depth)
+	img, err := LoadImageFromFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build 3D texture\n - %w", err)
+	}
+	return NewTexture3dFromStrip(img, depth, options)
+}
+
+// Depth returns the number of slices.
+func (texture *Texture3d) Depth() int {
+	return int(texture.layers)
+}
+
+// UpdateSlice replaces a slice with img (same size as the slices).
+func (texture *Texture3d) UpdateSlice(slice int, img image.Image) error {
+	return texture.updateLayer(slice, img)
+}
+
+// splitStrip cuts an horizontal strip into count images.
+func splitStrip(img image.Image, count int) ([]image.Image, error) {
+	bounds := img.Bounds()
+	if count <= 0 || bounds.Dx()%count != 0 {
+		return nil, fmt.Errorf("%d pixels wide image cannot be split in %d slices", bounds.Dx(), count)
+	}
+	width := bounds.Dx() / count
+	parts := make([]image.Image, 0, count)
+	for index := 0; index < count; index++ {
+		part := image.Rect(bounds.Min.X+index*width, bounds.Min.Y, bounds.Min.X+(index+1)*width, bounds.Max.Y)
+		parts = append(parts, subImage(img, part))
+	}
+	return parts, nil
+}
+
+// subImage returns the part of img inside rectangle (sharing pixels when possible).
+func subImage(img image.Image, rectangle image.Rectangle) image.Image {
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(rectangle)
+	}
+	copied := image.NewNRGBA(image.Rect(0, 0, rectangle.Dx(), rectangle.Dy()))
+	draw.Draw(copied, copied.Bounds(), img, rectangle.Min, draw.Src)
+	return copied
+}
@@ -0,0 +1,37 @@
+package graphic
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestSplitStrip(t *testing.T) {
+	strip := image.NewNRGBA(image.Rect(0, 0, 6, 2))
+	strip.SetNRGBA(4, 1, color.NRGBA{R: 255, A: 255})
+	slices, err := splitStrip(strip, 3)
+	if err != nil {
+		t.Fatalf("splitStrip() error = %v", err)
+	}
+	if len(slices) != 3 || slices[2].Bounds() != image.Rect(4, 0, 6, 2) {
+		t.Fatalf("splitStrip() = %d slices, last bounds %v, want 3 slices, last bounds (4,0)-(6,2)", len(slices), slices[2].Bounds())
+	}
+	if got := imageToPixels(slices[2], textureFormats[FORMAT_RGBA8]).([]byte)[8:12]; got[0] != 255 {
+		t.Fatalf("imageToPixels(slice) pixel (0, 1) = %v, want red", got)
+	}
+	if _, err := splitStrip(strip, 4); err == nil {
+		t.Fatalf("splitStrip(6 pixels, 4) = nil error, want error")
+	}
+}
+
+func TestFloatImageSubImage(t *testing.T) {
+	img := NewFloatImage(image.Rect(0, 0, 4, 2))
+	img.SetFloat(3, 1, 2, 0, 0, 1)
+	sub := img.SubImage(image.Rect(2, 0, 4, 2)).(*FloatImage)
+	if r, _, _, _ := sub.FloatAt(3, 1); r != 2 {
+		t.Fatalf("SubImage().FloatAt(3, 1) red = %v, want 2", r)
+	}
+	if components := sub.components(4); len(components) != 16 || components[12] != 2 {
+		t.Fatalf("SubImage().components(4) = %v, want 16 components with red 2 at index 12", components)
+	}
+}
@@ -0,0 +1,42 @@
+package graphic
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"image"
+	"log/slog"
+)
+
+// TextureArray is a 2D texture array (sampler2DArray in shaders), terrain splatting layers for example.
+type TextureArray struct {
+	layeredTexture
+}
+
+// NewTextureArrayFromImages returns a texture array with one layer per image (all images have the same size).
+func NewTextureArrayFromImages(images []image.Image, options TextureOptions) (*TextureArray, error) {
+	texture, err := newLayeredTexture(gl.TEXTURE_2D_ARRAY, images, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create texture array\n - %w", err)
+	}
+	return &TextureArray{layeredTexture: *texture}, nil
+}
+
+// LoadTextureArrayFromFiles returns a texture array with one layer per image file.
+func LoadTextureArrayFromFiles(filenames []string, options TextureOptions) (*TextureArray, error) {
+	slog.Debug("texture array creation from files", "filenames", filenames)
+	images, err := loadImagesFromFiles(filenames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build texture array\n - %w", err)
+	}
+	return NewTextureArrayFromImages(images, options)
+}
+
+// Layers returns the number of layers.
+func (texture *TextureArray) Layers() int {
+	return int(texture.layers)
+}
+
+// UpdateLayer replaces a layer with img (same size as the array).
+func (texture *TextureArray) UpdateLayer(layer int, img image.Image) error {
+	return texture.updateLayer(layer, img)
+}
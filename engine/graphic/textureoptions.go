@@ -0,0 +1,261 @@
+package graphic
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// TextureFilter defines how texels are sampled when a texture is scaled.
+type TextureFilter string
+
+// List of texture filters
+const (
+	// FILTER_NEAREST prend le texel le plus proche (pixel art)
+	FILTER_NEAREST TextureFilter = "nearest"
+	// FILTER_LINEAR interpole les 4 texels les plus proches
+	FILTER_LINEAR TextureFilter = "linear"
+	// FILTER_TRILINEAR interpole aussi entre deux niveaux de mipmaps (mipmaps générées automatiquement)
+	FILTER_TRILINEAR TextureFilter = "trilinear"
+)
+
+// TextureWrap defines how texture coordinates outside [0, 1] are handled.
+type TextureWrap string
+
+// List of texture wrap modes
+const (
+	// WRAP_CLAMP répète le texel du bord
+	WRAP_CLAMP TextureWrap = "clamp"
+	// WRAP_REPEAT répète la texture
+	WRAP_REPEAT TextureWrap = "repeat"
+	// WRAP_MIRROR répète la texture en miroir
+	WRAP_MIRROR TextureWrap = "mirror"
+)
+
+// TextureFormat is the internal format of a texture.
+type TextureFormat string
+
+// List of texture formats
+const (
+	// FORMAT_RGBA8 : 4 composantes sur 8 bits
+	FORMAT_RGBA8 TextureFormat = "rgba8"
+	// FORMAT_SRGBA8 : 4 composantes sur 8 bits, couleurs sRGB converties en linéaire à la lecture
+	FORMAT_SRGBA8 TextureFormat = "srgba8"
+	// FORMAT_R8 : une composante sur 8 bits (luminance de l'image)
+	FORMAT_R8 TextureFormat = "r8"
+	// FORMAT_R16F et FORMAT_R32F : une composante flottante (luminance de l'image)
+	FORMAT_R16F TextureFormat = "r16f"
+	FORMAT_R32F TextureFormat = "r32f"
+	// FORMAT_RGBA16F et FORMAT_RGBA32F : 4 composantes flottantes
+	FORMAT_RGBA16F TextureFormat = "rgba16f"
+	FORMAT_RGBA32F TextureFormat = "rgba32f"
+)
+
+// IsValid indicates if format is a known texture format.
+func (format TextureFormat) IsValid() bool {
+	_, found := textureFormats[format]
+	return found
+}
+
+// TextureOptions are the texture creation options.
+//
+//	Zero values are replaced by defaults: FILTER_LINEAR, WRAP_CLAMP and FORMAT_RGBA8 (FORMAT_RGBA16F for a FloatImage).
+type TextureOptions struct {
+	Filter TextureFilter
+	Wrap   TextureWrap
+	Format TextureFormat
+	// Mipmaps indique s'il faut générer les mipmaps (toujours vrai avec FILTER_TRILINEAR)
+	Mipmaps bool
+	// Anisotropy est le filtrage anisotrope maximal (0 ou 1 pour le désactiver, limité par le matériel)
+	Anisotropy float32
+}
+
+// DefaultTextureOptions returns the default options.
+func DefaultTextureOptions() TextureOptions {
+	return TextureOptions{Filter: FILTER_LINEAR, Wrap: WRAP_CLAMP, Format: FORMAT_RGBA8}
+}
+
+// normalized returns options with defaults instead of zero values.
+func (options TextureOptions) normalized() TextureOptions {
+	if options.Filter == "" {
+		options.Filter = FILTER_LINEAR
+	}
+	if options.Wrap == "" {
+		options.Wrap = WRAP_CLAMP
+	}
+	if options.Format == "" {
+		options.Format = FORMAT_RGBA8
+	}
+	if options.Filter == FILTER_TRILINEAR {
+		options.Mipmaps = true
+	}
+	return options
+}
+
+// withImageFormat returns options with FORMAT_RGBA16F as default format for a FloatImage.
+func (options TextureOptions) withImageFormat(img image.Image) TextureOptions {
+	if _, isFloat := img.(*FloatImage); isFloat && options.Format == "" {
+		options.Format = FORMAT_RGBA16F
+	}
+	return options
+}
+
+// validate checks options values.
+func (options TextureOptions) validate() error {
+	switch options.Filter {
+	case FILTER_NEAREST, FILTER_LINEAR, FILTER_TRILINEAR:
+	default:
+		return fmt.Errorf("unknown '%s' texture filter", options.Filter)
+	}
+	switch options.Wrap {
+	case WRAP_CLAMP, WRAP_REPEAT, WRAP_MIRROR:
+	default:
+		return fmt.Errorf("unknown '%s' texture wrap mode", options.Wrap)
+	}
+	if options.Format != FORMAT_COMPRESSED && !options.Format.IsValid() {
+		return fmt.Errorf("unknown '%s' texture format", options.Format)
+	}
+	return nil
+}
+
+// filters returns OpenGL minification and magnification filters.
+func (options TextureOptions) filters() (int32, int32) {
+	switch options.Filter {
+	case FILTER_NEAREST:
+		if options.Mipmaps {
+			return gl.NEAREST_MIPMAP_NEAREST, gl.NEAREST
+		}
+		return gl.NEAREST, gl.NEAREST
+	case FILTER_TRILINEAR:
+		return gl.LINEAR_MIPMAP_LINEAR, gl.LINEAR
+	default:
+		if options.Mipmaps {
+			return gl.LINEAR_MIPMAP_NEAREST, gl.LINEAR
+		}
+		return gl.LINEAR, gl.LINEAR
+	}
+}
+
+// wrap returns OpenGL wrap mode.
+func (options TextureOptions) wrap() int32 {
+	switch options.Wrap {
+	case WRAP_REPEAT:
+		return gl.REPEAT
+	case WRAP_MIRROR:
+		return gl.MIRRORED_REPEAT
+	default:
+		return gl.CLAMP_TO_EDGE
+	}
+}
+
+// applyTextureOptions sets filtering and wrapping parameters of an OpenGL texture.
+func applyTextureOptions(handle uint32, options TextureOptions) {
+	minFilter, magFilter := options.filters()
+	gl.TextureParameteri(handle, gl.TEXTURE_MIN_FILTER, minFilter)
+	gl.TextureParameteri(handle, gl.TEXTURE_MAG_FILTER, magFilter)
+	gl.TextureParameteri(handle, gl.TEXTURE_WRAP_S, options.wrap())
+	gl.TextureParameteri(handle, gl.TEXTURE_WRAP_T, options.wrap())
+	gl.TextureParameteri(handle, gl.TEXTURE_WRAP_R, options.wrap())
+	anisotropy := float32(1)
+	if options.Anisotropy > 1 {
+		var maxAnisotropy float32
+		gl.GetFloatv(gl.MAX_TEXTURE_MAX_ANISOTROPY, &maxAnisotropy)
+		anisotropy = max(min(options.Anisotropy, maxAnisotropy), 1)
+	}
+	gl.TextureParameterf(handle, gl.TEXTURE_MAX_ANISOTROPY, anisotropy)
+}
+
+// mipmapLevels returns the number of mipmap levels of a width x height texture.
+func mipmapLevels(width, height int32, mipmaps bool) int32 {
+	if !mipmaps {
+		return 1
+	}
+	levels := int32(1)
+	for size := max(width, height); size > 1; size /= 2 {
+		levels++
+	}
+	return levels
+}
+
+// textureFormat describes how a texture format is stored and transferred.
+type textureFormat struct {
+	// internalFormat est le format de stockage OpenGL
+	internalFormat uint32
+	// pixelFormat et pixelType sont le format des données transférées
+	pixelFormat uint32
+	pixelType   uint32
+	// channels est le nombre de composantes et float indique des composantes flottantes (sinon 8 bits)
+	channels int
+	float    bool
+}
+
+// textureFormats contains the description of each texture format
+var textureFormats = map[TextureFormat]textureFormat{
+	FORMAT_RGBA8:   {gl.RGBA8, gl.RGBA, gl.UNSIGNED_BYTE, 4, false},
+	FORMAT_SRGBA8:  {gl.SRGB8_ALPHA8, gl.RGBA, gl.UNSIGNED_BYTE, 4, false},
+	FORMAT_R8:      {gl.R8, gl.RED, gl.UNSIGNED_BYTE, 1, false},
+	FORMAT_R16F:    {gl.R16F, gl.RED, gl.FLOAT, 1, true},
+	FORMAT_R32F:    {gl.R32F, gl.RED, gl.FLOAT, 1, true},
+	FORMAT_RGBA16F: {gl.RGBA16F, gl.RGBA, gl.FLOAT, 4, true},
+	FORMAT_RGBA32F: {gl.RGBA32F, gl.RGBA, gl.FLOAT, 4, true},
+}
+
+// imageToPixels converts an image to texture data (8 bits components as []byte, float components as []float32).
+func imageToPixels(img image.Image, format textureFormat) any {
+	bounds := img.Bounds()
+	switch {
+	case format.channels == 4 && !format.float:
+		rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+		return rgba.Pix
+	case format.channels == 1 && !format.float:
+		gray := image.NewGray(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(gray, gray.Bounds(), img, bounds.Min, draw.Src)
+		return gray.Pix
+	}
+	if floatImage, isFloat := img.(*FloatImage); isFloat {
+		return floatImage.components(format.channels)
+	}
+	pixels := make([]float32, 0, bounds.Dx()*bounds.Dy()*format.channels)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := img.At(x, y)
+			if format.channels == 1 {
+				gray := color.Gray16Model.Convert(pixel).(color.Gray16)
+				pixels = append(pixels, float32(gray.Y)/0xffff)
+				continue
+			}
+			r, g, b, a := pixel.RGBA()
+			pixels = append(pixels, float32(r)/0xffff, float32(g)/0xffff, float32(b)/0xffff, float32(a)/0xffff)
+		}
+	}
+	return pixels
+}
+
+// pixelsToImage converts texture data to an image (see imageToPixels).
+func pixelsToImage(pixels any, width, height int, format textureFormat) image.Image {
+	bounds := image.Rect(0, 0, width, height)
+	switch data := pixels.(type) {
+	case []byte:
+		if format.channels == 1 {
+			return &image.Gray{Pix: data, Stride: width, Rect: bounds}
+		}
+		return &image.RGBA{Pix: data, Stride: width * 4, Rect: bounds}
+	case []float32:
+		component := func(value float32) uint16 {
+			return uint16(math.Round(float64(min(max(value, 0), 1)) * 0xffff))
+		}
+		if format.channels == 1 {
+			gray := image.NewGray16(bounds)
+			for index, value := range data {
+				gray.SetGray16(index%width, index/width, color.Gray16{Y: component(value)})
+			}
+			return gray
+		}
+		return &FloatImage{Pix: data, Stride: width * 4, Rect: bounds}
+	}
+	return nil
+}
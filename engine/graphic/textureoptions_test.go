@@ -0,0 +1,90 @@
+package graphic
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestTextureOptionsNormalized(t *testing.T) {
+	options := TextureOptions{}.normalized()
+	if options != DefaultTextureOptions() {
+		t.Fatalf("TextureOptions{}.normalized() = %v, want %v", options, DefaultTextureOptions())
+	}
+	options = TextureOptions{Filter: FILTER_TRILINEAR}.normalized()
+	if !options.Mipmaps {
+		t.Fatalf("trilinear options Mipmaps = false, want true")
+	}
+	if err := (TextureOptions{Filter: "bicubic"}).normalized().validate(); err == nil {
+		t.Fatalf("validate() with unknown filter = nil, want error")
+	}
+	if err := (TextureOptions{Format: "rgb565"}).normalized().validate(); err == nil {
+		t.Fatalf("validate() with unknown format = nil, want error")
+	}
+}
+
+func TestTextureOptionsFilters(t *testing.T) {
+	for _, test := range []struct {
+		options TextureOptions
+		wantMin int32
+		wantMag int32
+	}{
+		{TextureOptions{Filter: FILTER_NEAREST}, gl.NEAREST, gl.NEAREST},
+		{TextureOptions{Filter: FILTER_NEAREST, Mipmaps: true}, gl.NEAREST_MIPMAP_NEAREST, gl.NEAREST},
+		{TextureOptions{Filter: FILTER_LINEAR}, gl.LINEAR, gl.LINEAR},
+		{TextureOptions{Filter: FILTER_TRILINEAR}, gl.LINEAR_MIPMAP_LINEAR, gl.LINEAR},
+	} {
+		minFilter, magFilter := test.options.normalized().filters()
+		if minFilter != test.wantMin || magFilter != test.wantMag {
+			t.Fatalf("filters(%v) = %d, %d, want %d, %d", test.options, minFilter, magFilter, test.wantMin, test.wantMag)
+		}
+	}
+}
+
+func TestMipmapLevels(t *testing.T) {
+	for _, test := range []struct {
+		width, height int32
+		mipmaps       bool
+		want          int32
+	}{
+		{256, 256, false, 1},
+		{256, 256, true, 9},
+		{300, 20, true, 9},
+		{1, 1, true, 1},
+	} {
+		if levels := mipmapLevels(test.width, test.height, test.mipmaps); levels != test.want {
+			t.Fatalf("mipmapLevels(%d, %d, %v) = %d, want %d", test.width, test.height, test.mipmaps, levels, test.want)
+		}
+	}
+}
+
+func TestImageToPixels(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(10, 10, 12, 11))
+	img.SetNRGBA(10, 10, color.NRGBA{R: 255, A: 255})
+	img.SetNRGBA(11, 10, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+
+	rgba := imageToPixels(img, textureFormats[FORMAT_RGBA8]).([]byte)
+	if want := []byte{255, 0, 0, 255, 255, 255, 255, 255}; string(rgba) != string(want) {
+		t.Fatalf("imageToPixels(RGBA8) = %v, want %v", rgba, want)
+	}
+	gray := imageToPixels(img, textureFormats[FORMAT_R8]).([]byte)
+	if len(gray) != 2 || gray[1] != 255 {
+		t.Fatalf("imageToPixels(R8) = %v, want 2 values ending with 255", gray)
+	}
+	floats := imageToPixels(img, textureFormats[FORMAT_RGBA32F]).([]float32)
+	if want := []float32{1, 0, 0, 1, 1, 1, 1, 1}; len(floats) != len(want) || floats[0] != want[0] || floats[1] != want[1] || floats[7] != want[7] {
+		t.Fatalf("imageToPixels(RGBA32F) = %v, want %v", floats, want)
+	}
+}
+
+func TestPixelsToImage(t *testing.T) {
+	img := pixelsToImage([]float32{0, 0.5, 1, 1, 2, -1, 0, 1}, 2, 1, textureFormats[FORMAT_RGBA16F])
+	if got, want := img.At(1, 0), (color.NRGBA64{R: 0xffff, A: 0xffff}); got != want {
+		t.Fatalf("pixelsToImage() pixel (1, 0) = %v, want %v", got, want)
+	}
+	gray := pixelsToImage([]byte{12, 34}, 2, 1, textureFormats[FORMAT_R8])
+	if got, want := gray.At(1, 0), (color.Gray{Y: 34}); got != want {
+		t.Fatalf("pixelsToImage() pixel (1, 0) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+package graphic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+)
+
+// Types d'image TGA
+const (
+	tgaColorMapped    = 1
+	tgaTrueColor      = 2
+	tgaGrayscale      = 3
+	tgaRleColorMapped = 9
+	tgaRleTrueColor   = 10
+	tgaRleGrayscale   = 11
+)
+
+// tgaHeaderSize est la taille de l'entête d'un fichier TGA
+const tgaHeaderSize = 18
+
+// decodeTGA decodes a Truevision TGA image (color-mapped, true-color or grayscale, raw or RLE compressed).
+func decodeTGA(reader io.Reader) (image.Image, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(content) < tgaHeaderSize {
+		return nil, fmt.Errorf("invalid TGA header")
+	}
+	idLength := int(content[0])
+	colorMapType := content[1]
+	imageType := content[2]
+	colorMapFirst := int(binary.LittleEndian.Uint16(content[3:]))
+	colorMapLength := int(binary.LittleEndian.Uint16(content[5:]))
+	colorMapDepth := int(content[7])
+	width := int(binary.LittleEndian.Uint16(content[12:]))
+	height := int(binary.LittleEndian.Uint16(content[14:]))
+	depth := int(content[16])
+	descriptor := content[17]
+	alphaBits := int(descriptor & 0x0f)
+	rightToLeft := descriptor&0x10 != 0
+	topToBottom := descriptor&0x20 != 0
+
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("invalid %dx%d TGA image size", width, height)
+	}
+	offset := tgaHeaderSize + idLength
+
+	// Table des couleurs
+	var palette []color.NRGBA
+	if colorMapType == 1 {
+		if colorMapDepth != 15 && colorMapDepth != 16 && colorMapDepth != 24 && colorMapDepth != 32 {
+			return nil, fmt.Errorf("unsupported %d bits TGA color map entries", colorMapDepth)
+		}
+		entrySize := (colorMapDepth + 7) / 8
+		palette = make([]color.NRGBA, colorMapFirst+colorMapLength)
+		for index := 0; index < colorMapLength; index++ {
+			if offset+entrySize > len(content) {
+				return nil, fmt.Errorf("truncated TGA color map")
+			}
+			palette[colorMapFirst+index] = tgaColor(content[offset:offset+entrySize], colorMapDepth, colorMapDepth == 32 || alphaBits > 0)
+			offset += entrySize
+		}
+	}
+
+	switch imageType {
+	case tgaColorMapped, tgaRleColorMapped:
+		if palette == nil || (depth != 8 && depth != 16) {
+			return nil, fmt.Errorf("unsupported %d bits color-mapped TGA image", depth)
+		}
+	case tgaTrueColor, tgaRleTrueColor:
+		if depth != 15 && depth != 16 && depth != 24 && depth != 32 {
+			return nil, fmt.Errorf("unsupported %d bits true-color TGA image", depth)
+		}
+	case tgaGrayscale, tgaRleGrayscale:
+		if depth != 8 && depth != 16 {
+			return nil, fmt.Errorf("unsupported %d bits grayscale TGA image", depth)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported TGA image type %d", imageType)
+	}
+
+	pixelSize := (depth + 7) / 8
+	pixels, err := tgaPixels(content[offset:], width*height, pixelSize, imageType >= tgaRleColorMapped)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for index := 0; index < width*height; index++ {
+		data := pixels[index*pixelSize:]
+		var pixel color.NRGBA
+		switch imageType {
+		case tgaColorMapped, tgaRleColorMapped:
+			entry := int(data[0])
+			if pixelSize == 2 {
+				entry = int(binary.LittleEndian.Uint16(data))
+			}
+			if entry >= len(palette) {
+				return nil, fmt.Errorf("invalid TGA color index %d", entry)
+			}
+			pixel = palette[entry]
+		case tgaGrayscale, tgaRleGrayscale:
+			pixel = color.NRGBA{R: data[0], G: data[0], B: data[0], A: 255}
+			if pixelSize == 2 {
+				pixel.A = data[1]
+			}
+		default:
+			pixel = tgaColor(data, depth, alphaBits > 0)
+		}
+		// Les lignes sont stockées de bas en haut sauf indication contraire
+		x, y := index%width, index/width
+		if rightToLeft {
+			x = width - 1 - x
+		}
+		if !topToBottom {
+			y = height - 1 - y
+		}
+		img.SetNRGBA(x, y, pixel)
+	}
+	return img, nil
+}
+
+// tgaPixels returns count raw pixels (RLE packets are expanded).
+func tgaPixels(data []byte, count int, pixelSize int, rle bool) ([]byte, error) {
+	size := count * pixelSize
+	if !rle {
+		if len(data) < size {
+			return nil, fmt.Errorf("truncated TGA image data")
+		}
+		return data[:size], nil
+	}
+	// Un paquet code au plus 128 pixels : les données doivent contenir assez de paquets avant d'allouer l'image
+	if len(data)/(1+pixelSize) < (count+127)/128 {
+		return nil, fmt.Errorf("truncated TGA RLE data")
+	}
+	pixels := make([]byte, 0, size)
+	for position := 0; len(pixels) < size; {
+		if position >= len(data) {
+			return nil, fmt.Errorf("truncated TGA RLE data")
+		}
+		packet := data[position]
+		position++
+		repeat := int(packet&0x7f) + 1
+		if packet&0x80 != 0 {
+			// Paquet répété : un pixel répété
+			if position+pixelSize > len(data) {
+				return nil, fmt.Errorf("truncated TGA RLE data")
+			}
+			for ; repeat > 0; repeat-- {
+				pixels = append(pixels, data[position:position+pixelSize]...)
+			}
+			position += pixelSize
+		} else {
+			// Paquet brut : une suite de pixels
+			if position+repeat*pixelSize > len(data) {
+				return nil, fmt.Errorf("truncated TGA RLE data")
+			}
+			pixels = append(pixels, data[position:position+repeat*pixelSize]...)
+			position += repeat * pixelSize
+		}
+	}
+	return pixels[:size], nil
+}
+
+// tgaColor decodes a BGR(A) pixel (15, 16, 24 or 32 bits).
+func tgaColor(data []byte, depth int, hasAlpha bool) color.NRGBA {
+	switch depth {
+	case 15, 16:
+		value := binary.LittleEndian.Uint16(data)
+		scale := func(component uint16) uint8 {
+			return uint8(component * 255 / 31)
+		}
+		pixel := color.NRGBA{R: scale(value >> 10 & 0x1f), G: scale(value >> 5 & 0x1f), B: scale(value & 0x1f), A: 255}
+		if depth == 16 && hasAlpha && value&0x8000 == 0 {
+			pixel.A = 0
+		}
+		return pixel
+	case 24:
+		return color.NRGBA{R: data[2], G: data[1], B: data[0], A: 255}
+	default:
+		pixel := color.NRGBA{R: data[2], G: data[1], B: data[0], A: data[3]}
+		if !hasAlpha {
+			pixel.A = 255
+		}
+		return pixel
+	}
+}
@@ -0,0 +1,106 @@
+package graphic
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// Style describes how to draw a widget (texture region, slicing, font, colors and padding).
+type Style struct {
+	// Texture est le nom de la texture (ou de l'atlas) dans le gestionnaire de textures
+	Texture string `json:"texture,omitempty"`
+	// Region est la zone de la texture à utiliser (toute la texture si vide)
+	Region Rectangle `json:"region,omitempty"`
+	// Slice est le mode de découpage de la texture
+	Slice SliceMode `json:"slice,omitempty"`
+	// Borders sont les bordures de découpage sur la texture
+	Borders Insets `json:"borders,omitempty"`
+	// Color est la couleur appliquée à la texture
+	Color Color `json:"color"`
+	// Font est le nom de la police de caractères dans le gestionnaire de polices
+	Font string `json:"font,omitempty"`
+	// TextColor est la couleur du texte
+	TextColor Color `json:"textColor"`
+	// Padding est l'espace entre le bord du widget et son contenu
+	Padding Insets `json:"padding,omitempty"`
+}
+
+// UnmarshalJSON decodes a style (colors are white by default).
+func (style *Style) UnmarshalJSON(data []byte) error {
+	type jsonStyle Style
+	decoded := jsonStyle{Color: White, TextColor: White, Slice: STRETCH}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*style = Style(decoded)
+	return nil
+}
+
+// HasTexture indicates if style draws a background texture.
+func (style *Style) HasTexture() bool {
+	return style.Texture != ""
+}
+
+// HasRegion indicates if style uses only part of the texture.
+func (style *Style) HasRegion() bool {
+	return style.Region.Width() > 0 && style.Region.Height() > 0
+}
+
+// Theme is a set of named styles.
+type Theme struct {
+	// Name est le nom du thème
+	Name string `json:"name"`
+	// Styles contient les styles par nom de style de widget
+	Styles map[string]*Style `json:"styles"`
+}
+
+// LoadThemeFromFile loads a theme from a JSON file.
+func LoadThemeFromFile(filename string) (*Theme, error) {
+	slog.Debug("theme creation from file", "filename", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load theme file '%s'\n - %w", filename, err)
+	}
+	theme, err := LoadThemeFromBytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build theme from file '%s'\n - %w", filename, err)
+	}
+	return theme, nil
+}
+
+// LoadThemeFromBytes loads a theme from a JSON byte array.
+func LoadThemeFromBytes(content []byte) (*Theme, error) {
+	slog.Debug("theme creation from byte array")
+	theme := &Theme{}
+	if err := json.Unmarshal(content, theme); err != nil {
+		return nil, fmt.Errorf("failed to decode theme\n - %w", err)
+	}
+	if theme.Styles == nil {
+		theme.Styles = make(map[string]*Style)
+	}
+	for name, style := range theme.Styles {
+		if style == nil {
+			return nil, fmt.Errorf("theme style '%s' is empty", name)
+		}
+		switch style.Slice {
+		case STRETCH, NINE_SLICE, THREE_SLICE_HORIZONTAL, THREE_SLICE_VERTICAL:
+		default:
+			return nil, fmt.Errorf("theme style '%s' has unsupported '%s' slice mode", name, style.Slice)
+		}
+	}
+	return theme, nil
+}
+
+// Style returns named style (nil if style is not found).
+func (theme *Theme) Style(name string) *Style {
+	if theme == nil {
+		return nil
+	}
+	return theme.Styles[name]
+}
+
+// Release releases theme (theme does not own resources, textures and fonts are owned by managers).
+func (theme *Theme) Release() {
+}
@@ -0,0 +1,281 @@
+package graphic
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TileMapFormat is a tile map file supported format
+type TileMapFormat string
+
+// List of supported tile map formats (Tiled editor)
+const (
+	TMX TileMapFormat = ".tmx"
+	TMJ TileMapFormat = ".tmj"
+	// TSX et TSJ sont les formats des tilesets externes
+	TSX TileMapFormat = ".tsx"
+	TSJ TileMapFormat = ".tsj"
+	// JSON est l'ancienne extension des cartes et tilesets JSON
+	JSON TileMapFormat = ".json"
+)
+
+// TileMapReadFunc reads a file referenced by a map (name is relative to map directory, with '/' separator).
+type TileMapReadFunc func(name string) ([]byte, error)
+
+// LoadTileMapFromFile loads a Tiled map (".tmx", ".tmj" or ".json") and its tileset textures.
+//
+//	External tilesets and images are relative to map file.
+func LoadTileMapFromFile(filename string) (*TileMap, error) {
+	slog.Debug("tile map creation from file", "filename", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tile map file '%s'\n - %w", filename, err)
+	}
+	format := TileMapFormat(strings.ToLower(filepath.Ext(filename)))
+	tileMap, err := LoadTileMapFromBytes(content, format, filepath.Dir(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tile map from file '%s'\n - %w", filename, err)
+	}
+	return tileMap, nil
+}
+
+// LoadTileMapFromBytes loads a Tiled map and its tileset textures (external tilesets and images are relative to directory).
+func LoadTileMapFromBytes(content []byte, format TileMapFormat, directory string) (*TileMap, error) {
+	slog.Debug("tile map creation from byte array")
+	tileMap, err := DecodeTileMap(content, format, func(name string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(directory, filepath.FromSlash(name)))
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := tileMap.LoadTextures(directory); err != nil {
+		// Libérer les textures des tilesets chargées avant l'erreur
+		tileMap.Release()
+		return nil, err
+	}
+	return tileMap, nil
+}
+
+// DecodeTileMap decodes a Tiled map without loading textures.
+//
+//	readFile is used to read external tilesets (it can be nil if map has no external tileset).
+//	Only orthogonal and finite maps are supported. Group layers are flattened
+//	(group offset, opacity and visibility are applied to their layers).
+func DecodeTileMap(content []byte, format TileMapFormat, readFile TileMapReadFunc) (*TileMap, error) {
+	var tileMap *TileMap
+	var err error
+	switch format {
+	case TMX:
+		tileMap, err = decodeTmxMap(content, readFile)
+	case TMJ, JSON:
+		tileMap, err = decodeTmjMap(content, readFile)
+	default:
+		return nil, fmt.Errorf("unsupported '%s' tile map format - only '.tmx', '.tmj' and '.json' are supported", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tileMap.Tilesets, func(i, j int) bool {
+		return tileMap.Tilesets[i].FirstGid < tileMap.Tilesets[j].FirstGid
+	})
+	return tileMap, nil
+}
+
+// LoadTextures loads tileset textures (images are relative to directory).
+//
+//	Textures already set are kept (they are not released by the map).
+func (tileMap *TileMap) LoadTextures(directory string) error {
+	for _, tileset := range tileMap.Tilesets {
+		if tileset.Texture != nil {
+			continue
+		}
+		if tileset.Image == "" {
+			return fmt.Errorf("'%s' tileset has no image (image collection tilesets are not supported)", tileset.Name)
+		}
+		texture, err := LoadTextureFromFile(filepath.Join(directory, filepath.FromSlash(tileset.Image)))
+		if err != nil {
+			return fmt.Errorf("failed to load '%s' tileset texture\n - %w", tileset.Name, err)
+		}
+		tileset.Texture = texture
+		tileMap.ownTextures = append(tileMap.ownTextures, tileset)
+	}
+	return nil
+}
+
+// checkTileMap verifies map header.
+func checkTileMap(orientation string, infinite bool) error {
+	if orientation != "" && orientation != "orthogonal" {
+		return fmt.Errorf("unsupported '%s' map orientation - only orthogonal maps are supported", orientation)
+	}
+	if infinite {
+		return fmt.Errorf("infinite maps are not supported")
+	}
+	return nil
+}
+
+// readExternalTileset reads an external tileset referenced by a map.
+func readExternalTileset(source string, readFile TileMapReadFunc) (*Tileset, error) {
+	if readFile == nil {
+		return nil, fmt.Errorf("cannot read external tileset '%s' (no read function)", source)
+	}
+	content, err := readFile(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read external tileset '%s'\n - %w", source, err)
+	}
+	var tileset *Tileset
+	switch TileMapFormat(strings.ToLower(path.Ext(source))) {
+	case TSX:
+		xmlTileset := tmxTileset{}
+		if err := xml.Unmarshal(content, &xmlTileset); err != nil {
+			return nil, fmt.Errorf("failed to decode external tileset '%s'\n - %w", source, err)
+		}
+		tileset, err = xmlTileset.toTileset()
+	case TSJ, JSON:
+		jsonTileset := tmjTileset{}
+		if err := json.Unmarshal(content, &jsonTileset); err != nil {
+			return nil, fmt.Errorf("failed to decode external tileset '%s'\n - %w", source, err)
+		}
+		tileset, err = jsonTileset.toTileset()
+	default:
+		return nil, fmt.Errorf("unsupported external tileset '%s' - only '.tsx', '.tsj' and '.json' are supported", source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to build external tileset '%s'\n - %w", source, err)
+	}
+	// L'image est relative au fichier du tileset
+	if tileset.Image != "" {
+		tileset.Image = path.Join(path.Dir(source), tileset.Image)
+	}
+	return tileset, nil
+}
+
+// decodeTileData decodes encoded layer data (csv or base64 with optional compression).
+func decodeTileData(data string, encoding string, compression string, count int) ([]uint32, error) {
+	switch encoding {
+	case "csv":
+		gids := make([]uint32, 0, count)
+		for _, field := range strings.Split(data, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			gid, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode csv layer data\n - %w", err)
+			}
+			gids = append(gids, uint32(gid))
+		}
+		return checkTileData(gids, count)
+	case "base64":
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 layer data\n - %w", err)
+		}
+		var reader io.Reader = bytes.NewReader(raw)
+		switch compression {
+		case "":
+		case "zlib":
+			if reader, err = zlib.NewReader(reader); err != nil {
+				return nil, fmt.Errorf("failed to decompress zlib layer data\n - %w", err)
+			}
+		case "gzip":
+			if reader, err = gzip.NewReader(reader); err != nil {
+				return nil, fmt.Errorf("failed to decompress gzip layer data\n - %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported '%s' layer data compression - only zlib and gzip are supported", compression)
+		}
+		gids := make([]uint32, count)
+		if err := binary.Read(reader, binary.LittleEndian, gids); err != nil {
+			return nil, fmt.Errorf("failed to read layer data\n - %w", err)
+		}
+		return gids, nil
+	default:
+		return nil, fmt.Errorf("unsupported '%s' layer data encoding - only csv and base64 are supported", encoding)
+	}
+}
+
+// checkTileData verifies tile count of a layer.
+func checkTileData(gids []uint32, count int) ([]uint32, error) {
+	if len(gids) != count {
+		return nil, fmt.Errorf("layer data has %d tiles (%d expected)", len(gids), count)
+	}
+	return gids, nil
+}
+
+// parseTiledColor parses a Tiled color ("#RRGGBB" or "#AARRGGBB").
+func parseTiledColor(value string) (Color, error) {
+	hexadecimal := strings.TrimPrefix(value, "#")
+	if len(hexadecimal) != 6 && len(hexadecimal) != 8 {
+		return Color{}, fmt.Errorf("invalid '%s' color", value)
+	}
+	components, err := strconv.ParseUint(hexadecimal, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid '%s' color\n - %w", value, err)
+	}
+	alpha := uint64(255)
+	if len(hexadecimal) == 8 {
+		alpha = components >> 24
+	}
+	return CreateColorRVBA(
+		float32((components>>16)&0xFF)/255.,
+		float32((components>>8)&0xFF)/255.,
+		float32(components&0xFF)/255.,
+		float32(alpha&0xFF)/255.,
+	), nil
+}
+
+// parseTiledPoints parses TMX polygon points ("x1,y1 x2,y2 ...").
+func parseTiledPoints(value string) ([]mgl32.Vec2, error) {
+	points := make([]mgl32.Vec2, 0)
+	for _, pair := range strings.Fields(value) {
+		x, y, found := strings.Cut(pair, ",")
+		if !found {
+			return nil, fmt.Errorf("invalid '%s' point", pair)
+		}
+		pointX, err := strconv.ParseFloat(x, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' point\n - %w", pair, err)
+		}
+		pointY, err := strconv.ParseFloat(y, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' point\n - %w", pair, err)
+		}
+		points = append(points, mgl32.Vec2{float32(pointX), float32(pointY)})
+	}
+	return points, nil
+}
+
+// layerContext contains inherited values of group layers.
+type layerContext struct {
+	offset  mgl32.Vec2
+	opacity float32
+	visible bool
+}
+
+// rootLayerContext is the context of map layers.
+var rootLayerContext = layerContext{opacity: 1., visible: true}
+
+// child returns context of a layer in group.
+func (context layerContext) child(offset mgl32.Vec2, opacity float32, visible bool) layerContext {
+	return layerContext{
+		offset:  context.offset.Add(offset),
+		opacity: context.opacity * opacity,
+		visible: context.visible && visible,
+	}
+}
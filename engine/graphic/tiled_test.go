@@ -0,0 +1,271 @@
+package graphic
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"testing"
+)
+
+const testTmxMap = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" orientation="orthogonal" renderorder="right-down" width="4" height="3" tilewidth="16" tileheight="16" infinite="0" backgroundcolor="#80ff0000">
+ <properties>
+  <property name="music" value="level1"/>
+ </properties>
+ <tileset firstgid="1" source="tiles/ground.tsx"/>
+ <tileset firstgid="101" name="water" tilewidth="16" tileheight="16" tilecount="4" columns="2">
+  <image source="water.png" width="32" height="32"/>
+  <tile id="0">
+   <animation>
+    <frame tileid="0" duration="100"/>
+    <frame tileid="1" duration="200"/>
+   </animation>
+  </tile>
+ </tileset>
+ <layer id="1" name="ground" width="4" height="3">
+  <data encoding="csv">
+1,2,2,1,
+101,0,0,2147483649,
+0,0,0,0
+</data>
+ </layer>
+ <group id="2" name="front" offsetx="4" opacity="0.5">
+  <layer id="3" name="walls" width="4" height="3" offsety="2">
+   <properties>
+    <property name="collision" type="bool" value="true"/>
+   </properties>
+   <data>
+    <tile gid="3"/><tile gid="3"/><tile/><tile/>
+    <tile gid="3"/><tile gid="3"/><tile/><tile/>
+    <tile/><tile/><tile/><tile gid="3"/>
+   </data>
+  </layer>
+  <objectgroup id="4" name="spawns" visible="0">
+   <object id="1" name="player" class="spawn" x="8" y="24" width="16" height="16">
+    <properties>
+     <property name="direction" value="left"/>
+    </properties>
+   </object>
+   <object id="2" name="zone" x="10" y="10">
+    <polygon points="0,0 20,0 20,-10"/>
+   </object>
+  </objectgroup>
+ </group>
+</map>`
+
+const testTsxTileset = `<?xml version="1.0" encoding="UTF-8"?>
+<tileset version="1.10" name="ground" tilewidth="16" tileheight="16" spacing="1" margin="2" tilecount="100" columns="10">
+ <image source="ground.png" width="172" height="172"/>
+ <tile id="1" type="grass">
+  <properties>
+   <property name="collision" type="bool" value="true"/>
+  </properties>
+ </tile>
+</tileset>`
+
+func decodeTestTmxMap(t *testing.T) *TileMap {
+	tileMap, err := DecodeTileMap([]byte(testTmxMap), TMX, func(name string) ([]byte, error) {
+		if name != "tiles/ground.tsx" {
+			return nil, fmt.Errorf("unexpected '%s' file", name)
+		}
+		return []byte(testTsxTileset), nil
+	})
+	if err != nil {
+		t.Fatalf("DecodeTileMap() error = %v", err)
+	}
+	return tileMap
+}
+
+func TestDecodeTmxMap(t *testing.T) {
+	tileMap := decodeTestTmxMap(t)
+	if tileMap.Width != 4 || tileMap.Height != 3 || tileMap.PixelWidth() != 64 || tileMap.PixelHeight() != 48 {
+		t.Fatalf("map dimension = %dx%d (%vx%v pixels), want 4x3 (64x48 pixels)",
+			tileMap.Width, tileMap.Height, tileMap.PixelWidth(), tileMap.PixelHeight())
+	}
+	if tileMap.Properties.String("music", "") != "level1" {
+		t.Fatalf("map 'music' property = %q, want level1", tileMap.Properties.String("music", ""))
+	}
+	if alpha := tileMap.BackgroundColor[3]; alpha < 0.5 || alpha > 0.51 || tileMap.BackgroundColor[0] != 1 {
+		t.Fatalf("BackgroundColor = %v, want red with alpha 0.5", tileMap.BackgroundColor)
+	}
+	if len(tileMap.Tilesets) != 2 {
+		t.Fatalf("len(Tilesets) = %d, want 2", len(tileMap.Tilesets))
+	}
+	ground := tileMap.Tilesets[0]
+	if ground.FirstGid != 1 || ground.Image != "tiles/ground.png" {
+		t.Fatalf("external tileset first gid = %d, image = %q, want 1, tiles/ground.png", ground.FirstGid, ground.Image)
+	}
+	if region := ground.TileRegion(12); region != BuildRectangle(2+2*17, 2+17, 16, 16) {
+		t.Fatalf("TileRegion(12) = %v, want [36 19 16 16]", region)
+	}
+	if info := tileMap.TileInfo(2); info == nil || info.Type != "grass" {
+		t.Fatalf("TileInfo(2) = %v, want grass tile", info)
+	}
+
+	if len(tileMap.TileLayers) != 2 || len(tileMap.ObjectLayers) != 1 {
+		t.Fatalf("layers = %d tile layers and %d object layers, want 2 and 1", len(tileMap.TileLayers), len(tileMap.ObjectLayers))
+	}
+	groundLayer := tileMap.TileLayer("ground")
+	if gid := groundLayer.Gid(3, 1); gid != TILE_FLIPPED_HORIZONTALLY|1 {
+		t.Fatalf("Gid(3, 1) = %x, want flipped tile 1", gid)
+	}
+	if tileset, tileId := tileMap.Tileset(groundLayer.Gid(0, 1)); tileset == nil || tileset.Name != "water" || tileId != 0 {
+		t.Fatalf("Tileset(101) = %v, %d, want water tileset and tile 0", tileset, tileId)
+	}
+	walls := tileMap.TileLayer("walls")
+	if walls.Offset != (mgl32.Vec2{4, 2}) || walls.Opacity != 0.5 || !walls.IsCollisionLayer() {
+		t.Fatalf("walls layer offset = %v, opacity = %v, collision = %v, want (4, 2), 0.5, true",
+			walls.Offset, walls.Opacity, walls.IsCollisionLayer())
+	}
+
+	spawns := tileMap.ObjectLayer("spawns")
+	if spawns.Visible {
+		t.Fatalf("spawns layer is visible, want hidden")
+	}
+	player := spawns.Object("player")
+	if player == nil || player.Type != "spawn" || player.Properties.String("direction", "") != "left" {
+		t.Fatalf("player object = %v, want spawn with direction property", player)
+	}
+	zone := spawns.Object("zone")
+	if bounds := zone.Bounds(); bounds != BuildRectangle(10, 0, 20, 10) {
+		t.Fatalf("zone Bounds() = %v, want [10 0 20 10]", bounds)
+	}
+	if len(spawns.ObjectsByType("spawn")) != 1 {
+		t.Fatalf("ObjectsByType(spawn) has %d objects, want 1", len(spawns.ObjectsByType("spawn")))
+	}
+}
+
+func TestTileMapCollision(t *testing.T) {
+	tileMap := decodeTestTmxMap(t)
+	// Calque "walls" : bloc 2x2 en haut à gauche et une tuile en bas à droite
+	// Calque "ground" : tuiles 2 (propriété collision) en (1, 0) et (2, 0)
+	if !tileMap.IsSolid(0, 0) || !tileMap.IsSolid(2, 0) || tileMap.IsSolid(3, 0) || tileMap.IsSolid(2, 2) {
+		t.Fatalf("IsSolid() inconsistent results")
+	}
+	if !tileMap.IsSolidAt(mgl32.Vec2{60, 40}) || tileMap.IsSolidAt(mgl32.Vec2{-1, 0}) {
+		t.Fatalf("IsSolidAt() inconsistent results")
+	}
+	rectangles := tileMap.CollisionRectangles()
+	want := []Rectangle{
+		BuildRectangle(0, 0, 48, 16),
+		BuildRectangle(0, 16, 32, 16),
+		BuildRectangle(48, 32, 16, 16),
+	}
+	if fmt.Sprint(rectangles) != fmt.Sprint(want) {
+		t.Fatalf("CollisionRectangles() = %v, want %v", rectangles, want)
+	}
+	if layers := tileMap.CollisionLayers(); len(layers) != 1 || layers[0].Name != "walls" {
+		t.Fatalf("CollisionLayers() = %v, want walls layer", layers)
+	}
+}
+
+func TestAnimatedTile(t *testing.T) {
+	tileMap := decodeTestTmxMap(t)
+	water := tileMap.Tilesets[1]
+	for _, test := range []struct {
+		timeInMs int64
+		want     uint32
+	}{{0, 0}, {99, 0}, {100, 1}, {299, 1}, {300, 0}} {
+		if tileId := water.animatedTileId(0, test.timeInMs); tileId != test.want {
+			t.Fatalf("animatedTileId(0, %d) = %d, want %d", test.timeInMs, tileId, test.want)
+		}
+	}
+	if tileId := water.animatedTileId(3, 100); tileId != 3 {
+		t.Fatalf("animatedTileId(3, 100) = %d, want 3 (tile is not animated)", tileId)
+	}
+}
+
+func TestTileSprite(t *testing.T) {
+	tileset := &Tileset{TileWidth: 16, TileHeight: 32, Columns: 4, TileCount: 8}
+	target := BuildRectangle(0, 0, 16, 32)
+	tests := []struct {
+		name     string
+		flags    uint32
+		source   Rectangle
+		center   mgl32.Vec2
+		rotation Angle
+	}{
+		{"none", 0, BuildRectangle(16, 0, 16, 32), mgl32.Vec2{8, 16}, 0},
+		{"horizontal", TILE_FLIPPED_HORIZONTALLY, BuildRectangle(32, 0, -16, 32), mgl32.Vec2{8, 16}, 0},
+		{"vertical", TILE_FLIPPED_VERTICALLY, BuildRectangle(16, 32, 16, -32), mgl32.Vec2{8, 16}, 0},
+		// Quart de tour : la tuile reste alignée sur le coin inférieur gauche de la case
+		{"diagonal", TILE_FLIPPED_DIAGONALLY, BuildRectangle(32, 0, -16, 32), mgl32.Vec2{16, 24}, math.Pi / 2},
+	}
+	for _, test := range tests {
+		sprite := tileSprite(tileset, 1, test.flags, target, White)
+		if sprite.Source != test.source || sprite.Center != test.center || sprite.Rotation != test.rotation || sprite.Size != target.Dim() {
+			t.Fatalf("tileSprite() %s = %+v, want source %v, center %v, rotation %v", test.name, sprite, test.source, test.center, test.rotation)
+		}
+	}
+}
+
+func TestDecodeTmjMap(t *testing.T) {
+	gids := []uint32{1, 0, 0, 2 | TILE_FLIPPED_VERTICALLY}
+	buffer := bytes.Buffer{}
+	writer := zlib.NewWriter(&buffer)
+	if err := binary.Write(writer, binary.LittleEndian, gids); err != nil {
+		t.Fatalf("binary.Write() error = %v", err)
+	}
+	writer.Close()
+	data := base64.StdEncoding.EncodeToString(buffer.Bytes())
+	content := `{
+		"orientation": "orthogonal", "width": 2, "height": 2, "tilewidth": 32, "tileheight": 32,
+		"properties": [{"name": "gravity", "type": "float", "value": 9.8}, {"name": "dark", "type": "bool", "value": true}],
+		"tilesets": [{"firstgid": 1, "name": "tiles", "tilewidth": 32, "tileheight": 32, "tilecount": 4, "columns": 2,
+			"image": "tiles.png", "imagewidth": 64, "imageheight": 64,
+			"tiles": [{"id": 1, "animation": [{"tileid": 1, "duration": 50}, {"tileid": 2, "duration": 50}]}]}],
+		"layers": [
+			{"type": "tilelayer", "name": "compressed", "width": 2, "height": 2, "encoding": "base64", "compression": "zlib", "data": "` + data + `"},
+			{"type": "group", "name": "group", "visible": false, "layers": [
+				{"type": "tilelayer", "name": "plain", "width": 2, "height": 2, "data": [0, 1, 1, 0]},
+				{"type": "objectgroup", "name": "objects", "objects": [
+					{"id": 1, "name": "exit", "type": "door", "x": 5, "y": 6, "width": 7, "height": 8, "properties": [{"name": "target", "type": "string", "value": "level2"}]}
+				]}
+			]}
+		]
+	}`
+	tileMap, err := DecodeTileMap([]byte(content), TMJ, nil)
+	if err != nil {
+		t.Fatalf("DecodeTileMap() error = %v", err)
+	}
+	if gravity := tileMap.Properties.Float("gravity", 0); gravity != 9.8 || !tileMap.Properties.Bool("dark", false) {
+		t.Fatalf("map properties = %v, want gravity 9.8 and dark", tileMap.Properties)
+	}
+	compressed := tileMap.TileLayer("compressed")
+	if fmt.Sprint(compressed.Data) != fmt.Sprint(gids) {
+		t.Fatalf("compressed layer data = %v, want %v", compressed.Data, gids)
+	}
+	plain := tileMap.TileLayer("plain")
+	if plain.Visible || plain.Gid(1, 0) != 1 {
+		t.Fatalf("plain layer visible = %v, Gid(1, 0) = %d, want hidden layer and tile 1", plain.Visible, plain.Gid(1, 0))
+	}
+	exit := tileMap.ObjectLayer("objects").Object("exit")
+	if exit == nil || exit.Type != "door" || exit.Properties.String("target", "") != "level2" || exit.Bounds() != BuildRectangle(5, 6, 7, 8) {
+		t.Fatalf("exit object = %v, want door to level2", exit)
+	}
+	if info := tileMap.TileInfo(2); info == nil || len(info.Animation) != 2 {
+		t.Fatalf("TileInfo(2) = %v, want animated tile", info)
+	}
+}
+
+func TestDecodeTileMapErrors(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		content string
+		format  TileMapFormat
+	}{
+		{"unsupported format", `{}`, ".txt"},
+		{"isometric map", `{"orientation": "isometric"}`, TMJ},
+		{"infinite map", `<map orientation="orthogonal" infinite="1"></map>`, TMX},
+		{"bad tile count", `{"width": 2, "height": 2, "layers": [{"type": "tilelayer", "width": 2, "height": 2, "data": [1]}]}`, TMJ},
+		{"missing external tileset", `<map><tileset firstgid="1" source="missing.tsx"/></map>`, TMX},
+	} {
+		if _, err := DecodeTileMap([]byte(test.content), test.format, nil); err == nil {
+			t.Fatalf("DecodeTileMap() with %s error = nil, want error", test.name)
+		}
+	}
+}
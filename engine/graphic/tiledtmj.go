@@ -0,0 +1,345 @@
+package graphic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// tmjProperty is a custom property in TMJ format (value type depends on property type).
+type tmjProperty struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// tmjProperties are custom properties in TMJ format.
+type tmjProperties []tmjProperty
+
+func (properties tmjProperties) toProperties() Properties {
+	result := make(Properties)
+	for _, property := range properties {
+		value := string(bytes.TrimSpace(property.Value))
+		// Les chaînes sont décodées, les autres valeurs (nombres, booléens) sont conservées telles quelles
+		text := ""
+		if err := json.Unmarshal(property.Value, &text); err == nil {
+			value = text
+		}
+		result[property.Name] = value
+	}
+	return result
+}
+
+// tmjFrame is a frame of an animated tile in TMJ format.
+type tmjFrame struct {
+	TileId   uint32 `json:"tileid"`
+	Duration int64  `json:"duration"`
+}
+
+// tmjTile contains information on a tile of a tileset in TMJ format.
+type tmjTile struct {
+	Id          uint32        `json:"id"`
+	Type        string        `json:"type"`
+	Class       string        `json:"class"`
+	Properties  tmjProperties `json:"properties"`
+	Animation   []tmjFrame    `json:"animation"`
+	ObjectGroup *struct {
+		Objects []tmjObject `json:"objects"`
+	} `json:"objectgroup"`
+}
+
+// tmjTileset is a tileset in TMJ format (inline or external when source is defined).
+type tmjTileset struct {
+	FirstGid    uint32        `json:"firstgid"`
+	Source      string        `json:"source"`
+	Name        string        `json:"name"`
+	TileWidth   int32         `json:"tilewidth"`
+	TileHeight  int32         `json:"tileheight"`
+	Spacing     int32         `json:"spacing"`
+	Margin      int32         `json:"margin"`
+	TileCount   uint32        `json:"tilecount"`
+	Columns     int32         `json:"columns"`
+	Image       string        `json:"image"`
+	ImageWidth  int32         `json:"imagewidth"`
+	ImageHeight int32         `json:"imageheight"`
+	TileOffset  *tmjPoint     `json:"tileoffset"`
+	Properties  tmjProperties `json:"properties"`
+	Tiles       []tmjTile     `json:"tiles"`
+}
+
+func (jsonTileset *tmjTileset) toTileset() (*Tileset, error) {
+	tileset := &Tileset{
+		FirstGid:    jsonTileset.FirstGid,
+		Name:        jsonTileset.Name,
+		TileWidth:   jsonTileset.TileWidth,
+		TileHeight:  jsonTileset.TileHeight,
+		Spacing:     jsonTileset.Spacing,
+		Margin:      jsonTileset.Margin,
+		TileCount:   jsonTileset.TileCount,
+		Columns:     jsonTileset.Columns,
+		Image:       jsonTileset.Image,
+		ImageWidth:  jsonTileset.ImageWidth,
+		ImageHeight: jsonTileset.ImageHeight,
+		Properties:  jsonTileset.Properties.toProperties(),
+		Tiles:       make(map[uint32]*TileInfo),
+	}
+	if jsonTileset.TileOffset != nil {
+		tileset.TileOffset = mgl32.Vec2{jsonTileset.TileOffset.X, jsonTileset.TileOffset.Y}
+	}
+	for _, tile := range jsonTileset.Tiles {
+		info := &TileInfo{
+			Type:       tile.Type,
+			Properties: tile.Properties.toProperties(),
+			Animation:  make([]TileFrame, 0, len(tile.Animation)),
+			Objects:    make([]*MapObject, 0),
+		}
+		if tile.Class != "" {
+			info.Type = tile.Class
+		}
+		for _, frame := range tile.Animation {
+			info.Animation = append(info.Animation, TileFrame{TileId: frame.TileId, Duration: frame.Duration})
+		}
+		if tile.ObjectGroup != nil {
+			for index := range tile.ObjectGroup.Objects {
+				info.Objects = append(info.Objects, tile.ObjectGroup.Objects[index].toMapObject())
+			}
+		}
+		tileset.Tiles[tile.Id] = info
+	}
+	return tileset, nil
+}
+
+// tmjPoint is a position in TMJ format.
+type tmjPoint struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// tmjObject is an object in TMJ format.
+type tmjObject struct {
+	Id         int32         `json:"id"`
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Class      string        `json:"class"`
+	X          float32       `json:"x"`
+	Y          float32       `json:"y"`
+	Width      float32       `json:"width"`
+	Height     float32       `json:"height"`
+	Rotation   float32       `json:"rotation"`
+	Gid        uint32        `json:"gid"`
+	Visible    *bool         `json:"visible"`
+	Ellipse    bool          `json:"ellipse"`
+	Point      bool          `json:"point"`
+	Polygon    []tmjPoint    `json:"polygon"`
+	Polyline   []tmjPoint    `json:"polyline"`
+	Properties tmjProperties `json:"properties"`
+	Text       *struct {
+		Text string `json:"text"`
+	} `json:"text"`
+}
+
+func (jsonObject *tmjObject) toMapObject() *MapObject {
+	object := &MapObject{
+		Id:         jsonObject.Id,
+		Name:       jsonObject.Name,
+		Type:       jsonObject.Type,
+		X:          jsonObject.X,
+		Y:          jsonObject.Y,
+		Width:      jsonObject.Width,
+		Height:     jsonObject.Height,
+		Rotation:   jsonObject.Rotation,
+		Gid:        jsonObject.Gid,
+		Visible:    jsonObject.Visible == nil || *jsonObject.Visible,
+		Ellipse:    jsonObject.Ellipse,
+		Point:      jsonObject.Point,
+		Polygon:    toTiledPoints(jsonObject.Polygon),
+		Polyline:   toTiledPoints(jsonObject.Polyline),
+		Properties: jsonObject.Properties.toProperties(),
+	}
+	if jsonObject.Class != "" {
+		object.Type = jsonObject.Class
+	}
+	if jsonObject.Text != nil {
+		object.Text = jsonObject.Text.Text
+	}
+	return object
+}
+
+// toTiledPoints converts TMJ points (nil if there is no point).
+func toTiledPoints(jsonPoints []tmjPoint) []mgl32.Vec2 {
+	if len(jsonPoints) == 0 {
+		return nil
+	}
+	points := make([]mgl32.Vec2, 0, len(jsonPoints))
+	for _, point := range jsonPoints {
+		points = append(points, mgl32.Vec2{point.X, point.Y})
+	}
+	return points
+}
+
+// tmjLayer is a layer in TMJ format ("tilelayer", "objectgroup", "group" or "imagelayer" type).
+type tmjLayer struct {
+	Type        string        `json:"type"`
+	Name        string        `json:"name"`
+	Width       int32         `json:"width"`
+	Height      int32         `json:"height"`
+	Visible     *bool         `json:"visible"`
+	Opacity     *float32      `json:"opacity"`
+	OffsetX     float32       `json:"offsetx"`
+	OffsetY     float32       `json:"offsety"`
+	Properties  tmjProperties `json:"properties"`
+	Encoding    string        `json:"encoding"`
+	Compression string        `json:"compression"`
+	// Data est un tableau d'identifiants ou une chaîne encodée en base64
+	Data    json.RawMessage `json:"data"`
+	Chunks  json.RawMessage `json:"chunks"`
+	Objects []tmjObject     `json:"objects"`
+	Layers  []tmjLayer      `json:"layers"`
+}
+
+// tmjMap is a map in TMJ format.
+type tmjMap struct {
+	Orientation     string        `json:"orientation"`
+	Infinite        bool          `json:"infinite"`
+	Width           int32         `json:"width"`
+	Height          int32         `json:"height"`
+	TileWidth       int32         `json:"tilewidth"`
+	TileHeight      int32         `json:"tileheight"`
+	BackgroundColor string        `json:"backgroundcolor"`
+	Properties      tmjProperties `json:"properties"`
+	Tilesets        []tmjTileset  `json:"tilesets"`
+	Layers          []tmjLayer    `json:"layers"`
+}
+
+// decodeTmjMap decodes a map in TMJ format.
+func decodeTmjMap(content []byte, readFile TileMapReadFunc) (*TileMap, error) {
+	jsonMap := tmjMap{}
+	if err := json.Unmarshal(content, &jsonMap); err != nil {
+		return nil, fmt.Errorf("failed to decode TMJ map\n - %w", err)
+	}
+	if err := checkTileMap(jsonMap.Orientation, jsonMap.Infinite); err != nil {
+		return nil, err
+	}
+	tileMap := &TileMap{
+		Width:        jsonMap.Width,
+		Height:       jsonMap.Height,
+		TileWidth:    jsonMap.TileWidth,
+		TileHeight:   jsonMap.TileHeight,
+		Properties:   jsonMap.Properties.toProperties(),
+		Tilesets:     make([]*Tileset, 0, len(jsonMap.Tilesets)),
+		TileLayers:   make([]*TileLayer, 0),
+		ObjectLayers: make([]*ObjectLayer, 0),
+	}
+	if jsonMap.BackgroundColor != "" {
+		color, err := parseTiledColor(jsonMap.BackgroundColor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode TMJ map background color\n - %w", err)
+		}
+		tileMap.BackgroundColor = color
+	}
+	for index := range jsonMap.Tilesets {
+		jsonTileset := &jsonMap.Tilesets[index]
+		var tileset *Tileset
+		var err error
+		if jsonTileset.Source != "" {
+			tileset, err = readExternalTileset(jsonTileset.Source, readFile)
+		} else {
+			tileset, err = jsonTileset.toTileset()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode TMJ map tileset\n - %w", err)
+		}
+		tileset.FirstGid = jsonTileset.FirstGid
+		tileMap.Tilesets = append(tileMap.Tilesets, tileset)
+	}
+	if err := addTmjLayers(tileMap, jsonMap.Layers, rootLayerContext); err != nil {
+		return nil, err
+	}
+	return tileMap, nil
+}
+
+// addTmjLayers adds layers (and layers of groups) to map.
+func addTmjLayers(tileMap *TileMap, jsonLayers []tmjLayer, context layerContext) error {
+	for index := range jsonLayers {
+		jsonLayer := &jsonLayers[index]
+		opacity := float32(1.)
+		if jsonLayer.Opacity != nil {
+			opacity = *jsonLayer.Opacity
+		}
+		visible := jsonLayer.Visible == nil || *jsonLayer.Visible
+		layerContext := context.child(mgl32.Vec2{jsonLayer.OffsetX, jsonLayer.OffsetY}, opacity, visible)
+		switch jsonLayer.Type {
+		case "tilelayer":
+			layer, err := jsonLayer.toTileLayer(layerContext)
+			if err != nil {
+				return fmt.Errorf("failed to decode TMJ '%s' layer\n - %w", jsonLayer.Name, err)
+			}
+			tileMap.TileLayers = append(tileMap.TileLayers, layer)
+		case "objectgroup":
+			layer := &ObjectLayer{
+				Name:       jsonLayer.Name,
+				Visible:    layerContext.visible,
+				Opacity:    layerContext.opacity,
+				Offset:     layerContext.offset,
+				Properties: jsonLayer.Properties.toProperties(),
+				Objects:    make([]*MapObject, 0, len(jsonLayer.Objects)),
+			}
+			for objectIndex := range jsonLayer.Objects {
+				layer.Objects = append(layer.Objects, jsonLayer.Objects[objectIndex].toMapObject())
+			}
+			tileMap.ObjectLayers = append(tileMap.ObjectLayers, layer)
+		case "group":
+			if err := addTmjLayers(tileMap, jsonLayer.Layers, layerContext); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (jsonLayer *tmjLayer) toTileLayer(context layerContext) (*TileLayer, error) {
+	layer := &TileLayer{
+		Name:       jsonLayer.Name,
+		Width:      jsonLayer.Width,
+		Height:     jsonLayer.Height,
+		Visible:    context.visible,
+		Opacity:    context.opacity,
+		Offset:     context.offset,
+		Properties: jsonLayer.Properties.toProperties(),
+	}
+	if len(jsonLayer.Chunks) > 0 {
+		return nil, fmt.Errorf("infinite maps are not supported")
+	}
+	count := int(layer.Width * layer.Height)
+	raw := bytes.TrimSpace(jsonLayer.Data)
+	if len(raw) == 0 {
+		layer.Data = make([]uint32, count)
+		return layer, nil
+	}
+	if raw[0] == '"' {
+		text := ""
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, fmt.Errorf("failed to decode layer data\n - %w", err)
+		}
+		encoding := jsonLayer.Encoding
+		if encoding == "" {
+			encoding = "base64"
+		}
+		data, err := decodeTileData(text, encoding, jsonLayer.Compression, count)
+		if err != nil {
+			return nil, err
+		}
+		layer.Data = data
+		return layer, nil
+	}
+	gids := make([]uint32, 0, count)
+	if err := json.Unmarshal(raw, &gids); err != nil {
+		return nil, fmt.Errorf("failed to decode layer data\n - %w", err)
+	}
+	data, err := checkTileData(gids, count)
+	if err != nil {
+		return nil, err
+	}
+	layer.Data = data
+	return layer, nil
+}
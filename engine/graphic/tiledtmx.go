@@ -0,0 +1,356 @@
+package graphic
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"strings"
+)
+
+// tmxProperty is a custom property in TMX format (multiline string values are in element text).
+type tmxProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// tmxProperties are custom properties in TMX format.
+type tmxProperties struct {
+	Properties []tmxProperty `xml:"property"`
+}
+
+func (properties *tmxProperties) toProperties() Properties {
+	result := make(Properties)
+	if properties == nil {
+		return result
+	}
+	for _, property := range properties.Properties {
+		if property.Value == "" && strings.TrimSpace(property.Text) != "" {
+			result[property.Name] = property.Text
+		} else {
+			result[property.Name] = property.Value
+		}
+	}
+	return result
+}
+
+// tmxFrame is a frame of an animated tile in TMX format.
+type tmxFrame struct {
+	TileId   uint32 `xml:"tileid,attr"`
+	Duration int64  `xml:"duration,attr"`
+}
+
+// tmxTile contains information on a tile of a tileset in TMX format.
+type tmxTile struct {
+	Id         uint32         `xml:"id,attr"`
+	Type       string         `xml:"type,attr"`
+	Class      string         `xml:"class,attr"`
+	Properties *tmxProperties `xml:"properties"`
+	Animation  []tmxFrame     `xml:"animation>frame"`
+	Objects    []tmxObject    `xml:"objectgroup>object"`
+}
+
+// tmxImage is an image in TMX format.
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+	Width  int32  `xml:"width,attr"`
+	Height int32  `xml:"height,attr"`
+}
+
+// tmxTileset is a tileset in TMX format (inline or external when source is defined).
+type tmxTileset struct {
+	FirstGid   uint32         `xml:"firstgid,attr"`
+	Source     string         `xml:"source,attr"`
+	Name       string         `xml:"name,attr"`
+	TileWidth  int32          `xml:"tilewidth,attr"`
+	TileHeight int32          `xml:"tileheight,attr"`
+	Spacing    int32          `xml:"spacing,attr"`
+	Margin     int32          `xml:"margin,attr"`
+	TileCount  uint32         `xml:"tilecount,attr"`
+	Columns    int32          `xml:"columns,attr"`
+	TileOffset *tmxPoint      `xml:"tileoffset"`
+	Image      *tmxImage      `xml:"image"`
+	Properties *tmxProperties `xml:"properties"`
+	Tiles      []tmxTile      `xml:"tile"`
+}
+
+func (xmlTileset *tmxTileset) toTileset() (*Tileset, error) {
+	tileset := &Tileset{
+		FirstGid:   xmlTileset.FirstGid,
+		Name:       xmlTileset.Name,
+		TileWidth:  xmlTileset.TileWidth,
+		TileHeight: xmlTileset.TileHeight,
+		Spacing:    xmlTileset.Spacing,
+		Margin:     xmlTileset.Margin,
+		TileCount:  xmlTileset.TileCount,
+		Columns:    xmlTileset.Columns,
+		Properties: xmlTileset.Properties.toProperties(),
+		Tiles:      make(map[uint32]*TileInfo),
+	}
+	if xmlTileset.TileOffset != nil {
+		tileset.TileOffset = mgl32.Vec2{xmlTileset.TileOffset.X, xmlTileset.TileOffset.Y}
+	}
+	if xmlTileset.Image != nil {
+		tileset.Image = xmlTileset.Image.Source
+		tileset.ImageWidth = xmlTileset.Image.Width
+		tileset.ImageHeight = xmlTileset.Image.Height
+	}
+	for _, tile := range xmlTileset.Tiles {
+		info := &TileInfo{
+			Type:       tile.Type,
+			Properties: tile.Properties.toProperties(),
+			Animation:  make([]TileFrame, 0, len(tile.Animation)),
+			Objects:    make([]*MapObject, 0, len(tile.Objects)),
+		}
+		if tile.Class != "" {
+			info.Type = tile.Class
+		}
+		for _, frame := range tile.Animation {
+			info.Animation = append(info.Animation, TileFrame{TileId: frame.TileId, Duration: frame.Duration})
+		}
+		for index := range tile.Objects {
+			object, err := tile.Objects[index].toMapObject()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode tile %d collision shapes\n - %w", tile.Id, err)
+			}
+			info.Objects = append(info.Objects, object)
+		}
+		tileset.Tiles[tile.Id] = info
+	}
+	return tileset, nil
+}
+
+// tmxPoint is a position in TMX format.
+type tmxPoint struct {
+	X float32 `xml:"x,attr"`
+	Y float32 `xml:"y,attr"`
+}
+
+// tmxPoints is a list of points in TMX format.
+type tmxPoints struct {
+	Points string `xml:"points,attr"`
+}
+
+// tmxObject is an object in TMX format.
+type tmxObject struct {
+	Id         int32          `xml:"id,attr"`
+	Name       string         `xml:"name,attr"`
+	Type       string         `xml:"type,attr"`
+	Class      string         `xml:"class,attr"`
+	X          float32        `xml:"x,attr"`
+	Y          float32        `xml:"y,attr"`
+	Width      float32        `xml:"width,attr"`
+	Height     float32        `xml:"height,attr"`
+	Rotation   float32        `xml:"rotation,attr"`
+	Gid        uint32         `xml:"gid,attr"`
+	Visible    string         `xml:"visible,attr"`
+	Properties *tmxProperties `xml:"properties"`
+	Ellipse    *struct{}      `xml:"ellipse"`
+	Point      *struct{}      `xml:"point"`
+	Polygon    *tmxPoints     `xml:"polygon"`
+	Polyline   *tmxPoints     `xml:"polyline"`
+	Text       string         `xml:"text"`
+}
+
+func (xmlObject *tmxObject) toMapObject() (*MapObject, error) {
+	object := &MapObject{
+		Id:         xmlObject.Id,
+		Name:       xmlObject.Name,
+		Type:       xmlObject.Type,
+		X:          xmlObject.X,
+		Y:          xmlObject.Y,
+		Width:      xmlObject.Width,
+		Height:     xmlObject.Height,
+		Rotation:   xmlObject.Rotation,
+		Gid:        xmlObject.Gid,
+		Visible:    xmlObject.Visible != "0",
+		Ellipse:    xmlObject.Ellipse != nil,
+		Point:      xmlObject.Point != nil,
+		Text:       xmlObject.Text,
+		Properties: xmlObject.Properties.toProperties(),
+	}
+	if xmlObject.Class != "" {
+		object.Type = xmlObject.Class
+	}
+	var err error
+	if xmlObject.Polygon != nil {
+		if object.Polygon, err = parseTiledPoints(xmlObject.Polygon.Points); err != nil {
+			return nil, fmt.Errorf("failed to decode '%s' object polygon\n - %w", object.Name, err)
+		}
+	}
+	if xmlObject.Polyline != nil {
+		if object.Polyline, err = parseTiledPoints(xmlObject.Polyline.Points); err != nil {
+			return nil, fmt.Errorf("failed to decode '%s' object polyline\n - %w", object.Name, err)
+		}
+	}
+	return object, nil
+}
+
+// tmxData is layer data in TMX format.
+type tmxData struct {
+	Encoding    string `xml:"encoding,attr"`
+	Compression string `xml:"compression,attr"`
+	Tiles       []struct {
+		Gid uint32 `xml:"gid,attr"`
+	} `xml:"tile"`
+	Chunks []struct{} `xml:"chunk"`
+	Text   string     `xml:",chardata"`
+}
+
+// tmxLayer is a layer in TMX format ("layer", "objectgroup", "group" or "imagelayer" element).
+type tmxLayer struct {
+	XMLName    xml.Name
+	Name       string         `xml:"name,attr"`
+	Width      int32          `xml:"width,attr"`
+	Height     int32          `xml:"height,attr"`
+	Visible    string         `xml:"visible,attr"`
+	Opacity    *float32       `xml:"opacity,attr"`
+	OffsetX    float32        `xml:"offsetx,attr"`
+	OffsetY    float32        `xml:"offsety,attr"`
+	Properties *tmxProperties `xml:"properties"`
+	Data       *tmxData       `xml:"data"`
+	Objects    []tmxObject    `xml:"object"`
+	// Layers contient les calques d'un groupe (dans l'ordre du document)
+	Layers []tmxLayer `xml:",any"`
+}
+
+// tmxMap is a map in TMX format.
+type tmxMap struct {
+	Orientation     string         `xml:"orientation,attr"`
+	Infinite        bool           `xml:"infinite,attr"`
+	Width           int32          `xml:"width,attr"`
+	Height          int32          `xml:"height,attr"`
+	TileWidth       int32          `xml:"tilewidth,attr"`
+	TileHeight      int32          `xml:"tileheight,attr"`
+	BackgroundColor string         `xml:"backgroundcolor,attr"`
+	Properties      *tmxProperties `xml:"properties"`
+	Tilesets        []tmxTileset   `xml:"tileset"`
+	Layers          []tmxLayer     `xml:",any"`
+}
+
+// decodeTmxMap decodes a map in TMX format.
+func decodeTmxMap(content []byte, readFile TileMapReadFunc) (*TileMap, error) {
+	xmlMap := tmxMap{}
+	if err := xml.Unmarshal(content, &xmlMap); err != nil {
+		return nil, fmt.Errorf("failed to decode TMX map\n - %w", err)
+	}
+	if err := checkTileMap(xmlMap.Orientation, xmlMap.Infinite); err != nil {
+		return nil, err
+	}
+	tileMap := &TileMap{
+		Width:        xmlMap.Width,
+		Height:       xmlMap.Height,
+		TileWidth:    xmlMap.TileWidth,
+		TileHeight:   xmlMap.TileHeight,
+		Properties:   xmlMap.Properties.toProperties(),
+		Tilesets:     make([]*Tileset, 0, len(xmlMap.Tilesets)),
+		TileLayers:   make([]*TileLayer, 0),
+		ObjectLayers: make([]*ObjectLayer, 0),
+	}
+	if xmlMap.BackgroundColor != "" {
+		color, err := parseTiledColor(xmlMap.BackgroundColor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode TMX map background color\n - %w", err)
+		}
+		tileMap.BackgroundColor = color
+	}
+	for index := range xmlMap.Tilesets {
+		xmlTileset := &xmlMap.Tilesets[index]
+		var tileset *Tileset
+		var err error
+		if xmlTileset.Source != "" {
+			tileset, err = readExternalTileset(xmlTileset.Source, readFile)
+		} else {
+			tileset, err = xmlTileset.toTileset()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode TMX map tileset\n - %w", err)
+		}
+		tileset.FirstGid = xmlTileset.FirstGid
+		tileMap.Tilesets = append(tileMap.Tilesets, tileset)
+	}
+	if err := addTmxLayers(tileMap, xmlMap.Layers, rootLayerContext); err != nil {
+		return nil, err
+	}
+	return tileMap, nil
+}
+
+// addTmxLayers adds layers (and layers of groups) to map.
+func addTmxLayers(tileMap *TileMap, xmlLayers []tmxLayer, context layerContext) error {
+	for index := range xmlLayers {
+		xmlLayer := &xmlLayers[index]
+		opacity := float32(1.)
+		if xmlLayer.Opacity != nil {
+			opacity = *xmlLayer.Opacity
+		}
+		layerContext := context.child(mgl32.Vec2{xmlLayer.OffsetX, xmlLayer.OffsetY}, opacity, xmlLayer.Visible != "0")
+		switch xmlLayer.XMLName.Local {
+		case "layer":
+			layer, err := xmlLayer.toTileLayer(layerContext)
+			if err != nil {
+				return fmt.Errorf("failed to decode TMX '%s' layer\n - %w", xmlLayer.Name, err)
+			}
+			tileMap.TileLayers = append(tileMap.TileLayers, layer)
+		case "objectgroup":
+			layer := &ObjectLayer{
+				Name:       xmlLayer.Name,
+				Visible:    layerContext.visible,
+				Opacity:    layerContext.opacity,
+				Offset:     layerContext.offset,
+				Properties: xmlLayer.Properties.toProperties(),
+				Objects:    make([]*MapObject, 0, len(xmlLayer.Objects)),
+			}
+			for objectIndex := range xmlLayer.Objects {
+				object, err := xmlLayer.Objects[objectIndex].toMapObject()
+				if err != nil {
+					return fmt.Errorf("failed to decode TMX '%s' object layer\n - %w", xmlLayer.Name, err)
+				}
+				layer.Objects = append(layer.Objects, object)
+			}
+			tileMap.ObjectLayers = append(tileMap.ObjectLayers, layer)
+		case "group":
+			if err := addTmxLayers(tileMap, xmlLayer.Layers, layerContext); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (xmlLayer *tmxLayer) toTileLayer(context layerContext) (*TileLayer, error) {
+	layer := &TileLayer{
+		Name:       xmlLayer.Name,
+		Width:      xmlLayer.Width,
+		Height:     xmlLayer.Height,
+		Visible:    context.visible,
+		Opacity:    context.opacity,
+		Offset:     context.offset,
+		Properties: xmlLayer.Properties.toProperties(),
+	}
+	count := int(layer.Width * layer.Height)
+	if xmlLayer.Data == nil {
+		layer.Data = make([]uint32, count)
+		return layer, nil
+	}
+	if len(xmlLayer.Data.Chunks) > 0 {
+		return nil, fmt.Errorf("infinite maps are not supported")
+	}
+	if xmlLayer.Data.Encoding == "" {
+		gids := make([]uint32, 0, len(xmlLayer.Data.Tiles))
+		for _, tile := range xmlLayer.Data.Tiles {
+			gids = append(gids, tile.Gid)
+		}
+		data, err := checkTileData(gids, count)
+		if err != nil {
+			return nil, err
+		}
+		layer.Data = data
+		return layer, nil
+	}
+	data, err := decodeTileData(xmlLayer.Data.Text, xmlLayer.Data.Encoding, xmlLayer.Data.Compression, count)
+	if err != nil {
+		return nil, err
+	}
+	layer.Data = data
+	return layer, nil
+}
@@ -0,0 +1,545 @@
+package graphic
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"strconv"
+)
+
+// Tiled global tile identifier flags (stored in the highest bits of a tile identifier).
+const (
+	TILE_FLIPPED_HORIZONTALLY uint32 = 0x80000000
+	TILE_FLIPPED_VERTICALLY   uint32 = 0x40000000
+	TILE_FLIPPED_DIAGONALLY   uint32 = 0x20000000
+	TILE_ROTATED_HEXAGONAL    uint32 = 0x10000000
+	// TILE_FLAGS contains all flags
+	TILE_FLAGS = TILE_FLIPPED_HORIZONTALLY | TILE_FLIPPED_VERTICALLY | TILE_FLIPPED_DIAGONALLY | TILE_ROTATED_HEXAGONAL
+)
+
+// COLLISION_PROPERTY is the boolean property marking collision layers (or solid tiles of a tileset).
+const COLLISION_PROPERTY = "collision"
+
+// Properties contains Tiled custom properties (values are kept as text).
+type Properties map[string]string
+
+// String returns property value (defaultValue if property is not defined).
+func (properties Properties) String(name string, defaultValue string) string {
+	if value, found := properties[name]; found {
+		return value
+	}
+	return defaultValue
+}
+
+// Bool returns boolean property value (defaultValue if property is not defined or is not a boolean).
+func (properties Properties) Bool(name string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(properties[name]); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// Int returns integer property value (defaultValue if property is not defined or is not an integer).
+func (properties Properties) Int(name string, defaultValue int) int {
+	if value, err := strconv.Atoi(properties[name]); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// Float returns float property value (defaultValue if property is not defined or is not a number).
+func (properties Properties) Float(name string, defaultValue float32) float32 {
+	if value, err := strconv.ParseFloat(properties[name], 32); err == nil {
+		return float32(value)
+	}
+	return defaultValue
+}
+
+// TileFrame is a frame of an animated tile.
+type TileFrame struct {
+	// TileId est l'identifiant local (dans le tileset) de la tuile à afficher
+	TileId uint32
+	// Duration est la durée d'affichage en millisecondes
+	Duration int64
+}
+
+// TileInfo contains information on a tile of a tileset.
+type TileInfo struct {
+	// Type est la classe de la tuile
+	Type       string
+	Properties Properties
+	// Animation contient les frames d'une tuile animée (vide si la tuile n'est pas animée)
+	Animation []TileFrame
+	// Objects contient les formes de collision définies dans l'éditeur de tuile (coordonnées relatives à la tuile)
+	Objects []*MapObject
+}
+
+// Tileset is a set of tiles from one image.
+type Tileset struct {
+	// FirstGid est l'identifiant global de la première tuile
+	FirstGid   uint32
+	Name       string
+	TileWidth  int32
+	TileHeight int32
+	Spacing    int32
+	Margin     int32
+	TileCount  uint32
+	Columns    int32
+	// Image est le chemin de l'image (relatif au fichier de la carte ou du tileset)
+	Image       string
+	ImageWidth  int32
+	ImageHeight int32
+	// TileOffset est le décalage appliqué au dessin des tuiles
+	TileOffset mgl32.Vec2
+	Properties Properties
+	// Tiles contient les informations des tuiles par identifiant local
+	Tiles map[uint32]*TileInfo
+	// Texture est la texture de l'image (nil tant que les textures ne sont pas chargées)
+	Texture *Texture
+	// sprites est le tampon des tuiles visibles d'un calque (réutilisé d'un dessin à l'autre)
+	sprites []BatchSprite
+}
+
+// Contains indicates if global tile identifier (without flags) belongs to tileset.
+func (tileset *Tileset) Contains(gid uint32) bool {
+	return gid >= tileset.FirstGid && gid < tileset.FirstGid+tileset.TileCount
+}
+
+// TileRegion returns tile region on tileset image.
+func (tileset *Tileset) TileRegion(tileId uint32) Rectangle {
+	columns := max(tileset.Columns, 1)
+	column := int32(tileId) % columns
+	row := int32(tileId) / columns
+	return BuildRectangle(
+		float32(tileset.Margin+column*(tileset.TileWidth+tileset.Spacing)),
+		float32(tileset.Margin+row*(tileset.TileHeight+tileset.Spacing)),
+		float32(tileset.TileWidth),
+		float32(tileset.TileHeight),
+	)
+}
+
+// animatedTileId returns local identifier of the tile displayed at timeInMs (tileId if tile is not animated).
+func (tileset *Tileset) animatedTileId(tileId uint32, timeInMs int64) uint32 {
+	info := tileset.Tiles[tileId]
+	if info == nil || len(info.Animation) == 0 {
+		return tileId
+	}
+	duration := int64(0)
+	for _, frame := range info.Animation {
+		duration += frame.Duration
+	}
+	if duration <= 0 {
+		return info.Animation[0].TileId
+	}
+	position := timeInMs % duration
+	for _, frame := range info.Animation {
+		if position < frame.Duration {
+			return frame.TileId
+		}
+		position -= frame.Duration
+	}
+	return info.Animation[len(info.Animation)-1].TileId
+}
+
+// TileLayer is a layer of tiles.
+type TileLayer struct {
+	Name    string
+	Width   int32
+	Height  int32
+	Visible bool
+	Opacity float32
+	// Offset est le décalage du calque en pixels
+	Offset     mgl32.Vec2
+	Properties Properties
+	// Data contient les identifiants globaux des tuiles (avec leurs flags), ligne par ligne (0 = pas de tuile)
+	Data []uint32
+}
+
+// Gid returns global tile identifier (with flags) at column and row (0 if there is no tile or position is outside layer).
+func (layer *TileLayer) Gid(column, row int32) uint32 {
+	if column < 0 || row < 0 || column >= layer.Width || row >= layer.Height {
+		return 0
+	}
+	return layer.Data[row*layer.Width+column]
+}
+
+// SetGid modifies global tile identifier (with flags) at column and row.
+func (layer *TileLayer) SetGid(column, row int32, gid uint32) {
+	if column < 0 || row < 0 || column >= layer.Width || row >= layer.Height {
+		return
+	}
+	layer.Data[row*layer.Width+column] = gid
+}
+
+// IsCollisionLayer indicates if layer is a collision layer ("collision" property is true).
+func (layer *TileLayer) IsCollisionLayer() bool {
+	return layer.Properties.Bool(COLLISION_PROPERTY, false)
+}
+
+// MapObject is an object of an object layer.
+type MapObject struct {
+	Id       int32
+	Name     string
+	Type     string
+	X        float32
+	Y        float32
+	Width    float32
+	Height   float32
+	Rotation float32
+	// Gid est l'identifiant global de la tuile d'un objet tuile (0 pour les autres objets)
+	Gid     uint32
+	Visible bool
+	Ellipse bool
+	Point   bool
+	// Polygon contient les points d'un polygone (relatifs à la position de l'objet)
+	Polygon []mgl32.Vec2
+	// Polyline contient les points d'une ligne brisée (relatifs à la position de l'objet)
+	Polyline   []mgl32.Vec2
+	Text       string
+	Properties Properties
+}
+
+// Bounds returns object bounding box (rotation is ignored).
+func (object *MapObject) Bounds() Rectangle {
+	points := object.Polygon
+	if len(points) == 0 {
+		points = object.Polyline
+	}
+	if len(points) > 0 {
+		minimum, maximum := points[0], points[0]
+		for _, point := range points[1:] {
+			minimum = mgl32.Vec2{min(minimum.X(), point.X()), min(minimum.Y(), point.Y())}
+			maximum = mgl32.Vec2{max(maximum.X(), point.X()), max(maximum.Y(), point.Y())}
+		}
+		return BuildRectangle(object.X+minimum.X(), object.Y+minimum.Y(), maximum.X()-minimum.X(), maximum.Y()-minimum.Y())
+	}
+	if object.Gid != 0 {
+		// Les objets tuiles sont positionnés par leur coin inférieur gauche
+		return BuildRectangle(object.X, object.Y-object.Height, object.Width, object.Height)
+	}
+	return BuildRectangle(object.X, object.Y, object.Width, object.Height)
+}
+
+// WorldPolygon returns polygon points in map coordinates (nil if object is not a polygon).
+func (object *MapObject) WorldPolygon() Polygon {
+	if len(object.Polygon) == 0 {
+		return nil
+	}
+	polygon := make(Polygon, len(object.Polygon))
+	for index, point := range object.Polygon {
+		polygon[index] = point.Add(mgl32.Vec2{object.X, object.Y})
+	}
+	return polygon
+}
+
+// ObjectLayer is a layer of objects.
+type ObjectLayer struct {
+	Name    string
+	Visible bool
+	Opacity float32
+	// Offset est le décalage du calque en pixels
+	Offset     mgl32.Vec2
+	Properties Properties
+	Objects    []*MapObject
+}
+
+// Object returns first object with name (nil if object is not found).
+func (layer *ObjectLayer) Object(name string) *MapObject {
+	for _, object := range layer.Objects {
+		if object.Name == name {
+			return object
+		}
+	}
+	return nil
+}
+
+// ObjectsByType returns objects with type (class).
+func (layer *ObjectLayer) ObjectsByType(objectType string) []*MapObject {
+	objects := make([]*MapObject, 0)
+	for _, object := range layer.Objects {
+		if object.Type == objectType {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
+
+// TileMap is an orthogonal tile map (Tiled editor format).
+type TileMap struct {
+	// Width et Height sont les dimensions en nombre de tuiles
+	Width  int32
+	Height int32
+	// TileWidth et TileHeight sont les dimensions d'une case en pixels
+	TileWidth       int32
+	TileHeight      int32
+	BackgroundColor Color
+	Properties      Properties
+	// Tilesets sont triés par premier identifiant global
+	Tilesets []*Tileset
+	// TileLayers contient les calques de tuiles dans l'ordre de dessin (les groupes sont aplatis)
+	TileLayers []*TileLayer
+	// ObjectLayers contient les calques d'objets (les groupes sont aplatis)
+	ObjectLayers []*ObjectLayer
+	// timeInMs est le temps écoulé pour les tuiles animées
+	timeInMs int64
+	// ownTextures contient les tilesets dont la texture a été chargée par la carte (et doit être libérée)
+	ownTextures []*Tileset
+}
+
+// PixelWidth returns map width in pixels.
+func (tileMap *TileMap) PixelWidth() float32 {
+	return float32(tileMap.Width * tileMap.TileWidth)
+}
+
+// PixelHeight returns map height in pixels.
+func (tileMap *TileMap) PixelHeight() float32 {
+	return float32(tileMap.Height * tileMap.TileHeight)
+}
+
+// Bounds returns map rectangle in pixels (origin is top left corner of map).
+func (tileMap *TileMap) Bounds() Rectangle {
+	return BuildRectangle(0, 0, tileMap.PixelWidth(), tileMap.PixelHeight())
+}
+
+// TileLayer returns named tile layer (nil if layer is not found).
+func (tileMap *TileMap) TileLayer(name string) *TileLayer {
+	for _, layer := range tileMap.TileLayers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	return nil
+}
+
+// ObjectLayer returns named object layer (nil if layer is not found).
+func (tileMap *TileMap) ObjectLayer(name string) *ObjectLayer {
+	for _, layer := range tileMap.ObjectLayers {
+		if layer.Name == name {
+			return layer
+		}
+	}
+	return nil
+}
+
+// CollisionLayers returns tile layers with "collision" property.
+func (tileMap *TileMap) CollisionLayers() []*TileLayer {
+	layers := make([]*TileLayer, 0)
+	for _, layer := range tileMap.TileLayers {
+		if layer.IsCollisionLayer() {
+			layers = append(layers, layer)
+		}
+	}
+	return layers
+}
+
+// Tileset returns tileset and local tile identifier for a global tile identifier (flags are ignored).
+func (tileMap *TileMap) Tileset(gid uint32) (*Tileset, uint32) {
+	gid &^= TILE_FLAGS
+	if gid == 0 {
+		return nil, 0
+	}
+	for index := len(tileMap.Tilesets) - 1; index >= 0; index-- {
+		tileset := tileMap.Tilesets[index]
+		if gid >= tileset.FirstGid {
+			return tileset, gid - tileset.FirstGid
+		}
+	}
+	return nil, 0
+}
+
+// TileInfo returns information on a tile (nil if tile has no information).
+func (tileMap *TileMap) TileInfo(gid uint32) *TileInfo {
+	tileset, tileId := tileMap.Tileset(gid)
+	if tileset == nil {
+		return nil
+	}
+	return tileset.Tiles[tileId]
+}
+
+// TileAt returns tile position (column, row) at a position in map coordinates.
+func (tileMap *TileMap) TileAt(position mgl32.Vec2) (column, row int32) {
+	column = int32(math.Floor(float64(position.X() / float32(tileMap.TileWidth))))
+	row = int32(math.Floor(float64(position.Y() / float32(tileMap.TileHeight))))
+	return column, row
+}
+
+// TileRectangle returns cell rectangle in map coordinates.
+func (tileMap *TileMap) TileRectangle(column, row int32) Rectangle {
+	return BuildRectangle(float32(column*tileMap.TileWidth), float32(row*tileMap.TileHeight),
+		float32(tileMap.TileWidth), float32(tileMap.TileHeight))
+}
+
+// IsSolid indicates if a cell blocks movement: the cell has a tile in a collision layer
+// or a tile with "collision" property in any layer.
+func (tileMap *TileMap) IsSolid(column, row int32) bool {
+	for _, layer := range tileMap.TileLayers {
+		gid := layer.Gid(column, row)
+		if gid == 0 {
+			continue
+		}
+		if layer.IsCollisionLayer() {
+			return true
+		}
+		if info := tileMap.TileInfo(gid); info != nil && info.Properties.Bool(COLLISION_PROPERTY, false) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSolidAt indicates if the cell at a position in map coordinates blocks movement.
+func (tileMap *TileMap) IsSolidAt(position mgl32.Vec2) bool {
+	return tileMap.IsSolid(tileMap.TileAt(position))
+}
+
+// CollisionRectangles returns rectangles (in map coordinates) covering solid cells.
+//
+//	Adjacent solid cells are merged (horizontal runs first, then identical runs of following rows)
+//	to limit the number of rectangles (for example to build static physics bodies).
+func (tileMap *TileMap) CollisionRectangles() []Rectangle {
+	type run struct {
+		start, end int32
+	}
+	rectangles := make([]Rectangle, 0)
+	// open contient les rectangles en cours d'extension, par plage de colonnes
+	open := make(map[run]int)
+	for row := int32(0); row < tileMap.Height; row++ {
+		next := make(map[run]int)
+		for column := int32(0); column < tileMap.Width; column++ {
+			if !tileMap.IsSolid(column, row) {
+				continue
+			}
+			current := run{start: column}
+			for column < tileMap.Width && tileMap.IsSolid(column, row) {
+				column++
+			}
+			current.end = column
+			if index, found := open[current]; found {
+				rectangles[index][3] += float32(tileMap.TileHeight)
+				next[current] = index
+			} else {
+				next[current] = len(rectangles)
+				rectangles = append(rectangles, BuildRectangle(
+					float32(current.start*tileMap.TileWidth), float32(row*tileMap.TileHeight),
+					float32((current.end-current.start)*tileMap.TileWidth), float32(tileMap.TileHeight)))
+			}
+		}
+		open = next
+	}
+	return rectangles
+}
+
+// Update updates animated tiles.
+func (tileMap *TileMap) Update(elapsedTime int64) {
+	tileMap.timeInMs += elapsedTime
+}
+
+// Release releases tileset textures loaded by map.
+func (tileMap *TileMap) Release() {
+	for _, tileset := range tileMap.ownTextures {
+		if tileset.Texture != nil {
+			tileset.Texture.Release()
+			tileset.Texture = nil
+		}
+	}
+	tileMap.ownTextures = nil
+}
+
+// DrawTileMap draws visible tile layers of a map.
+//
+//	position is the position of map top left corner.
+//	view is the visible area (same coordinates as position) : tiles outside view are not drawn.
+func (renderer *Renderer2d) DrawTileMap(tileMap *TileMap, position mgl32.Vec2, view Rectangle) {
+	for _, layer := range tileMap.TileLayers {
+		if layer.Visible {
+			renderer.DrawTileLayer(tileMap, layer, position, view)
+		}
+	}
+}
+
+// DrawTileLayer draws tiles of a layer in view (layer visibility is ignored).
+//
+//	Tiles are batched by tileset: each tileset is drawn with few draw calls.
+//	position is the position of map top left corner.
+//	view is the visible area (same coordinates as position) : tiles outside view are not drawn.
+func (renderer *Renderer2d) DrawTileLayer(tileMap *TileMap, layer *TileLayer, position mgl32.Vec2, view Rectangle) {
+	if tileMap.TileWidth <= 0 || tileMap.TileHeight <= 0 {
+		return
+	}
+	origin := position.Add(layer.Offset)
+	color := CreateColorRVBA(1., 1., 1., layer.Opacity)
+
+	// Les tuiles plus grandes qu'une case débordent vers le haut et vers la droite :
+	// élargir la zone parcourue pour ne pas les oublier
+	extraColumns, extraRows := int32(0), int32(0)
+	for _, tileset := range tileMap.Tilesets {
+		extraColumns = max(extraColumns, (tileset.TileWidth+tileMap.TileWidth-1)/tileMap.TileWidth-1)
+		extraRows = max(extraRows, (tileset.TileHeight+tileMap.TileHeight-1)/tileMap.TileHeight-1)
+	}
+	firstColumn := int32(math.Floor(float64((view.X()-origin.X())/float32(tileMap.TileWidth)))) - extraColumns
+	firstRow := int32(math.Floor(float64((view.Y() - origin.Y()) / float32(tileMap.TileHeight))))
+	lastColumn := int32(math.Floor(float64((view.Right() - origin.X()) / float32(tileMap.TileWidth))))
+	lastRow := int32(math.Floor(float64((view.Bottom()-origin.Y())/float32(tileMap.TileHeight)))) + extraRows
+	firstColumn, firstRow = max(firstColumn, 0), max(firstRow, 0)
+	lastColumn, lastRow = min(lastColumn, layer.Width-1), min(lastRow, layer.Height-1)
+
+	for row := firstRow; row <= lastRow; row++ {
+		for column := firstColumn; column <= lastColumn; column++ {
+			gid := layer.Data[row*layer.Width+column]
+			if gid == 0 {
+				continue
+			}
+			tileset, tileId := tileMap.Tileset(gid)
+			if tileset == nil || tileset.Texture == nil {
+				continue
+			}
+			// Les tuiles sont alignées sur le coin inférieur gauche de la case
+			target := BuildRectangle(
+				origin.X()+float32(column*tileMap.TileWidth)+tileset.TileOffset.X(),
+				origin.Y()+float32((row+1)*tileMap.TileHeight-tileset.TileHeight)+tileset.TileOffset.Y(),
+				float32(tileset.TileWidth), float32(tileset.TileHeight))
+			if !view.Intersects(target) {
+				continue
+			}
+			tileset.sprites = append(tileset.sprites, tileSprite(tileset, tileset.animatedTileId(tileId, tileMap.timeInMs), gid&TILE_FLAGS, target, color))
+		}
+	}
+
+	for _, tileset := range tileMap.Tilesets {
+		if len(tileset.sprites) > 0 {
+			renderer.DrawBatch(tileset.Texture, tileset.sprites, renderer.BlendMode())
+			tileset.sprites = tileset.sprites[:0]
+		}
+	}
+}
+
+// tileSprite returns the batch sprite of a tile with Tiled flip flags.
+func tileSprite(tileset *Tileset, tileId uint32, flags uint32, target Rectangle, color Color) BatchSprite {
+	source := tileset.TileRegion(tileId)
+	flipX := flags&TILE_FLIPPED_HORIZONTALLY != 0
+	flipY := flags&TILE_FLIPPED_VERTICALLY != 0
+	rotation := Angle(0.)
+	center := target.Center()
+	if flags&TILE_FLIPPED_DIAGONALLY != 0 {
+		// La symétrie diagonale (appliquée avant les autres) est un quart de tour et une symétrie
+		flipX, flipY = !flipY, flipX
+		rotation = math.Pi / 2
+		// La tuile tournée reste alignée sur le coin inférieur gauche (largeur et hauteur sont échangées)
+		center = mgl32.Vec2{target.X() + target.Height()/2, target.Bottom() - target.Width()/2}
+	}
+	sourcePosition, sourceDimension := source.Pos(), source.Dim()
+	if flipX {
+		sourcePosition[0] += sourceDimension[0]
+		sourceDimension[0] = -sourceDimension[0]
+	}
+	if flipY {
+		sourcePosition[1] += sourceDimension[1]
+		sourceDimension[1] = -sourceDimension[1]
+	}
+	return BatchSprite{
+		Source:   BuildRectFromPosAndDim(sourcePosition, sourceDimension),
+		Center:   center,
+		Size:     target.Dim(),
+		Rotation: rotation,
+		Color:    color,
+	}
+}
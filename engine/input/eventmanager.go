@@ -124,6 +124,7 @@ func (mng *EventManager) mouseScrollCallback(_ *glfw.Window, horizontalShift flo
 
 func (mng *EventManager) resizeCallback(_ *glfw.Window, width int, height int) {
 	gl.Viewport(0, 0, int32(width), int32(height))
+	mng.window.resizeFramebuffers(width, height)
 	if mng.processor != nil {
 		event := WindowEvent{
 			window: mng.window,
@@ -5,6 +5,7 @@ import (
 	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
 	"log/slog"
+	"ogl46/engine/ogl"
 	"runtime"
 )
 
@@ -24,6 +25,9 @@ type Window struct {
 	// vsync indique si la synchronisation verticale est activée
 	vsync bool
 
+	// framebuffers contient les framebuffers redimensionnés avec la fenêtre
+	framebuffers []*ogl.Framebuffer
+
 	//
 	fullscreen    bool
 	initialX      int
@@ -67,6 +71,8 @@ func (window *Window) Launch() error {
 	version := gl.GoStr(gl.GetString(gl.VERSION))
 	vendor := gl.GoStr(gl.GetString(gl.VENDOR))
 	slog.Info("OpenGL", "version", version, "vendor", vendor)
+	// Le contexte OpenGL est nouveau : l'état connu du cache n'est plus valide
+	ogl.GlobalStateCache().Invalidate()
 
 	// Conserver position et taille
 	window.initialX, window.initialY = window.glfwWindow.GetPos()
@@ -90,16 +96,9 @@ func (window *Window) Close() {
 }
 
 func (window *Window) Clear() {
-	// Positionner les états
-	// - Transparence
-	gl.Disable(gl.BLEND)
-	// - Culling (masquer les faces qui tournent le dos à la caméra)
-	gl.Enable(gl.CULL_FACE)
-	gl.CullFace(gl.FRONT)
-	// - Z-Buffer
-	gl.Enable(gl.DEPTH_TEST)
+	// Positionner les états 3D (pas de transparence, culling des faces avant, Z-Buffer)
+	ogl.GlobalStateCache().Apply(ogl.DefaultRenderState3d())
 	gl.ClearDepth(1)
-	gl.DepthFunc(gl.LEQUAL)
 
 	// Vider les buffer
 	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
@@ -163,6 +162,42 @@ func (window *Window) ProcessEvents(eventCallback EventProcessor) {
 	window.eventManager.ProcessEvents(eventCallback)
 }
 
+// TrackFramebuffer redimensionne un framebuffer à la taille de la fenêtre, puis à chaque redimensionnement (WINDOW_RESIZED)
+func (window *Window) TrackFramebuffer(framebuffer *ogl.Framebuffer) error {
+	for _, tracked := range window.framebuffers {
+		if tracked == framebuffer {
+			return nil
+		}
+	}
+	if window.IsLaunched() {
+		width, height := window.glfwWindow.GetFramebufferSize()
+		if err := framebuffer.Resize(int32(width), int32(height)); err != nil {
+			return err
+		}
+	}
+	window.framebuffers = append(window.framebuffers, framebuffer)
+	return nil
+}
+
+// UntrackFramebuffer arrête le redimensionnement d'un framebuffer avec la fenêtre (à appeler avant sa libération)
+func (window *Window) UntrackFramebuffer(framebuffer *ogl.Framebuffer) {
+	for index, tracked := range window.framebuffers {
+		if tracked == framebuffer {
+			window.framebuffers = append(window.framebuffers[:index], window.framebuffers[index+1:]...)
+			return
+		}
+	}
+}
+
+// resizeFramebuffers redimensionne les framebuffers suivis
+func (window *Window) resizeFramebuffers(width, height int) {
+	for _, framebuffer := range window.framebuffers {
+		if err := framebuffer.Resize(int32(width), int32(height)); err != nil {
+			slog.Error("failed to resize framebuffer with window", "error", err)
+		}
+	}
+}
+
 // Size retourne la dimension de la fenêtre
 func (window *Window) Size() (width int, height int) {
 	return window.glfwWindow.GetSize()
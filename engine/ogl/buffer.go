@@ -0,0 +1,180 @@
+package ogl
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// BufferUsage indicates how often a buffer content is updated.
+type BufferUsage uint32
+
+// List of buffer usages
+const (
+	// USAGE_STATIC : contenu défini une fois et dessiné de nombreuses fois
+	USAGE_STATIC BufferUsage = gl.STATIC_DRAW
+	// USAGE_DYNAMIC : contenu modifié régulièrement
+	USAGE_DYNAMIC BufferUsage = gl.DYNAMIC_DRAW
+	// USAGE_STREAM : contenu redéfini à chaque frame
+	USAGE_STREAM BufferUsage = gl.STREAM_DRAW
+	// USAGE_DYNAMIC_COPY : contenu écrit par le GPU (compute shaders) et utilisé par le GPU
+	USAGE_DYNAMIC_COPY BufferUsage = gl.DYNAMIC_COPY
+)
+
+// Buffer is an OpenGL buffer object (vertices, indices, instance data...).
+type Buffer struct {
+	handle uint32
+	// size est la taille du buffer en octets
+	size  int
+	usage BufferUsage
+}
+
+// NewBuffer creates a buffer of size bytes with undefined content.
+func NewBuffer(size int, usage BufferUsage) *Buffer {
+	slog.Debug("buffer creation", "size", size)
+	buffer := &Buffer{size: size, usage: usage}
+	gl.CreateBuffers(1, &buffer.handle)
+	gl.NamedBufferData(buffer.handle, size, nil, uint32(usage))
+	return buffer
+}
+
+// NewBufferWithData creates a buffer filled with data (slice of numbers or of structures without pointers).
+func NewBufferWithData(data any, usage BufferUsage) (*Buffer, error) {
+	pointer, size, err := sliceData(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create buffer\n - %w", err)
+	}
+	slog.Debug("buffer creation", "size", size)
+	buffer := &Buffer{size: size, usage: usage}
+	gl.CreateBuffers(1, &buffer.handle)
+	gl.NamedBufferData(buffer.handle, size, pointer, uint32(usage))
+	return buffer, nil
+}
+
+// SetData replaces the buffer content with data (the buffer is reallocated with data size).
+func (buffer *Buffer) SetData(data any) error {
+	pointer, size, err := sliceData(data)
+	if err != nil {
+		return err
+	}
+	gl.NamedBufferData(buffer.handle, size, pointer, uint32(buffer.usage))
+	buffer.size = size
+	return nil
+}
+
+// Update replaces the buffer content at offset (in bytes) with data.
+func (buffer *Buffer) Update(offset int, data any) error {
+	pointer, size, err := sliceData(data)
+	if err != nil {
+		return err
+	}
+	if offset < 0 || offset+size > buffer.size {
+		return fmt.Errorf("update of %d bytes at offset %d exceeds %d bytes buffer", size, offset, buffer.size)
+	}
+	if size > 0 {
+		gl.NamedBufferSubData(buffer.handle, offset, size, pointer)
+	}
+	return nil
+}
+
+// Read copies the buffer content at offset (in bytes) into data (slice filled entirely).
+func (buffer *Buffer) Read(offset int, data any) error {
+	pointer, size, err := sliceData(data)
+	if err != nil {
+		return err
+	}
+	if offset < 0 || offset+size > buffer.size {
+		return fmt.Errorf("read of %d bytes at offset %d exceeds %d bytes buffer", size, offset, buffer.size)
+	}
+	if size > 0 {
+		gl.GetNamedBufferSubData(buffer.handle, offset, size, pointer)
+	}
+	return nil
+}
+
+// Clear fills the buffer with zeros.
+func (buffer *Buffer) Clear() {
+	gl.ClearNamedBufferData(buffer.handle, gl.R8UI, gl.RED_INTEGER, gl.UNSIGNED_BYTE, nil)
+}
+
+// BindStorage binds the buffer to a shader storage binding point (layout (std430, binding = ...) buffer).
+func (buffer *Buffer) BindStorage(binding uint32) {
+	gl.BindBufferBase(gl.SHADER_STORAGE_BUFFER, binding, buffer.handle)
+}
+
+// BindStorageRange binds size bytes from offset of the buffer to a shader storage binding point.
+func (buffer *Buffer) BindStorageRange(binding uint32, offset, size int) {
+	gl.BindBufferRange(gl.SHADER_STORAGE_BUFFER, binding, buffer.handle, offset, size)
+}
+
+// BindUniform binds the buffer to a uniform block binding point (layout (std140, binding = ...) uniform).
+func (buffer *Buffer) BindUniform(binding uint32) {
+	gl.BindBufferBase(gl.UNIFORM_BUFFER, binding, buffer.handle)
+}
+
+// Orphan releases the current storage (the GPU can still use it) and allocates a new one of the same size.
+//
+//	To be called before rewriting an USAGE_STREAM buffer each frame, so that the CPU does not wait for the GPU.
+func (buffer *Buffer) Orphan() {
+	gl.NamedBufferData(buffer.handle, buffer.size, nil, uint32(buffer.usage))
+}
+
+// Handle returns the OpenGL buffer.
+func (buffer *Buffer) Handle() uint32 {
+	return buffer.handle
+}
+
+// Size returns the buffer size in bytes.
+func (buffer *Buffer) Size() int {
+	return buffer.size
+}
+
+// Usage returns the buffer usage.
+func (buffer *Buffer) Usage() BufferUsage {
+	return buffer.usage
+}
+
+// Delete releases the OpenGL buffer.
+func (buffer *Buffer) Delete() {
+	if buffer.handle != 0 {
+		slog.Debug("buffer destruction")
+		gl.DeleteBuffers(1, &buffer.handle)
+		buffer.handle = 0
+	}
+}
+
+// sliceData returns the address and the size in bytes of a slice content.
+func sliceData(data any) (unsafe.Pointer, int, error) {
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return nil, 0, fmt.Errorf("buffer data must be a slice (%T)", data)
+	}
+	if containsPointers(value.Type().Elem()) {
+		return nil, 0, fmt.Errorf("buffer data cannot contain pointers (%T)", data)
+	}
+	size := value.Len() * int(value.Type().Elem().Size())
+	if size == 0 {
+		return nil, 0, nil
+	}
+	return value.UnsafePointer(), size, nil
+}
+
+// containsPointers indicates if a type contains pointers, at any level of its arrays and structures.
+func containsPointers(dataType reflect.Type) bool {
+	switch dataType.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Slice, reflect.Map, reflect.String, reflect.Interface, reflect.Chan, reflect.Func:
+		return true
+	case reflect.Array:
+		return containsPointers(dataType.Elem())
+	case reflect.Struct:
+		for index := 0; index < dataType.NumField(); index++ {
+			if containsPointers(dataType.Field(index).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package ogl
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// NewComputeProgram links a compute shader program (gl.COMPUTE_SHADER shader).
+//
+//	The program owns the compute shader: it is deleted with the program, or at once if the program cannot be built.
+func NewComputeProgram(shader *Shader) (*ShaderProgram, error) {
+	if shader.shaderType != gl.COMPUTE_SHADER {
+		shader.Delete()
+		return nil, fmt.Errorf("0x%X shader is not a compute shader", shader.shaderType)
+	}
+	program, err := NewShaderProgram(shader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link compute shader\n - %w", err)
+	}
+	return program, nil
+}
+
+// NewComputeProgramFromSource compiles and links a compute shader program.
+func NewComputeProgramFromSource(src string) (*ShaderProgram, error) {
+	shader, err := NewShaderFromSource(src, gl.COMPUTE_SHADER)
+	if err != nil {
+		return nil, err
+	}
+	return NewComputeProgram(shader)
+}
+
+// LoadComputeProgramFromFile compiles and links a compute shader program from a file.
+func LoadComputeProgramFromFile(filename string) (*ShaderProgram, error) {
+	fileContent, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load '%s' compute shader file\n - %w", filename, err)
+	}
+	program, err := NewComputeProgramFromSource(string(fileContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build compute program from '%s' file\n - %w", filename, err)
+	}
+	return program, nil
+}
+
+// IsCompute indicates if the program contains a compute shader.
+func (shaderProgram *ShaderProgram) IsCompute() bool {
+	for _, shader := range shaderProgram.shaders {
+		if shader.shaderType == gl.COMPUTE_SHADER {
+			return true
+		}
+	}
+	return false
+}
+
+// WorkGroupSize returns the local work group size of a compute program (layout (local_size_x = ...) in).
+func (shaderProgram *ShaderProgram) WorkGroupSize() [3]int32 {
+	return shaderProgram.workGroupSize
+}
+
+// Dispatch uses the compute program and launches groupsX x groupsY x groupsZ work groups.
+//
+//	Results written in buffers or images must be synchronized with MemoryBarrier before being used.
+func (shaderProgram *ShaderProgram) Dispatch(groupsX, groupsY, groupsZ uint32) {
+	if !shaderProgram.IsCompute() {
+		slog.Warn("cannot dispatch a program without compute shader", "program", shaderProgram.handle)
+		return
+	}
+	shaderProgram.Use()
+	gl.DispatchCompute(groupsX, groupsY, groupsZ)
+}
+
+// DispatchInvocations launches enough work groups to run width x height x depth invocations
+// (one per particle or per pixel for example, shaders must ignore invocations outside).
+func (shaderProgram *ShaderProgram) DispatchInvocations(width, height, depth int32) {
+	size := shaderProgram.workGroupSize
+	shaderProgram.Dispatch(workGroupCount(width, size[0]), workGroupCount(height, size[1]), workGroupCount(depth, size[2]))
+}
+
+// DispatchIndirect launches work groups whose counts are read in buffer at offset (in bytes, 3 uint32 values).
+func (shaderProgram *ShaderProgram) DispatchIndirect(buffer *Buffer, offset int) {
+	if !shaderProgram.IsCompute() {
+		slog.Warn("cannot dispatch a program without compute shader", "program", shaderProgram.handle)
+		return
+	}
+	shaderProgram.Use()
+	gl.BindBuffer(gl.DISPATCH_INDIRECT_BUFFER, buffer.handle)
+	gl.DispatchComputeIndirect(offset)
+	gl.BindBuffer(gl.DISPATCH_INDIRECT_BUFFER, 0)
+}
+
+// workGroupCount returns the number of groups of size invocations covering count invocations.
+func workGroupCount(count, size int32) uint32 {
+	if count <= 0 {
+		return 0
+	}
+	if size <= 0 {
+		size = 1
+	}
+	return uint32((count + size - 1) / size)
+}
+
+// MemoryBarrierBits indicates which usages of data written by shaders must be synchronized.
+type MemoryBarrierBits uint32
+
+// List of memory barriers
+const (
+	// BARRIER_SHADER_STORAGE : lecture par les shaders des shader storage buffers écrits
+	BARRIER_SHADER_STORAGE MemoryBarrierBits = gl.SHADER_STORAGE_BARRIER_BIT
+	// BARRIER_VERTEX_ATTRIB_ARRAY : buffers écrits utilisés comme vertex buffers (particules par exemple)
+	BARRIER_VERTEX_ATTRIB_ARRAY MemoryBarrierBits = gl.VERTEX_ATTRIB_ARRAY_BARRIER_BIT
+	// BARRIER_ELEMENT_ARRAY : buffers écrits utilisés comme index buffers
+	BARRIER_ELEMENT_ARRAY MemoryBarrierBits = gl.ELEMENT_ARRAY_BARRIER_BIT
+	// BARRIER_UNIFORM : buffers écrits utilisés comme uniform buffers
+	BARRIER_UNIFORM MemoryBarrierBits = gl.UNIFORM_BARRIER_BIT
+	// BARRIER_COMMAND : buffers écrits utilisés par DispatchIndirect
+	BARRIER_COMMAND MemoryBarrierBits = gl.COMMAND_BARRIER_BIT
+	// BARRIER_BUFFER_UPDATE : buffers écrits lus ou modifiés par le CPU (Buffer.Read, Buffer.Update)
+	BARRIER_BUFFER_UPDATE MemoryBarrierBits = gl.BUFFER_UPDATE_BARRIER_BIT
+	// BARRIER_SHADER_IMAGE_ACCESS : lecture par les shaders des images écrites (imageLoad)
+	BARRIER_SHADER_IMAGE_ACCESS MemoryBarrierBits = gl.SHADER_IMAGE_ACCESS_BARRIER_BIT
+	// BARRIER_TEXTURE_FETCH : images écrites utilisées comme textures (samplers)
+	BARRIER_TEXTURE_FETCH MemoryBarrierBits = gl.TEXTURE_FETCH_BARRIER_BIT
+	// BARRIER_TEXTURE_UPDATE : images écrites lues ou modifiées par le CPU (Texture.ToImage...)
+	BARRIER_TEXTURE_UPDATE MemoryBarrierBits = gl.TEXTURE_UPDATE_BARRIER_BIT
+	// BARRIER_FRAMEBUFFER : images écrites utilisées comme attachements de framebuffer
+	BARRIER_FRAMEBUFFER MemoryBarrierBits = gl.FRAMEBUFFER_BARRIER_BIT
+	// BARRIER_ALL : toutes les utilisations
+	BARRIER_ALL MemoryBarrierBits = gl.ALL_BARRIER_BITS
+)
+
+// MemoryBarrier waits for shader writes to be visible for the barriers usages (combined with |).
+func MemoryBarrier(barriers MemoryBarrierBits) {
+	gl.MemoryBarrier(uint32(barriers))
+}
+
+// ImageAccess indicates how a shader accesses an image (image2D... in shaders).
+type ImageAccess uint32
+
+// List of image accesses
+const (
+	ACCESS_READ_ONLY  ImageAccess = gl.READ_ONLY
+	ACCESS_WRITE_ONLY ImageAccess = gl.WRITE_ONLY
+	ACCESS_READ_WRITE ImageAccess = gl.READ_WRITE
+)
+
+// imageFormats contains the internal formats usable by image load/store (compressed and sRGB formats are excluded).
+var imageFormats = map[uint32]bool{
+	gl.RGBA32F: true, gl.RGBA16F: true, gl.RG32F: true, gl.RG16F: true, gl.R11F_G11F_B10F: true, gl.R32F: true, gl.R16F: true,
+	gl.RGBA32UI: true, gl.RGBA16UI: true, gl.RGB10_A2UI: true, gl.RGBA8UI: true, gl.RG32UI: true, gl.RG16UI: true, gl.RG8UI: true,
+	gl.R32UI: true, gl.R16UI: true, gl.R8UI: true,
+	gl.RGBA32I: true, gl.RGBA16I: true, gl.RGBA8I: true, gl.RG32I: true, gl.RG16I: true, gl.RG8I: true, gl.R32I: true, gl.R16I: true, gl.R8I: true,
+	gl.RGBA16: true, gl.RGB10_A2: true, gl.RGBA8: true, gl.RG16: true, gl.RG8: true, gl.R16: true, gl.R8: true,
+	gl.RGBA16_SNORM: true, gl.RGBA8_SNORM: true, gl.RG16_SNORM: true, gl.RG8_SNORM: true, gl.R16_SNORM: true, gl.R8_SNORM: true,
+}
+
+// BindImage binds the level of a texture to an image unit (layout (binding = unit) in shaders, 0 for the first unit).
+//
+//	layered binds all layers (arrays, cube maps, 3D textures), otherwise only layer is bound.
+//	The image format of the shader (layout (rgba8)...) must match the texture internal format.
+func BindImage(unit uint32, texture uint32, level int32, layered bool, layer int32, access ImageAccess) error {
+	var internalFormat int32
+	gl.GetTextureLevelParameteriv(texture, level, gl.TEXTURE_INTERNAL_FORMAT, &internalFormat)
+	if !imageFormats[uint32(internalFormat)] {
+		return fmt.Errorf("0x%X texture format cannot be used as an image", internalFormat)
+	}
+	gl.BindImageTexture(unit, texture, level, layered, layer, uint32(access), uint32(internalFormat))
+	return nil
+}
+
+// UnbindImage unbinds the texture of an image unit.
+func UnbindImage(unit uint32) {
+	gl.BindImageTexture(unit, 0, 0, false, 0, gl.READ_ONLY, gl.RGBA8)
+}
@@ -0,0 +1,39 @@
+package ogl
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+func TestWorkGroupCount(t *testing.T) {
+	tests := []struct {
+		count, size int32
+		groups      uint32
+	}{
+		{1024, 256, 4},
+		{1000, 256, 4},
+		{1, 64, 1},
+		{0, 64, 0},
+		{-5, 64, 0},
+		{7, 0, 7},
+	}
+	for _, test := range tests {
+		if groups := workGroupCount(test.count, test.size); groups != test.groups {
+			t.Fatalf("workGroupCount(%d, %d) = %d, want %d", test.count, test.size, groups, test.groups)
+		}
+	}
+}
+
+func TestImageFormats(t *testing.T) {
+	for _, format := range []uint32{gl.RGBA8, gl.RGBA16F, gl.R32F, gl.R32UI} {
+		if !imageFormats[format] {
+			t.Fatalf("imageFormats[0x%X] = false, want true", format)
+		}
+	}
+	for _, format := range []uint32{gl.SRGB8_ALPHA8, gl.COMPRESSED_RGBA_S3TC_DXT5_EXT, gl.DEPTH24_STENCIL8, gl.RGB8} {
+		if imageFormats[format] {
+			t.Fatalf("imageFormats[0x%X] = true, want false", format)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package ogl
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// FramebufferConfig describes framebuffer attachments.
+type FramebufferConfig struct {
+	// ColorFormats contient le format interne de chaque attachement couleur (gl.RGBA8, gl.RGBA16F...)
+	// Vide = un seul attachement gl.RGBA8
+	ColorFormats []int32
+	// DepthStencil ajoute un attachement profondeur / stencil (gl.DEPTH24_STENCIL8)
+	DepthStencil bool
+	// Filter est le filtre des textures couleur (gl.LINEAR, gl.NEAREST) - 0 = gl.LINEAR
+	Filter int32
+}
+
+// Framebuffer is an off-screen render target: its color attachments are textures that can be drawn later.
+type Framebuffer struct {
+	handle uint32
+	// colorAttachments contient les textures couleur
+	colorAttachments []uint32
+	// depthStencil est le renderbuffer profondeur / stencil (0 si absent)
+	depthStencil uint32
+	config       FramebufferConfig
+	width        int32
+	height       int32
+}
+
+// NewFramebuffer creates a framebuffer and its attachments.
+func NewFramebuffer(width, height int32, config FramebufferConfig) (*Framebuffer, error) {
+	slog.Debug("framebuffer creation", "width", width, "height", height)
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("invalid framebuffer size %dx%d", width, height)
+	}
+	if len(config.ColorFormats) == 0 {
+		config.ColorFormats = []int32{gl.RGBA8}
+	}
+	if config.Filter == 0 {
+		config.Filter = gl.LINEAR
+	}
+	var maxColorAttachments int32
+	gl.GetIntegerv(gl.MAX_COLOR_ATTACHMENTS, &maxColorAttachments)
+	if int32(len(config.ColorFormats)) > maxColorAttachments {
+		return nil, fmt.Errorf("too many framebuffer color attachments %d (maximum is %d)", len(config.ColorFormats), maxColorAttachments)
+	}
+
+	framebuffer := &Framebuffer{config: config, colorAttachments: make([]uint32, len(config.ColorFormats))}
+	gl.CreateFramebuffers(1, &framebuffer.handle)
+	gl.CreateTextures(gl.TEXTURE_2D, int32(len(framebuffer.colorAttachments)), &framebuffer.colorAttachments[0])
+	drawBuffers := make([]uint32, len(framebuffer.colorAttachments))
+	for index, texture := range framebuffer.colorAttachments {
+		gl.TextureParameteri(texture, gl.TEXTURE_MIN_FILTER, config.Filter)
+		gl.TextureParameteri(texture, gl.TEXTURE_MAG_FILTER, config.Filter)
+		gl.TextureParameteri(texture, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+		gl.TextureParameteri(texture, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+		drawBuffers[index] = gl.COLOR_ATTACHMENT0 + uint32(index)
+	}
+	gl.NamedFramebufferDrawBuffers(framebuffer.handle, int32(len(drawBuffers)), &drawBuffers[0])
+	if config.DepthStencil {
+		gl.CreateRenderbuffers(1, &framebuffer.depthStencil)
+	}
+
+	if err := framebuffer.allocate(width, height); err != nil {
+		framebuffer.Delete()
+		return nil, err
+	}
+	return framebuffer, nil
+}
+
+// allocate (re)allocates attachments storage and attaches them (OpenGL handles are kept).
+func (framebuffer *Framebuffer) allocate(width, height int32) error {
+	for index, texture := range framebuffer.colorAttachments {
+		gl.BindTexture(gl.TEXTURE_2D, texture)
+		internalFormat := framebuffer.config.ColorFormats[index]
+		gl.TexImage2D(gl.TEXTURE_2D, 0, internalFormat, width, height, 0, colorTransferFormat(internalFormat), gl.UNSIGNED_BYTE, nil)
+		gl.NamedFramebufferTexture(framebuffer.handle, gl.COLOR_ATTACHMENT0+uint32(index), texture, 0)
+	}
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	if framebuffer.depthStencil != 0 {
+		gl.NamedRenderbufferStorage(framebuffer.depthStencil, gl.DEPTH24_STENCIL8, width, height)
+		gl.NamedFramebufferRenderbuffer(framebuffer.handle, gl.DEPTH_STENCIL_ATTACHMENT, gl.RENDERBUFFER, framebuffer.depthStencil)
+	}
+	framebuffer.width, framebuffer.height = width, height
+
+	if status := gl.CheckNamedFramebufferStatus(framebuffer.handle, gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		return fmt.Errorf("framebuffer is not complete (status %#x)", status)
+	}
+	return nil
+}
+
+// integerColorFormats contient le format de transfert des formats internes entiers
+var integerColorFormats = map[int32]uint32{
+	gl.R8UI: gl.RED_INTEGER, gl.R8I: gl.RED_INTEGER, gl.R16UI: gl.RED_INTEGER, gl.R16I: gl.RED_INTEGER,
+	gl.R32UI: gl.RED_INTEGER, gl.R32I: gl.RED_INTEGER,
+	gl.RG8UI: gl.RG_INTEGER, gl.RG8I: gl.RG_INTEGER, gl.RG16UI: gl.RG_INTEGER, gl.RG16I: gl.RG_INTEGER,
+	gl.RG32UI: gl.RG_INTEGER, gl.RG32I: gl.RG_INTEGER,
+	gl.RGB8UI: gl.RGB_INTEGER, gl.RGB8I: gl.RGB_INTEGER, gl.RGB16UI: gl.RGB_INTEGER, gl.RGB16I: gl.RGB_INTEGER,
+	gl.RGB32UI: gl.RGB_INTEGER, gl.RGB32I: gl.RGB_INTEGER,
+	gl.RGBA8UI: gl.RGBA_INTEGER, gl.RGBA8I: gl.RGBA_INTEGER, gl.RGBA16UI: gl.RGBA_INTEGER, gl.RGBA16I: gl.RGBA_INTEGER,
+	gl.RGBA32UI: gl.RGBA_INTEGER, gl.RGBA32I: gl.RGBA_INTEGER, gl.RGB10_A2UI: gl.RGBA_INTEGER,
+}
+
+// colorTransferFormat returns a pixel format accepted by glTexImage2D for a color internal format.
+//
+//	Integer internal formats require an integer pixel format (gl.RED_INTEGER, gl.RGBA_INTEGER...).
+func colorTransferFormat(internalFormat int32) uint32 {
+	if format, found := integerColorFormats[internalFormat]; found {
+		return format
+	}
+	return gl.RGBA
+}
+
+// Delete releases framebuffer and its attachments.
+func (framebuffer *Framebuffer) Delete() {
+	if framebuffer.handle != 0 {
+		slog.Debug("framebuffer destruction")
+		if len(framebuffer.colorAttachments) > 0 {
+			gl.DeleteTextures(int32(len(framebuffer.colorAttachments)), &framebuffer.colorAttachments[0])
+		}
+		if framebuffer.depthStencil != 0 {
+			gl.DeleteRenderbuffers(1, &framebuffer.depthStencil)
+			framebuffer.depthStencil = 0
+		}
+		gl.DeleteFramebuffers(1, &framebuffer.handle)
+		framebuffer.handle = 0
+		framebuffer.colorAttachments = nil
+	}
+}
+
+// Resize changes attachments size (content is lost, attachment handles are kept).
+//
+//	A zero size (minimized window) is ignored.
+func (framebuffer *Framebuffer) Resize(width, height int32) error {
+	if framebuffer.handle == 0 || width <= 0 || height <= 0 || (width == framebuffer.width && height == framebuffer.height) {
+		return nil
+	}
+	slog.Debug("framebuffer resize", "width", width, "height", height)
+	if err := framebuffer.allocate(width, height); err != nil {
+		return fmt.Errorf("failed to resize framebuffer to %dx%d\n - %w", width, height, err)
+	}
+	return nil
+}
+
+// Bind makes framebuffer the render target (viewport is not changed).
+func (framebuffer *Framebuffer) Bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, framebuffer.handle)
+}
+
+// Unbind makes the default framebuffer (window) the render target.
+func (framebuffer *Framebuffer) Unbind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+// Clear clears color attachments with a color and depth / stencil attachment with 1 / 0.
+//
+//	Framebuffer does not need to be bound (scissor test and write masks are applied).
+func (framebuffer *Framebuffer) Clear(red, green, blue, alpha float32) {
+	color := [4]float32{red, green, blue, alpha}
+	for index := range framebuffer.colorAttachments {
+		gl.ClearNamedFramebufferfv(framebuffer.handle, gl.COLOR, int32(index), &color[0])
+	}
+	if framebuffer.depthStencil != 0 {
+		gl.ClearNamedFramebufferfi(framebuffer.handle, gl.DEPTH_STENCIL, 0, 1., 0)
+	}
+}
+
+// Handle returns the OpenGL framebuffer.
+func (framebuffer *Framebuffer) Handle() uint32 {
+	return framebuffer.handle
+}
+
+// Width returns framebuffer width.
+func (framebuffer *Framebuffer) Width() int32 {
+	return framebuffer.width
+}
+
+// Height returns framebuffer height.
+func (framebuffer *Framebuffer) Height() int32 {
+	return framebuffer.height
+}
+
+// ColorAttachmentCount returns the number of color attachments.
+func (framebuffer *Framebuffer) ColorAttachmentCount() int {
+	return len(framebuffer.colorAttachments)
+}
+
+// ColorAttachment returns the OpenGL texture of a color attachment (0 if index is invalid).
+func (framebuffer *Framebuffer) ColorAttachment(index int) uint32 {
+	if index < 0 || index >= len(framebuffer.colorAttachments) {
+		return 0
+	}
+	return framebuffer.colorAttachments[index]
+}
+
+// HasDepthStencil indicates if framebuffer has a depth / stencil attachment.
+func (framebuffer *Framebuffer) HasDepthStencil() bool {
+	return framebuffer.depthStencil != 0
+}
+
+// BoundFramebuffer returns the OpenGL framebuffer currently used to draw (0 = window).
+func BoundFramebuffer() uint32 {
+	var handle int32
+	gl.GetIntegerv(gl.DRAW_FRAMEBUFFER_BINDING, &handle)
+	return uint32(handle)
+}
+
+// BindFramebufferHandle makes an OpenGL framebuffer the render target (see BoundFramebuffer).
+func BindFramebufferHandle(handle uint32) {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, handle)
+}
@@ -0,0 +1,28 @@
+package ogl
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+func TestColorTransferFormat(t *testing.T) {
+	tests := []struct {
+		internalFormat int32
+		want           uint32
+	}{
+		{gl.RGBA8, gl.RGBA},
+		{gl.RGBA16F, gl.RGBA},
+		{gl.R32F, gl.RGBA},
+		{gl.RGBA8UI, gl.RGBA_INTEGER},
+		{gl.RGB10_A2UI, gl.RGBA_INTEGER},
+		{gl.R32UI, gl.RED_INTEGER},
+		{gl.RG16I, gl.RG_INTEGER},
+		{gl.RGB32I, gl.RGB_INTEGER},
+	}
+	for _, test := range tests {
+		if got := colorTransferFormat(test.internalFormat); got != test.want {
+			t.Fatalf("colorTransferFormat(%#x) = %#x, want %#x", test.internalFormat, got, test.want)
+		}
+	}
+}
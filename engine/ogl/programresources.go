@@ -0,0 +1,256 @@
+package ogl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// UniformInfo describes an active uniform of a shader program.
+type UniformInfo struct {
+	Name     string
+	Location int32
+	// Type est le type OpenGL du uniform (gl.FLOAT_VEC3, gl.SAMPLER_2D...)
+	Type uint32
+	// Size est le nombre d'éléments (1 si le uniform n'est pas un tableau)
+	Size int32
+}
+
+// AttributeInfo describes an active vertex attribute of a shader program.
+type AttributeInfo struct {
+	Name     string
+	Location int32
+	Type     uint32
+	Size     int32
+}
+
+// BlockInfo describes an active uniform block or shader storage block of a shader program.
+type BlockInfo struct {
+	Name  string
+	Index uint32
+	// Binding est le point d'attache du buffer (layout (binding = ...) ou SetUniformBlock)
+	Binding uint32
+	// DataSize est la taille minimale du buffer en octets
+	DataSize int32
+}
+
+// uniformType describes an OpenGL uniform type.
+type uniformType struct {
+	name string
+	// sampler indique un sampler ou une image (valeur = unité de texture)
+	sampler bool
+	// components est le nombre de composantes des booléens (acceptant des entiers ou des flottants)
+	components int
+}
+
+var uniformTypes = map[uint32]uniformType{
+	gl.FLOAT:             {name: "float"},
+	gl.FLOAT_VEC2:        {name: "vec2"},
+	gl.FLOAT_VEC3:        {name: "vec3"},
+	gl.FLOAT_VEC4:        {name: "vec4"},
+	gl.INT:               {name: "int"},
+	gl.INT_VEC2:          {name: "ivec2"},
+	gl.INT_VEC3:          {name: "ivec3"},
+	gl.INT_VEC4:          {name: "ivec4"},
+	gl.UNSIGNED_INT:      {name: "uint"},
+	gl.UNSIGNED_INT_VEC2: {name: "uvec2"},
+	gl.UNSIGNED_INT_VEC3: {name: "uvec3"},
+	gl.UNSIGNED_INT_VEC4: {name: "uvec4"},
+	gl.BOOL:              {name: "bool", components: 1},
+	gl.BOOL_VEC2:         {name: "bvec2", components: 2},
+	gl.BOOL_VEC3:         {name: "bvec3", components: 3},
+	gl.BOOL_VEC4:         {name: "bvec4", components: 4},
+	gl.FLOAT_MAT2:        {name: "mat2"},
+	gl.FLOAT_MAT3:        {name: "mat3"},
+	gl.FLOAT_MAT4:        {name: "mat4"},
+
+	gl.SAMPLER_1D:                   {name: "sampler1D", sampler: true},
+	gl.SAMPLER_2D:                   {name: "sampler2D", sampler: true},
+	gl.SAMPLER_3D:                   {name: "sampler3D", sampler: true},
+	gl.SAMPLER_CUBE:                 {name: "samplerCube", sampler: true},
+	gl.SAMPLER_2D_RECT:              {name: "sampler2DRect", sampler: true},
+	gl.SAMPLER_BUFFER:               {name: "samplerBuffer", sampler: true},
+	gl.SAMPLER_1D_ARRAY:             {name: "sampler1DArray", sampler: true},
+	gl.SAMPLER_2D_ARRAY:             {name: "sampler2DArray", sampler: true},
+	gl.SAMPLER_CUBE_MAP_ARRAY:       {name: "samplerCubeArray", sampler: true},
+	gl.SAMPLER_2D_MULTISAMPLE:       {name: "sampler2DMS", sampler: true},
+	gl.SAMPLER_2D_MULTISAMPLE_ARRAY: {name: "sampler2DMSArray", sampler: true},
+
+	gl.SAMPLER_1D_SHADOW:             {name: "sampler1DShadow", sampler: true},
+	gl.SAMPLER_2D_SHADOW:             {name: "sampler2DShadow", sampler: true},
+	gl.SAMPLER_CUBE_SHADOW:           {name: "samplerCubeShadow", sampler: true},
+	gl.SAMPLER_2D_RECT_SHADOW:        {name: "sampler2DRectShadow", sampler: true},
+	gl.SAMPLER_1D_ARRAY_SHADOW:       {name: "sampler1DArrayShadow", sampler: true},
+	gl.SAMPLER_2D_ARRAY_SHADOW:       {name: "sampler2DArrayShadow", sampler: true},
+	gl.SAMPLER_CUBE_MAP_ARRAY_SHADOW: {name: "samplerCubeArrayShadow", sampler: true},
+
+	gl.INT_SAMPLER_1D:             {name: "isampler1D", sampler: true},
+	gl.INT_SAMPLER_2D:             {name: "isampler2D", sampler: true},
+	gl.INT_SAMPLER_3D:             {name: "isampler3D", sampler: true},
+	gl.INT_SAMPLER_CUBE:           {name: "isamplerCube", sampler: true},
+	gl.INT_SAMPLER_2D_RECT:        {name: "isampler2DRect", sampler: true},
+	gl.INT_SAMPLER_BUFFER:         {name: "isamplerBuffer", sampler: true},
+	gl.INT_SAMPLER_1D_ARRAY:       {name: "isampler1DArray", sampler: true},
+	gl.INT_SAMPLER_2D_ARRAY:       {name: "isampler2DArray", sampler: true},
+	gl.INT_SAMPLER_CUBE_MAP_ARRAY: {name: "isamplerCubeArray", sampler: true},
+
+	gl.UNSIGNED_INT_SAMPLER_1D:             {name: "usampler1D", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_2D:             {name: "usampler2D", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_3D:             {name: "usampler3D", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_CUBE:           {name: "usamplerCube", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_2D_RECT:        {name: "usampler2DRect", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_BUFFER:         {name: "usamplerBuffer", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_1D_ARRAY:       {name: "usampler1DArray", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_2D_ARRAY:       {name: "usampler2DArray", sampler: true},
+	gl.UNSIGNED_INT_SAMPLER_CUBE_MAP_ARRAY: {name: "usamplerCubeArray", sampler: true},
+
+	gl.IMAGE_1D:                    {name: "image1D", sampler: true},
+	gl.IMAGE_2D:                    {name: "image2D", sampler: true},
+	gl.IMAGE_3D:                    {name: "image3D", sampler: true},
+	gl.IMAGE_CUBE:                  {name: "imageCube", sampler: true},
+	gl.IMAGE_BUFFER:                {name: "imageBuffer", sampler: true},
+	gl.IMAGE_2D_ARRAY:              {name: "image2DArray", sampler: true},
+	gl.INT_IMAGE_2D:                {name: "iimage2D", sampler: true},
+	gl.INT_IMAGE_3D:                {name: "iimage3D", sampler: true},
+	gl.INT_IMAGE_2D_ARRAY:          {name: "iimage2DArray", sampler: true},
+	gl.UNSIGNED_INT_IMAGE_2D:       {name: "uimage2D", sampler: true},
+	gl.UNSIGNED_INT_IMAGE_3D:       {name: "uimage3D", sampler: true},
+	gl.UNSIGNED_INT_IMAGE_2D_ARRAY: {name: "uimage2DArray", sampler: true},
+}
+
+// uniformTypeName returns the GLSL name of an OpenGL uniform type.
+func uniformTypeName(glType uint32) string {
+	if uniformType, found := uniformTypes[glType]; found {
+		return uniformType.name
+	}
+	return fmt.Sprintf("0x%X", glType)
+}
+
+// uniformAccepts indicates if a uniform of glType can be set with a value of valueType
+// (gl.FLOAT, gl.INT_VEC2, gl.FLOAT_MAT4...).
+//
+//	Samplers and images accept int values, booleans accept int, uint or float values with the same components.
+func uniformAccepts(glType, valueType uint32) bool {
+	if glType == valueType {
+		return true
+	}
+	uniformType := uniformTypes[glType]
+	if uniformType.sampler {
+		return valueType == gl.INT
+	}
+	if uniformType.components > 0 {
+		return valueComponents(valueType) == uniformType.components
+	}
+	return false
+}
+
+// valueComponents returns the number of components of a scalar or vector value type (0 for other types).
+func valueComponents(valueType uint32) int {
+	switch valueType {
+	case gl.FLOAT, gl.INT, gl.UNSIGNED_INT:
+		return 1
+	case gl.FLOAT_VEC2, gl.INT_VEC2, gl.UNSIGNED_INT_VEC2:
+		return 2
+	case gl.FLOAT_VEC3, gl.INT_VEC3, gl.UNSIGNED_INT_VEC3:
+		return 3
+	case gl.FLOAT_VEC4, gl.INT_VEC4, gl.UNSIGNED_INT_VEC4:
+		return 4
+	}
+	return 0
+}
+
+// arrayBaseName returns the name of an array without its first element suffix ("lights[0]" gives "lights").
+func arrayBaseName(name string) (string, bool) {
+	if base, found := strings.CutSuffix(name, "[0]"); found {
+		return base, true
+	}
+	return name, false
+}
+
+// introspect lists active uniforms, attributes and blocks of the linked program.
+func (shaderProgram *ShaderProgram) introspect() {
+	handle := shaderProgram.handle
+	shaderProgram.uniforms = make(map[string]UniformInfo)
+	shaderProgram.attributes = make(map[string]AttributeInfo)
+
+	// Uniforms hors blocs (ceux des blocs sont alimentés par des buffers)
+	properties := []uint32{gl.TYPE, gl.ARRAY_SIZE, gl.LOCATION, gl.BLOCK_INDEX}
+	for _, resource := range programResources(handle, gl.UNIFORM, properties) {
+		if resource.values[3] >= 0 || resource.values[2] < 0 {
+			continue
+		}
+		uniform := UniformInfo{Name: resource.name, Type: uint32(resource.values[0]), Size: resource.values[1], Location: resource.values[2]}
+		shaderProgram.uniforms[uniform.Name] = uniform
+		base, isArray := arrayBaseName(uniform.Name)
+		if !isArray {
+			continue
+		}
+		// Tableau : accès par le nom de base et par élément
+		shaderProgram.uniforms[base] = uniform
+		for index := int32(1); index < uniform.Size; index++ {
+			element := UniformInfo{Name: fmt.Sprintf("%s[%d]", base, index), Type: uniform.Type, Size: uniform.Size - index}
+			element.Location = gl.GetProgramResourceLocation(handle, gl.UNIFORM, gl.Str(element.Name+"\x00"))
+			shaderProgram.uniforms[element.Name] = element
+		}
+	}
+
+	properties = []uint32{gl.TYPE, gl.ARRAY_SIZE, gl.LOCATION}
+	for _, resource := range programResources(handle, gl.PROGRAM_INPUT, properties) {
+		attribute := AttributeInfo{Name: resource.name, Type: uint32(resource.values[0]), Size: resource.values[1], Location: resource.values[2]}
+		// Les variables prédéfinies (gl_VertexID...) n'ont pas d'emplacement
+		if attribute.Location < 0 {
+			continue
+		}
+		shaderProgram.attributes[attribute.Name] = attribute
+		if base, isArray := arrayBaseName(attribute.Name); isArray {
+			shaderProgram.attributes[base] = attribute
+		}
+	}
+
+	shaderProgram.uniformBlocks = programBlocks(handle, gl.UNIFORM_BLOCK)
+	shaderProgram.storageBlocks = programBlocks(handle, gl.SHADER_STORAGE_BLOCK)
+
+	if shaderProgram.IsCompute() {
+		gl.GetProgramiv(handle, gl.COMPUTE_WORK_GROUP_SIZE, &shaderProgram.workGroupSize[0])
+	}
+}
+
+// programResource is an active resource of a program with the values of the queried properties.
+type programResource struct {
+	index  uint32
+	name   string
+	values []int32
+}
+
+// programResources returns the active resources of a program interface (gl.UNIFORM, gl.PROGRAM_INPUT...).
+func programResources(handle uint32, programInterface uint32, properties []uint32) []programResource {
+	var count, maxNameLength int32
+	gl.GetProgramInterfaceiv(handle, programInterface, gl.ACTIVE_RESOURCES, &count)
+	gl.GetProgramInterfaceiv(handle, programInterface, gl.MAX_NAME_LENGTH, &maxNameLength)
+	resources := make([]programResource, 0, count)
+	name := make([]uint8, maxNameLength+1)
+	for index := uint32(0); index < uint32(count); index++ {
+		var length int32
+		gl.GetProgramResourceName(handle, programInterface, index, int32(len(name)), &length, &name[0])
+		resource := programResource{index: index, name: string(name[:length]), values: make([]int32, len(properties))}
+		if len(properties) > 0 {
+			gl.GetProgramResourceiv(handle, programInterface, index, int32(len(properties)), &properties[0], int32(len(properties)), nil, &resource.values[0])
+		}
+		resources = append(resources, resource)
+	}
+	return resources
+}
+
+// programBlocks returns the active blocks of a program interface (gl.UNIFORM_BLOCK or gl.SHADER_STORAGE_BLOCK).
+func programBlocks(handle uint32, programInterface uint32) map[string]BlockInfo {
+	blocks := make(map[string]BlockInfo)
+	for _, resource := range programResources(handle, programInterface, []uint32{gl.BUFFER_BINDING, gl.BUFFER_DATA_SIZE}) {
+		block := BlockInfo{Name: resource.name, Index: resource.index, Binding: uint32(resource.values[0]), DataSize: resource.values[1]}
+		blocks[block.Name] = block
+		if base, isArray := arrayBaseName(block.Name); isArray {
+			blocks[base] = block
+		}
+	}
+	return blocks
+}
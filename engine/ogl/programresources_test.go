@@ -0,0 +1,98 @@
+package ogl
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+func TestUniformAccepts(t *testing.T) {
+	tests := []struct {
+		name      string
+		glType    uint32
+		valueType uint32
+		accepted  bool
+	}{
+		{"same type", gl.FLOAT_VEC3, gl.FLOAT_VEC3, true},
+		{"float for int", gl.INT, gl.FLOAT, false},
+		{"vec3 for vec4", gl.FLOAT_VEC4, gl.FLOAT_VEC3, false},
+		{"sampler", gl.SAMPLER_2D, gl.INT, true},
+		{"cube sampler", gl.SAMPLER_CUBE, gl.INT, true},
+		{"image", gl.IMAGE_2D, gl.INT, true},
+		{"float sampler", gl.SAMPLER_2D, gl.FLOAT, false},
+		{"int bool", gl.BOOL, gl.INT, true},
+		{"float bool", gl.BOOL, gl.FLOAT, true},
+		{"uint bvec2", gl.BOOL_VEC2, gl.UNSIGNED_INT_VEC2, true},
+		{"vec3 bvec2", gl.BOOL_VEC2, gl.FLOAT_VEC3, false},
+		{"mat3 for mat4", gl.FLOAT_MAT4, gl.FLOAT_MAT3, false},
+	}
+	for _, test := range tests {
+		if accepted := uniformAccepts(test.glType, test.valueType); accepted != test.accepted {
+			t.Fatalf("uniformAccepts() %s = %v, want %v", test.name, accepted, test.accepted)
+		}
+	}
+}
+
+func TestUniformTypeName(t *testing.T) {
+	tests := []struct {
+		glType uint32
+		name   string
+	}{
+		{gl.FLOAT_MAT3, "mat3"},
+		{gl.UNSIGNED_INT_VEC4, "uvec4"},
+		{gl.SAMPLER_2D_ARRAY, "sampler2DArray"},
+		{0x1234, "0x1234"},
+	}
+	for _, test := range tests {
+		if name := uniformTypeName(test.glType); name != test.name {
+			t.Fatalf("uniformTypeName(0x%X) = %s, want %s", test.glType, name, test.name)
+		}
+	}
+}
+
+func TestArrayBaseName(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		isArray bool
+	}{
+		{"lights[0]", "lights", true},
+		{"lights[0].color", "lights[0].color", false},
+		{"bones[1]", "bones[1]", false},
+		{"projection", "projection", false},
+	}
+	for _, test := range tests {
+		if base, isArray := arrayBaseName(test.name); base != test.base || isArray != test.isArray {
+			t.Fatalf("arrayBaseName(%s) = %s, %v, want %s, %v", test.name, base, isArray, test.base, test.isArray)
+		}
+	}
+}
+
+func TestShaderProgramUniforms(t *testing.T) {
+	// Programme sans contexte OpenGL : seuls les uniforms relevés sont utilisés
+	program := &ShaderProgram{
+		uniforms: map[string]UniformInfo{
+			"projection": {Name: "projection", Location: 0, Type: gl.FLOAT_MAT4, Size: 1},
+			"lights":     {Name: "lights[0]", Location: 1, Type: gl.FLOAT_VEC3, Size: 4},
+			"lights[0]":  {Name: "lights[0]", Location: 1, Type: gl.FLOAT_VEC3, Size: 4},
+			"lights[1]":  {Name: "lights[1]", Location: 2, Type: gl.FLOAT_VEC3, Size: 3},
+		},
+		reported: make(map[string]bool),
+	}
+	uniforms := program.Uniforms()
+	if len(uniforms) != 2 || uniforms[0].Name != "lights[0]" || uniforms[1].Name != "projection" {
+		t.Fatalf("Uniforms() = %+v, want lights[0] and projection", uniforms)
+	}
+	if location, ok := program.location("lights[1]", gl.FLOAT_VEC3, 3); !ok || location != 2 {
+		t.Fatalf("location(lights[1]) = %d, %v, want 2, true", location, ok)
+	}
+	if _, ok := program.location("lights[1]", gl.FLOAT_VEC3, 4); ok {
+		t.Fatalf("location(lights[1]) with 4 values = true, want false")
+	}
+	if _, ok := program.location("projection", gl.FLOAT_MAT3, 1); ok || !program.reported["projection"] {
+		t.Fatalf("location(projection) with mat3 = %v (reported %v), want false (reported)", ok, program.reported["projection"])
+	}
+	if _, ok := program.location("time", gl.FLOAT, 1); ok || !program.reported["time"] {
+		t.Fatalf("location(time) = %v (reported %v), want false (reported)", ok, program.reported["time"])
+	}
+}
@@ -0,0 +1,161 @@
+package ogl
+
+import (
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// BlendMode defines how drawn pixels are mixed with pixels already drawn.
+type BlendMode string
+
+// List of blend modes
+const (
+	// BLEND_NONE désactive le mélange (les pixels dessinés remplacent les pixels existants)
+	BLEND_NONE BlendMode = "none"
+	// BLEND_ALPHA mixes pixels according to their transparency (default mode)
+	BLEND_ALPHA BlendMode = "alpha"
+	// BLEND_PREMULTIPLIED mélange des pixels dont les couleurs sont déjà multipliées par leur transparence
+	BLEND_PREMULTIPLIED BlendMode = "premultiplied"
+	// BLEND_ADDITIVE adds pixels colors (fire, light, explosion effects)
+	BLEND_ADDITIVE BlendMode = "additive"
+	// BLEND_MULTIPLY multiplie les couleurs (ombres, assombrissement)
+	BLEND_MULTIPLY BlendMode = "multiply"
+	// BLEND_SCREEN inverse, multiplie puis inverse les couleurs (éclaircissement sans saturation brutale)
+	BLEND_SCREEN BlendMode = "screen"
+)
+
+// IsValid indicates if mode is a known blend mode.
+func (mode BlendMode) IsValid() bool {
+	switch mode {
+	case BLEND_NONE, BLEND_ALPHA, BLEND_PREMULTIPLIED, BLEND_ADDITIVE, BLEND_MULTIPLY, BLEND_SCREEN:
+		return true
+	}
+	return false
+}
+
+// factors returns OpenGL source and destination blend factors.
+func (mode BlendMode) factors() (uint32, uint32) {
+	switch mode {
+	case BLEND_PREMULTIPLIED:
+		return gl.ONE, gl.ONE_MINUS_SRC_ALPHA
+	case BLEND_ADDITIVE:
+		return gl.SRC_ALPHA, gl.ONE
+	case BLEND_MULTIPLY:
+		return gl.DST_COLOR, gl.ONE_MINUS_SRC_ALPHA
+	case BLEND_SCREEN:
+		return gl.ONE, gl.ONE_MINUS_SRC_COLOR
+	default:
+		return gl.SRC_ALPHA, gl.ONE_MINUS_SRC_ALPHA
+	}
+}
+
+// CullMode defines which faces are not drawn.
+type CullMode string
+
+// List of cull modes
+const (
+	// CULL_NONE dessine toutes les faces
+	CULL_NONE CullMode = "none"
+	// CULL_BACK masque les faces arrières
+	CULL_BACK CullMode = "back"
+	// CULL_FRONT masque les faces avant
+	CULL_FRONT CullMode = "front"
+)
+
+// DepthState defines depth buffer (Z-Buffer) usage.
+type DepthState struct {
+	// Test active le test de profondeur
+	Test bool
+	// Write active l'écriture dans le buffer de profondeur
+	Write bool
+	// Func est la fonction de comparaison (gl.LESS, gl.LEQUAL...) - 0 = gl.LESS
+	Func uint32
+}
+
+// ScissorState defines the rectangle outside of which nothing is drawn.
+type ScissorState struct {
+	// Enabled active le test de découpe
+	Enabled bool
+	// Rectangle est la zone de découpe (x, y, largeur, hauteur - origine en bas à gauche de la fenêtre)
+	Rectangle [4]int32
+}
+
+// StencilState defines stencil buffer usage (masks, portals, outlines...).
+//
+//	ReadMask and WriteMask are used as is: a zero WriteMask prevents stencil buffer writes.
+type StencilState struct {
+	// Enabled active le test du stencil
+	Enabled bool
+	// Func est la fonction de comparaison avec Ref (gl.ALWAYS, gl.EQUAL...) - 0 = gl.ALWAYS
+	Func uint32
+	// Ref est la valeur de référence
+	Ref int32
+	// ReadMask est appliqué à Ref et à la valeur du stencil avant comparaison
+	ReadMask uint32
+	// WriteMask indique les bits du stencil modifiables
+	WriteMask uint32
+	// Fail, DepthFail et Pass sont les opérations (gl.KEEP, gl.REPLACE, gl.INCR...) en cas d'échec du stencil,
+	// d'échec du test de profondeur et de succès - 0 = gl.KEEP
+	Fail      uint32
+	DepthFail uint32
+	Pass      uint32
+}
+
+// normalized returns stencil state with OpenGL default values instead of zero values.
+func (stencil StencilState) normalized() StencilState {
+	if stencil.Func == 0 {
+		stencil.Func = gl.ALWAYS
+	}
+	for _, operation := range []*uint32{&stencil.Fail, &stencil.DepthFail, &stencil.Pass} {
+		if *operation == 0 {
+			*operation = gl.KEEP
+		}
+	}
+	return stencil
+}
+
+// RenderState contains the OpenGL states used to draw.
+//
+//	Render states are applied through a StateCache (see GlobalStateCache).
+type RenderState struct {
+	Blend   BlendMode
+	Depth   DepthState
+	Cull    CullMode
+	Scissor ScissorState
+	Stencil StencilState
+}
+
+// DefaultRenderState3d returns the states used to draw a 3D scene
+// (no blending, depth test and write, front faces culled).
+func DefaultRenderState3d() RenderState {
+	return RenderState{
+		Blend:   BLEND_NONE,
+		Depth:   DepthState{Test: true, Write: true, Func: gl.LEQUAL},
+		Cull:    CULL_FRONT,
+		Stencil: StencilState{ReadMask: 0xFF, WriteMask: 0xFF},
+	}
+}
+
+// DefaultRenderState2d returns the states used to draw a 2D scene
+// (alpha blending, no depth test, no culling).
+func DefaultRenderState2d() RenderState {
+	return RenderState{
+		Blend:   BLEND_ALPHA,
+		Cull:    CULL_NONE,
+		Stencil: StencilState{ReadMask: 0xFF, WriteMask: 0xFF},
+	}
+}
+
+// normalized returns render state with OpenGL default values instead of zero values.
+func (state RenderState) normalized() RenderState {
+	if state.Blend == "" {
+		state.Blend = BLEND_NONE
+	}
+	if state.Cull == "" {
+		state.Cull = CULL_NONE
+	}
+	if state.Depth.Func == 0 {
+		state.Depth.Func = gl.LESS
+	}
+	state.Stencil = state.Stencil.normalized()
+	return state
+}
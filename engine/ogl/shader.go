@@ -10,9 +10,20 @@ import (
 
 type Shader struct {
 	handle uint32
+	// shaderType est le type du shader (gl.VERTEX_SHADER, gl.COMPUTE_SHADER...)
+	shaderType uint32
 }
 
 func NewShaderFromSource(src string, shaderType uint32) (*Shader, error) {
+	shader, err := compileShader(src, shaderType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile shader from sources\n - %w", err)
+	}
+	return shader, nil
+}
+
+// compileShader compiles a shader source, the error contains the compiler log.
+func compileShader(src string, shaderType uint32) (*Shader, error) {
 	handle := gl.CreateShader(shaderType)
 	glSrc, freeFn := gl.Strs(src + "\x00")
 	defer freeFn()
@@ -20,9 +31,10 @@ func NewShaderFromSource(src string, shaderType uint32) (*Shader, error) {
 	gl.CompileShader(handle)
 	err := getGlError(handle, gl.COMPILE_STATUS, gl.GetShaderiv, gl.GetShaderInfoLog)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compile shader from sources\n - %w", err)
+		gl.DeleteShader(handle)
+		return nil, err
 	}
-	return &Shader{handle: handle}, nil
+	return &Shader{handle: handle, shaderType: shaderType}, nil
 }
 
 func LoadShaderFromFile(filename string, shaderType uint32) (*Shader, error) {
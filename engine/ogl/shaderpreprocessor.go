@@ -0,0 +1,318 @@
+package ogl
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// ShaderSourceLoader returns the content of a shader source file (files, embedded sources, assets...).
+type ShaderSourceLoader func(name string) (string, error)
+
+// ShaderDefines contains #define injected in a shader (feature flags of a variant).
+type ShaderDefines map[string]string
+
+// Key returns a canonical key of the defines (sorted "NAME=value" list), identifying a variant.
+func (defines ShaderDefines) Key() string {
+	names := make([]string, 0, len(defines))
+	for name := range defines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var key strings.Builder
+	for index, name := range names {
+		if index > 0 {
+			key.WriteByte(';')
+		}
+		key.WriteString(name)
+		if value := defines[name]; value != "" {
+			key.WriteByte('=')
+			key.WriteString(value)
+		}
+	}
+	return key.String()
+}
+
+// sourceLine is the origin of a preprocessed line.
+type sourceLine struct {
+	file string
+	line int
+}
+
+// ShaderSource is a preprocessed shader source (includes resolved and defines injected).
+type ShaderSource struct {
+	Name string
+	Code string
+	// lines contient l'origine de chaque ligne de Code
+	lines []sourceLine
+}
+
+// Origin returns the file and line (from 1) of a line (from 1) of the preprocessed code.
+func (source *ShaderSource) Origin(line int) (string, int, bool) {
+	if line < 1 || line > len(source.lines) {
+		return "", 0, false
+	}
+	origin := source.lines[line-1]
+	return origin.file, origin.line, true
+}
+
+// compilerLocation matches line references of compiler logs: "0(12)" (NVIDIA) or "0:12" (Mesa, AMD, Intel).
+var compilerLocation = regexp.MustCompile(`\b\d+(?:\((\d+)\)|:(\d+))`)
+
+// RemapLog replaces preprocessed line references of a compiler log by original files and lines.
+func (source *ShaderSource) RemapLog(log string) string {
+	logLines := strings.Split(log, "\n")
+	for index, logLine := range logLines {
+		match := compilerLocation.FindStringSubmatchIndex(logLine)
+		if match == nil {
+			continue
+		}
+		// Groupe 1 : format "0(12)", groupe 2 : format "0:12"
+		group := 2
+		if match[group] < 0 {
+			group = 4
+		}
+		line, err := strconv.Atoi(logLine[match[group]:match[group+1]])
+		if err != nil {
+			continue
+		}
+		if file, originLine, found := source.Origin(line); found {
+			logLines[index] = fmt.Sprintf("%s%s:%d%s", logLine[:match[0]], file, originLine, logLine[match[1]:])
+		}
+	}
+	return strings.Join(logLines, "\n")
+}
+
+// Compile compiles the source, compiler errors refer to original files and lines.
+func (source *ShaderSource) Compile(shaderType uint32) (*Shader, error) {
+	shader, err := compileShader(source.Code, shaderType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile '%s' shader\n - %s", source.Name, source.RemapLog(err.Error()))
+	}
+	return shader, nil
+}
+
+// ShaderPreprocessor resolves #include directives and injects #define in shader sources.
+//
+//	'#include "file"' is relative to the including file, '#include <file>' is given as is to the loader.
+//	'#pragma once' includes a file only once. Includes are resolved even inside #if blocks.
+type ShaderPreprocessor struct {
+	loader ShaderSourceLoader
+	// files contient le contenu des fichiers déjà chargés
+	files map[string]string
+	// sources contient les sources déjà préparées, par nom et variante
+	sources map[string]*ShaderSource
+}
+
+// NewShaderPreprocessor creates a preprocessor loading files with loader.
+func NewShaderPreprocessor(loader ShaderSourceLoader) *ShaderPreprocessor {
+	return &ShaderPreprocessor{
+		loader:  loader,
+		files:   make(map[string]string),
+		sources: make(map[string]*ShaderSource),
+	}
+}
+
+// Preprocess returns the source of the name file with its includes and defines (cached by variant).
+func (preprocessor *ShaderPreprocessor) Preprocess(name string, defines ShaderDefines) (*ShaderSource, error) {
+	key := name + "|" + defines.Key()
+	if source, found := preprocessor.sources[key]; found {
+		return source, nil
+	}
+	source := &ShaderSource{Name: name}
+	var code strings.Builder
+	state := &preprocessState{included: make(map[string]bool)}
+	if err := preprocessor.expand(name, source, &code, state, defines); err != nil {
+		return nil, fmt.Errorf("failed to preprocess '%s' shader\n - %w", name, err)
+	}
+	source.Code = code.String()
+	preprocessor.sources[key] = source
+	return source, nil
+}
+
+// Invalidate empties caches (files are reloaded by the next Preprocess, after a modification for example).
+func (preprocessor *ShaderPreprocessor) Invalidate() {
+	preprocessor.files = make(map[string]string)
+	preprocessor.sources = make(map[string]*ShaderSource)
+}
+
+// preprocessState is the state of a preprocessing.
+type preprocessState struct {
+	// stack contient les fichiers en cours d'inclusion (détection des cycles)
+	stack []string
+	// included contient les fichiers marqués '#pragma once' déjà inclus
+	included map[string]bool
+	// versionFound indique que la directive #version a été rencontrée (defines injectés)
+	versionFound bool
+}
+
+// load returns the content of a file.
+func (preprocessor *ShaderPreprocessor) load(name string) (string, error) {
+	if content, found := preprocessor.files[name]; found {
+		return content, nil
+	}
+	content, err := preprocessor.loader(name)
+	if err != nil {
+		return "", err
+	}
+	preprocessor.files[name] = content
+	return content, nil
+}
+
+// expand writes the lines of name file in code, including files recursively.
+func (preprocessor *ShaderPreprocessor) expand(name string, source *ShaderSource, code *strings.Builder, state *preprocessState, defines ShaderDefines) error {
+	for _, parent := range state.stack {
+		if parent == name {
+			return fmt.Errorf("circular inclusion of '%s' (%s)", name, strings.Join(append(state.stack, name), " -> "))
+		}
+	}
+	if state.included[name] {
+		return nil
+	}
+	content, err := preprocessor.load(name)
+	if err != nil {
+		return fmt.Errorf("failed to load '%s' shader file\n - %w", name, err)
+	}
+	state.stack = append(state.stack, name)
+	defer func() { state.stack = state.stack[:len(state.stack)-1] }()
+
+	// Fichier racine sans #version : les defines sont en tête
+	isRoot := len(state.stack) == 1
+	if isRoot && !hasVersionDirective(content) {
+		writeDefines(source, code, defines)
+		state.versionFound = true
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for index, line := range lines {
+		directive, argument := parseDirective(line)
+		switch {
+		case directive == "include":
+			included, err := resolveInclude(name, argument)
+			if err != nil {
+				return fmt.Errorf("%s:%d: %w", name, index+1, err)
+			}
+			if err := preprocessor.expand(included, source, code, state, defines); err != nil {
+				return fmt.Errorf("%s:%d: failed to include '%s'\n - %w", name, index+1, included, err)
+			}
+			continue
+		case directive == "pragma" && argument == "once":
+			state.included[name] = true
+			continue
+		}
+		source.writeLine(code, line, name, index+1)
+		if directive == "version" && isRoot && !state.versionFound {
+			state.versionFound = true
+			writeDefines(source, code, defines)
+		}
+	}
+	return nil
+}
+
+// writeLine adds a line to the preprocessed code.
+func (source *ShaderSource) writeLine(code *strings.Builder, line string, file string, number int) {
+	code.WriteString(line)
+	code.WriteByte('\n')
+	source.lines = append(source.lines, sourceLine{file: file, line: number})
+}
+
+// writeDefines adds the #define lines of the variant.
+func writeDefines(source *ShaderSource, code *strings.Builder, defines ShaderDefines) {
+	names := make([]string, 0, len(defines))
+	for name := range defines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for index, name := range names {
+		line := "#define " + name
+		if value := defines[name]; value != "" {
+			line += " " + value
+		}
+		source.writeLine(code, line, "<defines>", index+1)
+	}
+}
+
+// hasVersionDirective indicates if a source contains a #version directive.
+func hasVersionDirective(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if directive, _ := parseDirective(line); directive == "version" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDirective returns the name and the argument of a preprocessor directive ("" if line is not a directive).
+func parseDirective(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+	line = strings.TrimSpace(line[1:])
+	directive, argument := line, ""
+	if separator := strings.IndexAny(line, " \t"); separator >= 0 {
+		directive, argument = line[:separator], line[separator+1:]
+	}
+	// Suppression d'un commentaire de fin de ligne
+	if comment := strings.Index(argument, "//"); comment >= 0 {
+		argument = argument[:comment]
+	}
+	return directive, strings.TrimSpace(argument)
+}
+
+// resolveInclude returns the name of the file included by '#include argument' from includer file.
+func resolveInclude(includer string, argument string) (string, error) {
+	if len(argument) >= 2 {
+		switch {
+		case argument[0] == '"' && argument[len(argument)-1] == '"':
+			return path.Join(path.Dir(includer), argument[1:len(argument)-1]), nil
+		case argument[0] == '<' && argument[len(argument)-1] == '>':
+			return path.Clean(argument[1 : len(argument)-1]), nil
+		}
+	}
+	return "", fmt.Errorf("invalid #include argument '%s'", argument)
+}
+
+// BuildProgram preprocesses, compiles and links a vertex and a fragment shader variant.
+func (preprocessor *ShaderPreprocessor) BuildProgram(vertexName, fragmentName string, defines ShaderDefines) (*ShaderProgram, error) {
+	vertexShader, err := preprocessor.compile(vertexName, gl.VERTEX_SHADER, defines)
+	if err != nil {
+		return nil, err
+	}
+	fragmentShader, err := preprocessor.compile(fragmentName, gl.FRAGMENT_SHADER, defines)
+	if err != nil {
+		vertexShader.Delete()
+		return nil, err
+	}
+	program, err := NewShaderProgram(vertexShader, fragmentShader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link '%s' and '%s' shaders\n - %w", vertexName, fragmentName, err)
+	}
+	return program, nil
+}
+
+// compile preprocesses and compiles a shader variant.
+func (preprocessor *ShaderPreprocessor) compile(name string, shaderType uint32, defines ShaderDefines) (*Shader, error) {
+	source, err := preprocessor.Preprocess(name, defines)
+	if err != nil {
+		return nil, err
+	}
+	return source.Compile(shaderType)
+}
+
+// BuildComputeProgram preprocesses, compiles and links a compute shader variant.
+func (preprocessor *ShaderPreprocessor) BuildComputeProgram(name string, defines ShaderDefines) (*ShaderProgram, error) {
+	shader, err := preprocessor.compile(name, gl.COMPUTE_SHADER, defines)
+	if err != nil {
+		return nil, err
+	}
+	return NewComputeProgram(shader)
+}
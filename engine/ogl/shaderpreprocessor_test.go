@@ -0,0 +1,110 @@
+package ogl
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// newTestPreprocessor returns a preprocessor reading files from a map (and counting loads).
+func newTestPreprocessor(files map[string]string) (*ShaderPreprocessor, map[string]int) {
+	loads := make(map[string]int)
+	return NewShaderPreprocessor(func(name string) (string, error) {
+		loads[name]++
+		content, found := files[name]
+		if !found {
+			return "", fmt.Errorf("file '%s' not found", name)
+		}
+		return content, nil
+	}), loads
+}
+
+func TestShaderPreprocessorInclude(t *testing.T) {
+	preprocessor, loads := newTestPreprocessor(map[string]string{
+		"shaders/sprite.frag":          "#version 460 core\n#include \"lib/lighting.glsl\"\n#include <common.glsl>\nvoid main() {}\n",
+		"shaders/lib/lighting.glsl":    "#pragma once\n#include \"../../common.glsl\"\nvec3 light() { return vec3(1); }\n",
+		"common.glsl":                  "#pragma once // shared\nconst float PI = 3.14;\n",
+		"shaders/defines_only.vert":    "void main() {}\n",
+		"shaders/cycle_a.glsl":         "#include \"cycle_b.glsl\"\n",
+		"shaders/cycle_b.glsl":         "#include \"cycle_a.glsl\"\n",
+		"shaders/missing.glsl":         "#include \"nothing.glsl\"\n",
+		"shaders/invalid_include.frag": "#include nothing.glsl\n",
+	})
+
+	defines := ShaderDefines{"USE_LIGHTING": "", "MAX_LIGHTS": "4"}
+	source, err := preprocessor.Preprocess("shaders/sprite.frag", defines)
+	if err != nil {
+		t.Fatalf("Preprocess() error = %v, want nil", err)
+	}
+	want := "#version 460 core\n#define MAX_LIGHTS 4\n#define USE_LIGHTING\nconst float PI = 3.14;\nvec3 light() { return vec3(1); }\nvoid main() {}\n"
+	if source.Code != want {
+		t.Fatalf("Preprocess() code = %q, want %q", source.Code, want)
+	}
+	origins := []struct {
+		file string
+		line int
+	}{
+		{"shaders/sprite.frag", 1}, {"<defines>", 1}, {"<defines>", 2}, {"common.glsl", 2}, {"shaders/lib/lighting.glsl", 3}, {"shaders/sprite.frag", 4},
+	}
+	for index, origin := range origins {
+		if file, line, _ := source.Origin(index + 1); file != origin.file || line != origin.line {
+			t.Fatalf("Origin(%d) = %s:%d, want %s:%d", index+1, file, line, origin.file, origin.line)
+		}
+	}
+
+	// Variante en cache, fichiers chargés une seule fois
+	if cached, _ := preprocessor.Preprocess("shaders/sprite.frag", ShaderDefines{"MAX_LIGHTS": "4", "USE_LIGHTING": ""}); cached != source {
+		t.Fatalf("Preprocess() with same defines returns a new source, want cached source")
+	}
+	if other, _ := preprocessor.Preprocess("shaders/sprite.frag", nil); other == source || strings.Contains(other.Code, "#define") {
+		t.Fatalf("Preprocess() without defines = %q, want another source without defines", other.Code)
+	}
+	if loads["common.glsl"] != 1 {
+		t.Fatalf("common.glsl loaded %d times, want 1", loads["common.glsl"])
+	}
+
+	if source, _ := preprocessor.Preprocess("shaders/defines_only.vert", ShaderDefines{"FLAG": ""}); source.Code != "#define FLAG\nvoid main() {}\n" {
+		t.Fatalf("Preprocess() without #version = %q, want defines first", source.Code)
+	}
+	for _, name := range []string{"shaders/cycle_a.glsl", "shaders/missing.glsl", "shaders/invalid_include.frag", "unknown.frag"} {
+		if _, err := preprocessor.Preprocess(name, nil); err == nil {
+			t.Fatalf("Preprocess(%s) error = nil, want error", name)
+		}
+	}
+}
+
+func TestShaderSourceRemapLog(t *testing.T) {
+	preprocessor, _ := newTestPreprocessor(map[string]string{
+		"main.frag":  "#version 460 core\n#include \"light.glsl\"\nvoid main() {}\n",
+		"light.glsl": "float a;\nfloat b = ;\n",
+	})
+	source, err := preprocessor.Preprocess("main.frag", ShaderDefines{"A": ""})
+	if err != nil {
+		t.Fatalf("Preprocess() error = %v, want nil", err)
+	}
+	tests := []struct {
+		log  string
+		want string
+	}{
+		{"0(4) : error C0000: syntax error", "light.glsl:2 : error C0000: syntax error"},
+		{"ERROR: 0:4: '' : syntax error", "ERROR: light.glsl:2: '' : syntax error"},
+		{"0:5(1): error: syntax error", "main.frag:3(1): error: syntax error"},
+		{"0:99(1): error: unknown line", "0:99(1): error: unknown line"},
+		{"warning without line", "warning without line"},
+	}
+	for _, test := range tests {
+		if remapped := source.RemapLog(test.log); remapped != test.want {
+			t.Fatalf("RemapLog(%q) = %q, want %q", test.log, remapped, test.want)
+		}
+	}
+}
+
+func TestShaderDefinesKey(t *testing.T) {
+	defines := ShaderDefines{"SHADOWS": "", "LIGHTS": "8"}
+	if key := defines.Key(); key != "LIGHTS=8;SHADOWS" {
+		t.Fatalf("Key() = %s, want LIGHTS=8;SHADOWS", key)
+	}
+	if key := ShaderDefines(nil).Key(); key != "" {
+		t.Fatalf("Key() of nil defines = %s, want empty key", key)
+	}
+}
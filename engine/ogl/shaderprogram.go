@@ -1,6 +1,10 @@
 package ogl
 
 import (
+	"fmt"
+	"log/slog"
+	"sort"
+
 	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
 )
@@ -8,17 +12,33 @@ import (
 type ShaderProgram struct {
 	handle  uint32
 	shaders []*Shader
+
+	// uniforms, attributes et blocs actifs, relevés à l'édition de liens
+	uniforms      map[string]UniformInfo
+	attributes    map[string]AttributeInfo
+	uniformBlocks map[string]BlockInfo
+	storageBlocks map[string]BlockInfo
+	// reported contient les uniforms inconnus ou mal typés déjà signalés
+	reported map[string]bool
+	// workGroupSize est la taille des groupes de travail d'un programme de calcul (local_size_x, y, z)
+	workGroupSize [3]int32
 }
 
+// NewShaderProgram links shaders in a program.
+//
+//	The program owns shaders: they are deleted with the program (at once if linking fails).
 func NewShaderProgram(shaders ...*Shader) (*ShaderProgram, error) {
 	program := &ShaderProgram{
-		handle:  gl.CreateProgram(),
-		shaders: make([]*Shader, 0),
+		handle:   gl.CreateProgram(),
+		shaders:  make([]*Shader, 0),
+		reported: make(map[string]bool),
 	}
 	program.attach(shaders...)
 	if err := program.link(); err != nil {
+		program.Delete()
 		return nil, err
 	}
+	program.introspect()
 	return program, nil
 }
 
@@ -31,6 +51,16 @@ func (shaderProgram *ShaderProgram) Delete() {
 	}
 }
 
+// Replace deletes the program and takes the content of program (rebuilt after a source change for example).
+//
+//	Pointers to shaderProgram stay valid, program is emptied and must not be used anymore.
+func (shaderProgram *ShaderProgram) Replace(program *ShaderProgram) {
+	previous := *shaderProgram
+	*shaderProgram = *program
+	*program = ShaderProgram{}
+	previous.Delete()
+}
+
 func (shaderProgram *ShaderProgram) link() error {
 	gl.LinkProgram(shaderProgram.handle)
 	return getGlError(shaderProgram.handle, gl.LINK_STATUS, gl.GetProgramiv, gl.GetProgramInfoLog)
@@ -51,42 +81,281 @@ func (shaderProgram *ShaderProgram) Unuse() {
 	gl.UseProgram(0)
 }
 
+// GetUniformLocation returns the location of an active uniform (-1 if it is unknown or unused).
 func (shaderProgram *ShaderProgram) GetUniformLocation(name string) int32 {
-	return gl.GetUniformLocation(shaderProgram.handle, gl.Str(name+"\x00"))
+	if uniform, found := shaderProgram.uniforms[name]; found {
+		return uniform.Location
+	}
+	return -1
+}
+
+// GetAttributeLocation returns the location of an active vertex attribute (-1 if it is unknown or unused).
+func (shaderProgram *ShaderProgram) GetAttributeLocation(name string) int32 {
+	if attribute, found := shaderProgram.attributes[name]; found {
+		return attribute.Location
+	}
+	return -1
+}
+
+// HasUniform indicates if the program uses a uniform ("name", "name[index]" for arrays).
+func (shaderProgram *ShaderProgram) HasUniform(name string) bool {
+	_, found := shaderProgram.uniforms[name]
+	return found
+}
+
+// Uniform returns the description of an active uniform.
+func (shaderProgram *ShaderProgram) Uniform(name string) (UniformInfo, bool) {
+	uniform, found := shaderProgram.uniforms[name]
+	return uniform, found
+}
+
+// Uniforms returns the active uniforms outside blocks, sorted by name (arrays appear once).
+func (shaderProgram *ShaderProgram) Uniforms() []UniformInfo {
+	uniforms := make([]UniformInfo, 0, len(shaderProgram.uniforms))
+	for name, uniform := range shaderProgram.uniforms {
+		// Les tableaux sont indexés par leur nom de base et par élément : seul le nom de base est retenu
+		isArrayElement := name[len(name)-1] == ']'
+		if name == uniform.Name && !isArrayElement || name+"[0]" == uniform.Name {
+			uniforms = append(uniforms, uniform)
+		}
+	}
+	sort.Slice(uniforms, func(i, j int) bool { return uniforms[i].Name < uniforms[j].Name })
+	return uniforms
+}
+
+// Attributes returns the active vertex attributes, sorted by location.
+func (shaderProgram *ShaderProgram) Attributes() []AttributeInfo {
+	attributes := make([]AttributeInfo, 0, len(shaderProgram.attributes))
+	for name, attribute := range shaderProgram.attributes {
+		if name == attribute.Name {
+			attributes = append(attributes, attribute)
+		}
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Location < attributes[j].Location })
+	return attributes
+}
+
+// UniformBlock returns the description of an active uniform block.
+func (shaderProgram *ShaderProgram) UniformBlock(name string) (BlockInfo, bool) {
+	block, found := shaderProgram.uniformBlocks[name]
+	return block, found
+}
+
+// StorageBlock returns the description of an active shader storage block.
+func (shaderProgram *ShaderProgram) StorageBlock(name string) (BlockInfo, bool) {
+	block, found := shaderProgram.storageBlocks[name]
+	return block, found
+}
+
+// SetUniformBlock attaches a uniform block to binding and binds buffer to it (nil to only change the binding).
+func (shaderProgram *ShaderProgram) SetUniformBlock(name string, binding uint32, buffer *Buffer) error {
+	block, found := shaderProgram.uniformBlocks[name]
+	if !found {
+		return fmt.Errorf("unknown '%s' uniform block", name)
+	}
+	if block.Binding != binding {
+		gl.UniformBlockBinding(shaderProgram.handle, block.Index, binding)
+		block.Binding = binding
+		shaderProgram.uniformBlocks[name] = block
+	}
+	if buffer != nil {
+		buffer.BindUniform(binding)
+	}
+	return nil
+}
+
+// SetStorageBlock attaches a shader storage block to binding and binds buffer to it (nil to only change the binding).
+func (shaderProgram *ShaderProgram) SetStorageBlock(name string, binding uint32, buffer *Buffer) error {
+	block, found := shaderProgram.storageBlocks[name]
+	if !found {
+		return fmt.Errorf("unknown '%s' shader storage block", name)
+	}
+	if block.Binding != binding {
+		gl.ShaderStorageBlockBinding(shaderProgram.handle, block.Index, binding)
+		block.Binding = binding
+		shaderProgram.storageBlocks[name] = block
+	}
+	if buffer != nil {
+		buffer.BindStorage(binding)
+	}
+	return nil
+}
+
+// location returns the location of a uniform set with count values of valueType.
+//
+//	Unknown uniforms and type mismatches are reported once, the value is then ignored.
+func (shaderProgram *ShaderProgram) location(name string, valueType uint32, count int) (int32, bool) {
+	uniform, found := shaderProgram.uniforms[name]
+	if !found {
+		shaderProgram.report(name, "unknown uniform", "name", name)
+		return -1, false
+	}
+	if !uniformAccepts(uniform.Type, valueType) {
+		shaderProgram.report(name, "uniform type mismatch", "name", name,
+			"type", uniformTypeName(uniform.Type), "value", uniformTypeName(valueType))
+		return -1, false
+	}
+	if count > int(uniform.Size) {
+		shaderProgram.report(name, "too many uniform values", "name", name, "size", uniform.Size, "count", count)
+		return -1, false
+	}
+	return uniform.Location, true
+}
+
+// report logs a uniform error the first time it occurs.
+func (shaderProgram *ShaderProgram) report(name string, message string, args ...any) {
+	if shaderProgram.reported[name] {
+		return
+	}
+	shaderProgram.reported[name] = true
+	slog.Warn(message, append(args, "program", shaderProgram.handle)...)
 }
 
 func (shaderProgram *ShaderProgram) Uniform1i(name string, value int32) {
-	gl.Uniform1i(shaderProgram.GetUniformLocation(name), value)
+	if location, ok := shaderProgram.location(name, gl.INT, 1); ok {
+		gl.ProgramUniform1i(shaderProgram.handle, location, value)
+	}
+}
+
+func (shaderProgram *ShaderProgram) Uniform2i(name string, x, y int32) {
+	if location, ok := shaderProgram.location(name, gl.INT_VEC2, 1); ok {
+		gl.ProgramUniform2i(shaderProgram.handle, location, x, y)
+	}
+}
+
+func (shaderProgram *ShaderProgram) Uniform3i(name string, x, y, z int32) {
+	if location, ok := shaderProgram.location(name, gl.INT_VEC3, 1); ok {
+		gl.ProgramUniform3i(shaderProgram.handle, location, x, y, z)
+	}
+}
+
+func (shaderProgram *ShaderProgram) Uniform4i(name string, x, y, z, w int32) {
+	if location, ok := shaderProgram.location(name, gl.INT_VEC4, 1); ok {
+		gl.ProgramUniform4i(shaderProgram.handle, location, x, y, z, w)
+	}
+}
+
+func (shaderProgram *ShaderProgram) Uniform1ui(name string, value uint32) {
+	if location, ok := shaderProgram.location(name, gl.UNSIGNED_INT, 1); ok {
+		gl.ProgramUniform1ui(shaderProgram.handle, location, value)
+	}
+}
+
+// UniformSampler sets the texture unit of a sampler (0 for gl.TEXTURE0).
+func (shaderProgram *ShaderProgram) UniformSampler(name string, unit int32) {
+	shaderProgram.Uniform1i(name, unit)
 }
 
 func (shaderProgram *ShaderProgram) Uniform1f(name string, value float32) {
-	gl.Uniform1f(shaderProgram.GetUniformLocation(name), value)
+	if location, ok := shaderProgram.location(name, gl.FLOAT, 1); ok {
+		gl.ProgramUniform1f(shaderProgram.handle, location, value)
+	}
 }
 
 func (shaderProgram *ShaderProgram) Uniform2f(name string, x, y float32) {
-	gl.Uniform2f(shaderProgram.GetUniformLocation(name), x, y)
+	if location, ok := shaderProgram.location(name, gl.FLOAT_VEC2, 1); ok {
+		gl.ProgramUniform2f(shaderProgram.handle, location, x, y)
+	}
 }
 
 func (shaderProgram *ShaderProgram) UniformVector2f(name string, vec2 mgl32.Vec2) {
-	gl.Uniform2f(shaderProgram.GetUniformLocation(name), vec2.X(), vec2.Y())
+	shaderProgram.Uniform2f(name, vec2.X(), vec2.Y())
 }
 
 func (shaderProgram *ShaderProgram) Uniform3f(name string, x, y, z float32) {
-	gl.Uniform3f(shaderProgram.GetUniformLocation(name), x, y, z)
+	if location, ok := shaderProgram.location(name, gl.FLOAT_VEC3, 1); ok {
+		gl.ProgramUniform3f(shaderProgram.handle, location, x, y, z)
+	}
 }
 
 func (shaderProgram *ShaderProgram) UniformVector3f(name string, vec3 mgl32.Vec3) {
-	gl.Uniform3f(shaderProgram.GetUniformLocation(name), vec3.X(), vec3.Y(), vec3.Z())
+	shaderProgram.Uniform3f(name, vec3.X(), vec3.Y(), vec3.Z())
 }
 
 func (shaderProgram *ShaderProgram) Uniform4f(name string, x, y, z, w float32) {
-	gl.Uniform4f(shaderProgram.GetUniformLocation(name), x, y, z, w)
+	if location, ok := shaderProgram.location(name, gl.FLOAT_VEC4, 1); ok {
+		gl.ProgramUniform4f(shaderProgram.handle, location, x, y, z, w)
+	}
 }
 
 func (shaderProgram *ShaderProgram) UniformVector4f(name string, vec4 mgl32.Vec4) {
-	gl.Uniform4f(shaderProgram.GetUniformLocation(name), vec4.X(), vec4.Y(), vec4.Z(), vec4.W())
+	shaderProgram.Uniform4f(name, vec4.X(), vec4.Y(), vec4.Z(), vec4.W())
+}
+
+func (shaderProgram *ShaderProgram) UniformMatrix2fv(name string, mat2 *mgl32.Mat2) {
+	if location, ok := shaderProgram.location(name, gl.FLOAT_MAT2, 1); ok {
+		gl.ProgramUniformMatrix2fv(shaderProgram.handle, location, 1, false, &mat2[0])
+	}
+}
+
+func (shaderProgram *ShaderProgram) UniformMatrix3fv(name string, mat3 *mgl32.Mat3) {
+	if location, ok := shaderProgram.location(name, gl.FLOAT_MAT3, 1); ok {
+		gl.ProgramUniformMatrix3fv(shaderProgram.handle, location, 1, false, &mat3[0])
+	}
 }
 
 func (shaderProgram *ShaderProgram) UniformMatrix4fv(name string, mat4 *mgl32.Mat4) {
-	gl.UniformMatrix4fv(shaderProgram.GetUniformLocation(name), 1, false, &mat4[0])
+	if location, ok := shaderProgram.location(name, gl.FLOAT_MAT4, 1); ok {
+		gl.ProgramUniformMatrix4fv(shaderProgram.handle, location, 1, false, &mat4[0])
+	}
+}
+
+// UniformArray1i sets the elements of an int (or sampler) array from name ("lights" or "lights[2]").
+func (shaderProgram *ShaderProgram) UniformArray1i(name string, values []int32) {
+	if len(values) == 0 {
+		return
+	}
+	if location, ok := shaderProgram.location(name, gl.INT, len(values)); ok {
+		gl.ProgramUniform1iv(shaderProgram.handle, location, int32(len(values)), &values[0])
+	}
+}
+
+// UniformArray1f sets the elements of a float array from name.
+func (shaderProgram *ShaderProgram) UniformArray1f(name string, values []float32) {
+	if len(values) == 0 {
+		return
+	}
+	if location, ok := shaderProgram.location(name, gl.FLOAT, len(values)); ok {
+		gl.ProgramUniform1fv(shaderProgram.handle, location, int32(len(values)), &values[0])
+	}
+}
+
+// UniformArrayVector2f sets the elements of a vec2 array from name.
+func (shaderProgram *ShaderProgram) UniformArrayVector2f(name string, values []mgl32.Vec2) {
+	if len(values) == 0 {
+		return
+	}
+	if location, ok := shaderProgram.location(name, gl.FLOAT_VEC2, len(values)); ok {
+		gl.ProgramUniform2fv(shaderProgram.handle, location, int32(len(values)), &values[0][0])
+	}
+}
+
+// UniformArrayVector3f sets the elements of a vec3 array from name.
+func (shaderProgram *ShaderProgram) UniformArrayVector3f(name string, values []mgl32.Vec3) {
+	if len(values) == 0 {
+		return
+	}
+	if location, ok := shaderProgram.location(name, gl.FLOAT_VEC3, len(values)); ok {
+		gl.ProgramUniform3fv(shaderProgram.handle, location, int32(len(values)), &values[0][0])
+	}
+}
+
+// UniformArrayVector4f sets the elements of a vec4 array from name.
+func (shaderProgram *ShaderProgram) UniformArrayVector4f(name string, values []mgl32.Vec4) {
+	if len(values) == 0 {
+		return
+	}
+	if location, ok := shaderProgram.location(name, gl.FLOAT_VEC4, len(values)); ok {
+		gl.ProgramUniform4fv(shaderProgram.handle, location, int32(len(values)), &values[0][0])
+	}
+}
+
+// UniformArrayMatrix4f sets the elements of a mat4 array from name (skeleton bones for example).
+func (shaderProgram *ShaderProgram) UniformArrayMatrix4f(name string, values []mgl32.Mat4) {
+	if len(values) == 0 {
+		return
+	}
+	if location, ok := shaderProgram.location(name, gl.FLOAT_MAT4, len(values)); ok {
+		gl.ProgramUniformMatrix4fv(shaderProgram.handle, location, int32(len(values)), false, &values[0][0])
+	}
 }
@@ -0,0 +1,20 @@
+package ogl
+
+import (
+	"testing"
+)
+
+func TestShaderProgram_Replace(t *testing.T) {
+	program := &ShaderProgram{workGroupSize: [3]int32{8, 8, 1}}
+	rebuilt := &ShaderProgram{
+		uniforms:      map[string]UniformInfo{"time": {Name: "time", Location: 3}},
+		workGroupSize: [3]int32{16, 16, 1},
+	}
+	program.Replace(rebuilt)
+	if program.WorkGroupSize() != [3]int32{16, 16, 1} || !program.HasUniform("time") {
+		t.Fatalf("Replace() program = %+v, want rebuilt program content", program)
+	}
+	if rebuilt.handle != 0 || rebuilt.uniforms != nil {
+		t.Fatalf("Replace() rebuilt = %+v, want empty program", rebuilt)
+	}
+}
@@ -0,0 +1,205 @@
+package ogl
+
+import (
+	"log/slog"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// stateDriver sends render states to OpenGL (it is replaced in tests).
+type stateDriver interface {
+	setCapability(capability uint32, enabled bool)
+	blendFunc(source, destination uint32)
+	depthMask(write bool)
+	depthFunc(function uint32)
+	cullFace(mode uint32)
+	scissor(rectangle [4]int32)
+	stencilFunc(function uint32, ref int32, mask uint32)
+	stencilOp(fail, depthFail, pass uint32)
+	stencilMask(mask uint32)
+}
+
+// glStateDriver is the OpenGL state driver.
+type glStateDriver struct{}
+
+func (glStateDriver) setCapability(capability uint32, enabled bool) {
+	if enabled {
+		gl.Enable(capability)
+	} else {
+		gl.Disable(capability)
+	}
+}
+
+func (glStateDriver) blendFunc(source, destination uint32) { gl.BlendFunc(source, destination) }
+func (glStateDriver) depthMask(write bool)                 { gl.DepthMask(write) }
+func (glStateDriver) depthFunc(function uint32)            { gl.DepthFunc(function) }
+func (glStateDriver) cullFace(mode uint32)                 { gl.CullFace(mode) }
+func (glStateDriver) scissor(rectangle [4]int32) {
+	gl.Scissor(rectangle[0], rectangle[1], rectangle[2], rectangle[3])
+}
+func (glStateDriver) stencilFunc(function uint32, ref int32, mask uint32) {
+	gl.StencilFunc(function, ref, mask)
+}
+func (glStateDriver) stencilOp(fail, depthFail, pass uint32) { gl.StencilOp(fail, depthFail, pass) }
+func (glStateDriver) stencilMask(mask uint32)                { gl.StencilMask(mask) }
+
+// StateCache keeps the current OpenGL render state to avoid redundant OpenGL calls.
+//
+//	All state changes must go through the cache (direct OpenGL calls require Invalidate).
+//	Push and Pop allow a rendering stage to change states and to restore them when it is done.
+type StateCache struct {
+	driver stateDriver
+	// current est l'état connu d'OpenGL
+	current RenderState
+	// valid indique si current correspond à l'état d'OpenGL (sinon, tout l'état est envoyé au prochain Apply)
+	valid bool
+	// stack contient les états sauvegardés par Push
+	stack []RenderState
+}
+
+// globalStateCache is the state cache of the OpenGL context.
+var globalStateCache = NewStateCache()
+
+// GlobalStateCache returns the state cache shared by everything drawing in the OpenGL context.
+func GlobalStateCache() *StateCache {
+	return globalStateCache
+}
+
+// NewStateCache creates a state cache (its first Apply sends the whole state to OpenGL).
+func NewStateCache() *StateCache {
+	return &StateCache{driver: glStateDriver{}}
+}
+
+// Invalidate forgets the known state: the next Apply sends the whole state to OpenGL.
+//
+//	It must be called when OpenGL context is created or when states are changed without the cache.
+func (cache *StateCache) Invalidate() {
+	cache.valid = false
+}
+
+// Current returns the current render state.
+func (cache *StateCache) Current() RenderState {
+	return cache.current
+}
+
+// Apply changes OpenGL states (only states different from the current state are sent).
+func (cache *StateCache) Apply(state RenderState) {
+	state = state.normalized()
+	previous, full := cache.current, !cache.valid
+	driver := cache.driver
+
+	// Transparence
+	if full || (previous.Blend == BLEND_NONE) != (state.Blend == BLEND_NONE) {
+		driver.setCapability(gl.BLEND, state.Blend != BLEND_NONE)
+	}
+	if state.Blend != BLEND_NONE && (full || previous.Blend != state.Blend) {
+		source, destination := state.Blend.factors()
+		driver.blendFunc(source, destination)
+	}
+
+	// Z-Buffer
+	if full || previous.Depth.Test != state.Depth.Test {
+		driver.setCapability(gl.DEPTH_TEST, state.Depth.Test)
+	}
+	if full || previous.Depth.Write != state.Depth.Write {
+		driver.depthMask(state.Depth.Write)
+	}
+	if full || previous.Depth.Func != state.Depth.Func {
+		driver.depthFunc(state.Depth.Func)
+	}
+
+	// Culling
+	if full || (previous.Cull == CULL_NONE) != (state.Cull == CULL_NONE) {
+		driver.setCapability(gl.CULL_FACE, state.Cull != CULL_NONE)
+	}
+	if state.Cull != CULL_NONE && (full || previous.Cull != state.Cull) {
+		if state.Cull == CULL_BACK {
+			driver.cullFace(gl.BACK)
+		} else {
+			driver.cullFace(gl.FRONT)
+		}
+	}
+
+	// Découpe
+	if full || previous.Scissor.Enabled != state.Scissor.Enabled {
+		driver.setCapability(gl.SCISSOR_TEST, state.Scissor.Enabled)
+	}
+	if state.Scissor.Enabled && (full || previous.Scissor.Rectangle != state.Scissor.Rectangle) {
+		driver.scissor(state.Scissor.Rectangle)
+	}
+
+	// Stencil (le masque d'écriture est aussi utilisé par l'effacement du buffer)
+	if full || previous.Stencil.Enabled != state.Stencil.Enabled {
+		driver.setCapability(gl.STENCIL_TEST, state.Stencil.Enabled)
+	}
+	if full || previous.Stencil.WriteMask != state.Stencil.WriteMask {
+		driver.stencilMask(state.Stencil.WriteMask)
+	}
+	if full || previous.Stencil.Func != state.Stencil.Func || previous.Stencil.Ref != state.Stencil.Ref ||
+		previous.Stencil.ReadMask != state.Stencil.ReadMask {
+		driver.stencilFunc(state.Stencil.Func, state.Stencil.Ref, state.Stencil.ReadMask)
+	}
+	if full || previous.Stencil.Fail != state.Stencil.Fail || previous.Stencil.DepthFail != state.Stencil.DepthFail ||
+		previous.Stencil.Pass != state.Stencil.Pass {
+		driver.stencilOp(state.Stencil.Fail, state.Stencil.DepthFail, state.Stencil.Pass)
+	}
+
+	cache.current = state
+	cache.valid = true
+}
+
+// SetBlend changes blend mode.
+func (cache *StateCache) SetBlend(mode BlendMode) {
+	state := cache.current
+	state.Blend = mode
+	cache.Apply(state)
+}
+
+// SetDepth changes depth buffer usage.
+func (cache *StateCache) SetDepth(depth DepthState) {
+	state := cache.current
+	state.Depth = depth
+	cache.Apply(state)
+}
+
+// SetCull changes cull mode.
+func (cache *StateCache) SetCull(mode CullMode) {
+	state := cache.current
+	state.Cull = mode
+	cache.Apply(state)
+}
+
+// SetScissor changes scissor test.
+func (cache *StateCache) SetScissor(scissor ScissorState) {
+	state := cache.current
+	state.Scissor = scissor
+	cache.Apply(state)
+}
+
+// SetStencil changes stencil buffer usage.
+func (cache *StateCache) SetStencil(stencil StencilState) {
+	state := cache.current
+	state.Stencil = stencil
+	cache.Apply(state)
+}
+
+// Push saves the current render state (it is restored by Pop).
+func (cache *StateCache) Push() {
+	cache.stack = append(cache.stack, cache.current)
+}
+
+// Pop restores the render state saved by the last Push.
+func (cache *StateCache) Pop() {
+	if len(cache.stack) == 0 {
+		slog.Warn("render state stack is empty (Pop without Push)")
+		return
+	}
+	state := cache.stack[len(cache.stack)-1]
+	cache.stack = cache.stack[:len(cache.stack)-1]
+	cache.Apply(state)
+}
+
+// StackSize returns the number of saved render states.
+func (cache *StateCache) StackSize() int {
+	return len(cache.stack)
+}
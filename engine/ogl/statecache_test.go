@@ -0,0 +1,120 @@
+package ogl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// recordingDriver records OpenGL calls instead of sending them.
+type recordingDriver struct {
+	calls []string
+}
+
+func (driver *recordingDriver) record(format string, args ...any) {
+	driver.calls = append(driver.calls, fmt.Sprintf(format, args...))
+}
+
+func (driver *recordingDriver) setCapability(capability uint32, enabled bool) {
+	driver.record("capability %#x %v", capability, enabled)
+}
+func (driver *recordingDriver) blendFunc(source, destination uint32) {
+	driver.record("blendFunc %#x %#x", source, destination)
+}
+func (driver *recordingDriver) depthMask(write bool)      { driver.record("depthMask %v", write) }
+func (driver *recordingDriver) depthFunc(function uint32) { driver.record("depthFunc %#x", function) }
+func (driver *recordingDriver) cullFace(mode uint32)      { driver.record("cullFace %#x", mode) }
+func (driver *recordingDriver) scissor(rectangle [4]int32) {
+	driver.record("scissor %v", rectangle)
+}
+func (driver *recordingDriver) stencilFunc(function uint32, ref int32, mask uint32) {
+	driver.record("stencilFunc %#x %d %#x", function, ref, mask)
+}
+func (driver *recordingDriver) stencilOp(fail, depthFail, pass uint32) {
+	driver.record("stencilOp %#x %#x %#x", fail, depthFail, pass)
+}
+func (driver *recordingDriver) stencilMask(mask uint32) { driver.record("stencilMask %#x", mask) }
+
+func newRecordingCache() (*StateCache, *recordingDriver) {
+	driver := &recordingDriver{}
+	return &StateCache{driver: driver}, driver
+}
+
+func TestStateCacheFirstApplySendsWholeState(t *testing.T) {
+	cache, driver := newRecordingCache()
+	cache.Apply(DefaultRenderState3d())
+	// blend, depth (3), cull (2), scissor, stencil (4) - pas de blendFunc ni de scissor rectangle (désactivés)
+	if len(driver.calls) != 11 {
+		t.Fatalf("Apply() sent %d calls, want 11: %v", len(driver.calls), driver.calls)
+	}
+}
+
+func TestStateCacheSkipsRedundantCalls(t *testing.T) {
+	cache, driver := newRecordingCache()
+	cache.Apply(DefaultRenderState2d())
+	driver.calls = nil
+	cache.Apply(DefaultRenderState2d())
+	if len(driver.calls) != 0 {
+		t.Fatalf("Apply() of the same state sent %v, want nothing", driver.calls)
+	}
+	cache.SetBlend(BLEND_ADDITIVE)
+	want := fmt.Sprintf("blendFunc %#x %#x", gl.SRC_ALPHA, gl.ONE)
+	if len(driver.calls) != 1 || driver.calls[0] != want {
+		t.Fatalf("SetBlend() sent %v, want [%s]", driver.calls, want)
+	}
+}
+
+func TestStateCacheBlendNone(t *testing.T) {
+	cache, driver := newRecordingCache()
+	cache.Apply(DefaultRenderState2d())
+	driver.calls = nil
+	cache.SetBlend(BLEND_NONE)
+	want := fmt.Sprintf("capability %#x false", gl.BLEND)
+	if len(driver.calls) != 1 || driver.calls[0] != want {
+		t.Fatalf("SetBlend(BLEND_NONE) sent %v, want [%s]", driver.calls, want)
+	}
+}
+
+func TestStateCachePushPop(t *testing.T) {
+	cache, driver := newRecordingCache()
+	cache.Apply(DefaultRenderState3d())
+	cache.Push()
+	cache.Apply(DefaultRenderState2d())
+	cache.SetScissor(ScissorState{Enabled: true, Rectangle: [4]int32{1, 2, 3, 4}})
+	if cache.StackSize() != 1 {
+		t.Fatalf("StackSize() = %d, want 1", cache.StackSize())
+	}
+	driver.calls = nil
+	cache.Pop()
+	if cache.Current() != DefaultRenderState3d().normalized() {
+		t.Fatalf("Current() = %+v, want 3D state", cache.Current())
+	}
+	// blend, depth (3), cull (2) et scissor
+	if len(driver.calls) != 7 {
+		t.Fatalf("Pop() sent %d calls, want 7: %v", len(driver.calls), driver.calls)
+	}
+	// Pop sans Push : l'état est conservé
+	cache.Pop()
+	if cache.StackSize() != 0 || cache.Current() != DefaultRenderState3d().normalized() {
+		t.Fatalf("Pop() on empty stack changed state")
+	}
+}
+
+func TestStateCacheInvalidate(t *testing.T) {
+	cache, driver := newRecordingCache()
+	cache.Apply(DefaultRenderState2d())
+	cache.Invalidate()
+	driver.calls = nil
+	cache.Apply(DefaultRenderState2d())
+	// blend (2), depth (3), cull, scissor, stencil (4)
+	if len(driver.calls) != 11 {
+		t.Fatalf("Apply() after Invalidate() sent %d calls, want 11: %v", len(driver.calls), driver.calls)
+	}
+}
+
+func TestBlendModeIsValid(t *testing.T) {
+	if !BLEND_SCREEN.IsValid() || BlendMode("unknown").IsValid() {
+		t.Fatalf("IsValid() is wrong")
+	}
+}
@@ -0,0 +1,92 @@
+package ogl
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// UniformValues contains uniform values sent each time a shader program is used (materials, post-processing passes...).
+type UniformValues map[string]any
+
+// SetInt sets an int uniform.
+func (values UniformValues) SetInt(name string, value int32) {
+	values[name] = value
+}
+
+// SetFloat sets a float uniform.
+func (values UniformValues) SetFloat(name string, value float32) {
+	values[name] = value
+}
+
+// SetVec2 sets a vec2 uniform.
+func (values UniformValues) SetVec2(name string, value mgl32.Vec2) {
+	values[name] = value
+}
+
+// SetVec3 sets a vec3 uniform.
+func (values UniformValues) SetVec3(name string, value mgl32.Vec3) {
+	values[name] = value
+}
+
+// SetVec4 sets a vec4 uniform.
+func (values UniformValues) SetVec4(name string, value mgl32.Vec4) {
+	values[name] = value
+}
+
+// SetMat3 sets a mat3 uniform.
+func (values UniformValues) SetMat3(name string, value mgl32.Mat3) {
+	values[name] = value
+}
+
+// SetMat4 sets a mat4 uniform.
+func (values UniformValues) SetMat4(name string, value mgl32.Mat4) {
+	values[name] = value
+}
+
+// Float returns a float uniform value (0 if it is not set).
+func (values UniformValues) Float(name string) float32 {
+	value, _ := values[name].(float32)
+	return value
+}
+
+// Apply sends values to a shader program (program must be in use).
+func (values UniformValues) Apply(shaderProgram *ShaderProgram) {
+	for name, value := range values {
+		switch value := value.(type) {
+		case int32:
+			shaderProgram.Uniform1i(name, value)
+		case uint32:
+			shaderProgram.Uniform1ui(name, value)
+		case float32:
+			shaderProgram.Uniform1f(name, value)
+		case mgl32.Vec2:
+			shaderProgram.UniformVector2f(name, value)
+		case mgl32.Vec3:
+			shaderProgram.UniformVector3f(name, value)
+		case mgl32.Vec4:
+			shaderProgram.UniformVector4f(name, value)
+		case mgl32.Mat2:
+			shaderProgram.UniformMatrix2fv(name, &value)
+		case mgl32.Mat3:
+			shaderProgram.UniformMatrix3fv(name, &value)
+		case mgl32.Mat4:
+			shaderProgram.UniformMatrix4fv(name, &value)
+		case []int32:
+			shaderProgram.UniformArray1i(name, value)
+		case []float32:
+			shaderProgram.UniformArray1f(name, value)
+		case []mgl32.Vec2:
+			shaderProgram.UniformArrayVector2f(name, value)
+		case []mgl32.Vec3:
+			shaderProgram.UniformArrayVector3f(name, value)
+		case []mgl32.Vec4:
+			shaderProgram.UniformArrayVector4f(name, value)
+		case []mgl32.Mat4:
+			shaderProgram.UniformArrayMatrix4f(name, value)
+		default:
+			slog.Warn("unsupported uniform value", "name", name, "type", fmt.Sprintf("%T", value))
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package ogl
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/go-gl/gl/v4.6-core/gl"
@@ -9,52 +10,113 @@ import (
 type VertexArray struct {
 	// vao (Vertex Array Object)
 	vao uint32
-	// vbo (Vertex Buffer Object)
-	vbo uint32
+	// ownedBuffers sont les buffers créés par le vertex array (libérés avec lui)
+	ownedBuffers []*Buffer
+	// bindings est le nombre de vertex buffers attachés
+	bindings uint32
+
+	indexBuffer *Buffer
+	indexType   IndexType
 
 	mode uint32
+	// vertexCount est le nombre de vertices (ou d'indices) dessinés par Draw
+	vertexCount int32
 }
 
-func NewVertexArrayWithTexture(vertices []float32, mode uint32) *VertexArray {
+// NewVertexArray creates an empty vertex array drawing mode primitives (gl.TRIANGLES for example).
+//
+//	Vertex buffers are added with AddVertexBuffer, indices with SetIndexBuffer.
+func NewVertexArray(mode uint32) *VertexArray {
 	slog.Debug("vertex array creation")
+	vertexArray := &VertexArray{mode: mode}
+	gl.CreateVertexArrays(1, &vertexArray.vao)
+	return vertexArray
+}
 
-	// https://learnopengl.com/code_viewer_gh.php?code=src/1.getting_started/4.2.textures_combined/textures_combined.cpp
+func NewVertexArrayWithTexture(vertices []float32, mode uint32) *VertexArray {
+	vertexArray := NewVertexArray(mode)
+
+	// Vertex = 3 coordonnées de position + 2 coordonnées de texture
+	layout := NewVertexLayout(FloatAttribute(0, 3), FloatAttribute(1, 2))
+	buffer, err := NewBufferWithData(vertices, USAGE_STATIC)
+	if err != nil {
+		slog.Error("vertex array creation failed", "error", err)
+		return vertexArray
+	}
+	vertexArray.ownedBuffers = append(vertexArray.ownedBuffers, buffer)
+	if err := vertexArray.AddVertexBuffer(buffer, layout); err != nil {
+		slog.Error("vertex array creation failed", "error", err)
+	}
+	vertexArray.vertexCount = int32(len(vertices) / 5)
 
-	// Créer le VAO (Vertex Array Object)
-	var vao uint32
-	gl.GenVertexArrays(1, &vao)
-	// Créer le VBO (Vertex Buffer Object)
-	var vbo uint32
-	gl.GenBuffers(1, &vbo)
+	return vertexArray
+}
 
-	// Bind the Vertex Array Object first, then bind and set vertex buffer(s) and attribute pointers()
-	gl.BindVertexArray(vao)
+// AddVertexBuffer attaches buffer to the vertex array with its layout (interleaved attributes, instancing divisor).
+//
+//	The buffer is not released with the vertex array.
+func (vertexArray *VertexArray) AddVertexBuffer(buffer *Buffer, layout VertexLayout) error {
+	return vertexArray.AddVertexBufferWithOffset(buffer, 0, layout)
+}
 
-	// copy vertices data into VBO (it needs to be bound first)
-	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
-	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW) // 4 = float32 size
+// AddVertexBufferWithOffset attaches buffer from offset (in bytes) to the vertex array with its layout.
+func (vertexArray *VertexArray) AddVertexBufferWithOffset(buffer *Buffer, offset int, layout VertexLayout) error {
+	if err := layout.Validate(); err != nil {
+		return fmt.Errorf("failed to add vertex buffer\n - %w", err)
+	}
+	binding := vertexArray.bindings
+	gl.VertexArrayVertexBuffer(vertexArray.vao, binding, buffer.handle, offset, layout.Stride)
+	for _, attribute := range layout.Attributes {
+		if attribute.Integer {
+			gl.VertexArrayAttribIFormat(vertexArray.vao, attribute.Location, attribute.Count, uint32(attribute.Type), attribute.Offset)
+		} else {
+			gl.VertexArrayAttribFormat(vertexArray.vao, attribute.Location, attribute.Count, uint32(attribute.Type), attribute.Normalized, attribute.Offset)
+		}
+		gl.VertexArrayAttribBinding(vertexArray.vao, attribute.Location, binding)
+		gl.EnableVertexArrayAttrib(vertexArray.vao, attribute.Location)
+	}
+	gl.VertexArrayBindingDivisor(vertexArray.vao, binding, layout.Divisor)
+	vertexArray.bindings++
 
-	var stride int32 = 3*4 + 2*4 // Taille des données d'un vertex = 3 coordonnées + 2 coordonnées de texture
-	var offset uintptr = 0       // Au début, l'offset est au début
+	// Par défaut, Draw dessine tous les vertices du premier buffer
+	if vertexArray.vertexCount == 0 && layout.Divisor == 0 {
+		vertexArray.vertexCount = int32((buffer.size - offset) / int(layout.Stride))
+	}
+	return nil
+}
 
-	// position
-	gl.VertexAttribPointerWithOffset(0, 3, gl.FLOAT, false, stride, offset) // 3 = nb coordonnées
-	gl.EnableVertexAttribArray(0)
-	offset += 3 * 4 // Décalage de l'offset des 3 coordonnées
+// SetIndexBuffer attaches an element buffer (nil to draw without indices).
+//
+//	The buffer is not released with the vertex array, Draw then uses all its indices.
+func (vertexArray *VertexArray) SetIndexBuffer(buffer *Buffer, indexType IndexType) error {
+	if buffer == nil {
+		gl.VertexArrayElementBuffer(vertexArray.vao, 0)
+		vertexArray.indexBuffer = nil
+		return nil
+	}
+	if indexType.Size() == 0 {
+		return fmt.Errorf("unknown 0x%X index type", uint32(indexType))
+	}
+	gl.VertexArrayElementBuffer(vertexArray.vao, buffer.handle)
+	vertexArray.indexBuffer = buffer
+	vertexArray.indexType = indexType
+	vertexArray.vertexCount = int32(buffer.size / indexType.Size())
+	return nil
+}
 
-	// color
-	gl.VertexAttribPointerWithOffset(1, 2, gl.FLOAT, false, stride, offset) // 2 = nb coordonnées
-	gl.EnableVertexAttribArray(1)
-	offset += 2 * 4 // Décalage de l'offset des 2 coordonnées de texture
+// IsIndexed indicates if the vertex array has an element buffer.
+func (vertexArray *VertexArray) IsIndexed() bool {
+	return vertexArray.indexBuffer != nil
+}
 
-	// Unbind du VAO à la fin de la création
-	gl.BindVertexArray(0)
+// SetVertexCount defines the number of vertices (or indices) drawn by Draw.
+func (vertexArray *VertexArray) SetVertexCount(count int32) {
+	vertexArray.vertexCount = count
+}
 
-	return &VertexArray{
-		vao:  vao,
-		vbo:  vbo,
-		mode: mode,
-	}
+// VertexCount returns the number of vertices (or indices) drawn by Draw.
+func (vertexArray *VertexArray) VertexCount() int32 {
+	return vertexArray.vertexCount
 }
 
 func (vertexArray *VertexArray) Bind() {
@@ -68,15 +130,65 @@ func (vertexArray *VertexArray) UnBind() {
 func (vertexArray *VertexArray) Release() {
 	if vertexArray.vao != 0 {
 		slog.Debug("vertex array destruction")
-		gl.DeleteBuffers(1, &vertexArray.vbo)
-		vertexArray.vbo = 0
+		for _, buffer := range vertexArray.ownedBuffers {
+			buffer.Delete()
+		}
+		vertexArray.ownedBuffers = nil
 		gl.DeleteVertexArrays(1, &vertexArray.vao)
 		vertexArray.vao = 0
 	}
 }
 
+// Draw draws all vertices (or indices if the vertex array has an element buffer).
 func (vertexArray *VertexArray) Draw() {
+	vertexArray.DrawInstanced(1)
+}
+
+// DrawInstanced draws all vertices (or indices) instances times.
+func (vertexArray *VertexArray) DrawInstanced(instances int32) {
+	if vertexArray.IsIndexed() {
+		vertexArray.DrawElementsInstanced(vertexArray.vertexCount, 0, 0, instances)
+	} else {
+		vertexArray.DrawArraysInstanced(0, vertexArray.vertexCount, instances)
+	}
+}
+
+// DrawArrays draws count vertices from first.
+func (vertexArray *VertexArray) DrawArrays(first, count int32) {
+	vertexArray.DrawArraysInstanced(first, count, 1)
+}
+
+// DrawArraysInstanced draws count vertices from first, instances times.
+func (vertexArray *VertexArray) DrawArraysInstanced(first, count, instances int32) {
 	vertexArray.Bind()
-	//gl.DrawElements(gl.TRIANGLES, 3, gl.UNSIGNED_INT, unsafe.Pointer(nil))
-	gl.DrawArrays(vertexArray.mode, 0, 36)
+	if instances == 1 {
+		gl.DrawArrays(vertexArray.mode, first, count)
+	} else {
+		gl.DrawArraysInstanced(vertexArray.mode, first, count, instances)
+	}
+}
+
+// DrawElements draws count indices from the offset index (in indices, not bytes).
+func (vertexArray *VertexArray) DrawElements(count, offset int32) {
+	vertexArray.DrawElementsInstanced(count, offset, 0, 1)
+}
+
+// DrawElementsInstanced draws count indices from the offset index, instances times.
+//
+//	baseVertex is added to each index before fetching the vertex.
+func (vertexArray *VertexArray) DrawElementsInstanced(count, offset, baseVertex, instances int32) {
+	if !vertexArray.IsIndexed() {
+		slog.Warn("vertex array without index buffer")
+		return
+	}
+	vertexArray.Bind()
+	indices := gl.PtrOffset(int(offset) * vertexArray.indexType.Size())
+	switch {
+	case instances == 1 && baseVertex == 0:
+		gl.DrawElements(vertexArray.mode, count, uint32(vertexArray.indexType), indices)
+	case instances == 1:
+		gl.DrawElementsBaseVertex(vertexArray.mode, count, uint32(vertexArray.indexType), indices, baseVertex)
+	default:
+		gl.DrawElementsInstancedBaseVertex(vertexArray.mode, count, uint32(vertexArray.indexType), indices, instances, baseVertex)
+	}
 }
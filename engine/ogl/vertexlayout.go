@@ -0,0 +1,136 @@
+package ogl
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.6-core/gl"
+)
+
+// AttributeType is the type of a vertex attribute component.
+type AttributeType uint32
+
+// List of vertex attribute types
+const (
+	ATTRIBUTE_FLOAT          AttributeType = gl.FLOAT
+	ATTRIBUTE_HALF_FLOAT     AttributeType = gl.HALF_FLOAT
+	ATTRIBUTE_BYTE           AttributeType = gl.BYTE
+	ATTRIBUTE_UNSIGNED_BYTE  AttributeType = gl.UNSIGNED_BYTE
+	ATTRIBUTE_SHORT          AttributeType = gl.SHORT
+	ATTRIBUTE_UNSIGNED_SHORT AttributeType = gl.UNSIGNED_SHORT
+	ATTRIBUTE_INT            AttributeType = gl.INT
+	ATTRIBUTE_UNSIGNED_INT   AttributeType = gl.UNSIGNED_INT
+)
+
+// Size returns the size of a component in bytes (0 for an unknown type).
+func (attributeType AttributeType) Size() int32 {
+	switch attributeType {
+	case ATTRIBUTE_BYTE, ATTRIBUTE_UNSIGNED_BYTE:
+		return 1
+	case ATTRIBUTE_HALF_FLOAT, ATTRIBUTE_SHORT, ATTRIBUTE_UNSIGNED_SHORT:
+		return 2
+	case ATTRIBUTE_FLOAT, ATTRIBUTE_INT, ATTRIBUTE_UNSIGNED_INT:
+		return 4
+	}
+	return 0
+}
+
+// VertexAttribute describes a vertex attribute in a buffer.
+type VertexAttribute struct {
+	// Location est l'emplacement de l'attribut dans le shader (layout (location = ...))
+	Location uint32
+	Type     AttributeType
+	// Count est le nombre de composantes (1 à 4)
+	Count int32
+	// Normalized convertit les entiers en flottants dans [0, 1] (non signés) ou [-1, 1] (signés)
+	Normalized bool
+	// Integer transmet les entiers sans conversion (attribut int, ivec ou uvec dans le shader)
+	Integer bool
+	// Offset est la position de l'attribut dans le vertex en octets (calculée par NewVertexLayout)
+	Offset uint32
+}
+
+// size returns the attribute size in bytes.
+func (attribute VertexAttribute) size() int32 {
+	return attribute.Type.Size() * attribute.Count
+}
+
+// FloatAttribute returns a float attribute (vec2, vec3...).
+func FloatAttribute(location uint32, count int32) VertexAttribute {
+	return VertexAttribute{Location: location, Type: ATTRIBUTE_FLOAT, Count: count}
+}
+
+// VertexLayout describes the vertices of a buffer: interleaved attributes, stride and instancing divisor.
+//
+//	Separate buffers (one per attribute for example) use one layout each (see VertexArray.AddVertexBuffer).
+type VertexLayout struct {
+	Attributes []VertexAttribute
+	// Stride est la taille d'un vertex en octets (calculée par NewVertexLayout)
+	Stride int32
+	// Divisor vaut 0 pour des données par vertex, N pour des données changeant toutes les N instances
+	Divisor uint32
+}
+
+// NewVertexLayout returns a layout of interleaved attributes (offsets and stride are computed, Offset fields are ignored).
+func NewVertexLayout(attributes ...VertexAttribute) VertexLayout {
+	layout := VertexLayout{Attributes: make([]VertexAttribute, 0, len(attributes))}
+	for _, attribute := range attributes {
+		attribute.Offset = uint32(layout.Stride)
+		layout.Attributes = append(layout.Attributes, attribute)
+		layout.Stride += attribute.size()
+	}
+	return layout
+}
+
+// WithDivisor returns a copy of the layout for instance data (divisor = number of instances sharing the same data).
+func (layout VertexLayout) WithDivisor(divisor uint32) VertexLayout {
+	layout.Divisor = divisor
+	return layout
+}
+
+// Validate checks attribute types and counts.
+func (layout VertexLayout) Validate() error {
+	if len(layout.Attributes) == 0 {
+		return fmt.Errorf("vertex layout has no attribute")
+	}
+	if layout.Stride <= 0 {
+		return fmt.Errorf("invalid vertex layout stride %d", layout.Stride)
+	}
+	for _, attribute := range layout.Attributes {
+		if attribute.Type.Size() == 0 {
+			return fmt.Errorf("unknown 0x%X type of attribute %d", uint32(attribute.Type), attribute.Location)
+		}
+		if attribute.Count < 1 || attribute.Count > 4 {
+			return fmt.Errorf("invalid component count %d of attribute %d", attribute.Count, attribute.Location)
+		}
+		if attribute.Integer && (attribute.Type == ATTRIBUTE_FLOAT || attribute.Type == ATTRIBUTE_HALF_FLOAT) {
+			return fmt.Errorf("integer attribute %d cannot have a float type", attribute.Location)
+		}
+		if int32(attribute.Offset)+attribute.size() > layout.Stride {
+			return fmt.Errorf("attribute %d exceeds vertex stride %d", attribute.Location, layout.Stride)
+		}
+	}
+	return nil
+}
+
+// IndexType is the type of the indices of an element buffer.
+type IndexType uint32
+
+// List of index types
+const (
+	INDEX_UNSIGNED_BYTE  IndexType = gl.UNSIGNED_BYTE
+	INDEX_UNSIGNED_SHORT IndexType = gl.UNSIGNED_SHORT
+	INDEX_UNSIGNED_INT   IndexType = gl.UNSIGNED_INT
+)
+
+// Size returns the size of an index in bytes (0 for an unknown type).
+func (indexType IndexType) Size() int {
+	switch indexType {
+	case INDEX_UNSIGNED_BYTE:
+		return 1
+	case INDEX_UNSIGNED_SHORT:
+		return 2
+	case INDEX_UNSIGNED_INT:
+		return 4
+	}
+	return 0
+}
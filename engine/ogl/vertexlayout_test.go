@@ -0,0 +1,104 @@
+package ogl
+
+import "testing"
+
+func TestNewVertexLayout(t *testing.T) {
+	layout := NewVertexLayout(
+		FloatAttribute(0, 3),
+		VertexAttribute{Location: 1, Type: ATTRIBUTE_UNSIGNED_BYTE, Count: 4, Normalized: true},
+		VertexAttribute{Location: 2, Type: ATTRIBUTE_SHORT, Count: 2, Integer: true},
+	)
+	offsets := []uint32{0, 12, 16}
+	for index, attribute := range layout.Attributes {
+		if attribute.Offset != offsets[index] {
+			t.Fatalf("NewVertexLayout() attribute %d offset = %d, want %d", index, attribute.Offset, offsets[index])
+		}
+	}
+	if layout.Stride != 20 {
+		t.Fatalf("NewVertexLayout() stride = %d, want 20", layout.Stride)
+	}
+	if err := layout.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+	if instanced := layout.WithDivisor(1); instanced.Divisor != 1 || layout.Divisor != 0 {
+		t.Fatalf("WithDivisor(1) = %d (layout %d), want 1 (layout 0)", instanced.Divisor, layout.Divisor)
+	}
+}
+
+func TestVertexLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout VertexLayout
+	}{
+		{"empty", NewVertexLayout()},
+		{"unknown type", NewVertexLayout(VertexAttribute{Type: 0x1234, Count: 2})},
+		{"no component", NewVertexLayout(FloatAttribute(0, 0))},
+		{"too many components", NewVertexLayout(FloatAttribute(0, 5))},
+		{"integer float", NewVertexLayout(VertexAttribute{Type: ATTRIBUTE_FLOAT, Count: 1, Integer: true})},
+		{"exceeding stride", VertexLayout{Attributes: []VertexAttribute{FloatAttribute(0, 4)}, Stride: 12}},
+	}
+	for _, test := range tests {
+		if err := test.layout.Validate(); err == nil {
+			t.Fatalf("Validate() %s = nil, want error", test.name)
+		}
+	}
+}
+
+func TestIndexTypeSize(t *testing.T) {
+	tests := []struct {
+		indexType IndexType
+		size      int
+	}{
+		{INDEX_UNSIGNED_BYTE, 1},
+		{INDEX_UNSIGNED_SHORT, 2},
+		{INDEX_UNSIGNED_INT, 4},
+		{IndexType(0), 0},
+	}
+	for _, test := range tests {
+		if size := test.indexType.Size(); size != test.size {
+			t.Fatalf("Size() of 0x%X = %d, want %d", uint32(test.indexType), size, test.size)
+		}
+	}
+}
+
+func TestSliceData(t *testing.T) {
+	type vertex struct {
+		position [3]float32
+		color    [4]uint8
+	}
+	tests := []struct {
+		name string
+		data any
+		size int
+	}{
+		{"float32", []float32{1, 2, 3}, 12},
+		{"uint16", []uint16{1, 2, 3}, 6},
+		{"struct", []vertex{{}, {}}, 32},
+		{"empty", []uint32{}, 0},
+	}
+	for _, test := range tests {
+		_, size, err := sliceData(test.data)
+		if err != nil || size != test.size {
+			t.Fatalf("sliceData() %s = %d, %v, want %d, nil", test.name, size, err, test.size)
+		}
+	}
+	type named struct {
+		Name string
+	}
+	type linked struct {
+		Next *linked
+	}
+	type nested struct {
+		Position [3]float32
+		Names    [2]named
+	}
+	invalidData := []any{
+		[3]float32{}, 1.0, []*float32{}, []string{"a"},
+		[]named{{"a"}}, []linked{{}}, [][4]*float32{{}}, []nested{{}}, [][2][]int{{}},
+	}
+	for _, data := range invalidData {
+		if _, _, err := sliceData(data); err == nil {
+			t.Fatalf("sliceData(%T) = nil error, want error", data)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package particles
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"log/slog"
+	"ogl46/engine/graphic"
+	"os"
+)
+
+// ShapeType est la forme de la zone d'apparition des particules
+type ShapeType string
+
+// Liste des formes d'émetteur
+const (
+	// SHAPE_POINT fait apparaître les particules à la position de l'émetteur
+	SHAPE_POINT ShapeType = "point"
+	// SHAPE_CIRCLE fait apparaître les particules dans un disque (ou sur un cercle)
+	SHAPE_CIRCLE ShapeType = "circle"
+	// SHAPE_RECTANGLE fait apparaître les particules dans un rectangle centré sur l'émetteur
+	SHAPE_RECTANGLE ShapeType = "rectangle"
+	// SHAPE_LINE fait apparaître les particules sur un segment partant de l'émetteur
+	SHAPE_LINE ShapeType = "line"
+)
+
+// Burst est une émission instantanée de particules
+type Burst struct {
+	// TimeInMs est l'instant de l'émission (depuis le démarrage de l'émetteur)
+	TimeInMs int64 `json:"time"`
+	Count    int   `json:"count"`
+	// Cycles est le nombre d'émissions (1 par défaut, -1 = indéfiniment)
+	Cycles int `json:"cycles,omitempty"`
+	// IntervalInMs est l'intervalle entre deux émissions
+	IntervalInMs int64 `json:"interval,omitempty"`
+}
+
+// EmitterConfig décrit un émetteur de particules (peut être décrit dans un fichier JSON)
+type EmitterConfig struct {
+	Name string `json:"name,omitempty"`
+	// Texture est le nom de la texture des particules (résolu lors de la construction de l'effet)
+	Texture string `json:"texture"`
+	// Regions sont les zones de la texture utilisables par les particules (une zone au hasard par particule,
+	// toute la texture si vide)
+	Regions []graphic.Rectangle `json:"regions,omitempty"`
+	// Blend est le mode de mélange des particules ("alpha" par défaut ou "additive")
+	Blend graphic.BlendMode `json:"blend,omitempty"`
+
+	// Shape est la forme de la zone d'apparition ("point" par défaut)
+	Shape ShapeType `json:"shape,omitempty"`
+	// Radius est le rayon d'un émetteur circulaire
+	Radius float32 `json:"radius,omitempty"`
+	// Edge indique que les particules apparaissent sur le bord du cercle (et non dans le disque)
+	Edge bool `json:"edge,omitempty"`
+	// Size est la dimension d'un émetteur rectangulaire
+	Size mgl32.Vec2 `json:"size,omitempty"`
+	// LineEnd est l'extrémité d'un émetteur linéaire (relative à la position de l'émetteur)
+	LineEnd mgl32.Vec2 `json:"lineEnd,omitempty"`
+	// Offset est le décalage de la zone d'apparition par rapport à la position de l'émetteur
+	Offset mgl32.Vec2 `json:"offset,omitempty"`
+
+	// Rate est le nombre de particules émises par seconde
+	Rate float32 `json:"rate,omitempty"`
+	// Bursts sont les émissions instantanées
+	Bursts []Burst `json:"bursts,omitempty"`
+	// DurationInMs est la durée d'émission (0 = indéfiniment)
+	DurationInMs int64 `json:"duration,omitempty"`
+	// Loop indique que l'émission recommence à la fin de la durée
+	Loop bool `json:"loop,omitempty"`
+	// MaxParticles est le nombre maximal de particules vivantes (DEFAULT_MAX_PARTICLES par défaut)
+	MaxParticles int `json:"maxParticles,omitempty"`
+
+	// LifetimeInMs est la durée de vie des particules
+	LifetimeInMs Range `json:"lifetime"`
+	// Speed est la vitesse initiale (pixels par seconde)
+	Speed Range `json:"speed"`
+	// Direction est la direction d'émission (en degrés, 0 = vers la droite, 90 = vers le bas)
+	Direction float32 `json:"direction,omitempty"`
+	// Spread est l'écart maximal autour de la direction (en degrés, 360 = toutes les directions)
+	Spread float32 `json:"spread,omitempty"`
+	// Gravity est l'accélération appliquée aux particules (pixels par seconde²)
+	Gravity mgl32.Vec2 `json:"gravity,omitempty"`
+	// Drag est le freinage des particules (fraction de vitesse perdue par seconde)
+	Drag float32 `json:"drag,omitempty"`
+
+	// StartSize est la dimension initiale des particules (en pixels)
+	StartSize Range `json:"startSize"`
+	// SizeCurve est le facteur de dimension selon l'âge de la particule
+	SizeCurve Curve `json:"sizeCurve,omitempty"`
+	// ColorCurve est la couleur selon l'âge de la particule
+	ColorCurve ColorCurve `json:"colorCurve,omitempty"`
+	// StartRotation est la rotation initiale (en degrés)
+	StartRotation Range `json:"startRotation,omitempty"`
+	// AngularVelocity est la vitesse de rotation (en degrés par seconde)
+	AngularVelocity Range `json:"angularVelocity,omitempty"`
+	// RotationCurve est la rotation ajoutée selon l'âge de la particule (en degrés, aucune si vide)
+	RotationCurve Curve `json:"rotationCurve,omitempty"`
+}
+
+// DEFAULT_MAX_PARTICLES est le nombre maximal de particules vivantes par défaut d'un émetteur
+const DEFAULT_MAX_PARTICLES = 1000
+
+// Validate vérifie la cohérence de la description
+func (config *EmitterConfig) Validate() error {
+	switch config.Shape {
+	case "", SHAPE_POINT, SHAPE_CIRCLE, SHAPE_RECTANGLE, SHAPE_LINE:
+	default:
+		return fmt.Errorf("unknown '%s' emitter shape", config.Shape)
+	}
+	if config.Blend != "" && !config.Blend.IsValid() {
+		return fmt.Errorf("unknown '%s' blend mode", config.Blend)
+	}
+	if config.Rate < 0 {
+		return fmt.Errorf("invalid emission rate %v", config.Rate)
+	}
+	if config.LifetimeInMs.Max <= 0 {
+		return fmt.Errorf("invalid particle lifetime [%v, %v]", config.LifetimeInMs.Min, config.LifetimeInMs.Max)
+	}
+	for _, burst := range config.Bursts {
+		if burst.Count < 0 || burst.TimeInMs < 0 || (burst.Cycles != 1 && burst.Cycles != 0 && burst.IntervalInMs <= 0) {
+			return fmt.Errorf("invalid burst (time %d, count %d, cycles %d, interval %d)", burst.TimeInMs, burst.Count, burst.Cycles, burst.IntervalInMs)
+		}
+	}
+	return nil
+}
+
+// EffectConfig décrit un effet composé de plusieurs émetteurs (explosion = flash + étincelles + fumée par exemple)
+type EffectConfig struct {
+	Emitters []EmitterConfig `json:"emitters"`
+}
+
+// LoadEffectConfigFromFile charge la description d'un effet depuis un fichier JSON
+func LoadEffectConfigFromFile(filename string) (*EffectConfig, error) {
+	slog.Debug("particle effect creation from file", "filename", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load particle effect file '%s'\n - %w", filename, err)
+	}
+	config, err := LoadEffectConfigFromBytes(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build particle effect from file '%s'\n - %w", filename, err)
+	}
+	return config, nil
+}
+
+// LoadEffectConfigFromBytes charge la description d'un effet depuis un contenu JSON
+func LoadEffectConfigFromBytes(content []byte) (*EffectConfig, error) {
+	config := EffectConfig{}
+	if err := json.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode particle effect\n - %w", err)
+	}
+	if len(config.Emitters) == 0 {
+		return nil, fmt.Errorf("particle effect has no emitter")
+	}
+	for index := range config.Emitters {
+		if err := config.Emitters[index].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid '%s' emitter (#%d)\n - %w", config.Emitters[index].Name, index, err)
+		}
+	}
+	return &config, nil
+}
@@ -0,0 +1,96 @@
+package particles
+
+import (
+	"math/rand"
+	"ogl46/engine/graphic"
+)
+
+// Range est un intervalle de valeurs (une valeur est tirée au hasard dans l'intervalle)
+type Range struct {
+	Min float32 `json:"min"`
+	Max float32 `json:"max"`
+}
+
+// BuildRange construit un intervalle
+func BuildRange(minimum, maximum float32) Range {
+	return Range{Min: minimum, Max: maximum}
+}
+
+// Fixed construit un intervalle réduit à une valeur
+func Fixed(value float32) Range {
+	return Range{Min: value, Max: value}
+}
+
+// Random tire une valeur au hasard dans l'intervalle
+func (valueRange Range) Random(random *rand.Rand) float32 {
+	if valueRange.Max <= valueRange.Min {
+		return valueRange.Min
+	}
+	return valueRange.Min + random.Float32()*(valueRange.Max-valueRange.Min)
+}
+
+// Key est une valeur clé d'une courbe
+type Key struct {
+	// Time est la position dans la vie de la particule (0 = naissance, 1 = mort)
+	Time  float32 `json:"time"`
+	Value float32 `json:"value"`
+}
+
+// Curve est une courbe d'évolution d'une valeur pendant la vie d'une particule (interpolation linéaire entre les clés)
+//
+//	Les clés doivent être triées par temps. Une courbe vide vaut 1 (valeur neutre pour un facteur).
+type Curve []Key
+
+// Value retourne la valeur de la courbe au temps indiqué (entre 0 et 1)
+func (curve Curve) Value(time float32) float32 {
+	if len(curve) == 0 {
+		return 1
+	}
+	if time <= curve[0].Time {
+		return curve[0].Value
+	}
+	for index := 1; index < len(curve); index++ {
+		next := curve[index]
+		if time < next.Time {
+			previous := curve[index-1]
+			ratio := (time - previous.Time) / (next.Time - previous.Time)
+			return previous.Value + (next.Value-previous.Value)*ratio
+		}
+	}
+	return curve[len(curve)-1].Value
+}
+
+// ColorKey est une couleur clé d'une courbe de couleurs
+type ColorKey struct {
+	// Time est la position dans la vie de la particule (0 = naissance, 1 = mort)
+	Time  float32       `json:"time"`
+	Color graphic.Color `json:"color"`
+}
+
+// ColorCurve est une courbe d'évolution de la couleur pendant la vie d'une particule
+//
+//	Les clés doivent être triées par temps. Une courbe vide vaut blanc (pas de teinte).
+type ColorCurve []ColorKey
+
+// Value retourne la couleur de la courbe au temps indiqué (entre 0 et 1)
+func (curve ColorCurve) Value(time float32) graphic.Color {
+	if len(curve) == 0 {
+		return graphic.White
+	}
+	if time <= curve[0].Time {
+		return curve[0].Color
+	}
+	for index := 1; index < len(curve); index++ {
+		next := curve[index]
+		if time < next.Time {
+			previous := curve[index-1]
+			ratio := (time - previous.Time) / (next.Time - previous.Time)
+			var color graphic.Color
+			for component := range color {
+				color[component] = previous.Color[component] + (next.Color[component]-previous.Color[component])*ratio
+			}
+			return color
+		}
+	}
+	return curve[len(curve)-1].Color
+}
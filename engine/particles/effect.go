@@ -0,0 +1,116 @@
+package particles
+
+import (
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine/graphic"
+)
+
+// TextureFunc retourne la texture portant le nom indiqué (TextureManager().Get par exemple)
+type TextureFunc func(name string) (*graphic.Texture, error)
+
+// Effect est un effet de particules composé de plusieurs émetteurs partageant la même position
+type Effect struct {
+	emitters []*Emitter
+	position mgl32.Vec2
+}
+
+// NewEffect construit un effet à partir de sa description (l'émission démarre immédiatement)
+//
+//	textures permet de récupérer les textures des émetteurs (peut être nil pour simuler l'effet sans le dessiner).
+func NewEffect(config *EffectConfig, textures TextureFunc) (*Effect, error) {
+	effect := &Effect{
+		emitters: make([]*Emitter, 0, len(config.Emitters)),
+	}
+	for index := range config.Emitters {
+		emitterConfig := &config.Emitters[index]
+		var texture *graphic.Texture
+		if textures != nil && emitterConfig.Texture != "" {
+			var err error
+			if texture, err = textures(emitterConfig.Texture); err != nil {
+				return nil, fmt.Errorf("failed to get '%s' emitter texture\n - %w", emitterConfig.Name, err)
+			}
+		}
+		emitter, err := NewEmitter(emitterConfig, texture)
+		if err != nil {
+			return nil, err
+		}
+		effect.emitters = append(effect.emitters, emitter)
+	}
+	return effect, nil
+}
+
+// Emitters retourne les émetteurs de l'effet
+func (effect *Effect) Emitters() []*Emitter {
+	return effect.emitters
+}
+
+// Emitter retourne l'émetteur portant le nom indiqué (nil si absent)
+func (effect *Effect) Emitter(name string) *Emitter {
+	for _, emitter := range effect.emitters {
+		if emitter.config.Name == name {
+			return emitter
+		}
+	}
+	return nil
+}
+
+// Position retourne la position de l'effet
+func (effect *Effect) Position() mgl32.Vec2 {
+	return effect.position
+}
+
+// SetPosition déplace tous les émetteurs de l'effet
+func (effect *Effect) SetPosition(position mgl32.Vec2) {
+	effect.position = position
+	for _, emitter := range effect.emitters {
+		emitter.Position = position
+	}
+}
+
+// Start (re)démarre tous les émetteurs
+func (effect *Effect) Start() {
+	for _, emitter := range effect.emitters {
+		emitter.Start()
+	}
+}
+
+// Stop arrête l'émission de tous les émetteurs (les particules déjà émises continuent à vivre)
+func (effect *Effect) Stop() {
+	for _, emitter := range effect.emitters {
+		emitter.Stop()
+	}
+}
+
+// IsAlive indique si un émetteur émet encore ou a encore des particules vivantes
+func (effect *Effect) IsAlive() bool {
+	for _, emitter := range effect.emitters {
+		if emitter.IsAlive() {
+			return true
+		}
+	}
+	return false
+}
+
+// ParticleCount retourne le nombre de particules vivantes
+func (effect *Effect) ParticleCount() int {
+	count := 0
+	for _, emitter := range effect.emitters {
+		count += emitter.ParticleCount()
+	}
+	return count
+}
+
+// Update fait vivre tous les émetteurs
+func (effect *Effect) Update(elapsedTime int64) {
+	for _, emitter := range effect.emitters {
+		emitter.Update(elapsedTime)
+	}
+}
+
+// Draw dessine les particules de tous les émetteurs (dans l'ordre des émetteurs)
+func (effect *Effect) Draw(renderer *graphic.Renderer2d) {
+	for _, emitter := range effect.emitters {
+		emitter.Draw(renderer)
+	}
+}
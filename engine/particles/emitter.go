@@ -0,0 +1,266 @@
+package particles
+
+import (
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"math/rand"
+	"ogl46/engine/graphic"
+	"time"
+)
+
+// particle est une particule vivante
+type particle struct {
+	position mgl32.Vec2
+	velocity mgl32.Vec2
+	// ageInMs et lifetimeInMs sont l'âge et la durée de vie de la particule
+	ageInMs      float32
+	lifetimeInMs float32
+	startSize    float32
+	// rotation et angularVelocity sont en degrés (et degrés par seconde)
+	rotation        float32
+	angularVelocity float32
+	region          graphic.Rectangle
+}
+
+// Emitter est un émetteur de particules
+//
+//	Les particules sont dans les coordonnées du monde : déplacer l'émetteur ne déplace pas les particules déjà émises.
+type Emitter struct {
+	config  *EmitterConfig
+	texture *graphic.Texture
+	// Position est la position de l'émetteur
+	Position mgl32.Vec2
+
+	particles []particle
+	// sprites est le tampon des sprites dessinés (réutilisé d'un dessin à l'autre)
+	sprites []graphic.BatchSprite
+	random  *rand.Rand
+
+	emitting bool
+	// elapsedInMs est le temps écoulé depuis le début du cycle d'émission
+	elapsedInMs int64
+	// pending est la part de particule restant à émettre (émission continue)
+	pending float32
+	// burstCycles est le nombre d'émissions déjà faites par burst
+	burstCycles []int
+}
+
+// NewEmitter construit un émetteur (l'émission démarre immédiatement) à partir d'une description valide
+//
+//	texture peut être nil (les particules sont alors simulées mais pas dessinées).
+func NewEmitter(config *EmitterConfig, texture *graphic.Texture) (*Emitter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid '%s' emitter\n - %w", config.Name, err)
+	}
+	maxParticles := config.MaxParticles
+	if maxParticles <= 0 {
+		maxParticles = DEFAULT_MAX_PARTICLES
+	}
+	emitter := &Emitter{
+		config:      config,
+		texture:     texture,
+		particles:   make([]particle, 0, maxParticles),
+		sprites:     make([]graphic.BatchSprite, 0, maxParticles),
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		burstCycles: make([]int, len(config.Bursts)),
+	}
+	emitter.Start()
+	return emitter, nil
+}
+
+// Config retourne la description de l'émetteur
+func (emitter *Emitter) Config() *EmitterConfig {
+	return emitter.config
+}
+
+// SetSeed initialise le générateur aléatoire (pour obtenir des effets reproductibles)
+func (emitter *Emitter) SetSeed(seed int64) {
+	emitter.random = rand.New(rand.NewSource(seed))
+}
+
+// Start (re)démarre l'émission depuis le début
+func (emitter *Emitter) Start() {
+	emitter.emitting = true
+	emitter.elapsedInMs = 0
+	emitter.pending = 0
+	clear(emitter.burstCycles)
+}
+
+// Stop arrête l'émission (les particules déjà émises continuent à vivre)
+func (emitter *Emitter) Stop() {
+	emitter.emitting = false
+}
+
+// Clear supprime toutes les particules
+func (emitter *Emitter) Clear() {
+	emitter.particles = emitter.particles[:0]
+}
+
+// IsEmitting indique si l'émetteur émet des particules
+func (emitter *Emitter) IsEmitting() bool {
+	return emitter.emitting
+}
+
+// IsAlive indique si l'émetteur émet encore ou a encore des particules vivantes
+func (emitter *Emitter) IsAlive() bool {
+	return emitter.emitting || len(emitter.particles) > 0
+}
+
+// ParticleCount retourne le nombre de particules vivantes
+func (emitter *Emitter) ParticleCount() int {
+	return len(emitter.particles)
+}
+
+// Emit émet immédiatement des particules (dans la limite du nombre maximal de particules)
+func (emitter *Emitter) Emit(count int) {
+	for ; count > 0 && len(emitter.particles) < cap(emitter.particles); count-- {
+		emitter.particles = append(emitter.particles, emitter.spawn())
+	}
+}
+
+// Update fait vivre les particules et émet les nouvelles particules
+func (emitter *Emitter) Update(elapsedTime int64) {
+	emitter.updateParticles(float32(elapsedTime))
+	if emitter.emitting {
+		emitter.updateEmission(elapsedTime)
+	}
+}
+
+// updateEmission émet les particules du débit continu et des bursts
+func (emitter *Emitter) updateEmission(elapsedTime int64) {
+	config := emitter.config
+	emitter.elapsedInMs += elapsedTime
+
+	emitter.pending += config.Rate * float32(elapsedTime) / 1000.
+	if count := int(emitter.pending); count > 0 {
+		emitter.pending -= float32(count)
+		emitter.Emit(count)
+	}
+	for index, burst := range config.Bursts {
+		cycles := burst.Cycles
+		if cycles == 0 || burst.IntervalInMs <= 0 {
+			// Sans intervalle, les répétitions seraient toutes émises au même instant (sans fin pour un burst infini)
+			cycles = 1
+		}
+		for (cycles < 0 || emitter.burstCycles[index] < cycles) &&
+			burst.TimeInMs+int64(emitter.burstCycles[index])*burst.IntervalInMs <= emitter.elapsedInMs {
+			emitter.burstCycles[index]++
+			emitter.Emit(burst.Count)
+		}
+	}
+
+	if config.DurationInMs > 0 && emitter.elapsedInMs >= config.DurationInMs {
+		if config.Loop {
+			emitter.elapsedInMs -= config.DurationInMs
+			clear(emitter.burstCycles)
+		} else {
+			emitter.emitting = false
+		}
+	}
+}
+
+// updateParticles fait vieillir et déplace les particules (les particules mortes sont supprimées)
+func (emitter *Emitter) updateParticles(elapsedInMs float32) {
+	config := emitter.config
+	seconds := elapsedInMs / 1000.
+	gravity := config.Gravity.Mul(seconds)
+	drag := max(1-config.Drag*seconds, 0)
+	for index := 0; index < len(emitter.particles); {
+		current := &emitter.particles[index]
+		current.ageInMs += elapsedInMs
+		if current.ageInMs >= current.lifetimeInMs {
+			// Remplacer la particule morte par la dernière (l'ordre des particules n'a pas d'importance)
+			last := len(emitter.particles) - 1
+			emitter.particles[index] = emitter.particles[last]
+			emitter.particles = emitter.particles[:last]
+			continue
+		}
+		current.velocity = current.velocity.Add(gravity).Mul(drag)
+		current.position = current.position.Add(current.velocity.Mul(seconds))
+		current.rotation += current.angularVelocity * seconds
+		index++
+	}
+}
+
+// spawn construit une nouvelle particule
+func (emitter *Emitter) spawn() particle {
+	config := emitter.config
+	random := emitter.random
+	spread := float64(config.Spread) * (random.Float64() - 0.5)
+	angle := mgl32.DegToRad(config.Direction + float32(spread))
+	speed := config.Speed.Random(random)
+	created := particle{
+		position:        emitter.Position.Add(config.Offset).Add(emitter.spawnOffset()),
+		velocity:        mgl32.Vec2{float32(math.Cos(float64(angle))), float32(math.Sin(float64(angle)))}.Mul(speed),
+		lifetimeInMs:    config.LifetimeInMs.Random(random),
+		startSize:       config.StartSize.Random(random),
+		rotation:        config.StartRotation.Random(random),
+		angularVelocity: config.AngularVelocity.Random(random),
+	}
+	if len(config.Regions) > 0 {
+		created.region = config.Regions[random.Intn(len(config.Regions))]
+	} else if emitter.texture != nil {
+		created.region = emitter.texture.Rectangle()
+	}
+	return created
+}
+
+// spawnOffset retourne la position d'apparition relative à l'émetteur selon sa forme
+func (emitter *Emitter) spawnOffset() mgl32.Vec2 {
+	config := emitter.config
+	random := emitter.random
+	switch config.Shape {
+	case SHAPE_CIRCLE:
+		radius := config.Radius
+		if !config.Edge {
+			// Racine carrée : répartition uniforme dans le disque
+			radius *= float32(math.Sqrt(random.Float64()))
+		}
+		sin, cos := math.Sincos(random.Float64() * 2 * math.Pi)
+		return mgl32.Vec2{float32(cos) * radius, float32(sin) * radius}
+	case SHAPE_RECTANGLE:
+		return mgl32.Vec2{
+			(random.Float32() - 0.5) * config.Size.X(),
+			(random.Float32() - 0.5) * config.Size.Y(),
+		}
+	case SHAPE_LINE:
+		return config.LineEnd.Mul(random.Float32())
+	default:
+		return mgl32.Vec2{}
+	}
+}
+
+// Draw dessine les particules (en un minimum d'appels OpenGL)
+func (emitter *Emitter) Draw(renderer *graphic.Renderer2d) {
+	if emitter.texture == nil || len(emitter.particles) == 0 {
+		return
+	}
+	config := emitter.config
+	emitter.sprites = emitter.sprites[:0]
+	for index := range emitter.particles {
+		current := &emitter.particles[index]
+		age := current.ageInMs / current.lifetimeInMs
+		width := current.startSize * config.SizeCurve.Value(age)
+		height := width
+		if current.region.Width() > 0 {
+			height = width * current.region.Height() / current.region.Width()
+		}
+		rotation := current.rotation
+		if len(config.RotationCurve) > 0 {
+			rotation += config.RotationCurve.Value(age)
+		}
+		emitter.sprites = append(emitter.sprites, graphic.BatchSprite{
+			Source:   current.region,
+			Center:   current.position,
+			Size:     mgl32.Vec2{width, height},
+			Rotation: graphic.AngleInDegree(float64(rotation)),
+			Color:    config.ColorCurve.Value(age),
+		})
+	}
+	blend := config.Blend
+	if blend == "" {
+		blend = graphic.BLEND_ALPHA
+	}
+	renderer.DrawBatch(emitter.texture, emitter.sprites, blend)
+}
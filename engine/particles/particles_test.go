@@ -0,0 +1,217 @@
+package particles
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine/graphic"
+	"testing"
+)
+
+func TestCurve(t *testing.T) {
+	curve := Curve{{Time: 0, Value: 0}, {Time: 0.5, Value: 1}, {Time: 1, Value: 0}}
+	for _, test := range []struct {
+		time, want float32
+	}{{-1, 0}, {0.25, 0.5}, {0.5, 1}, {0.75, 0.5}, {2, 0}} {
+		if value := curve.Value(test.time); value != test.want {
+			t.Fatalf("Value(%v) = %v, want %v", test.time, value, test.want)
+		}
+	}
+	if value := (Curve{}).Value(0.3); value != 1 {
+		t.Fatalf("empty curve Value() = %v, want 1", value)
+	}
+	colors := ColorCurve{{Time: 0, Color: graphic.White}, {Time: 1, Color: graphic.CreateColorRVBA(1, 0, 0, 0)}}
+	if color := colors.Value(0.5); color != graphic.CreateColorRVBA(1, 0.5, 0.5, 0.5) {
+		t.Fatalf("ColorCurve.Value(0.5) = %v, want [1 0.5 0.5 0.5]", color)
+	}
+}
+
+func TestEmitterRateAndLifetime(t *testing.T) {
+	config := &EmitterConfig{
+		Rate:         100,
+		LifetimeInMs: Fixed(500),
+		Speed:        Fixed(100),
+		MaxParticles: 30,
+	}
+	emitter, err := NewEmitter(config, nil)
+	if err != nil {
+		t.Fatalf("NewEmitter() returns error %v", err)
+	}
+	emitter.SetSeed(1)
+	emitter.Update(105)
+	if count := emitter.ParticleCount(); count != 10 {
+		t.Fatalf("ParticleCount() = %d, want 10 (100 particles per second during 105 ms)", count)
+	}
+	emitter.Update(1000)
+	if count := emitter.ParticleCount(); count != 30 {
+		t.Fatalf("ParticleCount() = %d, want 30 (maximum)", count)
+	}
+	emitter.Stop()
+	emitter.Update(500)
+	if emitter.ParticleCount() != 0 || emitter.IsAlive() {
+		t.Fatalf("ParticleCount() = %d, IsAlive() = %v, want 0, false after lifetime", emitter.ParticleCount(), emitter.IsAlive())
+	}
+}
+
+func TestNewEmitter_InvalidBurst(t *testing.T) {
+	for _, cycles := range []int{-1, 2} {
+		config := &EmitterConfig{
+			Bursts:       []Burst{{Count: 5, Cycles: cycles}},
+			LifetimeInMs: Fixed(1000),
+		}
+		if _, err := NewEmitter(config, nil); err == nil {
+			t.Fatalf("NewEmitter() with %d cycles and no interval returns no error, want error", cycles)
+		}
+	}
+}
+
+func TestEmitterBurstWithoutInterval(t *testing.T) {
+	config := &EmitterConfig{
+		Bursts:       []Burst{{Count: 5, Cycles: -1, IntervalInMs: 100}},
+		LifetimeInMs: Fixed(1000),
+	}
+	emitter, err := NewEmitter(config, nil)
+	if err != nil {
+		t.Fatalf("NewEmitter() returns error %v", err)
+	}
+	// Description modifiée après la construction : le burst n'est émis qu'une fois
+	config.Bursts[0].IntervalInMs = 0
+	emitter.Update(10)
+	if count := emitter.ParticleCount(); count != 5 {
+		t.Fatalf("ParticleCount() = %d, want 5 (single burst)", count)
+	}
+}
+
+func TestEmitterBurstsAndDuration(t *testing.T) {
+	config := &EmitterConfig{
+		Bursts:       []Burst{{TimeInMs: 0, Count: 5}, {TimeInMs: 100, Count: 2, Cycles: 3, IntervalInMs: 50}},
+		DurationInMs: 400,
+		LifetimeInMs: Fixed(10000),
+	}
+	emitter, err := NewEmitter(config, nil)
+	if err != nil {
+		t.Fatalf("NewEmitter() returns error %v", err)
+	}
+	emitter.Update(1)
+	if count := emitter.ParticleCount(); count != 5 {
+		t.Fatalf("ParticleCount() = %d, want 5 after first burst", count)
+	}
+	emitter.Update(200)
+	if count := emitter.ParticleCount(); count != 11 {
+		t.Fatalf("ParticleCount() = %d, want 11 after 3 cycles of second burst", count)
+	}
+	emitter.Update(300)
+	if emitter.IsEmitting() {
+		t.Fatalf("IsEmitting() = true after duration, want false")
+	}
+	config.Loop = true
+	emitter.Start()
+	emitter.Update(450)
+	emitter.Update(1)
+	// 11 particules du premier démarrage, 11 du premier cycle et 5 du premier burst du deuxième cycle
+	if count := emitter.ParticleCount(); count != 27 || !emitter.IsEmitting() {
+		t.Fatalf("ParticleCount() = %d, IsEmitting() = %v, want 27, true (looping emitter)", count, emitter.IsEmitting())
+	}
+}
+
+func TestEmitterMotion(t *testing.T) {
+	config := &EmitterConfig{
+		LifetimeInMs: Fixed(10000),
+		Speed:        Fixed(100),
+		Direction:    90,
+		Gravity:      mgl32.Vec2{10, 0},
+	}
+	emitter, err := NewEmitter(config, nil)
+	if err != nil {
+		t.Fatalf("NewEmitter() returns error %v", err)
+	}
+	emitter.Position = mgl32.Vec2{50, 50}
+	emitter.Emit(1)
+	emitter.Update(1000)
+	// Direction 90° = vers le bas ; la gravité est appliquée à la vitesse avant le déplacement
+	if position := emitter.particles[0].position; !position.ApproxEqualThreshold(mgl32.Vec2{60, 150}, 1e-3) {
+		t.Fatalf("particle position = %v, want (60, 150)", position)
+	}
+
+	config.Drag = 0.5
+	emitter.Clear()
+	emitter.Emit(1)
+	emitter.Update(1000)
+	if velocity := emitter.particles[0].velocity; !velocity.ApproxEqualThreshold(mgl32.Vec2{5, 50}, 1e-3) {
+		t.Fatalf("particle velocity = %v, want (5, 50) with drag", velocity)
+	}
+}
+
+func TestEmitterShapes(t *testing.T) {
+	for _, test := range []struct {
+		config EmitterConfig
+		inside func(offset mgl32.Vec2) bool
+	}{
+		{EmitterConfig{Shape: SHAPE_POINT}, func(offset mgl32.Vec2) bool { return offset == mgl32.Vec2{} }},
+		{EmitterConfig{Shape: SHAPE_CIRCLE, Radius: 10}, func(offset mgl32.Vec2) bool { return offset.Len() <= 10.001 }},
+		{EmitterConfig{Shape: SHAPE_CIRCLE, Radius: 10, Edge: true}, func(offset mgl32.Vec2) bool { return mgl32.Abs(offset.Len()-10) < 1e-3 }},
+		{EmitterConfig{Shape: SHAPE_RECTANGLE, Size: mgl32.Vec2{20, 10}}, func(offset mgl32.Vec2) bool {
+			return mgl32.Abs(offset.X()) <= 10 && mgl32.Abs(offset.Y()) <= 5
+		}},
+		{EmitterConfig{Shape: SHAPE_LINE, LineEnd: mgl32.Vec2{30, 0}}, func(offset mgl32.Vec2) bool {
+			return offset.Y() == 0 && offset.X() >= 0 && offset.X() <= 30
+		}},
+	} {
+		test.config.LifetimeInMs = Fixed(1000)
+		emitter, err := NewEmitter(&test.config, nil)
+		if err != nil {
+			t.Fatalf("NewEmitter() returns error %v", err)
+		}
+		for spawn := 0; spawn < 100; spawn++ {
+			if offset := emitter.spawnOffset(); !test.inside(offset) {
+				t.Fatalf("%s emitter spawnOffset() = %v, outside shape", test.config.Shape, offset)
+			}
+		}
+	}
+}
+
+func TestLoadEffectConfig(t *testing.T) {
+	content := `{
+		"emitters": [
+			{"name": "sparks", "texture": "particles", "regions": [[0, 0, 8, 8], [8, 0, 8, 8]], "blend": "additive",
+			 "shape": "circle", "radius": 4, "bursts": [{"time": 0, "count": 50}], "duration": 100,
+			 "lifetime": {"min": 200, "max": 400}, "speed": {"min": 50, "max": 150}, "spread": 360,
+			 "gravity": [0, 200], "drag": 0.5, "startSize": {"min": 4, "max": 8},
+			 "sizeCurve": [{"time": 0, "value": 1}, {"time": 1, "value": 0}],
+			 "colorCurve": [{"time": 0, "color": [1, 1, 0, 1]}, {"time": 1, "color": [1, 0, 0, 0]}]},
+			{"name": "smoke", "texture": "particles", "rate": 20, "lifetime": {"min": 1000, "max": 1000}, "speed": {"min": 10, "max": 20}}
+		]
+	}`
+	config, err := LoadEffectConfigFromBytes([]byte(content))
+	if err != nil {
+		t.Fatalf("LoadEffectConfigFromBytes() error = %v", err)
+	}
+	sparks := config.Emitters[0]
+	if sparks.Blend != graphic.BLEND_ADDITIVE || sparks.Shape != SHAPE_CIRCLE || len(sparks.Regions) != 2 || sparks.Gravity != (mgl32.Vec2{0, 200}) {
+		t.Fatalf("sparks emitter = %+v, want additive circle emitter with 2 regions", sparks)
+	}
+	effect, err := NewEffect(config, nil)
+	if err != nil {
+		t.Fatalf("NewEffect() error = %v", err)
+	}
+	effect.SetPosition(mgl32.Vec2{100, 100})
+	effect.Update(10)
+	if count := effect.Emitter("sparks").ParticleCount(); count != 50 {
+		t.Fatalf("sparks ParticleCount() = %d, want 50", count)
+	}
+	effect.Stop()
+	effect.Update(1000)
+	if effect.IsAlive() {
+		t.Fatalf("IsAlive() = true after stop and lifetime, want false")
+	}
+
+	for _, invalid := range []string{
+		`{"emitters": []}`,
+		`{"emitters": [{"shape": "star", "lifetime": {"min": 1, "max": 1}}]}`,
+		`{"emitters": [{"blend": "xor", "lifetime": {"min": 1, "max": 1}}]}`,
+		`{"emitters": [{"lifetime": {"min": 0, "max": 0}}]}`,
+		`{"emitters": [{"lifetime": {"min": 1, "max": 1}, "bursts": [{"count": 1, "cycles": -1}]}]}`,
+	} {
+		if _, err := LoadEffectConfigFromBytes([]byte(invalid)); err == nil {
+			t.Fatalf("LoadEffectConfigFromBytes(%s) error = nil, want error", invalid)
+		}
+	}
+}
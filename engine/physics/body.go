@@ -0,0 +1,181 @@
+package physics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// BodyType est le type d'un corps
+type BodyType uint8
+
+const (
+	// STATIC est un corps immobile (sol, murs)
+	STATIC BodyType = iota
+	// KINEMATIC est un corps déplacé par sa vitesse, insensible aux forces et aux collisions (plateformes mobiles)
+	KINEMATIC
+	// DYNAMIC est un corps soumis aux forces, à la gravité et aux collisions
+	DYNAMIC
+)
+
+// ALL_CATEGORIES est le masque de collision avec toutes les catégories
+const ALL_CATEGORIES uint32 = 0xFFFFFFFF
+
+// Body est un corps rigide
+type Body struct {
+	// id identifie le corps dans le monde (ordre de traitement déterministe)
+	id         uint64
+	bodyType   BodyType
+	shape      *Shape
+	density    float32
+	mass       float32
+	invMass    float32
+	inertia    float32
+	invInertia float32
+
+	// Position est la position du centre de masse
+	Position mgl32.Vec2
+	// Angle est la rotation du corps (en radians)
+	Angle float32
+	// Velocity est la vitesse (unités par seconde)
+	Velocity mgl32.Vec2
+	// AngularVelocity est la vitesse de rotation (radians par seconde)
+	AngularVelocity float32
+	force           mgl32.Vec2
+	torque          float32
+
+	// Restitution est le rebond (0 = pas de rebond, 1 = rebond parfait)
+	Restitution float32
+	// Friction est le coefficient de frottement
+	Friction float32
+	// GravityScale multiplie la gravité du monde pour ce corps
+	GravityScale float32
+	// LinearDamping ralentit le corps (fraction de la vitesse perdue par seconde)
+	LinearDamping float32
+
+	// fixedRotation empêche le corps de tourner
+	fixedRotation bool
+	// sensor indique que le corps détecte les chevauchements sans provoquer de collision
+	sensor bool
+
+	// Category est la catégorie de collision du corps (un bit)
+	Category uint32
+	// Mask indique les catégories avec lesquelles le corps entre en collision
+	Mask uint32
+
+	// OnSensorEnter est appelée quand un corps commence à chevaucher ce capteur (ou quand ce corps entre dans un capteur)
+	OnSensorEnter func(body *Body, other *Body)
+	// OnSensorExit est appelée quand un corps ne chevauche plus ce capteur (ou quand ce corps sort d'un capteur)
+	OnSensorExit func(body *Body, other *Body)
+
+	// UserData permet d'associer une donnée du jeu au corps (entité, composant, ...)
+	UserData any
+}
+
+// NewBody construit un corps de densité 1
+func NewBody(bodyType BodyType, shape *Shape, position mgl32.Vec2) *Body {
+	body := &Body{
+		bodyType:     bodyType,
+		shape:        shape,
+		density:      1,
+		Position:     position,
+		Friction:     0.3,
+		GravityScale: 1,
+		Category:     1,
+		Mask:         ALL_CATEGORIES,
+	}
+	body.updateMass()
+	return body
+}
+
+// NewSensor construit un capteur statique (zone de déclenchement)
+func NewSensor(shape *Shape, position mgl32.Vec2) *Body {
+	body := NewBody(STATIC, shape, position)
+	body.sensor = true
+	return body
+}
+
+// updateMass calcule la masse et l'inertie à partir de la forme, de la densité et du type
+func (body *Body) updateMass() {
+	body.mass, body.inertia = body.shape.computeMass(body.density)
+	body.invMass, body.invInertia = 0, 0
+	if body.bodyType != DYNAMIC {
+		return
+	}
+	if body.mass > 0 {
+		body.invMass = 1 / body.mass
+	}
+	if body.inertia > 0 && !body.fixedRotation && body.shape.shapeType != SHAPE_AABB {
+		body.invInertia = 1 / body.inertia
+	}
+}
+
+func (body *Body) Type() BodyType {
+	return body.bodyType
+}
+
+// SetType change le type du corps
+func (body *Body) SetType(bodyType BodyType) {
+	body.bodyType = bodyType
+	body.updateMass()
+}
+
+func (body *Body) Shape() *Shape {
+	return body.shape
+}
+
+func (body *Body) Mass() float32 {
+	return body.mass
+}
+
+// SetDensity change la densité du corps (et donc sa masse)
+func (body *Body) SetDensity(density float32) {
+	body.density = density
+	body.updateMass()
+}
+
+func (body *Body) FixedRotation() bool {
+	return body.fixedRotation
+}
+
+// SetFixedRotation empêche (ou autorise) la rotation du corps (personnages de jeux de plateforme)
+func (body *Body) SetFixedRotation(fixedRotation bool) {
+	body.fixedRotation = fixedRotation
+	if fixedRotation {
+		body.AngularVelocity = 0
+	}
+	body.updateMass()
+}
+
+func (body *Body) IsSensor() bool {
+	return body.sensor
+}
+
+// SetSensor transforme le corps en capteur (ou en corps solide)
+func (body *Body) SetSensor(sensor bool) {
+	body.sensor = sensor
+}
+
+// AABB retourne la boîte englobante du corps
+func (body *Body) AABB() AABB {
+	return body.shape.computeAABB(body.Position, body.Angle)
+}
+
+// ApplyForce applique une force au centre de masse (jusqu'au prochain pas de simulation)
+func (body *Body) ApplyForce(force mgl32.Vec2) {
+	body.force = body.force.Add(force)
+}
+
+// ApplyTorque applique un couple (jusqu'au prochain pas de simulation)
+func (body *Body) ApplyTorque(torque float32) {
+	body.torque += torque
+}
+
+// ApplyImpulse applique une impulsion (changement immédiat de vitesse) au point indiqué (relatif au centre de masse)
+func (body *Body) ApplyImpulse(impulse mgl32.Vec2, contactVector mgl32.Vec2) {
+	body.Velocity = body.Velocity.Add(impulse.Mul(body.invMass))
+	body.AngularVelocity += body.invInertia * cross(contactVector, impulse)
+}
+
+// collides indique si deux corps peuvent entrer en contact (filtrage par catégories)
+func (body *Body) collides(other *Body) bool {
+	return body.Category&other.Mask != 0 && other.Category&body.Mask != 0
+}
@@ -0,0 +1,120 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+// DEFAULT_CELL_SIZE est la taille par défaut des cellules de la grille de la phase large
+const DEFAULT_CELL_SIZE = 128
+
+// cellKey identifie une cellule de la grille
+type cellKey struct {
+	x, y int32
+}
+
+// bodyPair est une paire de corps dont les boîtes englobantes se chevauchent (A.id < B.id)
+type bodyPair struct {
+	a, b *Body
+}
+
+// spatialHash est la phase large : une grille de cellules contenant les corps qui les chevauchent
+type spatialHash struct {
+	cellSize float32
+	cells    map[cellKey][]*Body
+	// seen évite les paires en double (corps présents dans plusieurs cellules communes)
+	seen  map[bodyPair]struct{}
+	pairs []bodyPair
+}
+
+func newSpatialHash(cellSize float32) *spatialHash {
+	return &spatialHash{
+		cellSize: cellSize,
+		cells:    make(map[cellKey][]*Body),
+		seen:     make(map[bodyPair]struct{}),
+		pairs:    make([]bodyPair, 0),
+	}
+}
+
+// cellRange retourne les cellules couvertes par une boîte englobante
+func (hash *spatialHash) cellRange(box AABB) (minCell cellKey, maxCell cellKey) {
+	toCell := func(value float32) int32 {
+		return int32(math.Floor(float64(value / hash.cellSize)))
+	}
+	return cellKey{toCell(box.Min.X()), toCell(box.Min.Y())}, cellKey{toCell(box.Max.X()), toCell(box.Max.Y())}
+}
+
+// computePairs retourne les paires de corps à tester (triées pour un traitement déterministe)
+func (hash *spatialHash) computePairs(bodies []*Body) []bodyPair {
+	for key, cell := range hash.cells {
+		hash.cells[key] = cell[:0]
+	}
+	clear(hash.seen)
+	hash.pairs = hash.pairs[:0]
+
+	boxes := make(map[*Body]AABB, len(bodies))
+	for _, body := range bodies {
+		box := body.AABB()
+		boxes[body] = box
+		minCell, maxCell := hash.cellRange(box)
+		for x := minCell.x; x <= maxCell.x; x++ {
+			for y := minCell.y; y <= maxCell.y; y++ {
+				key := cellKey{x, y}
+				for _, other := range hash.cells[key] {
+					hash.addPair(body, other, box, boxes[other])
+				}
+				hash.cells[key] = append(hash.cells[key], body)
+			}
+		}
+	}
+	// Supprimer les cellules vides (le monde peut se déplacer)
+	for key, cell := range hash.cells {
+		if len(cell) == 0 {
+			delete(hash.cells, key)
+		}
+	}
+	slices.SortFunc(hash.pairs, func(first, second bodyPair) int {
+		if first.a.id != second.a.id {
+			return compareIds(first.a.id, second.a.id)
+		}
+		return compareIds(first.b.id, second.b.id)
+	})
+	return hash.pairs
+}
+
+func (hash *spatialHash) addPair(body *Body, other *Body, box AABB, otherBox AABB) {
+	if !needsTest(body, other) || !box.Overlaps(otherBox) {
+		return
+	}
+	pair := bodyPair{body, other}
+	if other.id < body.id {
+		pair = bodyPair{other, body}
+	}
+	if _, found := hash.seen[pair]; found {
+		return
+	}
+	hash.seen[pair] = struct{}{}
+	hash.pairs = append(hash.pairs, pair)
+}
+
+// needsTest indique si une paire de corps doit être testée (au moins un corps dynamique, ou un capteur et un corps mobile)
+func needsTest(body *Body, other *Body) bool {
+	if !body.collides(other) {
+		return false
+	}
+	if body.sensor || other.sensor {
+		return !(body.sensor && other.sensor) && (body.bodyType != STATIC || other.bodyType != STATIC)
+	}
+	return body.bodyType == DYNAMIC || other.bodyType == DYNAMIC
+}
+
+func compareIds(first, second uint64) int {
+	switch {
+	case first < second:
+		return -1
+	case first > second:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,233 @@
+package physics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// Manifold décrit le contact entre deux corps
+type Manifold struct {
+	A *Body
+	B *Body
+	// Normal est la direction du contact (de A vers B)
+	Normal mgl32.Vec2
+	// Penetration est la profondeur d'interpénétration
+	Penetration float32
+	// Contacts sont les points de contact (dans le monde)
+	Contacts [2]mgl32.Vec2
+	// ContactCount est le nombre de points de contact (1 ou 2)
+	ContactCount int
+}
+
+// collide calcule le contact entre deux corps (faux s'ils ne se touchent pas)
+func collide(a *Body, b *Body) (Manifold, bool) {
+	manifold := Manifold{A: a, B: b}
+	aCircle := a.shape.shapeType == SHAPE_CIRCLE
+	bCircle := b.shape.shapeType == SHAPE_CIRCLE
+	switch {
+	case aCircle && bCircle:
+		return manifold, collideCircles(&manifold)
+	case aCircle:
+		// Calculer le contact polygone / cercle puis inverser
+		flipped := Manifold{A: b, B: a}
+		if !collidePolygonCircle(&flipped) {
+			return manifold, false
+		}
+		manifold.Normal = flipped.Normal.Mul(-1)
+		manifold.Penetration = flipped.Penetration
+		manifold.Contacts = flipped.Contacts
+		manifold.ContactCount = flipped.ContactCount
+		return manifold, true
+	case bCircle:
+		return manifold, collidePolygonCircle(&manifold)
+	default:
+		return manifold, collidePolygons(&manifold)
+	}
+}
+
+func collideCircles(manifold *Manifold) bool {
+	a, b := manifold.A, manifold.B
+	delta := b.Position.Sub(a.Position)
+	radius := a.shape.radius + b.shape.radius
+	distanceSquared := delta.Dot(delta)
+	if distanceSquared >= radius*radius {
+		return false
+	}
+	distance := float32(math.Sqrt(float64(distanceSquared)))
+	manifold.ContactCount = 1
+	if distance == 0 {
+		manifold.Penetration = a.shape.radius
+		manifold.Normal = mgl32.Vec2{1, 0}
+		manifold.Contacts[0] = a.Position
+		return true
+	}
+	manifold.Penetration = radius - distance
+	manifold.Normal = delta.Mul(1 / distance)
+	manifold.Contacts[0] = a.Position.Add(manifold.Normal.Mul(a.shape.radius))
+	return true
+}
+
+// collidePolygonCircle calcule le contact entre un polygone (A) et un cercle (B)
+func collidePolygonCircle(manifold *Manifold) bool {
+	polygon, circle := manifold.A, manifold.B
+	vertices, normals := polygon.shape.worldPolygon(polygon.Position, polygon.Angle, nil, nil)
+	radius := circle.shape.radius
+	center := circle.Position
+
+	// Trouver le côté le plus proche du centre du cercle
+	separation := float32(-math.MaxFloat32)
+	face := 0
+	for index, vertex := range vertices {
+		faceSeparation := normals[index].Dot(center.Sub(vertex))
+		if faceSeparation > radius {
+			return false
+		}
+		if faceSeparation > separation {
+			separation = faceSeparation
+			face = index
+		}
+	}
+	first := vertices[face]
+	second := vertices[(face+1)%len(vertices)]
+	manifold.ContactCount = 1
+
+	// Centre dans le polygone
+	if separation < 1e-6 {
+		manifold.Normal = normals[face]
+		manifold.Contacts[0] = center.Sub(manifold.Normal.Mul(radius))
+		manifold.Penetration = radius - separation
+		return true
+	}
+
+	// Déterminer la région de Voronoï du côté où se trouve le centre
+	dotFirst := center.Sub(first).Dot(second.Sub(first))
+	dotSecond := center.Sub(second).Dot(first.Sub(second))
+	var closest mgl32.Vec2
+	switch {
+	case dotFirst <= 0:
+		closest = first
+	case dotSecond <= 0:
+		closest = second
+	default:
+		manifold.Normal = normals[face]
+		manifold.Contacts[0] = center.Sub(manifold.Normal.Mul(radius))
+		manifold.Penetration = radius - separation
+		return true
+	}
+	delta := center.Sub(closest)
+	distanceSquared := delta.Dot(delta)
+	if distanceSquared > radius*radius {
+		return false
+	}
+	distance := float32(math.Sqrt(float64(distanceSquared)))
+	manifold.Normal = delta.Mul(1 / distance)
+	manifold.Contacts[0] = closest
+	manifold.Penetration = radius - distance
+	return true
+}
+
+// collidePolygons calcule le contact entre deux polygones (théorème des axes séparateurs puis découpage du côté incident)
+func collidePolygons(manifold *Manifold) bool {
+	aVertices, aNormals := manifold.A.shape.worldPolygon(manifold.A.Position, manifold.A.Angle, nil, nil)
+	bVertices, bNormals := manifold.B.shape.worldPolygon(manifold.B.Position, manifold.B.Angle, nil, nil)
+
+	aFace, aSeparation := leastPenetrationAxis(aVertices, aNormals, bVertices)
+	if aSeparation >= 0 {
+		return false
+	}
+	bFace, bSeparation := leastPenetrationAxis(bVertices, bNormals, aVertices)
+	if bSeparation >= 0 {
+		return false
+	}
+
+	// Choisir le polygone de référence (préférer A pour la stabilité)
+	referenceVertices, referenceNormals, referenceFace := aVertices, aNormals, aFace
+	incidentVertices, incidentNormals := bVertices, bNormals
+	flip := false
+	if bSeparation > 0.98*aSeparation+0.001 {
+		referenceVertices, referenceNormals, referenceFace = bVertices, bNormals, bFace
+		incidentVertices, incidentNormals = aVertices, aNormals
+		flip = true
+	}
+	referenceNormal := referenceNormals[referenceFace]
+
+	// Côté incident : le plus opposé à la normale de référence
+	incidentFace := 0
+	minDot := float32(math.MaxFloat32)
+	for index, normal := range incidentNormals {
+		if dot := normal.Dot(referenceNormal); dot < minDot {
+			minDot = dot
+			incidentFace = index
+		}
+	}
+	incident := [2]mgl32.Vec2{incidentVertices[incidentFace], incidentVertices[(incidentFace+1)%len(incidentVertices)]}
+
+	// Découper le côté incident par les plans latéraux du côté de référence
+	referenceFirst := referenceVertices[referenceFace]
+	referenceSecond := referenceVertices[(referenceFace+1)%len(referenceVertices)]
+	tangent := referenceSecond.Sub(referenceFirst).Normalize()
+	var clipped bool
+	if incident, clipped = clipSegment(incident, tangent.Mul(-1), -tangent.Dot(referenceFirst)); !clipped {
+		return false
+	}
+	if incident, clipped = clipSegment(incident, tangent, tangent.Dot(referenceSecond)); !clipped {
+		return false
+	}
+
+	// Garder les points sous le côté de référence
+	manifold.Normal = referenceNormal
+	if flip {
+		manifold.Normal = referenceNormal.Mul(-1)
+	}
+	referenceOffset := referenceNormal.Dot(referenceFirst)
+	penetration := float32(0)
+	for _, point := range incident {
+		if separation := referenceNormal.Dot(point) - referenceOffset; separation <= 0 {
+			manifold.Contacts[manifold.ContactCount] = point
+			manifold.ContactCount++
+			penetration -= separation
+		}
+	}
+	if manifold.ContactCount == 0 {
+		return false
+	}
+	manifold.Penetration = penetration / float32(manifold.ContactCount)
+	return true
+}
+
+// leastPenetrationAxis retourne le côté du polygone A dont la séparation avec B est maximale
+func leastPenetrationAxis(aVertices, aNormals, bVertices []mgl32.Vec2) (int, float32) {
+	bestFace := 0
+	bestSeparation := float32(-math.MaxFloat32)
+	for index, normal := range aNormals {
+		// Point de B le plus loin dans la direction opposée à la normale
+		support := float32(math.MaxFloat32)
+		for _, vertex := range bVertices {
+			support = min(support, normal.Dot(vertex.Sub(aVertices[index])))
+		}
+		if support > bestSeparation {
+			bestSeparation = support
+			bestFace = index
+		}
+	}
+	return bestFace, bestSeparation
+}
+
+// clipSegment garde la partie du segment telle que dot(normal, point) <= offset
+func clipSegment(segment [2]mgl32.Vec2, normal mgl32.Vec2, offset float32) ([2]mgl32.Vec2, bool) {
+	firstDistance := normal.Dot(segment[0]) - offset
+	secondDistance := normal.Dot(segment[1]) - offset
+	if firstDistance > 0 && secondDistance > 0 {
+		return segment, false
+	}
+	if firstDistance*secondDistance < 0 {
+		ratio := firstDistance / (firstDistance - secondDistance)
+		intersection := segment[0].Add(segment[1].Sub(segment[0]).Mul(ratio))
+		if firstDistance > 0 {
+			segment[0] = intersection
+		} else {
+			segment[1] = intersection
+		}
+	}
+	return segment, true
+}
@@ -0,0 +1,127 @@
+package physics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// RayHit est le résultat d'un lancer de rayon
+type RayHit struct {
+	Body *Body
+	// Point est le point d'impact
+	Point mgl32.Vec2
+	// Normal est la normale de la surface touchée
+	Normal mgl32.Vec2
+	// Distance est la distance entre l'origine du rayon et le point d'impact
+	Distance float32
+}
+
+// RayCast retourne le premier corps touché par un rayon (capteurs exclus)
+//
+// Le filtre (optionnel) permet d'ignorer des corps (le corps qui lance le rayon par exemple).
+func (world *World) RayCast(origin mgl32.Vec2, direction mgl32.Vec2, maxDistance float32, filter func(body *Body) bool) (RayHit, bool) {
+	if direction.Len() == 0 {
+		return RayHit{}, false
+	}
+	direction = direction.Normalize()
+	closest := RayHit{Distance: maxDistance}
+	found := false
+	for _, body := range world.bodies {
+		if body.sensor || (filter != nil && !filter(body)) {
+			continue
+		}
+		if hit, touched := rayCastBody(body, origin, direction, closest.Distance); touched {
+			closest = hit
+			found = true
+		}
+	}
+	return closest, found
+}
+
+// rayCastBody lance un rayon (direction normée) sur un corps
+func rayCastBody(body *Body, origin mgl32.Vec2, direction mgl32.Vec2, maxDistance float32) (RayHit, bool) {
+	if body.shape.shapeType == SHAPE_CIRCLE {
+		return rayCastCircle(body, origin, direction, maxDistance)
+	}
+	return rayCastPolygon(body, origin, direction, maxDistance)
+}
+
+func rayCastCircle(body *Body, origin mgl32.Vec2, direction mgl32.Vec2, maxDistance float32) (RayHit, bool) {
+	offset := origin.Sub(body.Position)
+	radius := body.shape.radius
+	b := offset.Dot(direction)
+	c := offset.Dot(offset) - radius*radius
+	// Origine dans le cercle ou cercle derrière le rayon
+	if c <= 0 || b > 0 {
+		return RayHit{}, false
+	}
+	discriminant := b*b - c
+	if discriminant < 0 {
+		return RayHit{}, false
+	}
+	distance := -b - float32(math.Sqrt(float64(discriminant)))
+	if distance < 0 || distance > maxDistance {
+		return RayHit{}, false
+	}
+	point := origin.Add(direction.Mul(distance))
+	return RayHit{Body: body, Point: point, Normal: point.Sub(body.Position).Normalize(), Distance: distance}, true
+}
+
+func rayCastPolygon(body *Body, origin mgl32.Vec2, direction mgl32.Vec2, maxDistance float32) (RayHit, bool) {
+	vertices, normals := body.shape.worldPolygon(body.Position, body.Angle, nil, nil)
+	lower, upper := float32(0), maxDistance
+	face := -1
+	for index, normal := range normals {
+		numerator := normal.Dot(vertices[index].Sub(origin))
+		denominator := normal.Dot(direction)
+		if denominator == 0 {
+			if numerator < 0 {
+				// Rayon parallèle au côté et à l'extérieur
+				return RayHit{}, false
+			}
+			continue
+		}
+		if denominator < 0 && numerator < lower*denominator {
+			// Le rayon entre par ce côté
+			lower = numerator / denominator
+			face = index
+		} else if denominator > 0 && numerator < upper*denominator {
+			// Le rayon sort par ce côté
+			upper = numerator / denominator
+		}
+		if upper < lower {
+			return RayHit{}, false
+		}
+	}
+	if face < 0 {
+		// Origine dans le polygone
+		return RayHit{}, false
+	}
+	return RayHit{Body: body, Point: origin.Add(direction.Mul(lower)), Normal: normals[face], Distance: lower}, true
+}
+
+// QueryPoint retourne les corps contenant le point indiqué
+func (world *World) QueryPoint(point mgl32.Vec2) []*Body {
+	bodies := make([]*Body, 0)
+	for _, body := range world.bodies {
+		if body.AABB().Contains(point) && containsPoint(body, point) {
+			bodies = append(bodies, body)
+		}
+	}
+	return bodies
+}
+
+// containsPoint indique si le point est dans la forme du corps
+func containsPoint(body *Body, point mgl32.Vec2) bool {
+	if body.shape.shapeType == SHAPE_CIRCLE {
+		offset := point.Sub(body.Position)
+		return offset.Dot(offset) <= body.shape.radius*body.shape.radius
+	}
+	vertices, normals := body.shape.worldPolygon(body.Position, body.Angle, nil, nil)
+	for index, normal := range normals {
+		if normal.Dot(point.Sub(vertices[index])) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,202 @@
+package physics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+)
+
+// ShapeType est le type de forme d'un corps
+type ShapeType uint8
+
+const (
+	// SHAPE_CIRCLE est un cercle
+	SHAPE_CIRCLE ShapeType = iota
+	// SHAPE_AABB est un rectangle aligné sur les axes (il ne tourne jamais)
+	SHAPE_AABB
+	// SHAPE_POLYGON est un polygone convexe
+	SHAPE_POLYGON
+)
+
+// AABB est une boîte englobante alignée sur les axes
+type AABB struct {
+	Min mgl32.Vec2
+	Max mgl32.Vec2
+}
+
+// Overlaps indique si deux boîtes se chevauchent
+func (box AABB) Overlaps(other AABB) bool {
+	return box.Min.X() <= other.Max.X() && other.Min.X() <= box.Max.X() &&
+		box.Min.Y() <= other.Max.Y() && other.Min.Y() <= box.Max.Y()
+}
+
+// Contains indique si le point est dans la boîte
+func (box AABB) Contains(point mgl32.Vec2) bool {
+	return point.X() >= box.Min.X() && point.X() <= box.Max.X() && point.Y() >= box.Min.Y() && point.Y() <= box.Max.Y()
+}
+
+// Shape est la forme d'un corps, définie autour de la position du corps (son centre de masse)
+type Shape struct {
+	shapeType ShapeType
+	// radius est le rayon du cercle
+	radius float32
+	// vertices sont les sommets du polygone ou du rectangle (sens horaire à l'écran, centrés sur le centre de masse)
+	vertices []mgl32.Vec2
+	// normals sont les normales extérieures des côtés (côté i = sommets i et i+1)
+	normals []mgl32.Vec2
+}
+
+// NewCircle construit un cercle centré sur la position du corps
+func NewCircle(radius float32) *Shape {
+	return &Shape{shapeType: SHAPE_CIRCLE, radius: radius}
+}
+
+// NewAABB construit un rectangle aligné sur les axes centré sur la position du corps
+func NewAABB(width, height float32) *Shape {
+	shape := newPolygon(boxVertices(width, height))
+	shape.shapeType = SHAPE_AABB
+	return shape
+}
+
+// NewBox construit un rectangle (qui peut tourner) centré sur la position du corps
+func NewBox(width, height float32) *Shape {
+	return newPolygon(boxVertices(width, height))
+}
+
+// NewPolygon construit un polygone convexe (les sommets sont recentrés sur leur centre de masse)
+func NewPolygon(vertices []mgl32.Vec2) *Shape {
+	centroid := polygonCentroid(vertices)
+	centered := make([]mgl32.Vec2, len(vertices))
+	for index, vertex := range vertices {
+		centered[index] = vertex.Sub(centroid)
+	}
+	return newPolygon(centered)
+}
+
+func newPolygon(vertices []mgl32.Vec2) *Shape {
+	// Orienter les sommets pour que les normales soient extérieures
+	if signedArea(vertices) < 0 {
+		reversed := make([]mgl32.Vec2, len(vertices))
+		for index, vertex := range vertices {
+			reversed[len(vertices)-1-index] = vertex
+		}
+		vertices = reversed
+	}
+	normals := make([]mgl32.Vec2, len(vertices))
+	for index, vertex := range vertices {
+		edge := vertices[(index+1)%len(vertices)].Sub(vertex)
+		normals[index] = mgl32.Vec2{edge.Y(), -edge.X()}.Normalize()
+	}
+	return &Shape{shapeType: SHAPE_POLYGON, vertices: vertices, normals: normals}
+}
+
+func boxVertices(width, height float32) []mgl32.Vec2 {
+	halfWidth, halfHeight := width/2, height/2
+	return []mgl32.Vec2{{-halfWidth, -halfHeight}, {halfWidth, -halfHeight}, {halfWidth, halfHeight}, {-halfWidth, halfHeight}}
+}
+
+func (shape *Shape) Type() ShapeType {
+	return shape.shapeType
+}
+
+// Radius retourne le rayon du cercle
+func (shape *Shape) Radius() float32 {
+	return shape.radius
+}
+
+// Vertices retourne les sommets du polygone (relatifs à la position du corps, sans rotation)
+func (shape *Shape) Vertices() []mgl32.Vec2 {
+	return shape.vertices
+}
+
+// computeMass retourne la masse et le moment d'inertie de la forme pour la densité indiquée
+func (shape *Shape) computeMass(density float32) (mass float32, inertia float32) {
+	if shape.shapeType == SHAPE_CIRCLE {
+		mass = math.Pi * shape.radius * shape.radius * density
+		return mass, mass * shape.radius * shape.radius / 2
+	}
+	// Découpage en triangles depuis le centre de masse
+	for index, first := range shape.vertices {
+		second := shape.vertices[(index+1)%len(shape.vertices)]
+		cross := cross(first, second)
+		triangleArea := cross / 2
+		mass += triangleArea * density
+		inertia += density * cross / 12 * (first.Dot(first) + first.Dot(second) + second.Dot(second))
+	}
+	return mass, inertia
+}
+
+// worldPolygon retourne les sommets et normales du polygone dans le monde
+func (shape *Shape) worldPolygon(position mgl32.Vec2, angle float32, vertices []mgl32.Vec2, normals []mgl32.Vec2) ([]mgl32.Vec2, []mgl32.Vec2) {
+	if shape.shapeType == SHAPE_AABB {
+		angle = 0
+	}
+	sin, cos := sinCos(angle)
+	vertices, normals = vertices[:0], normals[:0]
+	for index, vertex := range shape.vertices {
+		vertices = append(vertices, rotate(vertex, sin, cos).Add(position))
+		normals = append(normals, rotate(shape.normals[index], sin, cos))
+	}
+	return vertices, normals
+}
+
+// computeAABB retourne la boîte englobante de la forme dans le monde
+func (shape *Shape) computeAABB(position mgl32.Vec2, angle float32) AABB {
+	if shape.shapeType == SHAPE_CIRCLE {
+		radius := mgl32.Vec2{shape.radius, shape.radius}
+		return AABB{Min: position.Sub(radius), Max: position.Add(radius)}
+	}
+	if shape.shapeType == SHAPE_AABB {
+		angle = 0
+	}
+	sin, cos := sinCos(angle)
+	box := AABB{
+		Min: mgl32.Vec2{math.MaxFloat32, math.MaxFloat32},
+		Max: mgl32.Vec2{-math.MaxFloat32, -math.MaxFloat32},
+	}
+	for _, vertex := range shape.vertices {
+		point := rotate(vertex, sin, cos).Add(position)
+		box.Min = mgl32.Vec2{min(box.Min.X(), point.X()), min(box.Min.Y(), point.Y())}
+		box.Max = mgl32.Vec2{max(box.Max.X(), point.X()), max(box.Max.Y(), point.Y())}
+	}
+	return box
+}
+
+func signedArea(vertices []mgl32.Vec2) float32 {
+	area := float32(0)
+	for index, vertex := range vertices {
+		area += cross(vertex, vertices[(index+1)%len(vertices)])
+	}
+	return area / 2
+}
+
+func polygonCentroid(vertices []mgl32.Vec2) mgl32.Vec2 {
+	area := signedArea(vertices)
+	if area == 0 {
+		return mgl32.Vec2{}
+	}
+	centroid := mgl32.Vec2{}
+	for index, first := range vertices {
+		second := vertices[(index+1)%len(vertices)]
+		centroid = centroid.Add(first.Add(second).Mul(cross(first, second)))
+	}
+	return centroid.Mul(1 / (6 * area))
+}
+
+// cross retourne le produit vectoriel 2D (composante z)
+func cross(first, second mgl32.Vec2) float32 {
+	return first.X()*second.Y() - first.Y()*second.X()
+}
+
+// crossScalar retourne le produit vectoriel d'un scalaire (vitesse angulaire) et d'un vecteur
+func crossScalar(scalar float32, vector mgl32.Vec2) mgl32.Vec2 {
+	return mgl32.Vec2{-scalar * vector.Y(), scalar * vector.X()}
+}
+
+func sinCos(angle float32) (float32, float32) {
+	sin, cos := math.Sincos(float64(angle))
+	return float32(sin), float32(cos)
+}
+
+func rotate(vector mgl32.Vec2, sin, cos float32) mgl32.Vec2 {
+	return mgl32.Vec2{vector.X()*cos - vector.Y()*sin, vector.X()*sin + vector.Y()*cos}
+}
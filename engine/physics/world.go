@@ -0,0 +1,351 @@
+package physics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"math"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+	"slices"
+)
+
+// DEFAULT_FIXED_STEP_IN_MS est la durée par défaut d'un pas de simulation (60 pas par seconde)
+const DEFAULT_FIXED_STEP_IN_MS = 1000.0 / 60.0
+
+// MAX_STEPS_PER_UPDATE limite le nombre de pas par mise à jour (évite l'emballement quand une frame est trop longue)
+const MAX_STEPS_PER_UPDATE = 5
+
+// Boxed est un objet positionné par un rectangle (les composants de scène par exemple)
+type Boxed interface {
+	Box() *graphic.Rectangle
+}
+
+// binding associe un corps à un objet positionné (synchronisé après chaque mise à jour)
+type binding struct {
+	body   *Body
+	target Boxed
+}
+
+// World est un monde physique 2D
+//
+// Le monde avance par pas fixes (Update accumule le temps des frames) : la simulation ne dépend pas du nombre d'images
+// par seconde. Les unités sont libres (pixels par exemple) : la gravité s'exprime en unités par seconde².
+type World struct {
+	// Gravity est la gravité (vers le bas de l'écran avec des y positifs)
+	Gravity mgl32.Vec2
+	// Iterations est le nombre d'itérations de résolution des contacts par pas
+	Iterations int
+
+	bodies []*Body
+	nextId uint64
+	// broadPhase trouve les paires de corps proches
+	broadPhase *spatialHash
+	// contacts sont les contacts du dernier pas de simulation
+	contacts []Manifold
+	// overlaps sont les chevauchements de capteurs en cours
+	overlaps map[bodyPair]struct{}
+	bindings []binding
+
+	fixedStepInMs float64
+	// accumulatorInMs est le temps à simuler
+	accumulatorInMs float64
+	// stepping indique qu'un pas est en cours (les retraits de corps sont alors différés)
+	stepping bool
+	removed  []*Body
+}
+
+// NewWorld construit un monde physique avec la gravité indiquée
+func NewWorld(gravity mgl32.Vec2) *World {
+	return &World{
+		Gravity:       gravity,
+		Iterations:    8,
+		bodies:        make([]*Body, 0),
+		nextId:        1,
+		broadPhase:    newSpatialHash(DEFAULT_CELL_SIZE),
+		contacts:      make([]Manifold, 0),
+		overlaps:      make(map[bodyPair]struct{}),
+		bindings:      make([]binding, 0),
+		fixedStepInMs: DEFAULT_FIXED_STEP_IN_MS,
+		removed:       make([]*Body, 0),
+	}
+}
+
+// SetCellSize change la taille des cellules de la phase large (de l'ordre de la taille des corps mobiles)
+func (world *World) SetCellSize(cellSize float32) {
+	world.broadPhase = newSpatialHash(cellSize)
+}
+
+// SetFixedStep change la durée d'un pas de simulation (en millisecondes)
+func (world *World) SetFixedStep(fixedStepInMs float64) {
+	world.fixedStepInMs = max(fixedStepInMs, 1)
+}
+
+// Bodies retourne les corps du monde
+func (world *World) Bodies() []*Body {
+	return world.bodies
+}
+
+// AddBody ajoute un corps au monde
+func (world *World) AddBody(body *Body) *Body {
+	if body.id == 0 {
+		body.id = world.nextId
+		world.nextId++
+	}
+	world.bodies = append(world.bodies, body)
+	return body
+}
+
+// RemoveBody retire un corps du monde (les capteurs qu'il chevauche reçoivent un évènement de sortie)
+func (world *World) RemoveBody(body *Body) {
+	if world.stepping {
+		world.removed = append(world.removed, body)
+		return
+	}
+	for pair := range world.overlaps {
+		if pair.a == body || pair.b == body {
+			delete(world.overlaps, pair)
+			notifySensorExit(pair)
+		}
+	}
+	world.bodies = slices.DeleteFunc(world.bodies, func(current *Body) bool {
+		return current == body
+	})
+	world.bindings = slices.DeleteFunc(world.bindings, func(current binding) bool {
+		return current.body == body
+	})
+}
+
+// Bind synchronise la position d'un objet (composant de scène, ...) avec celle du corps :
+// le rectangle de l'objet est centré sur le corps après chaque mise à jour.
+func (world *World) Bind(body *Body, target Boxed) {
+	world.bindings = append(world.bindings, binding{body: body, target: target})
+}
+
+// Unbind arrête la synchronisation d'un objet
+func (world *World) Unbind(target Boxed) {
+	world.bindings = slices.DeleteFunc(world.bindings, func(current binding) bool {
+		return current.target == target
+	})
+}
+
+// Contacts retourne les contacts du dernier pas de simulation (hors capteurs)
+func (world *World) Contacts() []Manifold {
+	return world.contacts
+}
+
+// ContactsOf retourne les contacts d'un corps lors du dernier pas de simulation
+func (world *World) ContactsOf(body *Body) []Manifold {
+	contacts := make([]Manifold, 0)
+	for _, contact := range world.contacts {
+		if contact.A == body || contact.B == body {
+			contacts = append(contacts, contact)
+		}
+	}
+	return contacts
+}
+
+// Update avance la simulation du temps écoulé depuis la frame précédente (par pas fixes)
+func (world *World) Update(timer *engine.Timer) {
+	world.accumulatorInMs += float64(timer.ElapsedTime())
+	steps := 0
+	for world.accumulatorInMs >= world.fixedStepInMs && steps < MAX_STEPS_PER_UPDATE {
+		world.Step(float32(world.fixedStepInMs / 1000))
+		world.accumulatorInMs -= world.fixedStepInMs
+		steps++
+	}
+	if steps == MAX_STEPS_PER_UPDATE {
+		// Abandonner le retard plutôt que de ralentir les frames suivantes
+		world.accumulatorInMs = math.Mod(world.accumulatorInMs, world.fixedStepInMs)
+	}
+	world.SyncBindings()
+}
+
+// SyncBindings place les objets synchronisés sur leur corps
+func (world *World) SyncBindings() {
+	for _, current := range world.bindings {
+		box := current.target.Box()
+		box.SetPos(current.body.Position.Sub(box.Dim().Mul(0.5)))
+	}
+}
+
+// Step avance la simulation d'un pas (durée en secondes)
+func (world *World) Step(deltaTime float32) {
+	world.stepping = true
+
+	// Intégrer les forces
+	for _, body := range world.bodies {
+		if body.bodyType != DYNAMIC {
+			continue
+		}
+		acceleration := world.Gravity.Mul(body.GravityScale).Add(body.force.Mul(body.invMass))
+		body.Velocity = body.Velocity.Add(acceleration.Mul(deltaTime))
+		body.AngularVelocity += body.torque * body.invInertia * deltaTime
+		if body.LinearDamping > 0 {
+			body.Velocity = body.Velocity.Mul(max(0, 1-body.LinearDamping*deltaTime))
+		}
+	}
+
+	// Détection des contacts
+	world.contacts = world.contacts[:0]
+	overlaps := make(map[bodyPair]struct{}, len(world.overlaps))
+	for _, pair := range world.broadPhase.computePairs(world.bodies) {
+		manifold, touching := collide(pair.a, pair.b)
+		if !touching {
+			continue
+		}
+		if pair.a.sensor || pair.b.sensor {
+			overlaps[pair] = struct{}{}
+			continue
+		}
+		world.contacts = append(world.contacts, manifold)
+	}
+
+	// Résolution des vitesses
+	restingSpeed := world.Gravity.Mul(deltaTime).Len() + 1e-3
+	for iteration := 0; iteration < world.Iterations; iteration++ {
+		for index := range world.contacts {
+			resolveContact(&world.contacts[index], restingSpeed)
+		}
+	}
+
+	// Intégrer les vitesses
+	for _, body := range world.bodies {
+		if body.bodyType == STATIC {
+			continue
+		}
+		body.Position = body.Position.Add(body.Velocity.Mul(deltaTime))
+		if !body.fixedRotation && body.shape.shapeType != SHAPE_AABB {
+			body.Angle += body.AngularVelocity * deltaTime
+		}
+		body.force = mgl32.Vec2{}
+		body.torque = 0
+	}
+
+	// Corriger les interpénétrations
+	for index := range world.contacts {
+		correctPositions(&world.contacts[index])
+	}
+
+	// Évènements des capteurs
+	world.notifySensors(overlaps)
+
+	world.stepping = false
+	removed := world.removed
+	world.removed = world.removed[:0]
+	for _, body := range removed {
+		world.RemoveBody(body)
+	}
+}
+
+// notifySensors compare les chevauchements des capteurs avec ceux du pas précédent
+func (world *World) notifySensors(overlaps map[bodyPair]struct{}) {
+	entered := make([]bodyPair, 0)
+	exited := make([]bodyPair, 0)
+	for pair := range overlaps {
+		if _, found := world.overlaps[pair]; !found {
+			entered = append(entered, pair)
+		}
+	}
+	for pair := range world.overlaps {
+		if _, found := overlaps[pair]; !found {
+			exited = append(exited, pair)
+		}
+	}
+	world.overlaps = overlaps
+	sortPairs(entered)
+	sortPairs(exited)
+	for _, pair := range exited {
+		notifySensorExit(pair)
+	}
+	for _, pair := range entered {
+		notifySensorEnter(pair)
+	}
+}
+
+func notifySensorEnter(pair bodyPair) {
+	if pair.a.OnSensorEnter != nil {
+		pair.a.OnSensorEnter(pair.a, pair.b)
+	}
+	if pair.b.OnSensorEnter != nil {
+		pair.b.OnSensorEnter(pair.b, pair.a)
+	}
+}
+
+func notifySensorExit(pair bodyPair) {
+	if pair.a.OnSensorExit != nil {
+		pair.a.OnSensorExit(pair.a, pair.b)
+	}
+	if pair.b.OnSensorExit != nil {
+		pair.b.OnSensorExit(pair.b, pair.a)
+	}
+}
+
+func sortPairs(pairs []bodyPair) {
+	slices.SortFunc(pairs, func(first, second bodyPair) int {
+		if first.a.id != second.a.id {
+			return compareIds(first.a.id, second.a.id)
+		}
+		return compareIds(first.b.id, second.b.id)
+	})
+}
+
+// resolveContact applique les impulsions de collision et de frottement d'un contact
+func resolveContact(manifold *Manifold, restingSpeed float32) {
+	a, b := manifold.A, manifold.B
+	invMassSum := a.invMass + b.invMass
+	if invMassSum == 0 {
+		return
+	}
+	for index := 0; index < manifold.ContactCount; index++ {
+		contact := manifold.Contacts[index]
+		ra := contact.Sub(a.Position)
+		rb := contact.Sub(b.Position)
+		relativeVelocity := b.Velocity.Add(crossScalar(b.AngularVelocity, rb)).Sub(a.Velocity).Sub(crossScalar(a.AngularVelocity, ra))
+		contactVelocity := relativeVelocity.Dot(manifold.Normal)
+		if contactVelocity > 0 {
+			continue
+		}
+		raCrossN := cross(ra, manifold.Normal)
+		rbCrossN := cross(rb, manifold.Normal)
+		inverseMass := invMassSum + raCrossN*raCrossN*a.invInertia + rbCrossN*rbCrossN*b.invInertia
+		restitution := max(a.Restitution, b.Restitution)
+		if -contactVelocity < restingSpeed {
+			// Contact au repos : pas de rebond (évite les tremblements)
+			restitution = 0
+		}
+		impulseLength := -(1 + restitution) * contactVelocity / inverseMass / float32(manifold.ContactCount)
+		impulse := manifold.Normal.Mul(impulseLength)
+		a.ApplyImpulse(impulse.Mul(-1), ra)
+		b.ApplyImpulse(impulse, rb)
+
+		// Frottement
+		relativeVelocity = b.Velocity.Add(crossScalar(b.AngularVelocity, rb)).Sub(a.Velocity).Sub(crossScalar(a.AngularVelocity, ra))
+		tangent := relativeVelocity.Sub(manifold.Normal.Mul(relativeVelocity.Dot(manifold.Normal)))
+		if tangent.Len() < 1e-6 {
+			continue
+		}
+		tangent = tangent.Normalize()
+		raCrossT := cross(ra, tangent)
+		rbCrossT := cross(rb, tangent)
+		tangentInverseMass := invMassSum + raCrossT*raCrossT*a.invInertia + rbCrossT*rbCrossT*b.invInertia
+		frictionLength := -relativeVelocity.Dot(tangent) / tangentInverseMass / float32(manifold.ContactCount)
+		friction := float32(math.Sqrt(float64(a.Friction * b.Friction)))
+		frictionLength = mgl32.Clamp(frictionLength, -impulseLength*friction, impulseLength*friction)
+		frictionImpulse := tangent.Mul(frictionLength)
+		a.ApplyImpulse(frictionImpulse.Mul(-1), ra)
+		b.ApplyImpulse(frictionImpulse, rb)
+	}
+}
+
+// correctPositions sépare les corps qui s'interpénètrent (correction partielle pour rester stable)
+func correctPositions(manifold *Manifold) {
+	const percent = 0.4
+	const slop = 0.05
+	a, b := manifold.A, manifold.B
+	invMassSum := a.invMass + b.invMass
+	if invMassSum == 0 {
+		return
+	}
+	correction := manifold.Normal.Mul(max(manifold.Penetration-slop, 0) / invMassSum * percent)
+	a.Position = a.Position.Sub(correction.Mul(a.invMass))
+	b.Position = b.Position.Add(correction.Mul(b.invMass))
+}
@@ -0,0 +1,86 @@
+package physics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine/graphic"
+	"testing"
+)
+
+// simulate avance la simulation du nombre de pas indiqué (60 pas par seconde)
+func simulate(world *World, steps int) {
+	for step := 0; step < steps; step++ {
+		world.Step(1. / 60.)
+	}
+}
+
+func TestWorld_BodyRestsOnGround(t *testing.T) {
+	for _, shape := range []*Shape{NewAABB(20, 20), NewCircle(10), NewBox(20, 20)} {
+		world := NewWorld(mgl32.Vec2{0, 980})
+		world.AddBody(NewBody(STATIC, NewAABB(400, 20), mgl32.Vec2{200, 310}))
+		body := world.AddBody(NewBody(DYNAMIC, shape, mgl32.Vec2{200, 100}))
+		simulate(world, 180)
+		// Le sol est à y=300, le corps mesure 20 de haut
+		if y := body.Position.Y(); y < 288 || y > 291 {
+			t.Fatalf("shape %d: Position.Y() = %v, want ~290", shape.Type(), y)
+		}
+		if len(world.ContactsOf(body)) == 0 {
+			t.Fatalf("shape %d: ContactsOf() = 0 contact, want ground contact", shape.Type())
+		}
+	}
+}
+
+func TestWorld_Sensor(t *testing.T) {
+	world := NewWorld(mgl32.Vec2{})
+	sensor := world.AddBody(NewSensor(NewAABB(50, 50), mgl32.Vec2{100, 0}))
+	body := world.AddBody(NewBody(DYNAMIC, NewCircle(5), mgl32.Vec2{0, 0}))
+	body.Velocity = mgl32.Vec2{120, 0}
+	events := make([]string, 0)
+	sensor.OnSensorEnter = func(sensor *Body, other *Body) { events = append(events, "enter") }
+	sensor.OnSensorExit = func(sensor *Body, other *Body) { events = append(events, "exit") }
+	simulate(world, 120)
+	if len(events) != 2 || events[0] != "enter" || events[1] != "exit" {
+		t.Fatalf("sensor events = %v, want [enter exit]", events)
+	}
+	// Le capteur ne bloque pas le corps
+	if body.Position.X() < 230 {
+		t.Fatalf("Position.X() = %v, want > 230", body.Position.X())
+	}
+}
+
+func TestWorld_RayCast(t *testing.T) {
+	world := NewWorld(mgl32.Vec2{})
+	world.AddBody(NewBody(STATIC, NewAABB(20, 20), mgl32.Vec2{100, 0}))
+	circle := world.AddBody(NewBody(STATIC, NewCircle(10), mgl32.Vec2{50, 0}))
+	hit, found := world.RayCast(mgl32.Vec2{0, 0}, mgl32.Vec2{1, 0}, 500, nil)
+	if !found || hit.Body != circle || mgl32.Abs(hit.Distance-40) > 1e-3 || hit.Normal.ApproxEqual(mgl32.Vec2{-1, 0}) == false {
+		t.Fatalf("RayCast() = %+v, %v, want circle at distance 40", hit, found)
+	}
+	hit, found = world.RayCast(mgl32.Vec2{0, 0}, mgl32.Vec2{1, 0}, 500, func(body *Body) bool { return body != circle })
+	if !found || mgl32.Abs(hit.Distance-90) > 1e-3 {
+		t.Fatalf("RayCast() with filter = %+v, %v, want box at distance 90", hit, found)
+	}
+	if _, found = world.RayCast(mgl32.Vec2{0, 0}, mgl32.Vec2{0, 1}, 500, nil); found {
+		t.Fatalf("RayCast() down found a body, want nothing")
+	}
+}
+
+func TestWorld_Bind(t *testing.T) {
+	world := NewWorld(mgl32.Vec2{})
+	body := world.AddBody(NewBody(KINEMATIC, NewAABB(10, 10), mgl32.Vec2{0, 0}))
+	body.Velocity = mgl32.Vec2{60, 0}
+	box := &graphic.Rectangle{0, 0, 10, 10}
+	world.Bind(body, boxed{box})
+	simulate(world, 60)
+	world.SyncBindings()
+	if !box.Pos().ApproxEqualThreshold(mgl32.Vec2{55, -5}, 1e-2) {
+		t.Fatalf("SyncBindings() position = %v, want (55, -5)", box.Pos())
+	}
+}
+
+type boxed struct {
+	box *graphic.Rectangle
+}
+
+func (target boxed) Box() *graphic.Rectangle {
+	return target.box
+}
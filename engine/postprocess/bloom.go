@@ -0,0 +1,129 @@
+package postprocess
+
+import (
+	_ "embed"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"ogl46/engine/ogl"
+)
+
+//go:embed shader/bloomextract.frag
+var bloomExtractFragmentShader string
+
+//go:embed shader/bloomcombine.frag
+var bloomCombineFragmentShader string
+
+// Bloom fait rayonner les pixels lumineux
+//
+//	Les pixels plus lumineux que le seuil sont extraits à demi-résolution, floutés puis ajoutés à l'image.
+type Bloom struct {
+	extract *ShaderPass
+	blur    *Blur
+	combine *ShaderPass
+	// bright et blurred sont les framebuffers à demi-résolution
+	bright  *ogl.Framebuffer
+	blurred *ogl.Framebuffer
+	enabled bool
+}
+
+// NewBloom construit un effet de rayonnement
+//
+//	threshold est la luminosité à partir de laquelle les pixels rayonnent (0 à 1),
+//	intensity est la force du rayonnement et radius le rayon du flou (en pixels à demi-résolution).
+func NewBloom(threshold, intensity, radius float32) (*Bloom, error) {
+	bloom := &Bloom{enabled: true}
+	var err error
+	if bloom.extract, err = NewShaderPass(BLOOM, bloomExtractFragmentShader); err != nil {
+		return nil, err
+	}
+	if bloom.blur, err = NewBlur(radius); err != nil {
+		bloom.Release()
+		return nil, err
+	}
+	if bloom.combine, err = NewShaderPass(BLOOM, bloomCombineFragmentShader); err != nil {
+		bloom.Release()
+		return nil, err
+	}
+	bloom.SetThreshold(threshold)
+	bloom.SetIntensity(intensity)
+	return bloom, nil
+}
+
+// Name retourne le nom de l'effet
+func (bloom *Bloom) Name() string {
+	return BLOOM
+}
+
+// IsEnabled indique si l'effet est appliqué
+func (bloom *Bloom) IsEnabled() bool {
+	return bloom.enabled
+}
+
+// SetEnabled active ou désactive l'effet
+func (bloom *Bloom) SetEnabled(enabled bool) {
+	bloom.enabled = enabled
+}
+
+// Threshold retourne la luminosité à partir de laquelle les pixels rayonnent
+func (bloom *Bloom) Threshold() float32 {
+	return bloom.extract.Parameters().Float("threshold")
+}
+
+// SetThreshold modifie la luminosité à partir de laquelle les pixels rayonnent
+func (bloom *Bloom) SetThreshold(threshold float32) {
+	bloom.extract.Parameters().SetFloat("threshold", threshold)
+}
+
+// Intensity retourne la force du rayonnement
+func (bloom *Bloom) Intensity() float32 {
+	return bloom.combine.Parameters().Float("intensity")
+}
+
+// SetIntensity modifie la force du rayonnement
+func (bloom *Bloom) SetIntensity(intensity float32) {
+	bloom.combine.Parameters().SetFloat("intensity", intensity)
+}
+
+// Radius retourne le rayon du flou
+func (bloom *Bloom) Radius() float32 {
+	return bloom.blur.Radius()
+}
+
+// SetRadius modifie le rayon du flou
+func (bloom *Bloom) SetRadius(radius float32) {
+	bloom.blur.SetRadius(radius)
+}
+
+// Apply ajoute le rayonnement de la source dans la cible
+func (bloom *Bloom) Apply(chain *Chain, source uint32, target uint32) {
+	width, height := max(chain.Width()/2, 1), max(chain.Height()/2, 1)
+	if !ensureFramebuffer(&bloom.bright, width, height) || !ensureFramebuffer(&bloom.blurred, width, height) {
+		return
+	}
+	gl.Viewport(0, 0, width, height)
+	// Extraction des pixels lumineux
+	bloom.bright.Bind()
+	bloom.extract.draw(chain, source, chain.Width(), chain.Height())
+	// Flou horizontal puis vertical
+	bloom.blurred.Bind()
+	bloom.blur.draw(chain, bloom.bright.ColorAttachment(0), width, height, 1, 0)
+	bloom.bright.Bind()
+	bloom.blur.draw(chain, bloom.blurred.ColorAttachment(0), width, height, 0, 1)
+	// Ajout du rayonnement à l'image
+	chain.BindTarget(target)
+	bloom.combine.SetTextureHandle("bloom", bloom.bright.ColorAttachment(0))
+	bloom.combine.draw(chain, source, chain.Width(), chain.Height())
+}
+
+// Release libère les shaders et les framebuffers intermédiaires
+func (bloom *Bloom) Release() {
+	for _, pass := range []*ShaderPass{bloom.extract, bloom.combine} {
+		if pass != nil {
+			pass.Release()
+		}
+	}
+	if bloom.blur != nil {
+		bloom.blur.Release()
+	}
+	releaseFramebuffer(&bloom.bright)
+	releaseFramebuffer(&bloom.blurred)
+}
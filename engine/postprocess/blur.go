@@ -0,0 +1,103 @@
+package postprocess
+
+import (
+	_ "embed"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+	"log/slog"
+	"ogl46/engine/ogl"
+)
+
+//go:embed shader/blur.frag
+var blurFragmentShader string
+
+// ensureFramebuffer crée ou redimensionne un framebuffer intermédiaire
+func ensureFramebuffer(framebuffer **ogl.Framebuffer, width, height int32) bool {
+	width, height = max(width, 1), max(height, 1)
+	var err error
+	if *framebuffer == nil {
+		*framebuffer, err = ogl.NewFramebuffer(width, height, ogl.FramebufferConfig{})
+	} else {
+		err = (*framebuffer).Resize(width, height)
+	}
+	if err != nil {
+		slog.Error("failed to prepare post-processing framebuffer", "error", err)
+		return false
+	}
+	return true
+}
+
+// releaseFramebuffer libère un framebuffer intermédiaire
+func releaseFramebuffer(framebuffer **ogl.Framebuffer) {
+	if *framebuffer != nil {
+		(*framebuffer).Delete()
+		*framebuffer = nil
+	}
+}
+
+// Blur est un flou gaussien (une passe horizontale puis une passe verticale)
+type Blur struct {
+	pass *ShaderPass
+	// intermediate contient le résultat de la passe horizontale
+	intermediate *ogl.Framebuffer
+	radius       float32
+}
+
+// NewBlur construit un flou gaussien (radius en pixels, 32 au maximum)
+func NewBlur(radius float32) (*Blur, error) {
+	pass, err := NewShaderPass(BLUR, blurFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	return &Blur{pass: pass, radius: radius}, nil
+}
+
+// Name retourne le nom de l'effet
+func (blur *Blur) Name() string {
+	return blur.pass.Name()
+}
+
+// IsEnabled indique si l'effet est appliqué
+func (blur *Blur) IsEnabled() bool {
+	return blur.pass.IsEnabled()
+}
+
+// SetEnabled active ou désactive l'effet
+func (blur *Blur) SetEnabled(enabled bool) {
+	blur.pass.SetEnabled(enabled)
+}
+
+// Radius retourne le rayon du flou (en pixels)
+func (blur *Blur) Radius() float32 {
+	return blur.radius
+}
+
+// SetRadius modifie le rayon du flou (en pixels)
+func (blur *Blur) SetRadius(radius float32) {
+	blur.radius = max(radius, 0)
+}
+
+// Apply floute la source dans la cible
+func (blur *Blur) Apply(chain *Chain, source uint32, target uint32) {
+	if !ensureFramebuffer(&blur.intermediate, chain.Width(), chain.Height()) {
+		return
+	}
+	blur.intermediate.Bind()
+	gl.Viewport(0, 0, chain.Width(), chain.Height())
+	blur.draw(chain, source, chain.Width(), chain.Height(), 1, 0)
+	chain.BindTarget(target)
+	blur.draw(chain, blur.intermediate.ColorAttachment(0), chain.Width(), chain.Height(), 0, 1)
+}
+
+// draw applique une passe de flou dans une direction
+func (blur *Blur) draw(chain *Chain, source uint32, width, height int32, directionX, directionY float32) {
+	blur.pass.Parameters().SetFloat("radius", blur.radius)
+	blur.pass.Parameters().SetVec2("direction", mgl32.Vec2{directionX, directionY})
+	blur.pass.draw(chain, source, width, height)
+}
+
+// Release libère le shader et le framebuffer intermédiaire
+func (blur *Blur) Release() {
+	blur.pass.Release()
+	releaseFramebuffer(&blur.intermediate)
+}
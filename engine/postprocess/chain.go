@@ -0,0 +1,218 @@
+package postprocess
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"log/slog"
+	"ogl46/engine/ogl"
+)
+
+//go:embed shader/fullscreen.vert
+var fullscreenVertexShader string
+
+//go:embed shader/copy.frag
+var copyFragmentShader string
+
+// Chain est une chaîne de post-traitement : la scène est dessinée dans un framebuffer,
+// puis les effets actifs sont appliqués l'un après l'autre avant l'affichage
+//
+//	Begin et End encadrent le rendu de la scène (voir Application.SetPostProcessing).
+type Chain struct {
+	// scene est le framebuffer où la scène est dessinée
+	scene *ogl.Framebuffer
+	// buffers sont les framebuffers intermédiaires (utilisés en alternance)
+	buffers [2]*ogl.Framebuffer
+	// vao est le vertex array vide utilisé par le triangle plein écran
+	vao uint32
+	// copy recopie la scène quand aucun effet n'est actif
+	copy    *ShaderPass
+	effects []Effect
+
+	// timeInMs est le temps écoulé depuis la création de la chaîne (en millisecondes)
+	timeInMs int64
+
+	// output et outputViewport sont la cible finale et son viewport (mémorisés par Begin)
+	output         uint32
+	outputViewport [4]int32
+}
+
+// NewChain construit une chaîne de post-traitement de la taille indiquée (taille de la fenêtre en général)
+func NewChain(width, height int32) (*Chain, error) {
+	slog.Debug("post-processing chain creation", "width", width, "height", height)
+	chain := &Chain{}
+	var err error
+	if chain.scene, err = ogl.NewFramebuffer(width, height, ogl.FramebufferConfig{DepthStencil: true}); err != nil {
+		return nil, fmt.Errorf("failed to create post-processing scene framebuffer\n - %w", err)
+	}
+	for index := range chain.buffers {
+		if chain.buffers[index], err = ogl.NewFramebuffer(width, height, ogl.FramebufferConfig{}); err != nil {
+			chain.Release()
+			return nil, fmt.Errorf("failed to create post-processing framebuffer\n - %w", err)
+		}
+	}
+	if chain.copy, err = NewShaderPass("copy", copyFragmentShader); err != nil {
+		chain.Release()
+		return nil, err
+	}
+	gl.GenVertexArrays(1, &chain.vao)
+	return chain, nil
+}
+
+// Release libère la chaîne et ses effets
+func (chain *Chain) Release() {
+	slog.Debug("post-processing chain destruction")
+	for _, effect := range chain.effects {
+		effect.Release()
+	}
+	chain.effects = nil
+	if chain.copy != nil {
+		chain.copy.Release()
+		chain.copy = nil
+	}
+	for index, buffer := range chain.buffers {
+		if buffer != nil {
+			buffer.Delete()
+			chain.buffers[index] = nil
+		}
+	}
+	if chain.scene != nil {
+		chain.scene.Delete()
+		chain.scene = nil
+	}
+	if chain.vao != 0 {
+		gl.DeleteVertexArrays(1, &chain.vao)
+		chain.vao = 0
+	}
+}
+
+// Resize change la taille de la chaîne (sur WINDOW_RESIZED)
+func (chain *Chain) Resize(width, height int32) error {
+	if err := chain.scene.Resize(width, height); err != nil {
+		return err
+	}
+	for _, buffer := range chain.buffers {
+		if err := buffer.Resize(width, height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Width retourne la largeur de la chaîne
+func (chain *Chain) Width() int32 {
+	return chain.scene.Width()
+}
+
+// Height retourne la hauteur de la chaîne
+func (chain *Chain) Height() int32 {
+	return chain.scene.Height()
+}
+
+// Scene retourne le framebuffer où la scène est dessinée
+func (chain *Chain) Scene() *ogl.Framebuffer {
+	return chain.scene
+}
+
+// Add ajoute un effet à la fin de la chaîne
+func (chain *Chain) Add(effect Effect) error {
+	if chain.Effect(effect.Name()) != nil {
+		return fmt.Errorf("post-processing effect '%s' already exists", effect.Name())
+	}
+	chain.effects = append(chain.effects, effect)
+	return nil
+}
+
+// Remove retire un effet de la chaîne (l'effet n'est pas libéré)
+func (chain *Chain) Remove(name string) Effect {
+	for index, effect := range chain.effects {
+		if effect.Name() == name {
+			chain.effects = append(chain.effects[:index], chain.effects[index+1:]...)
+			return effect
+		}
+	}
+	return nil
+}
+
+// Effect retourne l'effet portant le nom indiqué (nil s'il n'existe pas)
+func (chain *Chain) Effect(name string) Effect {
+	for _, effect := range chain.effects {
+		if effect.Name() == name {
+			return effect
+		}
+	}
+	return nil
+}
+
+// Effects retourne les effets de la chaîne (dans l'ordre d'application)
+func (chain *Chain) Effects() []Effect {
+	return chain.effects
+}
+
+// Update fait avancer le temps transmis aux shaders (uniform time)
+func (chain *Chain) Update(elapsedTime int64) {
+	chain.timeInMs += elapsedTime
+}
+
+// Time retourne le temps écoulé en secondes
+func (chain *Chain) Time() float32 {
+	return float32(chain.timeInMs) / 1000.
+}
+
+// Begin redirige le rendu vers le framebuffer de la scène
+func (chain *Chain) Begin() {
+	chain.output = ogl.BoundFramebuffer()
+	gl.GetIntegerv(gl.VIEWPORT, &chain.outputViewport[0])
+	chain.scene.Bind()
+	gl.Viewport(0, 0, chain.Width(), chain.Height())
+}
+
+// End applique les effets actifs et dessine le résultat dans la cible d'origine
+func (chain *Chain) End() {
+	states := ogl.GlobalStateCache()
+	states.Push()
+	states.Apply(ogl.RenderState{Blend: ogl.BLEND_NONE, Cull: ogl.CULL_NONE})
+
+	enabled := make([]Effect, 0, len(chain.effects))
+	for _, effect := range chain.effects {
+		if effect.IsEnabled() {
+			enabled = append(enabled, effect)
+		}
+	}
+	if len(enabled) == 0 {
+		enabled = append(enabled, chain.copy)
+	}
+	source := chain.scene.ColorAttachment(0)
+	for index, effect := range enabled {
+		target := chain.output
+		if index < len(enabled)-1 {
+			target = chain.buffers[index%2].Handle()
+		}
+		effect.Apply(chain, source, target)
+		if index < len(enabled)-1 {
+			source = chain.buffers[index%2].ColorAttachment(0)
+		}
+	}
+
+	ogl.BindFramebufferHandle(chain.output)
+	gl.Viewport(chain.outputViewport[0], chain.outputViewport[1], chain.outputViewport[2], chain.outputViewport[3])
+	gl.BindVertexArray(0)
+	gl.UseProgram(0)
+	states.Pop()
+}
+
+// BindTarget rend une cible courante (framebuffer OpenGL) et positionne le viewport correspondant
+func (chain *Chain) BindTarget(target uint32) {
+	ogl.BindFramebufferHandle(target)
+	if target == chain.output {
+		gl.Viewport(chain.outputViewport[0], chain.outputViewport[1], chain.outputViewport[2], chain.outputViewport[3])
+	} else {
+		gl.Viewport(0, 0, chain.Width(), chain.Height())
+	}
+}
+
+// DrawFullscreen dessine un triangle couvrant toute la cible (le shader doit être actif)
+func (chain *Chain) DrawFullscreen() {
+	gl.BindVertexArray(chain.vao)
+	gl.DrawArrays(gl.TRIANGLES, 0, 3)
+}
@@ -0,0 +1,51 @@
+package postprocess
+
+import (
+	"testing"
+)
+
+// fakeEffect est un effet sans rendu
+type fakeEffect struct {
+	name    string
+	enabled bool
+}
+
+func (effect *fakeEffect) Name() string                                     { return effect.name }
+func (effect *fakeEffect) IsEnabled() bool                                  { return effect.enabled }
+func (effect *fakeEffect) SetEnabled(enabled bool)                          { effect.enabled = enabled }
+func (effect *fakeEffect) Apply(chain *Chain, source uint32, target uint32) {}
+func (effect *fakeEffect) Release()                                         {}
+
+func TestChainEffects(t *testing.T) {
+	chain := &Chain{}
+	for _, name := range []string{VIGNETTE, BLOOM, CRT} {
+		if err := chain.Add(&fakeEffect{name: name, enabled: true}); err != nil {
+			t.Fatalf("Add(%s) error: %v", name, err)
+		}
+	}
+	if err := chain.Add(&fakeEffect{name: BLOOM}); err == nil {
+		t.Fatalf("Add() of an existing effect, want error")
+	}
+	if chain.Effect(BLOOM) == nil || chain.Effect(FXAA) != nil {
+		t.Fatalf("Effect() is wrong")
+	}
+	if removed := chain.Remove(BLOOM); removed == nil || removed.Name() != BLOOM {
+		t.Fatalf("Remove(%s) = %v, want bloom effect", BLOOM, removed)
+	}
+	effects := chain.Effects()
+	if len(effects) != 2 || effects[0].Name() != VIGNETTE || effects[1].Name() != CRT {
+		t.Fatalf("Effects() = %v, want [vignette crt]", effects)
+	}
+	if chain.Remove(BLOOM) != nil {
+		t.Fatalf("Remove() of a missing effect, want nil")
+	}
+}
+
+func TestChainTime(t *testing.T) {
+	chain := &Chain{}
+	chain.Update(1500)
+	chain.Update(250)
+	if chain.Time() != 1.75 {
+		t.Fatalf("Time() = %v, want 1.75", chain.Time())
+	}
+}
@@ -0,0 +1,105 @@
+package postprocess
+
+import (
+	_ "embed"
+	"fmt"
+	"ogl46/engine/graphic"
+)
+
+//go:embed shader/vignette.frag
+var vignetteFragmentShader string
+
+//go:embed shader/pixelate.frag
+var pixelateFragmentShader string
+
+//go:embed shader/crt.frag
+var crtFragmentShader string
+
+//go:embed shader/fxaa.frag
+var fxaaFragmentShader string
+
+//go:embed shader/colorgrading.frag
+var colorGradingFragmentShader string
+
+// Noms des effets prédéfinis
+const (
+	VIGNETTE      = "vignette"
+	PIXELATE      = "pixelate"
+	CRT           = "crt"
+	FXAA          = "fxaa"
+	COLOR_GRADING = "colorGrading"
+	BLUR          = "blur"
+	BLOOM         = "bloom"
+)
+
+// NewVignette construit un effet assombrissant les bords de l'écran
+//
+//	Paramètres : intensity (0.5), radius (0.75), softness (0.45).
+func NewVignette() (*ShaderPass, error) {
+	pass, err := NewShaderPass(VIGNETTE, vignetteFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	pass.Parameters().SetFloat("intensity", 0.5)
+	pass.Parameters().SetFloat("radius", 0.75)
+	pass.Parameters().SetFloat("softness", 0.45)
+	return pass, nil
+}
+
+// NewPixelate construit un effet de pixellisation (pixelSize est la taille des gros pixels)
+//
+//	Paramètre : pixelSize.
+func NewPixelate(pixelSize float32) (*ShaderPass, error) {
+	pass, err := NewShaderPass(PIXELATE, pixelateFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	pass.Parameters().SetFloat("pixelSize", pixelSize)
+	return pass, nil
+}
+
+// NewCRT construit un effet d'écran cathodique (courbure, lignes de balayage et aberration chromatique)
+//
+//	Paramètres : curvature (0.05), scanlineIntensity (0.25), scanlineCount (0 = une ligne pour deux pixels), aberration (1).
+func NewCRT() (*ShaderPass, error) {
+	pass, err := NewShaderPass(CRT, crtFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	pass.Parameters().SetFloat("curvature", 0.05)
+	pass.Parameters().SetFloat("scanlineIntensity", 0.25)
+	pass.Parameters().SetFloat("scanlineCount", 0.)
+	pass.Parameters().SetFloat("aberration", 1.)
+	return pass, nil
+}
+
+// NewFXAA construit un anti-crénelage rapide (Fast Approximate Anti-Aliasing)
+//
+//	Paramètre : spanMax (8).
+func NewFXAA() (*ShaderPass, error) {
+	pass, err := NewShaderPass(FXAA, fxaaFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	pass.Parameters().SetFloat("spanMax", 8.)
+	return pass, nil
+}
+
+// NewColorGrading construit un étalonnage des couleurs à partir d'une table de correspondance (LUT)
+//
+//	lut est une bande horizontale de size carrés de size x size pixels (bleu = carré, rouge = colonne,
+//	vert = ligne de haut en bas). Paramètre : intensity (1).
+func NewColorGrading(lut *graphic.Texture) (*ShaderPass, error) {
+	size := lut.Height()
+	if size < 2 || lut.Width() != size*size {
+		return nil, fmt.Errorf("invalid %dx%d color look-up table (%dx%d expected)", lut.Width(), lut.Height(), size*size, size)
+	}
+	pass, err := NewShaderPass(COLOR_GRADING, colorGradingFragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	pass.SetTexture("lut", lut)
+	pass.Parameters().SetFloat("lutSize", float32(size))
+	pass.Parameters().SetFloat("intensity", 1.)
+	return pass, nil
+}
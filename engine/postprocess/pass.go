@@ -0,0 +1,177 @@
+package postprocess
+
+import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
+	"log/slog"
+	"ogl46/engine/graphic"
+	"ogl46/engine/ogl"
+	"os"
+)
+
+// Effect est un effet plein écran d'une chaîne de post-traitement
+type Effect interface {
+	// Name retourne le nom de l'effet (unique dans une chaîne)
+	Name() string
+	// IsEnabled indique si l'effet est appliqué
+	IsEnabled() bool
+	// SetEnabled active ou désactive l'effet
+	SetEnabled(enabled bool)
+	// Apply dessine la texture source (texture OpenGL) dans la cible (framebuffer OpenGL, voir Chain.BindTarget)
+	Apply(chain *Chain, source uint32, target uint32)
+	// Release libère les ressources de l'effet
+	Release()
+}
+
+// passTexture est une texture supplémentaire utilisée par une passe
+type passTexture struct {
+	name    string
+	texture graphic.Sampler
+	// handle est la texture OpenGL utilisée quand texture est nil (attachement d'un framebuffer par exemple)
+	handle uint32
+}
+
+// ShaderPass est une passe composée d'un fragment shader appliqué à tout l'écran
+//
+//	Le fragment shader reçoit :
+//	  in vec2 TexCoords;       // coordonnées de texture (origine en bas à gauche)
+//	  uniform sampler2D image; // image source (unité 0)
+//	  uniform vec2 resolution; // taille de l'image source en pixels
+//	  uniform float time;      // temps écoulé en secondes (voir Chain.Update)
+//	  out vec4 color;          // couleur du pixel
+//	Les autres uniforms sont les paramètres de la passe (modifiables à tout moment).
+type ShaderPass struct {
+	name          string
+	enabled       bool
+	shaderProgram *ogl.ShaderProgram
+	parameters    ogl.UniformValues
+	// textures contient les textures supplémentaires (unités 1, 2...)
+	textures []passTexture
+}
+
+// NewShaderPass construit une passe à partir d'un fragment shader
+func NewShaderPass(name string, fragmentSource string) (*ShaderPass, error) {
+	slog.Debug("post-processing pass creation", "name", name)
+	shaderProgram, err := newPassProgram(fragmentSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build '%s' post-processing pass\n - %w", name, err)
+	}
+	return &ShaderPass{
+		name:          name,
+		enabled:       true,
+		shaderProgram: shaderProgram,
+		parameters:    make(ogl.UniformValues),
+	}, nil
+}
+
+// LoadShaderPassFromFile construit une passe à partir d'un fichier de fragment shader
+func LoadShaderPassFromFile(name string, filename string) (*ShaderPass, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load '%s' post-processing shader file\n - %w", filename, err)
+	}
+	return NewShaderPass(name, string(content))
+}
+
+// newPassProgram compile le fragment shader avec le vertex shader plein écran
+func newPassProgram(fragmentSource string) (*ogl.ShaderProgram, error) {
+	vertexShader, err := ogl.NewShaderFromSource(fullscreenVertexShader, gl.VERTEX_SHADER)
+	if err != nil {
+		return nil, err
+	}
+	fragmentShader, err := ogl.NewShaderFromSource(fragmentSource, gl.FRAGMENT_SHADER)
+	if err != nil {
+		vertexShader.Delete()
+		return nil, err
+	}
+	shaderProgram, err := ogl.NewShaderProgram(vertexShader, fragmentShader)
+	if err != nil {
+		return nil, err
+	}
+	return shaderProgram, nil
+}
+
+// Name retourne le nom de la passe
+func (pass *ShaderPass) Name() string {
+	return pass.name
+}
+
+// IsEnabled indique si la passe est appliquée
+func (pass *ShaderPass) IsEnabled() bool {
+	return pass.enabled
+}
+
+// SetEnabled active ou désactive la passe
+func (pass *ShaderPass) SetEnabled(enabled bool) {
+	pass.enabled = enabled
+}
+
+// Parameters retourne les paramètres (uniforms) de la passe
+func (pass *ShaderPass) Parameters() ogl.UniformValues {
+	return pass.parameters
+}
+
+// SetTexture associe une texture à un uniform sampler (unités 1, 2... dans l'ordre de première association)
+//
+//	La texture peut être une graphic.Texture, une graphic.CubeMap, un graphic.TextureArray ou une graphic.Texture3d.
+func (pass *ShaderPass) SetTexture(name string, texture graphic.Sampler) {
+	pass.setTexture(passTexture{name: name, texture: texture})
+}
+
+// SetTextureHandle associe une texture OpenGL (attachement d'un framebuffer par exemple) à un uniform sampler2D
+func (pass *ShaderPass) SetTextureHandle(name string, handle uint32) {
+	pass.setTexture(passTexture{name: name, handle: handle})
+}
+
+// setTexture ajoute ou remplace une texture supplémentaire
+func (pass *ShaderPass) setTexture(texture passTexture) {
+	for index := range pass.textures {
+		if pass.textures[index].name == texture.name {
+			pass.textures[index] = texture
+			return
+		}
+	}
+	pass.textures = append(pass.textures, texture)
+}
+
+// Apply dessine la source dans la cible avec le shader de la passe
+func (pass *ShaderPass) Apply(chain *Chain, source uint32, target uint32) {
+	chain.BindTarget(target)
+	pass.draw(chain, source, chain.Width(), chain.Height())
+}
+
+// Release libère le shader de la passe
+func (pass *ShaderPass) Release() {
+	if pass.shaderProgram != nil {
+		slog.Debug("post-processing pass destruction", "name", pass.name)
+		pass.shaderProgram.Delete()
+		pass.shaderProgram = nil
+	}
+}
+
+// draw dessine la source (de taille width x height) dans le framebuffer courant
+func (pass *ShaderPass) draw(chain *Chain, source uint32, width, height int32) {
+	shaderProgram := pass.shaderProgram
+	shaderProgram.Use()
+	gl.BindTextureUnit(0, source)
+	// Uniforms prédéfinis, facultatifs dans les shaders des passes
+	if shaderProgram.HasUniform("image") {
+		shaderProgram.Uniform1i("image", 0)
+	}
+	if shaderProgram.HasUniform("resolution") {
+		shaderProgram.Uniform2f("resolution", float32(width), float32(height))
+	}
+	if shaderProgram.HasUniform("time") {
+		shaderProgram.Uniform1f("time", chain.Time())
+	}
+	pass.parameters.Apply(shaderProgram)
+	for index, texture := range pass.textures {
+		if texture.texture != nil {
+			texture.texture.BindTextureUnit(gl.TEXTURE1 + uint32(index))
+		} else {
+			gl.BindTextureUnit(uint32(index+1), texture.handle)
+		}
+		shaderProgram.Uniform1i(texture.name, int32(index+1))
+	}
+	chain.DrawFullscreen()
+}
@@ -0,0 +1,14 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform sampler2D bloom; // Blurred bright pixels
+uniform float intensity; // Glow intensity
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords);
+    color = vec4(pixel.rgb + texture(bloom, TexCoords).rgb * intensity, pixel.a);
+}
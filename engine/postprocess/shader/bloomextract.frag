@@ -0,0 +1,15 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform float threshold; // Brightness from which pixels glow
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords);
+    float brightness = max(pixel.r, max(pixel.g, pixel.b));
+    float contribution = max(brightness - threshold, 0.0) / max(brightness, 0.0001);
+    color = vec4(pixel.rgb * contribution, 1.0);
+}
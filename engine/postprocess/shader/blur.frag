@@ -0,0 +1,24 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform vec2 resolution; // Source size (pixels)
+uniform vec2 direction;  // Blur direction ((1, 0) = horizontal, (0, 1) = vertical)
+uniform float radius;    // Blur radius (pixels)
+
+void main()
+{
+    // separable gaussian blur (one direction by pass)
+    float sigma = max(radius * 0.5, 0.0001);
+    int taps = min(int(ceil(radius)), 32);
+    vec4 sum = vec4(0.0);
+    float total = 0.0;
+    for (int i = -taps; i <= taps; i++) {
+        float weight = exp(-float(i * i) / (2.0 * sigma * sigma));
+        sum += texture(image, TexCoords + direction * float(i) / resolution) * weight;
+        total += weight;
+    }
+    color = sum / total;
+}
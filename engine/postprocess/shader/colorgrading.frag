@@ -0,0 +1,29 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform sampler2D lut;   // Color look-up table (horizontal strip of lutSize slices of lutSize x lutSize)
+uniform float lutSize;   // Number of colors by channel in look-up table
+uniform float intensity; // Grading intensity (0 = original colors)
+
+// lookup returns graded color of a slice (blue), red is horizontal and green is vertical (top to bottom)
+vec3 lookup(vec3 pixel, float slice)
+{
+    vec2 coordinates = vec2(
+        (slice * lutSize + pixel.r * (lutSize - 1.0) + 0.5) / (lutSize * lutSize),
+        (pixel.g * (lutSize - 1.0) + 0.5) / lutSize
+    );
+    return texture(lut, coordinates).rgb;
+}
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords);
+    vec3 clamped = clamp(pixel.rgb, 0.0, 1.0);
+    float blue = clamped.b * (lutSize - 1.0);
+    float slice = floor(blue);
+    vec3 graded = mix(lookup(clamped, slice), lookup(clamped, min(slice + 1.0, lutSize - 1.0)), blue - slice);
+    color = vec4(mix(pixel.rgb, graded, intensity), pixel.a);
+}
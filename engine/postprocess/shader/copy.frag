@@ -0,0 +1,11 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+
+void main()
+{
+    color = texture(image, TexCoords);
+}
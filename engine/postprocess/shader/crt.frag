@@ -0,0 +1,32 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image;         // Source
+uniform vec2 resolution;         // Source size (pixels)
+uniform float curvature;         // Screen curvature (0 = flat)
+uniform float scanlineIntensity; // Scanline darkening (0 = none)
+uniform float scanlineCount;     // Number of scanlines (0 = one line every two pixels)
+uniform float aberration;        // Chromatic aberration (pixels)
+
+void main()
+{
+    // curved screen
+    vec2 uv = TexCoords * 2.0 - 1.0;
+    uv *= 1.0 + curvature * uv.yx * uv.yx;
+    vec2 coordinates = uv * 0.5 + 0.5;
+    if (coordinates.x < 0.0 || coordinates.x > 1.0 || coordinates.y < 0.0 || coordinates.y > 1.0) {
+        color = vec4(0.0, 0.0, 0.0, 1.0);
+        return;
+    }
+    // chromatic aberration
+    vec2 offset = vec2(aberration / resolution.x, 0.0);
+    vec4 pixel = texture(image, coordinates);
+    pixel.r = texture(image, coordinates + offset).r;
+    pixel.b = texture(image, coordinates - offset).b;
+    // scanlines
+    float lines = scanlineCount > 0.0 ? scanlineCount : resolution.y * 0.5;
+    float scanline = sin(coordinates.y * lines * 3.14159265) * 0.5 + 0.5;
+    color = vec4(pixel.rgb * (1.0 - scanlineIntensity * (1.0 - scanline)), pixel.a);
+}
@@ -0,0 +1,11 @@
+#version 460 core
+
+out vec2 TexCoords; // Texture coordinates (out)
+
+void main()
+{
+    // Full screen triangle built from vertex index (no vertex buffer)
+    vec2 position = vec2((gl_VertexID << 1) & 2, gl_VertexID & 2);
+    TexCoords = position;
+    gl_Position = vec4(position * 2.0 - 1.0, 0.0, 1.0);
+}
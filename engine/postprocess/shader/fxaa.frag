@@ -0,0 +1,39 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform vec2 resolution; // Source size (pixels)
+uniform float spanMax;   // Maximum search distance (pixels)
+
+const float REDUCE_MIN = 1.0 / 128.0;
+const float REDUCE_MUL = 1.0 / 8.0;
+const vec3 LUMA = vec3(0.299, 0.587, 0.114);
+
+void main()
+{
+    vec2 texel = 1.0 / resolution;
+    vec4 pixel = texture(image, TexCoords);
+    float lumaNW = dot(texture(image, TexCoords + vec2(-1.0, -1.0) * texel).rgb, LUMA);
+    float lumaNE = dot(texture(image, TexCoords + vec2(1.0, -1.0) * texel).rgb, LUMA);
+    float lumaSW = dot(texture(image, TexCoords + vec2(-1.0, 1.0) * texel).rgb, LUMA);
+    float lumaSE = dot(texture(image, TexCoords + vec2(1.0, 1.0) * texel).rgb, LUMA);
+    float lumaM = dot(pixel.rgb, LUMA);
+    float lumaMin = min(lumaM, min(min(lumaNW, lumaNE), min(lumaSW, lumaSE)));
+    float lumaMax = max(lumaM, max(max(lumaNW, lumaNE), max(lumaSW, lumaSE)));
+
+    // edge direction
+    vec2 direction = vec2(-((lumaNW + lumaNE) - (lumaSW + lumaSE)), (lumaNW + lumaSW) - (lumaNE + lumaSE));
+    float directionReduce = max((lumaNW + lumaNE + lumaSW + lumaSE) * 0.25 * REDUCE_MUL, REDUCE_MIN);
+    float inverseDirectionMin = 1.0 / (min(abs(direction.x), abs(direction.y)) + directionReduce);
+    direction = clamp(direction * inverseDirectionMin, vec2(-spanMax), vec2(spanMax)) * texel;
+
+    // samples along edge
+    vec3 colorA = 0.5 * (texture(image, TexCoords + direction * (1.0 / 3.0 - 0.5)).rgb +
+                         texture(image, TexCoords + direction * (2.0 / 3.0 - 0.5)).rgb);
+    vec3 colorB = colorA * 0.5 + 0.25 * (texture(image, TexCoords - direction * 0.5).rgb +
+                                         texture(image, TexCoords + direction * 0.5).rgb);
+    float lumaB = dot(colorB, LUMA);
+    color = vec4((lumaB < lumaMin || lumaB > lumaMax) ? colorA : colorB, pixel.a);
+}
@@ -0,0 +1,14 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform vec2 resolution; // Source size (pixels)
+uniform float pixelSize; // Size of big pixels (pixels)
+
+void main()
+{
+    vec2 size = max(pixelSize, 1.0) / resolution;
+    color = texture(image, (floor(TexCoords / size) + 0.5) * size);
+}
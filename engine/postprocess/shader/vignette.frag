@@ -0,0 +1,17 @@
+#version 460 core
+
+in vec2 TexCoords; // Texture coordinates
+out vec4 color;    // Pixel color
+
+uniform sampler2D image; // Source
+uniform float intensity; // Darkening intensity (0 = none, 1 = black borders)
+uniform float radius;    // Distance from center where darkening starts (0.5 = screen border)
+uniform float softness;  // Darkening transition width
+
+void main()
+{
+    vec4 pixel = texture(image, TexCoords);
+    float distance = length(TexCoords - 0.5);
+    float vignette = smoothstep(radius, radius - softness, distance);
+    color = vec4(mix(pixel.rgb, pixel.rgb * vignette, intensity), pixel.a);
+}
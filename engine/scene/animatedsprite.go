@@ -0,0 +1,127 @@
+package scene
+
+import (
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+)
+
+// AnimatedSpriteComponentType est le nom du type de composant sprite animé dans les descriptions de scène
+const AnimatedSpriteComponentType = "animated-sprite"
+
+// Propriétés des descriptions de scène utilisées par les sprites animés
+const (
+	// animatedSpriteSheetProperty est le nom de la planche de sprites dans le gestionnaire de planches
+	animatedSpriteSheetProperty = "sheet"
+	// animatedSpriteClipProperty est le nom du clip joué au démarrage
+	animatedSpriteClipProperty = "clip"
+)
+
+// AnimatedSpriteComponent est un composant qui joue des clips d'une planche de sprites
+type AnimatedSpriteComponent struct {
+	GenericComponent
+
+	texture *graphic.Texture
+	sheet   *graphic.SpriteSheet
+	player  graphic.AnimationPlayer
+	color   graphic.Color
+
+	// Noms des ressources dans les gestionnaires (pour les scènes déclaratives)
+	textureName string
+	sheetName   string
+}
+
+func BuildAnimatedSpriteComponent(texture *graphic.Texture, sheet *graphic.SpriteSheet) *AnimatedSpriteComponent {
+	return &AnimatedSpriteComponent{
+		GenericComponent: BuildGenericComponent(),
+		texture:          texture,
+		sheet:            sheet,
+		player:           graphic.BuildAnimationPlayer(),
+		color:            graphic.White,
+	}
+}
+
+func (sprite *AnimatedSpriteComponent) GetComponentUnder(position mgl32.Vec2) Component {
+	if sprite.IsComponentUnder(position) {
+		return sprite
+	}
+	return nil
+}
+
+// Play démarre le clip indiqué depuis sa première frame
+func (sprite *AnimatedSpriteComponent) Play(clipName string) error {
+	clip := sprite.sheet.Clip(clipName)
+	if clip == nil {
+		return fmt.Errorf("cannot play unknown '%s' clip", clipName)
+	}
+	sprite.player.Play(clip)
+	return nil
+}
+
+// Player retourne le lecteur d'animation (vitesse, pause, évènements, ...)
+func (sprite *AnimatedSpriteComponent) Player() *graphic.AnimationPlayer {
+	return &sprite.player
+}
+
+func (sprite *AnimatedSpriteComponent) Color() graphic.Color {
+	return sprite.color
+}
+
+func (sprite *AnimatedSpriteComponent) SetColor(color graphic.Color) {
+	sprite.color = color
+}
+
+// Traitement
+func (sprite *AnimatedSpriteComponent) Execute(application *engine.Application, scene *Scene2d, timer *engine.Timer) {
+	sprite.player.Update(timer.ElapsedTime())
+}
+
+// Dessin
+func (sprite *AnimatedSpriteComponent) Draw(application *engine.Application, scene *Scene2d, drawer *Scene2dDrawer, timer *engine.Timer) {
+	frame := sprite.player.Frame()
+	if frame == nil {
+		return
+	}
+	drawer.DrawSpriteFromRectWithColor(sprite.texture, frame.Region, sprite.box, sprite.color)
+}
+
+// Describe retourne la description du composant
+func (sprite *AnimatedSpriteComponent) Describe() ComponentDescription {
+	description := ComponentDescription{
+		Type:       AnimatedSpriteComponentType,
+		Texture:    sprite.textureName,
+		Properties: map[string]string{animatedSpriteSheetProperty: sprite.sheetName},
+	}
+	if sprite.color != graphic.White {
+		color := sprite.color
+		description.Color = &color
+	}
+	if clip := sprite.player.Clip(); clip != nil {
+		description.Properties[animatedSpriteClipProperty] = clip.Name
+	}
+	return description
+}
+
+// buildAnimatedSpriteComponentFromDescription construit un sprite animé à partir de sa description
+func buildAnimatedSpriteComponentFromDescription(application *engine.Application, description *ComponentDescription) (Component, error) {
+	texture, err := application.TextureManager().Get(description.Texture)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get animated sprite texture\n - %w", err)
+	}
+	sheetName := description.Properties[animatedSpriteSheetProperty]
+	sheet, err := application.SpriteSheetManager().Get(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get animated sprite sheet\n - %w", err)
+	}
+	sprite := BuildAnimatedSpriteComponent(texture, sheet)
+	sprite.textureName = description.Texture
+	sprite.sheetName = sheetName
+	sprite.color = descriptionColor(description, graphic.White)
+	if clipName := description.Properties[animatedSpriteClipProperty]; clipName != "" {
+		if err := sprite.Play(clipName); err != nil {
+			return nil, err
+		}
+	}
+	return sprite, nil
+}
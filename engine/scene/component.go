@@ -41,6 +41,8 @@ type Component interface {
 }
 
 type GenericComponent struct {
+	// Nom du composant (optionnel, permet de retrouver le composant dans la scène)
+	name string
 	// Position et dimension du composant
 	box graphic.Rectangle
 	// Le composant est-il visible
@@ -51,6 +53,9 @@ type GenericComponent struct {
 	OnMouseMoveFunc   OnMouseMoveFunc
 	OnMouseButtonFunc OnMouseButtonFunc
 	OnMouseScrollFunc OnMouseScrollFunc
+
+	// Noms des fonctions de traitement dans le registre (pour les scènes déclaratives)
+	callbackNames componentCallbackNames
 }
 
 func BuildGenericComponent() GenericComponent {
@@ -64,6 +69,16 @@ func BuildGenericComponent() GenericComponent {
 	}
 }
 
+// Name retourne le nom du composant
+func (comp *GenericComponent) Name() string {
+	return comp.name
+}
+
+// SetName modifie le nom du composant
+func (comp *GenericComponent) SetName(name string) {
+	comp.name = name
+}
+
 // Box retourne la position et dimension du composant
 func (comp *GenericComponent) Box() *graphic.Rectangle {
 	return &comp.box
@@ -107,3 +122,8 @@ func (comp *GenericComponent) OnMouseButton() OnMouseButtonFunc {
 func (comp *GenericComponent) OnMouseScroll() OnMouseScrollFunc {
 	return comp.OnMouseScrollFunc
 }
+
+// generic retourne la partie générique du composant (utilisé par le chargement / la sauvegarde des scènes)
+func (comp *GenericComponent) generic() *GenericComponent {
+	return comp
+}
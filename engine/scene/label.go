@@ -0,0 +1,88 @@
+package scene
+
+import (
+	"fmt"
+	"github.com/go-gl/mathgl/mgl32"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+)
+
+// LabelComponentType est le nom du type de composant texte dans les descriptions de scène
+const LabelComponentType = "label"
+
+// LabelComponent est un composant affichant un texte dans sa zone
+type LabelComponent struct {
+	GenericComponent
+
+	font *graphic.Font
+	// fontName est le nom de la police dans le gestionnaire de polices (pour les scènes déclaratives)
+	fontName string
+	text     string
+	color    graphic.Color
+}
+
+func BuildLabelComponent(font *graphic.Font, text string, color graphic.Color) *LabelComponent {
+	return &LabelComponent{
+		GenericComponent: BuildGenericComponent(),
+		font:             font,
+		text:             text,
+		color:            color,
+	}
+}
+
+func (label *LabelComponent) GetComponentUnder(position mgl32.Vec2) Component {
+	if label.IsComponentUnder(position) {
+		return label
+	}
+	return nil
+}
+
+func (label *LabelComponent) Text() string {
+	return label.text
+}
+
+func (label *LabelComponent) SetText(text string) {
+	label.text = text
+}
+
+func (label *LabelComponent) Color() graphic.Color {
+	return label.color
+}
+
+func (label *LabelComponent) SetColor(color graphic.Color) {
+	label.color = color
+}
+
+// Traitement
+func (label *LabelComponent) Execute(application *engine.Application, scene *Scene2d, timer *engine.Timer) {
+	// Rien ici
+}
+
+// Dessin
+func (label *LabelComponent) Draw(application *engine.Application, scene *Scene2d, drawer *Scene2dDrawer, timer *engine.Timer) {
+	if label.font != nil && label.text != "" {
+		drawer.DrawTextInRect(label.font, label.text, label.box, label.color)
+	}
+}
+
+// Describe retourne la description du composant
+func (label *LabelComponent) Describe() ComponentDescription {
+	color := label.color
+	return ComponentDescription{
+		Type:  LabelComponentType,
+		Font:  label.fontName,
+		Text:  label.text,
+		Color: &color,
+	}
+}
+
+// buildLabelComponentFromDescription construit un texte à partir de sa description
+func buildLabelComponentFromDescription(application *engine.Application, description *ComponentDescription) (Component, error) {
+	font, err := application.FontManager().Get(description.Font)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label font\n - %w", err)
+	}
+	label := BuildLabelComponent(font, description.Text, descriptionColor(description, graphic.White))
+	label.fontName = description.Font
+	return label, nil
+}
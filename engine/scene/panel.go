@@ -0,0 +1,108 @@
+package scene
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"log/slog"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+)
+
+// PanelComponent est un composant dessiné à partir d'un style du thème de la scène (fond et texte optionnel)
+type PanelComponent struct {
+	GenericComponent
+
+	// styleName est le nom du style dans le thème de la scène
+	styleName string
+	// hoverStyleName est le nom du style utilisé quand la souris est au-dessus du composant (optionnel)
+	hoverStyleName string
+	// text est le texte affiché dans le composant (optionnel)
+	text string
+}
+
+// PanelComponentType est le nom du type de composant panneau dans les descriptions de scène
+const PanelComponentType = "panel"
+
+func BuildPanelComponent(styleName string) *PanelComponent {
+	return &PanelComponent{
+		GenericComponent: BuildGenericComponent(),
+		styleName:        styleName,
+	}
+}
+
+func (panel *PanelComponent) GetComponentUnder(position mgl32.Vec2) Component {
+	if panel.IsComponentUnder(position) {
+		return panel
+	}
+	return nil
+}
+
+func (panel *PanelComponent) StyleName() string {
+	return panel.styleName
+}
+
+func (panel *PanelComponent) SetStyleName(styleName string) {
+	panel.styleName = styleName
+}
+
+func (panel *PanelComponent) HoverStyleName() string {
+	return panel.hoverStyleName
+}
+
+func (panel *PanelComponent) SetHoverStyleName(styleName string) {
+	panel.hoverStyleName = styleName
+}
+
+func (panel *PanelComponent) Text() string {
+	return panel.text
+}
+
+func (panel *PanelComponent) SetText(text string) {
+	panel.text = text
+}
+
+// Traitement
+func (panel *PanelComponent) Execute(application *engine.Application, scene *Scene2d, timer *engine.Timer) {
+	// Rien ici
+}
+
+// Dessin
+func (panel *PanelComponent) Draw(application *engine.Application, scene *Scene2d, drawer *Scene2dDrawer, timer *engine.Timer) {
+	style := panel.currentStyle(scene.Theme())
+	if style == nil {
+		return
+	}
+	if err := drawer.DrawStyle(style, panel.box); err != nil {
+		slog.Warn("panel background drawing failed", "style", panel.styleName, "error", err)
+	}
+	if err := drawer.DrawStyleText(style, panel.text, panel.box); err != nil {
+		slog.Warn("panel text drawing failed", "style", panel.styleName, "error", err)
+	}
+}
+
+// currentStyle retourne le style à utiliser selon l'état du composant
+func (panel *PanelComponent) currentStyle(theme *graphic.Theme) *graphic.Style {
+	if panel.mouseOver && panel.hoverStyleName != "" {
+		if style := theme.Style(panel.hoverStyleName); style != nil {
+			return style
+		}
+	}
+	return theme.Style(panel.styleName)
+}
+
+// Describe retourne la description du composant
+func (panel *PanelComponent) Describe() ComponentDescription {
+	return ComponentDescription{
+		Type:       PanelComponentType,
+		Style:      panel.styleName,
+		HoverStyle: panel.hoverStyleName,
+		Text:       panel.text,
+	}
+}
+
+// buildPanelComponentFromDescription construit un panneau à partir de sa description
+func buildPanelComponentFromDescription(_ *engine.Application, description *ComponentDescription) (Component, error) {
+	panel := BuildPanelComponent(description.Style)
+	panel.hoverStyleName = description.HoverStyle
+	panel.text = description.Text
+	return panel, nil
+}
@@ -1,6 +1,7 @@
 package scene
 
 import (
+	"fmt"
 	"github.com/go-gl/mathgl/mgl32"
 	"ogl46/engine"
 	"ogl46/engine/graphic"
@@ -10,8 +11,13 @@ type PictureComponent struct {
 	GenericComponent
 
 	texture *graphic.Texture
+	// textureName est le nom de la texture dans le gestionnaire de textures (pour les scènes déclaratives)
+	textureName string
 }
 
+// PictureComponentType est le nom du type de composant image dans les descriptions de scène
+const PictureComponentType = "picture"
+
 func (picture *PictureComponent) GetComponentUnder(position mgl32.Vec2) Component {
 	if picture.IsComponentUnder(position) {
 		return picture
@@ -40,3 +46,22 @@ func (picture *PictureComponent) Draw(application *engine.Application, scene *Sc
 	target := picture.box
 	drawer.DrawSpriteFromRect(picture.texture, source, target)
 }
+
+// Describe retourne la description du composant
+func (picture *PictureComponent) Describe() ComponentDescription {
+	return ComponentDescription{
+		Type:    PictureComponentType,
+		Texture: picture.textureName,
+	}
+}
+
+// buildPictureComponentFromDescription construit une image à partir de sa description
+func buildPictureComponentFromDescription(application *engine.Application, description *ComponentDescription) (Component, error) {
+	texture, err := application.TextureManager().Get(description.Texture)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get picture texture\n - %w", err)
+	}
+	picture := BuildPictureComponent(texture)
+	picture.textureName = description.Texture
+	return picture, nil
+}
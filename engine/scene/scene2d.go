@@ -1,10 +1,14 @@
 package scene
 
 import (
+	"fmt"
+	"github.com/go-gl/gl/v4.6-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
+	"log/slog"
 	"ogl46/engine"
 	"ogl46/engine/graphic"
 	"ogl46/engine/input"
+	"ogl46/engine/ogl"
 	"slices"
 )
 
@@ -15,12 +19,30 @@ type Scene2d struct {
 	position mgl32.Vec2
 	// ratio et le ratio dimension écran / dimension scène
 	ratio mgl32.Vec2
+	// camera est la caméra de la scène (nil sans caméra) : les coordonnées de la scène sont alors celles du monde
+	camera *graphic.Camera2d
+
+	// scaleMode est le mode de résolution virtuelle (SCALE_NONE = rendu direct à l'écran)
+	scaleMode graphic.ScaleMode
+	// virtualScreen est le framebuffer où la scène est dessinée à sa dimension interne (résolution virtuelle)
+	virtualScreen  *ogl.Framebuffer
+	virtualTexture *graphic.Texture
+	// virtualZone et virtualScale sont la zone de l'écran où la résolution virtuelle est affichée et son facteur d'agrandissement
+	virtualZone  graphic.Rectangle
+	virtualScale float32
 
 	// Liste des composants de la scène
 	components []Component
 
 	componentUnderMouse Component
 
+	// theme est le thème utilisé pour dessiner les composants stylés
+	theme *graphic.Theme
+	// themeName est le nom du thème dans le gestionnaire de thèmes (pour les scènes déclaratives)
+	themeName string
+	// onDrawName est le nom de la fonction de dessin dans le registre (pour les scènes déclaratives)
+	onDrawName string
+
 	// Sur dessin
 	OnDraw OnDrawFunc
 }
@@ -31,19 +53,131 @@ func BuildScene2d(width, height float32) *Scene2d {
 		internalSize: mgl32.Vec2{width, height},
 		position:     mgl32.Vec2{0, 0},
 		ratio:        mgl32.Vec2{1, 1},
+		scaleMode:    graphic.SCALE_NONE,
+		virtualZone:  graphic.Rectangle{0, 0, width, height},
+		virtualScale: 1,
 	}
 }
 
 // DrawerBuilder permet d'obtenir une structure de mise en place d'un "scene2d" pour la scène
 func (scene2d *Scene2d) DrawerBuilder(application *engine.Application) *Scene2dDrawerBuilder {
 	screenWidth, screenHeight := application.Size()
+	viewSize := mgl32.Vec2{float32(screenWidth), float32(screenHeight)}
+	if scene2d.virtualScreen != nil {
+		// En résolution virtuelle, la vue est la dimension interne de la scène
+		viewSize = scene2d.internalSize
+	}
 	return &Scene2dDrawerBuilder{
 		application:      application,
-		viewSize:         mgl32.Vec2{float32(screenWidth), float32(screenHeight)},
+		viewSize:         viewSize,
 		screenTargetZone: graphic.Rectangle{0, 0, -1, -1},
 		scene:            scene2d,
 		keepRatio:        true,
+		camera:           scene2d.camera,
+		framebuffer:      scene2d.virtualScreen,
+	}
+}
+
+// ScaleMode retourne le mode de résolution virtuelle
+func (scene2d *Scene2d) ScaleMode() graphic.ScaleMode {
+	return scene2d.scaleMode
+}
+
+// SetScaleMode change le mode de résolution virtuelle
+//
+//	Avec SCALE_INTEGER ou SCALE_FIT, la scène est dessinée hors écran à sa dimension interne (un pixel de la scène
+//	par pixel), puis agrandie sans lissage et centrée à l'écran (bandes noires). Les conversions écran / scène
+//	(position de la souris par exemple) en tiennent compte.
+func (scene2d *Scene2d) SetScaleMode(mode graphic.ScaleMode) {
+	scene2d.scaleMode = mode
+	if !scene2d.isVirtualResolution() {
+		scene2d.releaseVirtualScreen()
+	}
+}
+
+// Release libère les ressources de la scène (framebuffer de la résolution virtuelle, recréé si la scène est à nouveau dessinée)
+func (scene2d *Scene2d) Release() {
+	scene2d.releaseVirtualScreen()
+}
+
+// isVirtualResolution indique si la scène est dessinée en résolution virtuelle
+func (scene2d *Scene2d) isVirtualResolution() bool {
+	return scene2d.scaleMode == graphic.SCALE_INTEGER || scene2d.scaleMode == graphic.SCALE_FIT
+}
+
+// prepareVirtualScreen crée le framebuffer de la résolution virtuelle
+func (scene2d *Scene2d) prepareVirtualScreen() error {
+	if scene2d.virtualScreen != nil {
+		return nil
+	}
+	framebuffer, err := ogl.NewFramebuffer(int32(scene2d.internalSize.X()), int32(scene2d.internalSize.Y()),
+		ogl.FramebufferConfig{Filter: gl.NEAREST})
+	if err != nil {
+		return fmt.Errorf("failed to create virtual screen\n - %w", err)
+	}
+	texture, err := graphic.NewTextureFromFramebuffer(framebuffer, 0)
+	if err != nil {
+		framebuffer.Delete()
+		return fmt.Errorf("failed to create virtual screen\n - %w", err)
 	}
+	scene2d.virtualScreen, scene2d.virtualTexture = framebuffer, texture
+	return nil
+}
+
+// releaseVirtualScreen libère le framebuffer de la résolution virtuelle
+func (scene2d *Scene2d) releaseVirtualScreen() {
+	if scene2d.virtualScreen != nil {
+		scene2d.virtualScreen.Delete()
+		scene2d.virtualScreen, scene2d.virtualTexture = nil, nil
+	}
+	scene2d.virtualZone = graphic.Rectangle{0, 0, scene2d.internalSize.X(), scene2d.internalSize.Y()}
+	scene2d.virtualScale = 1
+}
+
+// presentVirtualScreen dessine la résolution virtuelle agrandie à l'écran
+func (scene2d *Scene2d) presentVirtualScreen(application *engine.Application) {
+	screenWidth, screenHeight := application.Size()
+	screen := graphic.Rectangle{0, 0, float32(screenWidth), float32(screenHeight)}
+	scene2d.virtualZone, scene2d.virtualScale = graphic.ComputeScaledZone(scene2d.internalSize, screen, scene2d.scaleMode)
+	renderer := application.Renderer2d()
+	renderer.Begin(screen.Width(), screen.Height())
+	renderer.DrawSpriteFromRect(scene2d.virtualTexture, scene2d.virtualTexture.Rectangle(), scene2d.virtualZone)
+	renderer.End()
+}
+
+// Camera retourne la caméra de la scène (nil sans caméra)
+func (scene2d *Scene2d) Camera() *graphic.Camera2d {
+	return scene2d.camera
+}
+
+// SetCamera change la caméra de la scène (nil pour ne plus utiliser de caméra)
+//
+//	La caméra est utilisée pour dessiner la scène et pour convertir la position de la souris.
+//	Sa zone d'affichage est par défaut la zone de rendu de la scène (tout l'écran).
+func (scene2d *Scene2d) SetCamera(camera *graphic.Camera2d) {
+	scene2d.camera = camera
+}
+
+// Theme retourne le thème de la scène (nil si aucun thème)
+func (scene2d *Scene2d) Theme() *graphic.Theme {
+	return scene2d.theme
+}
+
+// SetTheme change le thème de la scène (peut être modifié en cours d'exécution)
+func (scene2d *Scene2d) SetTheme(theme *graphic.Theme) {
+	scene2d.theme = theme
+	scene2d.themeName = ""
+}
+
+// UseTheme change le thème de la scène par le thème enregistré sous ce nom dans le gestionnaire de thèmes
+func (scene2d *Scene2d) UseTheme(application *engine.Application, name string) error {
+	theme, err := application.ThemeManager().Get(name)
+	if err != nil {
+		return fmt.Errorf("failed to use '%s' theme\n - %w", name, err)
+	}
+	scene2d.theme = theme
+	scene2d.themeName = name
+	return nil
 }
 
 // Traitement
@@ -55,7 +189,24 @@ func (scene2d *Scene2d) Execute(application *engine.Application, timer *engine.T
 
 // Dessin
 func (scene2d *Scene2d) Draw(application *engine.Application, timer *engine.Timer) {
+	if scene2d.isVirtualResolution() {
+		if err := scene2d.prepareVirtualScreen(); err != nil {
+			slog.Error("virtual resolution is disabled", "error", err)
+			scene2d.SetScaleMode(graphic.SCALE_NONE)
+		}
+	}
 	drawer := scene2d.DrawerBuilder(application).Build()
+	if drawer.framebuffer != nil {
+		drawer.framebuffer.Clear(0, 0, 0, 0)
+	}
+	scene2d.drawComponents(application, drawer, timer)
+	if drawer.framebuffer != nil {
+		scene2d.presentVirtualScreen(application)
+	}
+}
+
+// drawComponents dessine les composants de la scène
+func (scene2d *Scene2d) drawComponents(application *engine.Application, drawer *Scene2dDrawer, timer *engine.Timer) {
 	drawer.Begin()
 	defer drawer.End()
 	for _, component := range scene2d.components {
@@ -120,10 +271,25 @@ func (scene2d *Scene2d) GetComponentUnder(position mgl32.Vec2) Component {
 	return nil
 }
 
+// ComponentByName retourne le premier composant portant ce nom (retourne null si rien)
+func (scene2d *Scene2d) ComponentByName(name string) Component {
+	for _, component := range scene2d.components {
+		if named, ok := component.(interface{ Name() string }); ok && named.Name() == name {
+			return component
+		}
+	}
+	return nil
+}
+
+// Components retourne les composants de la scène (dans l'ordre de dessin)
+func (scene2d *Scene2d) Components() []Component {
+	return scene2d.components
+}
+
 // RemoveComponent supprime un composant
 func (scene2d *Scene2d) RemoveComponent(component Component) {
 	// Enlever le composant s'il est déjà présent
-	slices.DeleteFunc(scene2d.components, func(comp Component) bool { return comp == component })
+	scene2d.components = slices.DeleteFunc(scene2d.components, func(comp Component) bool { return comp == component })
 }
 
 // AddComponent Ajoute un composant
@@ -133,46 +299,110 @@ func (scene2d *Scene2d) AddComponent(component Component) {
 	scene2d.components = append(scene2d.components, component)
 }
 
-// RectSceneToScreen convertit un rectangle de la scène en rectangle à l'écran
+// RectSceneToScreen convertit un rectangle de la scène en rectangle à l'écran (boîte englobante si la caméra est tournée)
 func (scene2d *Scene2d) RectSceneToScreen(rectangleScene graphic.Rectangle) graphic.Rectangle {
-	return graphic.Rectangle{
-		rectangleScene.X()*scene2d.ratio.X() + scene2d.position.X(), rectangleScene.Y()*scene2d.ratio.Y() + scene2d.position.Y(),
-		rectangleScene.Width() * scene2d.ratio.X(), rectangleScene.Height() * scene2d.ratio.Y(),
+	var rectangle graphic.Rectangle
+	if scene2d.camera != nil {
+		polygon := rectangleScene.Polygon()
+		for index, point := range polygon {
+			polygon[index] = scene2d.camera.WorldToScreen(point)
+		}
+		rectangle = polygon.Bounds()
+	} else {
+		rectangle = scene2d.rectSceneToRender(rectangleScene)
 	}
+	return graphic.BuildRectFromPosAndDim(scene2d.posVirtualToScreen(rectangle.Pos()), rectangle.Dim().Mul(scene2d.virtualScale))
 }
 
 // PosSceneToScreen convertit une position de la scène en position à l'écran
 func (scene2d *Scene2d) PosSceneToScreen(posScene mgl32.Vec2) mgl32.Vec2 {
-	return mgl32.Vec2{
-		posScene.X()*scene2d.ratio.X() + scene2d.position.X(), posScene.Y()*scene2d.ratio.Y() + scene2d.position.Y(),
+	if scene2d.camera != nil {
+		return scene2d.posVirtualToScreen(scene2d.camera.WorldToScreen(posScene))
 	}
+	return scene2d.posVirtualToScreen(scene2d.posSceneToRender(posScene))
 }
 
 // DimSceneToScreen convertit une dimension de la scène en dimension à l'écran
 func (scene2d *Scene2d) DimSceneToScreen(dimScene mgl32.Vec2) mgl32.Vec2 {
-	return mgl32.Vec2{
-		dimScene.X() * scene2d.ratio.X(), dimScene.Y() * scene2d.ratio.Y(),
+	if scene2d.camera != nil {
+		return dimScene.Mul(scene2d.camera.EffectiveZoom() * scene2d.virtualScale)
 	}
+	return scene2d.dimSceneToRender(dimScene).Mul(scene2d.virtualScale)
 }
 
-// RectScreenToScene convertit un rectangle à l'écran en rectangle de la scène
-func (scene2d *Scene2d) RectScreenToScene(rectangleScene graphic.Rectangle) graphic.Rectangle {
-	return graphic.Rectangle{
-		(rectangleScene.X() - scene2d.position.X()) / scene2d.ratio.X(), (rectangleScene.Y() - scene2d.position.Y()) / scene2d.ratio.Y(),
-		rectangleScene.Width() / scene2d.ratio.X(), rectangleScene.Height() / scene2d.ratio.Y(),
+// RectScreenToScene convertit un rectangle à l'écran en rectangle de la scène (boîte englobante si la caméra est tournée)
+func (scene2d *Scene2d) RectScreenToScene(rectangleScreen graphic.Rectangle) graphic.Rectangle {
+	rectangle := graphic.BuildRectFromPosAndDim(scene2d.posScreenToVirtual(rectangleScreen.Pos()), rectangleScreen.Dim().Mul(1/scene2d.virtualScale))
+	if scene2d.camera != nil {
+		polygon := rectangle.Polygon()
+		for index, point := range polygon {
+			polygon[index] = scene2d.camera.ScreenToWorld(point)
+		}
+		return polygon.Bounds()
 	}
+	return scene2d.rectRenderToScene(rectangle)
 }
 
 // PosScreenToScene convertit une position à l'écran en position de la scène
-func (scene2d *Scene2d) PosScreenToScene(posScene mgl32.Vec2) mgl32.Vec2 {
+func (scene2d *Scene2d) PosScreenToScene(posScreen mgl32.Vec2) mgl32.Vec2 {
+	position := scene2d.posScreenToVirtual(posScreen)
+	if scene2d.camera != nil {
+		return scene2d.camera.ScreenToWorld(position)
+	}
 	return mgl32.Vec2{
-		(posScene.X() - scene2d.position.X()) / scene2d.ratio.X(), (posScene.Y() - scene2d.position.Y()) / scene2d.ratio.Y(),
+		(position.X() - scene2d.position.X()) / scene2d.ratio.X(), (position.Y() - scene2d.position.Y()) / scene2d.ratio.Y(),
 	}
 }
 
 // DimScreenToScene convertit une dimension à l'écran en dimension de la scène
-func (scene2d *Scene2d) DimScreenToScene(dimScene mgl32.Vec2) mgl32.Vec2 {
+func (scene2d *Scene2d) DimScreenToScene(dimScreen mgl32.Vec2) mgl32.Vec2 {
+	dimension := dimScreen.Mul(1 / scene2d.virtualScale)
+	if scene2d.camera != nil {
+		return dimension.Mul(1 / scene2d.camera.EffectiveZoom())
+	}
 	return mgl32.Vec2{
-		dimScene.Y() / scene2d.ratio.X(), dimScene.Y() / scene2d.ratio.Y(),
+		dimension.X() / scene2d.ratio.X(), dimension.Y() / scene2d.ratio.Y(),
+	}
+}
+
+// posScreenToVirtual convertit une position à l'écran en position dans la résolution virtuelle
+// (identique sans résolution virtuelle)
+func (scene2d *Scene2d) posScreenToVirtual(posScreen mgl32.Vec2) mgl32.Vec2 {
+	return posScreen.Sub(scene2d.virtualZone.Pos()).Mul(1 / scene2d.virtualScale)
+}
+
+// posVirtualToScreen convertit une position dans la résolution virtuelle en position à l'écran
+func (scene2d *Scene2d) posVirtualToScreen(posVirtual mgl32.Vec2) mgl32.Vec2 {
+	return posVirtual.Mul(scene2d.virtualScale).Add(scene2d.virtualZone.Pos())
+}
+
+// rectRenderToScene convertit un rectangle de rendu en rectangle de la scène (sans caméra)
+func (scene2d *Scene2d) rectRenderToScene(rectangleRender graphic.Rectangle) graphic.Rectangle {
+	return graphic.Rectangle{
+		(rectangleRender.X() - scene2d.position.X()) / scene2d.ratio.X(), (rectangleRender.Y() - scene2d.position.Y()) / scene2d.ratio.Y(),
+		rectangleRender.Width() / scene2d.ratio.X(), rectangleRender.Height() / scene2d.ratio.Y(),
+	}
+}
+
+// rectSceneToRender convertit un rectangle de la scène en rectangle de rendu
+// (coordonnées écran, ou coordonnées du monde avec une caméra)
+func (scene2d *Scene2d) rectSceneToRender(rectangleScene graphic.Rectangle) graphic.Rectangle {
+	return graphic.Rectangle{
+		rectangleScene.X()*scene2d.ratio.X() + scene2d.position.X(), rectangleScene.Y()*scene2d.ratio.Y() + scene2d.position.Y(),
+		rectangleScene.Width() * scene2d.ratio.X(), rectangleScene.Height() * scene2d.ratio.Y(),
+	}
+}
+
+// posSceneToRender convertit une position de la scène en position de rendu
+func (scene2d *Scene2d) posSceneToRender(posScene mgl32.Vec2) mgl32.Vec2 {
+	return mgl32.Vec2{
+		posScene.X()*scene2d.ratio.X() + scene2d.position.X(), posScene.Y()*scene2d.ratio.Y() + scene2d.position.Y(),
+	}
+}
+
+// dimSceneToRender convertit une dimension de la scène en dimension de rendu
+func (scene2d *Scene2d) dimSceneToRender(dimScene mgl32.Vec2) mgl32.Vec2 {
+	return mgl32.Vec2{
+		dimScene.X() * scene2d.ratio.X(), dimScene.Y() * scene2d.ratio.Y(),
 	}
 }
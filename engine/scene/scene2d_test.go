@@ -0,0 +1,30 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScene2d_DimScreenToScene(t *testing.T) {
+	tests := []struct {
+		ratio     mgl32.Vec2
+		dimScreen mgl32.Vec2
+		want      mgl32.Vec2
+	}{
+		{mgl32.Vec2{1, 1}, mgl32.Vec2{40, 10}, mgl32.Vec2{40, 10}},
+		{mgl32.Vec2{2, 4}, mgl32.Vec2{40, 10}, mgl32.Vec2{20, 2.5}},
+		{mgl32.Vec2{0.5, 2}, mgl32.Vec2{10, 40}, mgl32.Vec2{20, 20}},
+	}
+	for _, test := range tests {
+		scene2d := BuildScene2d(320, 200)
+		scene2d.ratio = test.ratio
+		if got := scene2d.DimScreenToScene(test.dimScreen); got != test.want {
+			t.Fatalf("DimScreenToScene(%v) with ratio %v = %v, want %v", test.dimScreen, test.ratio, got, test.want)
+		}
+		// La conversion inverse doit retrouver la dimension à l'écran
+		if got := scene2d.DimSceneToScreen(test.want); got != test.dimScreen {
+			t.Fatalf("DimSceneToScreen(%v) with ratio %v = %v, want %v", test.want, test.ratio, got, test.dimScreen)
+		}
+	}
+}
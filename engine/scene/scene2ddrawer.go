@@ -1,9 +1,11 @@
 package scene
 
 import (
+	"fmt"
 	"github.com/go-gl/mathgl/mgl32"
 	"ogl46/engine"
 	"ogl46/engine/graphic"
+	"ogl46/engine/ogl"
 )
 
 // Scene2dDrawer est un outil de dessin de la scène sur l'écran
@@ -14,11 +16,38 @@ type Scene2dDrawer struct {
 	scene *Scene2d
 	// 	viewSize est la dimension de la vue (pour initialiser le rendu 2d côté OpenGL) - par défaut, la taille de l'écran
 	viewSize mgl32.Vec2
+	// camera est la caméra utilisée pour le rendu (nil sans caméra)
+	camera *graphic.Camera2d
+	// framebuffer est la cible du rendu (nil pour l'écran)
+	framebuffer *ogl.Framebuffer
 }
 
 // Begin initialise le rendu de la vue 2d
 func (drawer *Scene2dDrawer) Begin() {
-	drawer.application.Renderer2d().Begin(drawer.viewSize.X(), drawer.viewSize.Y())
+	if drawer.framebuffer != nil {
+		if drawer.camera != nil {
+			drawer.application.Renderer2d().BeginOnFramebufferWithCamera(drawer.framebuffer, drawer.camera)
+		} else {
+			drawer.application.Renderer2d().BeginOnFramebuffer(drawer.framebuffer)
+		}
+	} else if drawer.camera != nil {
+		drawer.application.Renderer2d().BeginWithCamera(drawer.camera, drawer.viewSize.X(), drawer.viewSize.Y())
+	} else {
+		drawer.application.Renderer2d().Begin(drawer.viewSize.X(), drawer.viewSize.Y())
+	}
+}
+
+// Camera retourne la caméra utilisée pour le rendu (nil sans caméra)
+func (drawer *Scene2dDrawer) Camera() *graphic.Camera2d {
+	return drawer.camera
+}
+
+// VisibleRect retourne la zone visible de la scène (pour ne dessiner que ce qui est visible)
+func (drawer *Scene2dDrawer) VisibleRect() graphic.Rectangle {
+	if drawer.camera != nil {
+		return drawer.camera.VisibleRect()
+	}
+	return drawer.scene.rectRenderToScene(graphic.Rectangle{0, 0, drawer.viewSize.X(), drawer.viewSize.Y()})
 }
 
 // End finalise le rendu de la vue 2d
@@ -32,8 +61,8 @@ func (drawer *Scene2dDrawer) DrawSprite(texture *graphic.Texture, targetPosition
 	sourcePosition := mgl32.Vec2{0., 0.}
 	sourceDimension := mgl32.Vec2{float32(texture.Width()), float32(texture.Height())}
 	// Screen / target zone
-	screenTargetPosition := drawer.scene.PosSceneToScreen(targetPosition)
-	screenTargetDimension := drawer.scene.DimSceneToScreen(sourceDimension)
+	screenTargetPosition := drawer.scene.posSceneToRender(targetPosition)
+	screenTargetDimension := drawer.scene.dimSceneToRender(sourceDimension)
 
 	drawer.application.Renderer2d().DrawSpriteExWithRotateAndColor(
 		texture, sourcePosition, sourceDimension, screenTargetPosition, screenTargetDimension,
@@ -42,13 +71,13 @@ func (drawer *Scene2dDrawer) DrawSprite(texture *graphic.Texture, targetPosition
 
 // DrawSpriteFromRect dessine une image à l'écran
 func (drawer *Scene2dDrawer) DrawSpriteFromRect(texture *graphic.Texture, sourceRectangle graphic.Rectangle, targetRectangle graphic.Rectangle) {
-	screenTarget := drawer.scene.RectSceneToScreen(targetRectangle)
+	screenTarget := drawer.scene.rectSceneToRender(targetRectangle)
 	drawer.application.Renderer2d().DrawSpriteFromRect(texture, sourceRectangle, screenTarget)
 }
 
 // DrawSpriteFromRectWithColor teinte et dessine une image à l'écran
 func (drawer *Scene2dDrawer) DrawSpriteFromRectWithColor(texture *graphic.Texture, sourceRectangle graphic.Rectangle, targetRectangle graphic.Rectangle, color graphic.Color) {
-	screenTarget := drawer.scene.RectSceneToScreen(targetRectangle)
+	screenTarget := drawer.scene.rectSceneToRender(targetRectangle)
 	drawer.application.Renderer2d().DrawSpriteFromRectWithColor(texture, sourceRectangle, screenTarget, color)
 }
 
@@ -62,22 +91,81 @@ func (drawer *Scene2dDrawer) DrawSpriteFromRectWithColor(texture *graphic.Textur
 func (drawer *Scene2dDrawer) DrawSpriteExWithRotateAndColor(texture *graphic.Texture, sourcePosition mgl32.Vec2, sourceDimension mgl32.Vec2,
 	targetPosition mgl32.Vec2, targetDimension mgl32.Vec2, rotationCenter mgl32.Vec2, rotation graphic.Angle, color graphic.Color) {
 	drawer.application.Renderer2d().DrawSpriteExWithRotateAndColor(texture, sourcePosition, sourceDimension,
-		drawer.scene.PosSceneToScreen(targetPosition), drawer.scene.DimSceneToScreen(targetDimension),
-		drawer.scene.DimSceneToScreen(rotationCenter), rotation, color)
+		drawer.scene.posSceneToRender(targetPosition), drawer.scene.dimSceneToRender(targetDimension),
+		drawer.scene.dimSceneToRender(rotationCenter), rotation, color)
+}
+
+// DrawNineSlice dessine une partie de texture dans la zone indiquée sans étirer les coins
+//
+//	insets définissent les bordures sur la texture (et leur taille dans la scène).
+func (drawer *Scene2dDrawer) DrawNineSlice(texture *graphic.Texture, sourceRectangle graphic.Rectangle, insets graphic.Insets, targetRectangle graphic.Rectangle, color graphic.Color) {
+	drawer.DrawSliced(texture, graphic.NINE_SLICE, sourceRectangle, insets, targetRectangle, color)
+}
+
+// DrawThreeSliceHorizontal dessine une partie de texture dans la zone indiquée sans étirer les parties gauche et droite
+func (drawer *Scene2dDrawer) DrawThreeSliceHorizontal(texture *graphic.Texture, sourceRectangle graphic.Rectangle, left, right float32, targetRectangle graphic.Rectangle, color graphic.Color) {
+	drawer.DrawSliced(texture, graphic.THREE_SLICE_HORIZONTAL, sourceRectangle, graphic.BuildInsets(left, 0, right, 0), targetRectangle, color)
+}
+
+// DrawThreeSliceVertical dessine une partie de texture dans la zone indiquée sans étirer les parties haute et basse
+func (drawer *Scene2dDrawer) DrawThreeSliceVertical(texture *graphic.Texture, sourceRectangle graphic.Rectangle, top, bottom float32, targetRectangle graphic.Rectangle, color graphic.Color) {
+	drawer.DrawSliced(texture, graphic.THREE_SLICE_VERTICAL, sourceRectangle, graphic.BuildInsets(0, top, 0, bottom), targetRectangle, color)
+}
+
+// DrawSliced dessine une partie de texture dans la zone indiquée selon le mode de découpage
+func (drawer *Scene2dDrawer) DrawSliced(texture *graphic.Texture, mode graphic.SliceMode, sourceRectangle graphic.Rectangle, insets graphic.Insets, targetRectangle graphic.Rectangle, color graphic.Color) {
+	screenTarget := drawer.scene.rectSceneToRender(targetRectangle)
+	screenInsets := insets.Scale(drawer.scene.ratio)
+	drawer.application.Renderer2d().DrawSliced(texture, mode, sourceRectangle, insets, screenTarget, screenInsets, color)
+}
+
+// DrawStyle dessine le fond d'un widget selon le style indiqué (textures récupérées auprès du gestionnaire de textures)
+func (drawer *Scene2dDrawer) DrawStyle(style *graphic.Style, targetRectangle graphic.Rectangle) error {
+	if style == nil || !style.HasTexture() {
+		return nil
+	}
+	texture, err := drawer.application.TextureManager().Get(style.Texture)
+	if err != nil {
+		return fmt.Errorf("failed to draw style\n - %w", err)
+	}
+	source := style.Region
+	if !style.HasRegion() {
+		source = texture.Rectangle()
+	}
+	drawer.DrawSliced(texture, style.Slice, source, style.Borders, targetRectangle, style.Color)
+	return nil
+}
+
+// DrawStyleText dessine un texte dans la zone indiquée (moins le padding) avec la police et la couleur du style
+func (drawer *Scene2dDrawer) DrawStyleText(style *graphic.Style, text string, targetRectangle graphic.Rectangle) error {
+	if style == nil || style.Font == "" || text == "" {
+		return nil
+	}
+	font, err := drawer.application.FontManager().Get(style.Font)
+	if err != nil {
+		return fmt.Errorf("failed to draw style text\n - %w", err)
+	}
+	drawer.DrawTextInRect(font, text, style.Padding.Apply(targetRectangle), style.TextColor)
+	return nil
 }
 
 // DrawText dessine un texte à la position indiquée
 func (drawer *Scene2dDrawer) DrawText(font *graphic.Font, text string, position mgl32.Vec2, color graphic.Color) {
-	screenPosition := drawer.scene.PosSceneToScreen(position)
+	screenPosition := drawer.scene.posSceneToRender(position)
 	drawer.application.Renderer2d().DrawTextEx(font, text, screenPosition, drawer.scene.ratio, color)
 }
 
 // DrawTextInRect dessine un texte dans le rectangle indiqué en essayant d'utiliser toute la place
 func (drawer *Scene2dDrawer) DrawTextInRect(font *graphic.Font, text string, targetRectangle graphic.Rectangle, color graphic.Color) {
-	screenTarget := drawer.scene.RectSceneToScreen(targetRectangle)
+	screenTarget := drawer.scene.rectSceneToRender(targetRectangle)
 	drawer.application.Renderer2d().DrawTextInRect(font, text, screenTarget, color)
 }
 
+// Theme retourne le thème de la scène
+func (drawer *Scene2dDrawer) Theme() *graphic.Theme {
+	return drawer.scene.Theme()
+}
+
 // Taille de la scène
 func (drawer *Scene2dDrawer) SceneDimension() mgl32.Vec2 {
 	return drawer.scene.Dimension()
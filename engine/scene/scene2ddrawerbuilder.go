@@ -4,6 +4,7 @@ import (
 	"github.com/go-gl/mathgl/mgl32"
 	"ogl46/engine"
 	"ogl46/engine/graphic"
+	"ogl46/engine/ogl"
 )
 
 // Scene2dDrawerBuilder est une classe de construction de l'outil de rendu de la scène à l'écran
@@ -18,6 +19,16 @@ type Scene2dDrawerBuilder struct {
 	screenTargetZone graphic.Rectangle
 	// keepRatio indique s'il faut conserver le ratio Largeur / hauteur - vrai par défaut
 	keepRatio bool
+	// camera est la caméra utilisée pour le rendu - par défaut, la caméra de la scène
+	camera *graphic.Camera2d
+	// framebuffer est la cible du rendu (nil pour l'écran) - par défaut, celle de la résolution virtuelle de la scène
+	framebuffer *ogl.Framebuffer
+}
+
+// Camera indique la caméra à utiliser pour le rendu (une caméra par vue pour un écran partagé)
+func (builder *Scene2dDrawerBuilder) Camera(camera *graphic.Camera2d) *Scene2dDrawerBuilder {
+	builder.camera = camera
+	return builder
 }
 
 func (builder *Scene2dDrawerBuilder) KeepRatio(keep bool) *Scene2dDrawerBuilder {
@@ -42,6 +53,21 @@ func (builder *Scene2dDrawerBuilder) Build() *Scene2dDrawer {
 	if builder.screenTargetZone.Height() == -1 {
 		builder.screenTargetZone.SetHeight(builder.viewSize.Y())
 	}
+	if builder.camera != nil {
+		// Avec une caméra, les coordonnées de la scène sont celles du monde (la caméra fait la transformation)
+		if builder.camera.Viewport.IsEmpty() {
+			builder.camera.Viewport = builder.screenTargetZone
+		}
+		builder.scene.position = mgl32.Vec2{0, 0}
+		builder.scene.ratio = mgl32.Vec2{1, 1}
+		return &Scene2dDrawer{
+			application: builder.application,
+			scene:       builder.scene,
+			viewSize:    builder.viewSize,
+			camera:      builder.camera,
+			framebuffer: builder.framebuffer,
+		}
+	}
 	ratioX := builder.screenTargetZone.Width() / builder.scene.internalSize.X()
 	ratioY := builder.screenTargetZone.Height() / builder.scene.internalSize.Y()
 	if builder.keepRatio {
@@ -59,5 +85,6 @@ func (builder *Scene2dDrawerBuilder) Build() *Scene2dDrawer {
 		application: builder.application,
 		scene:       builder.scene,
 		viewSize:    builder.viewSize,
+		framebuffer: builder.framebuffer,
 	}
 }
@@ -0,0 +1,210 @@
+package scene
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+	"os"
+)
+
+// SceneDescription est la description déclarative d'une scène 2D (format JSON)
+type SceneDescription struct {
+	// Width et Height sont les dimensions internes de la scène
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+	// Theme est le nom du thème dans le gestionnaire de thèmes (optionnel)
+	Theme string `json:"theme,omitempty"`
+	// OnDraw est le nom de la fonction de dessin dans le registre (optionnel)
+	OnDraw string `json:"onDraw,omitempty"`
+	// ScaleMode est le mode de résolution virtuelle (optionnel, "none" par défaut)
+	ScaleMode graphic.ScaleMode `json:"scaleMode,omitempty"`
+	// Components est la liste des composants (dans l'ordre de dessin)
+	Components []ComponentDescription `json:"components"`
+}
+
+// ComponentDescription est la description déclarative d'un composant
+type ComponentDescription struct {
+	// Type est le nom du type de composant dans le registre
+	Type string `json:"type"`
+	// Name est le nom du composant (optionnel)
+	Name string `json:"name,omitempty"`
+	// Box est la position et la dimension du composant (optionnel, dépend du type de composant sinon)
+	Box *graphic.Rectangle `json:"box,omitempty"`
+	// Visible indique si le composant est visible (vrai par défaut)
+	Visible *bool `json:"visible,omitempty"`
+
+	// Texture est le nom de la texture dans le gestionnaire de textures
+	Texture string `json:"texture,omitempty"`
+	// Font est le nom de la police de caractères dans le gestionnaire de polices
+	Font string `json:"font,omitempty"`
+	// Style et HoverStyle sont les noms des styles dans le thème de la scène
+	Style      string `json:"style,omitempty"`
+	HoverStyle string `json:"hoverStyle,omitempty"`
+	// Text est le texte du composant
+	Text string `json:"text,omitempty"`
+	// Color est la couleur du composant
+	Color *graphic.Color `json:"color,omitempty"`
+	// Properties contient les propriétés spécifiques aux composants définis par le jeu
+	Properties map[string]string `json:"properties,omitempty"`
+
+	// Noms des fonctions de traitement dans le registre
+	OnMouseMove   string `json:"onMouseMove,omitempty"`
+	OnMouseButton string `json:"onMouseButton,omitempty"`
+	OnMouseScroll string `json:"onMouseScroll,omitempty"`
+}
+
+// LoadScene2dFromFile construit une scène à partir d'un fichier de description JSON
+func LoadScene2dFromFile(application *engine.Application, registry *SceneRegistry, filename string) (*Scene2d, error) {
+	slog.Debug("scene creation from file", "filename", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load scene file '%s'\n - %w", filename, err)
+	}
+	scene, err := LoadScene2dFromBytes(application, registry, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build scene from file '%s'\n - %w", filename, err)
+	}
+	return scene, nil
+}
+
+// LoadScene2dFromBytes construit une scène à partir d'une description JSON
+func LoadScene2dFromBytes(application *engine.Application, registry *SceneRegistry, content []byte) (*Scene2d, error) {
+	description := SceneDescription{}
+	if err := json.Unmarshal(content, &description); err != nil {
+		return nil, fmt.Errorf("failed to decode scene description\n - %w", err)
+	}
+	return BuildScene2dFromDescription(application, registry, &description)
+}
+
+// BuildScene2dFromDescription construit une scène à partir de sa description
+func BuildScene2dFromDescription(application *engine.Application, registry *SceneRegistry, description *SceneDescription) (*Scene2d, error) {
+	if description.Width <= 0 || description.Height <= 0 {
+		return nil, fmt.Errorf("invalid scene size %.0fx%.0f", description.Width, description.Height)
+	}
+	if description.ScaleMode != "" && !description.ScaleMode.IsValid() {
+		return nil, fmt.Errorf("invalid '%s' scene scale mode", description.ScaleMode)
+	}
+	scene2d := BuildScene2d(description.Width, description.Height)
+	if description.ScaleMode != "" {
+		scene2d.SetScaleMode(description.ScaleMode)
+	}
+	if description.Theme != "" {
+		theme, err := application.ThemeManager().Get(description.Theme)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scene theme\n - %w", err)
+		}
+		scene2d.theme = theme
+		scene2d.themeName = description.Theme
+	}
+	if description.OnDraw != "" {
+		onDraw, found := registry.onDraw[description.OnDraw]
+		if !found {
+			return nil, fmt.Errorf("unknown '%s' draw callback", description.OnDraw)
+		}
+		scene2d.OnDraw = onDraw
+		scene2d.onDrawName = description.OnDraw
+	}
+	for idx := range description.Components {
+		component, err := registry.buildComponent(application, &description.Components[idx])
+		if err != nil {
+			return nil, fmt.Errorf("failed to build component #%d\n - %w", idx, err)
+		}
+		scene2d.AddComponent(component)
+	}
+	return scene2d, nil
+}
+
+// Describe retourne la description de la scène (les composants qui ne sont pas "DescribableComponent" sont ignorés)
+func (scene2d *Scene2d) Describe() SceneDescription {
+	description := SceneDescription{
+		Width:      scene2d.internalSize.X(),
+		Height:     scene2d.internalSize.Y(),
+		Theme:      scene2d.themeName,
+		OnDraw:     scene2d.onDrawName,
+		Components: make([]ComponentDescription, 0, len(scene2d.components)),
+	}
+	if scene2d.scaleMode != graphic.SCALE_NONE {
+		description.ScaleMode = scene2d.scaleMode
+	}
+	for _, component := range scene2d.components {
+		describable, ok := component.(DescribableComponent)
+		if !ok {
+			slog.Warn("component cannot be saved in scene description", "component", fmt.Sprintf("%T", component))
+			continue
+		}
+		componentDescription := describable.Describe()
+		if asset := unnamedAsset(&componentDescription); asset != "" {
+			slog.Warn("component uses an unnamed asset and cannot be saved in scene description", "component", fmt.Sprintf("%T", component), "asset", asset)
+			continue
+		}
+		box := *component.Box()
+		componentDescription.Box = &box
+		if !component.Visible() {
+			visible := false
+			componentDescription.Visible = &visible
+		}
+		if generic, ok := component.(interface{ generic() *GenericComponent }); ok {
+			componentDescription.Name = generic.generic().name
+			componentDescription.OnMouseMove = generic.generic().callbackNames.onMouseMove
+			componentDescription.OnMouseButton = generic.generic().callbackNames.onMouseButton
+			componentDescription.OnMouseScroll = generic.generic().callbackNames.onMouseScroll
+		}
+		description.Components = append(description.Components, componentDescription)
+	}
+	return description
+}
+
+// SaveToBytes retourne la description JSON de la scène
+func (scene2d *Scene2d) SaveToBytes() ([]byte, error) {
+	content, err := json.MarshalIndent(scene2d.Describe(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode scene description\n - %w", err)
+	}
+	return content, nil
+}
+
+// SaveToFile sauvegarde la description JSON de la scène dans un fichier
+func (scene2d *Scene2d) SaveToFile(filename string) error {
+	content, err := scene2d.SaveToBytes()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, content, 0644); err != nil {
+		return fmt.Errorf("failed to save scene file '%s'\n - %w", filename, err)
+	}
+	return nil
+}
+
+// unnamedAsset retourne le type de ressource sans nom d'un composant du moteur ("" si toutes ses ressources sont nommées)
+//
+//	Un composant construit avec une ressource qui n'est pas dans un gestionnaire ne peut pas être rechargé.
+func unnamedAsset(description *ComponentDescription) string {
+	switch description.Type {
+	case PictureComponentType:
+		if description.Texture == "" {
+			return "texture"
+		}
+	case LabelComponentType:
+		if description.Font == "" {
+			return "font"
+		}
+	case AnimatedSpriteComponentType:
+		if description.Texture == "" {
+			return "texture"
+		}
+		if description.Properties[animatedSpriteSheetProperty] == "" {
+			return animatedSpriteSheetProperty
+		}
+	}
+	return ""
+}
+
+// descriptionColor retourne la couleur de la description (ou la couleur par défaut)
+func descriptionColor(description *ComponentDescription, defaultColor graphic.Color) graphic.Color {
+	if description.Color == nil {
+		return defaultColor
+	}
+	return *description.Color
+}
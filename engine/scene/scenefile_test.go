@@ -0,0 +1,67 @@
+package scene
+
+import (
+	"ogl46/engine/graphic"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScene2d_DescribeLoadRoundTrip(t *testing.T) {
+	scene2d := BuildScene2d(320, 200)
+	scene2d.SetScaleMode(graphic.SCALE_INTEGER)
+	panel := BuildPanelComponent("button")
+	panel.SetName("play")
+	panel.SetHoverStyleName("button-hover")
+	panel.SetText("Jouer")
+	panel.SetBox(graphic.BuildRectangle(10, 20, 100, 30))
+	scene2d.AddComponent(panel)
+	hidden := BuildPanelComponent("frame")
+	hidden.SetBox(graphic.BuildRectangle(0, 0, 320, 200))
+	hidden.SetVisible(false)
+	scene2d.AddComponent(hidden)
+	// Composants construits avec des ressources sans nom : ne peuvent pas être rechargés
+	// (une texture vide suffit : elle n'est jamais envoyée à OpenGL)
+	scene2d.AddComponent(BuildPictureComponent(&graphic.Texture{}))
+	scene2d.AddComponent(BuildLabelComponent(nil, "score", graphic.White))
+
+	content, err := scene2d.SaveToBytes()
+	if err != nil {
+		t.Fatalf("SaveToBytes() returns error %v", err)
+	}
+	for _, field := range []string{`"texture"`, `"font"`} {
+		if strings.Contains(string(content), field) {
+			t.Fatalf("SaveToBytes() = %s, want no %s field", content, field)
+		}
+	}
+	loaded, err := LoadScene2dFromBytes(nil, NewSceneRegistry(), content)
+	if err != nil {
+		t.Fatalf("LoadScene2dFromBytes() returns error %v", err)
+	}
+	if got, want := loaded.Describe(), scene2d.Describe(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("LoadScene2dFromBytes().Describe() = %+v, want %+v", got, want)
+	}
+	if got := len(loaded.Describe().Components); got != 2 {
+		t.Fatalf("LoadScene2dFromBytes() loads %d components, want 2", got)
+	}
+}
+
+func TestUnnamedAsset(t *testing.T) {
+	tests := []struct {
+		description ComponentDescription
+		want        string
+	}{
+		{ComponentDescription{Type: PictureComponentType}, "texture"},
+		{ComponentDescription{Type: PictureComponentType, Texture: "logo"}, ""},
+		{ComponentDescription{Type: LabelComponentType}, "font"},
+		{ComponentDescription{Type: LabelComponentType, Font: "default"}, ""},
+		{ComponentDescription{Type: AnimatedSpriteComponentType, Texture: "hero"}, animatedSpriteSheetProperty},
+		{ComponentDescription{Type: AnimatedSpriteComponentType, Texture: "hero", Properties: map[string]string{animatedSpriteSheetProperty: "hero"}}, ""},
+		{ComponentDescription{Type: PanelComponentType}, ""},
+	}
+	for _, test := range tests {
+		if got := unnamedAsset(&test.description); got != test.want {
+			t.Fatalf("unnamedAsset(%+v) = %q, want %q", test.description, got, test.want)
+		}
+	}
+}
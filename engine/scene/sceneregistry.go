@@ -0,0 +1,125 @@
+package scene
+
+import (
+	"fmt"
+	"ogl46/engine"
+)
+
+// ComponentFactory construit un composant à partir de sa description
+type ComponentFactory func(application *engine.Application, description *ComponentDescription) (Component, error)
+
+// DescribableComponent est un composant qui peut être sauvegardé dans un fichier de description de scène
+type DescribableComponent interface {
+	Component
+
+	// Describe retourne la description spécifique au type du composant (type, ressources, propriétés)
+	Describe() ComponentDescription
+}
+
+// componentCallbackNames contient les noms des fonctions de traitement d'un composant
+type componentCallbackNames struct {
+	onMouseMove   string
+	onMouseButton string
+	onMouseScroll string
+}
+
+// SceneRegistry associe des noms aux types de composants et aux fonctions Go utilisés par les fichiers de description de scène
+type SceneRegistry struct {
+	factories     map[string]ComponentFactory
+	onMouseMove   map[string]OnMouseMoveFunc
+	onMouseButton map[string]OnMouseButtonFunc
+	onMouseScroll map[string]OnMouseScrollFunc
+	onDraw        map[string]OnDrawFunc
+}
+
+// NewSceneRegistry retourne un registre contenant les types de composants du moteur ("picture", "panel", "label" et "animated-sprite")
+func NewSceneRegistry() *SceneRegistry {
+	registry := &SceneRegistry{
+		factories:     make(map[string]ComponentFactory),
+		onMouseMove:   make(map[string]OnMouseMoveFunc),
+		onMouseButton: make(map[string]OnMouseButtonFunc),
+		onMouseScroll: make(map[string]OnMouseScrollFunc),
+		onDraw:        make(map[string]OnDrawFunc),
+	}
+	registry.RegisterComponentType(PictureComponentType, buildPictureComponentFromDescription)
+	registry.RegisterComponentType(PanelComponentType, buildPanelComponentFromDescription)
+	registry.RegisterComponentType(LabelComponentType, buildLabelComponentFromDescription)
+	registry.RegisterComponentType(AnimatedSpriteComponentType, buildAnimatedSpriteComponentFromDescription)
+	return registry
+}
+
+// RegisterComponentType enregistre un type de composant
+func (registry *SceneRegistry) RegisterComponentType(typeName string, factory ComponentFactory) {
+	registry.factories[typeName] = factory
+}
+
+// RegisterOnMouseMove enregistre une fonction de traitement des déplacements de la souris
+func (registry *SceneRegistry) RegisterOnMouseMove(name string, function OnMouseMoveFunc) {
+	registry.onMouseMove[name] = function
+}
+
+// RegisterOnMouseButton enregistre une fonction de traitement des boutons de la souris
+func (registry *SceneRegistry) RegisterOnMouseButton(name string, function OnMouseButtonFunc) {
+	registry.onMouseButton[name] = function
+}
+
+// RegisterOnMouseScroll enregistre une fonction de traitement de la molette de la souris
+func (registry *SceneRegistry) RegisterOnMouseScroll(name string, function OnMouseScrollFunc) {
+	registry.onMouseScroll[name] = function
+}
+
+// RegisterOnDraw enregistre une fonction de dessin de scène
+func (registry *SceneRegistry) RegisterOnDraw(name string, function OnDrawFunc) {
+	registry.onDraw[name] = function
+}
+
+// buildComponent construit un composant à partir de sa description
+func (registry *SceneRegistry) buildComponent(application *engine.Application, description *ComponentDescription) (Component, error) {
+	factory, found := registry.factories[description.Type]
+	if !found {
+		return nil, fmt.Errorf("unknown '%s' component type", description.Type)
+	}
+	component, err := factory(application, description)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build '%s' component\n - %w", description.Type, err)
+	}
+	if description.Box != nil {
+		component.SetBox(*description.Box)
+	}
+	if description.Visible != nil {
+		component.SetVisible(*description.Visible)
+	}
+	if generic, ok := component.(interface{ generic() *GenericComponent }); ok {
+		if err := registry.bindComponent(generic.generic(), description); err != nil {
+			return nil, err
+		}
+	}
+	return component, nil
+}
+
+// bindComponent positionne le nom et les fonctions de traitement d'un composant
+func (registry *SceneRegistry) bindComponent(component *GenericComponent, description *ComponentDescription) error {
+	component.name = description.Name
+	component.callbackNames = componentCallbackNames{
+		onMouseMove:   description.OnMouseMove,
+		onMouseButton: description.OnMouseButton,
+		onMouseScroll: description.OnMouseScroll,
+	}
+	var found bool
+	if description.OnMouseMove != "" {
+		if component.OnMouseMoveFunc, found = registry.onMouseMove[description.OnMouseMove]; !found {
+			return fmt.Errorf("unknown '%s' mouse move callback", description.OnMouseMove)
+		}
+	}
+	if description.OnMouseButton != "" {
+		if component.OnMouseButtonFunc, found = registry.onMouseButton[description.OnMouseButton]; !found {
+			return fmt.Errorf("unknown '%s' mouse button callback", description.OnMouseButton)
+		}
+	}
+	if description.OnMouseScroll != "" {
+		if component.OnMouseScrollFunc, found = registry.onMouseScroll[description.OnMouseScroll]; !found {
+			return fmt.Errorf("unknown '%s' mouse scroll callback", description.OnMouseScroll)
+		}
+	}
+	return nil
+}
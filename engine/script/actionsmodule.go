@@ -0,0 +1,297 @@
+package script
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	lua "github.com/yuin/gopher-lua"
+	"log/slog"
+	"ogl46/engine"
+	"ogl46/engine/actions"
+)
+
+const (
+	actionMetatable       = "ogl46.action"
+	actionHandleMetatable = "ogl46.actionhandle"
+)
+
+// easingFunctions associe les noms utilisables dans les scripts aux fonctions d'adoucissement
+var easingFunctions = map[string]actions.EasingFunc{
+	"linear":           actions.Linear,
+	"easeInQuad":       actions.EaseInQuad,
+	"easeOutQuad":      actions.EaseOutQuad,
+	"easeInOutQuad":    actions.EaseInOutQuad,
+	"easeInCubic":      actions.EaseInCubic,
+	"easeOutCubic":     actions.EaseOutCubic,
+	"easeInOutCubic":   actions.EaseInOutCubic,
+	"easeInSine":       actions.EaseInSine,
+	"easeOutSine":      actions.EaseOutSine,
+	"easeInOutSine":    actions.EaseInOutSine,
+	"easeInBack":       actions.EaseInBack,
+	"easeOutBack":      actions.EaseOutBack,
+	"easeInOutBack":    actions.EaseInOutBack,
+	"easeInElastic":    actions.EaseInElastic,
+	"easeOutElastic":   actions.EaseOutElastic,
+	"easeInOutElastic": actions.EaseInOutElastic,
+	"easeInBounce":     actions.EaseInBounce,
+	"easeOutBounce":    actions.EaseOutBounce,
+	"easeInOutBounce":  actions.EaseInOutBounce,
+}
+
+// registerActionsModule enregistre le module "actions" (construction et exécution d'actions)
+//
+// Les actions construites par le module sont lancées par actions.run(action, [tag]) qui retourne une poignée
+// (handle:pause(), handle:resume(), handle:cancel(), handle:setSpeed(vitesse), handle:isEnd()).
+func (runtime *Runtime) registerActionsModule() {
+	state := runtime.state
+
+	state.NewTypeMetatable(actionMetatable)
+	handleMethods := state.SetFuncs(state.NewTable(), map[string]lua.LGFunction{
+		"pause": func(state *lua.LState) int {
+			checkActionHandle(state, 1).Pause()
+			return 0
+		},
+		"resume": func(state *lua.LState) int {
+			checkActionHandle(state, 1).Resume()
+			return 0
+		},
+		"cancel": func(state *lua.LState) int {
+			checkActionHandle(state, 1).Cancel()
+			return 0
+		},
+		"setSpeed": func(state *lua.LState) int {
+			checkActionHandle(state, 1).SetSpeed(float64(state.CheckNumber(2)))
+			return 0
+		},
+		"isEnd": func(state *lua.LState) int {
+			state.Push(lua.LBool(checkActionHandle(state, 1).IsEnd()))
+			return 1
+		},
+	})
+	handleMetatableValue := state.NewTypeMetatable(actionHandleMetatable)
+	state.SetField(handleMetatableValue, "__index", handleMethods)
+
+	runtime.registerModule("actions", map[string]lua.LGFunction{
+		// actions.run(action, [tag]) => poignée de l'action
+		"run": func(state *lua.LState) int {
+			action := checkAction(state, 1)
+			var handle *actions.ActionHandle
+			if state.GetTop() >= 2 {
+				handle = runtime.actionEngine.AddWithTags(action, state.CheckString(2))
+			} else {
+				handle = runtime.actionEngine.Add(action)
+			}
+			state.Push(runtime.toUserData(handle, actionHandleMetatable))
+			return 1
+		},
+		// actions.cancelTag(tag), actions.pauseTag(tag), actions.resumeTag(tag)
+		"cancelTag": func(state *lua.LState) int {
+			runtime.actionEngine.CancelTag(state.CheckString(1))
+			return 0
+		},
+		"pauseTag": func(state *lua.LState) int {
+			runtime.actionEngine.PauseTag(state.CheckString(1))
+			return 0
+		},
+		"resumeTag": func(state *lua.LState) int {
+			runtime.actionEngine.ResumeTag(state.CheckString(1))
+			return 0
+		},
+		// actions.setSpeed(vitesse) modifie la vitesse de toutes les actions des scripts
+		"setSpeed": func(state *lua.LState) int {
+			runtime.actionEngine.SetSpeed(float64(state.CheckNumber(1)))
+			return 0
+		},
+		// actions.wait(durée en ms)
+		"wait": func(state *lua.LState) int {
+			return runtime.pushAction(actions.BuildWaitAction(int64(state.CheckInt(1))))
+		},
+		// actions.call(fonction)
+		"call": func(state *lua.LState) int {
+			function := state.CheckFunction(1)
+			return runtime.pushAction(actions.BuildExecuteAction(func() {
+				runtime.callFunction(function)
+			}))
+		},
+		// actions.waitUntil(fonction retournant vrai quand l'attente est finie)
+		"waitUntil": func(state *lua.LState) int {
+			function := state.CheckFunction(1)
+			return runtime.pushAction(actions.BuildWaitUntilAction(func() bool {
+				return runtime.callPredicate(function)
+			}))
+		},
+		// actions.move(composant, x, y, vitesse en pixels par seconde)
+		"move": func(state *lua.LState) int {
+			box := checkComponent(state, 1).Box()
+			target := mgl32.Vec2{float32(state.CheckNumber(2)), float32(state.CheckNumber(3))}
+			return runtime.pushAction(actions.BuildMoveAction(box.Pos, box.SetPos, target, float32(state.CheckNumber(4))))
+		},
+		// actions.tween(composant, propriété ("x", "y", "width" ou "height"), cible, durée en ms, [adoucissement])
+		"tween": func(state *lua.LState) int {
+			box := checkComponent(state, 1).Box()
+			var getValue func() float32
+			var setValue func(float32)
+			switch property := state.CheckString(2); property {
+			case "x":
+				getValue, setValue = box.X, box.SetX
+			case "y":
+				getValue, setValue = box.Y, box.SetY
+			case "width":
+				getValue, setValue = box.Width, box.SetWidth
+			case "height":
+				getValue, setValue = box.Height, box.SetHeight
+			default:
+				state.ArgError(2, "unknown property '"+property+"'")
+			}
+			easing, found := easingFunctions[state.OptString(5, "linear")]
+			if !found {
+				state.ArgError(5, "unknown easing function")
+			}
+			return runtime.pushAction(actions.BuildFloatTween(getValue, setValue, float32(state.CheckNumber(3)), int64(state.CheckInt(4)), easing))
+		},
+		// actions.sequence(action1, action2, ...)
+		"sequence": func(state *lua.LState) int {
+			sequence := actions.BuildSequenceAction()
+			for position := 1; position <= state.GetTop(); position++ {
+				sequence.Add(checkAction(state, position))
+			}
+			return runtime.pushAction(sequence)
+		},
+		// actions.parallel(action1, action2, ...) se termine quand toutes les actions sont terminées
+		"parallel": func(state *lua.LState) int {
+			return runtime.pushAction(buildParallel(state, actions.PARALLEL_ALL))
+		},
+		// actions.parallelAny(action1, action2, ...) se termine dès qu'une action est terminée
+		"parallelAny": func(state *lua.LState) int {
+			return runtime.pushAction(buildParallel(state, actions.PARALLEL_ANY))
+		},
+		// actions.loop(fonction retournant l'action à répéter, [nombre de fois, indéfiniment par défaut])
+		"loop": func(state *lua.LState) int {
+			function := state.CheckFunction(1)
+			times := state.OptInt(2, actions.REPEAT_FOREVER)
+			return runtime.pushAction(actions.BuildRepeatAction(func() actions.Action {
+				return runtime.callActionBuilder(function)
+			}, times))
+		},
+		// actions.script(fonction) exécute une fonction Lua comme une coroutine :
+		// coroutine.yield() attend la prochaine frame, coroutine.yield(durée en ms) attend la durée indiquée
+		"script": func(state *lua.LState) int {
+			return runtime.pushAction(buildScriptAction(runtime, state.CheckFunction(1)))
+		},
+	})
+}
+
+// pushAction ajoute une action sur la pile Lua
+func (runtime *Runtime) pushAction(action actions.Action) int {
+	userData := runtime.state.NewUserData()
+	userData.Value = action
+	runtime.state.SetMetatable(userData, runtime.state.GetTypeMetatable(actionMetatable))
+	runtime.state.Push(userData)
+	return 1
+}
+
+// callPredicate appelle une fonction Lua retournant un booléen (faux en cas d'erreur)
+func (runtime *Runtime) callPredicate(function *lua.LFunction) bool {
+	if err := runtime.state.CallByParam(lua.P{Fn: function, NRet: 1, Protect: true}); err != nil {
+		slog.Error("script predicate failed", "error", err)
+		return false
+	}
+	result := runtime.state.Get(-1)
+	runtime.state.Pop(1)
+	return lua.LVAsBool(result)
+}
+
+// callActionBuilder appelle une fonction Lua retournant une action (nil en cas d'erreur)
+func (runtime *Runtime) callActionBuilder(function *lua.LFunction) actions.Action {
+	if err := runtime.state.CallByParam(lua.P{Fn: function, NRet: 1, Protect: true}); err != nil {
+		slog.Error("script action builder failed", "error", err)
+		return nil
+	}
+	result := runtime.state.Get(-1)
+	runtime.state.Pop(1)
+	if userData, ok := result.(*lua.LUserData); ok {
+		if action, ok := userData.Value.(actions.Action); ok {
+			return action
+		}
+	}
+	slog.Error("script action builder must return an action")
+	return nil
+}
+
+func buildParallel(state *lua.LState, mode actions.ParallelMode) *actions.ParallelAction {
+	parallel := actions.BuildParallelAction(mode)
+	for position := 1; position <= state.GetTop(); position++ {
+		parallel.Add(checkAction(state, position))
+	}
+	return parallel
+}
+
+// checkAction vérifie que l'argument est une action
+func checkAction(state *lua.LState, position int) actions.Action {
+	if action, ok := state.CheckUserData(position).Value.(actions.Action); ok {
+		return action
+	}
+	state.ArgError(position, "action expected")
+	return nil
+}
+
+// checkActionHandle vérifie que l'argument est une poignée d'action
+func checkActionHandle(state *lua.LState, position int) *actions.ActionHandle {
+	if handle, ok := state.CheckUserData(position).Value.(*actions.ActionHandle); ok {
+		return handle
+	}
+	state.ArgError(position, "action handle expected")
+	return nil
+}
+
+// scriptAction exécute une fonction Lua comme une coroutine reprise à chaque frame
+type scriptAction struct {
+	runtime  *Runtime
+	function *lua.LFunction
+	thread   *lua.LState
+	// waitInMs est le temps restant avant de reprendre la coroutine
+	waitInMs int64
+	end      bool
+}
+
+func buildScriptAction(runtime *Runtime, function *lua.LFunction) *scriptAction {
+	thread, _ := runtime.state.NewThread()
+	return &scriptAction{
+		runtime:  runtime,
+		function: function,
+		thread:   thread,
+	}
+}
+
+func (action *scriptAction) Execute(timer *engine.Timer) {
+	if action.end {
+		return
+	}
+	if action.waitInMs > 0 {
+		action.waitInMs -= timer.ElapsedTime()
+		if action.waitInMs > 0 {
+			return
+		}
+	}
+	status, err, values := action.runtime.state.Resume(action.thread, action.function)
+	switch status {
+	case lua.ResumeYield:
+		if len(values) > 0 {
+			if waitInMs, ok := values[0].(lua.LNumber); ok {
+				action.waitInMs = int64(waitInMs)
+			}
+		}
+	case lua.ResumeError:
+		slog.Error("script coroutine failed", "error", err)
+		action.end = true
+	default:
+		action.end = true
+	}
+}
+
+// Cancel abandonne la coroutine (elle ne sera plus reprise)
+func (action *scriptAction) Cancel() {
+	action.end = true
+}
+
+func (action *scriptAction) IsEnd() bool {
+	return action.end
+}
@@ -0,0 +1,123 @@
+package script
+
+import (
+	lua "github.com/yuin/gopher-lua"
+	"ogl46/engine/input"
+)
+
+// registerAppModule enregistre le module "app" (application, clavier, souris)
+func (runtime *Runtime) registerAppModule() {
+	application := runtime.application
+	runtime.registerModule("app", map[string]lua.LGFunction{
+		// app.size() => largeur, hauteur
+		"size": func(state *lua.LState) int {
+			width, height := application.Size()
+			state.Push(lua.LNumber(width))
+			state.Push(lua.LNumber(height))
+			return 2
+		},
+		// app.stop() arrête l'application
+		"stop": func(state *lua.LState) int {
+			application.Stop()
+			return 0
+		},
+		"fullScreen": func(state *lua.LState) int {
+			state.Push(lua.LBool(application.FullScreen()))
+			return 1
+		},
+		"setFullScreen": func(state *lua.LState) int {
+			application.SetFullScreen(state.CheckBool(1))
+			return 0
+		},
+		"vsync": func(state *lua.LState) int {
+			state.Push(lua.LBool(application.VSync()))
+			return 1
+		},
+		"setVSync": func(state *lua.LState) int {
+			application.SetVSync(state.CheckBool(1))
+			return 0
+		},
+		// app.keyPressed(code) indique si une touche est pressée (codes GLFW)
+		"keyPressed": func(state *lua.LState) int {
+			status := application.Keyboard().KeyStatus(input.KeyCode(state.CheckInt(1)))
+			state.Push(lua.LBool(status == input.KEY_PRESSED))
+			return 1
+		},
+		// app.mouse() => x, y
+		"mouse": func(state *lua.LState) int {
+			x, y := application.Mouse().Pos()
+			state.Push(lua.LNumber(x))
+			state.Push(lua.LNumber(y))
+			return 2
+		},
+		// app.mouseButtonPressed(code) indique si un bouton de la souris est pressé (0 = gauche, 1 = droit, 2 = milieu)
+		"mouseButtonPressed": func(state *lua.LState) int {
+			status := application.Mouse().ButtonStatus(input.MouseButtonCode(state.CheckInt(1)))
+			state.Push(lua.LBool(status == input.BUTTON_PRESSED))
+			return 1
+		},
+	})
+}
+
+// registerModule enregistre un module préchargé (accessible par "require" et par une variable globale)
+func (runtime *Runtime) registerModule(name string, functions map[string]lua.LGFunction) *lua.LTable {
+	module := runtime.state.SetFuncs(runtime.state.NewTable(), functions)
+	runtime.state.PreloadModule(name, func(state *lua.LState) int {
+		state.Push(module)
+		return 1
+	})
+	runtime.state.SetGlobal(name, module)
+	return module
+}
+
+// eventToTable convertit un évènement du moteur en table Lua
+func eventToTable(state *lua.LState, event input.Event) *lua.LTable {
+	table := state.NewTable()
+	switch typedEvent := event.(type) {
+	case *input.KeyboardEvent:
+		table.RawSetString("source", lua.LString("keyboard"))
+		table.RawSetString("key", lua.LNumber(typedEvent.Key()))
+		table.RawSetString("action", lua.LString(keyActionName(typedEvent.Action())))
+	case *input.MouseButtonEvent:
+		table.RawSetString("source", lua.LString("mouseButton"))
+		table.RawSetString("button", lua.LNumber(typedEvent.Button()))
+		table.RawSetString("action", lua.LString(buttonActionName(typedEvent.Action())))
+		table.RawSetString("x", lua.LNumber(typedEvent.Mouse().X()))
+		table.RawSetString("y", lua.LNumber(typedEvent.Mouse().Y()))
+	case *input.MouseMoveEvent:
+		table.RawSetString("source", lua.LString("mouseMove"))
+		table.RawSetString("x", lua.LNumber(typedEvent.X()))
+		table.RawSetString("y", lua.LNumber(typedEvent.Y()))
+		table.RawSetString("dx", lua.LNumber(typedEvent.HShift()))
+		table.RawSetString("dy", lua.LNumber(typedEvent.VShift()))
+	case *input.MouseScrollEvent:
+		table.RawSetString("source", lua.LString("mouseScroll"))
+		table.RawSetString("dx", lua.LNumber(typedEvent.HOffset()))
+		table.RawSetString("dy", lua.LNumber(typedEvent.VOffset()))
+	default:
+		table.RawSetString("source", lua.LString("window"))
+	}
+	return table
+}
+
+func keyActionName(action input.KeyAction) string {
+	switch action {
+	case input.KEY_PRESS:
+		return "press"
+	case input.KEY_RELEASE:
+		return "release"
+	default:
+		return "repeat"
+	}
+}
+
+func buttonActionName(action input.MouseButtonAction) string {
+	switch action {
+	case input.BUTTON_PRESS:
+		return "press"
+	case input.BUTTON_RELEASE:
+		return "release"
+	default:
+		return "repeat"
+	}
+}
@@ -0,0 +1,130 @@
+package script
+
+import (
+	"fmt"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// registerAssetsModule enregistre le module "assets" (ressources du bac à sable et sons / musiques)
+func (runtime *Runtime) registerAssetsModule() {
+	application := runtime.application
+	// resolve retourne le chemin d'un fichier du bac à sable (erreur Lua si l'accès est refusé)
+	resolve := func(state *lua.LState, position int) string {
+		path, err := runtime.sandbox.Resolve(state.CheckString(position))
+		if err != nil {
+			state.RaiseError("%v", err)
+		}
+		return path
+	}
+	runtime.registerModule("assets", map[string]lua.LGFunction{
+		// assets.texture(nom, fichier)
+		"texture": func(state *lua.LState) int {
+			application.TextureManager().RegisterTextureFromFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.font(nom, fichier, largeur, hauteur)
+		"font": func(state *lua.LState) int {
+			application.FontManager().RegisterFontFromImageFile(state.CheckString(1), resolve(state, 2), int32(state.CheckInt(3)), int32(state.CheckInt(4)))
+			return 0
+		},
+		// assets.sound(nom, fichier)
+		"sound": func(state *lua.LState) int {
+			application.SoundManager().RegisterSoundFromFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.music(nom, fichier)
+		"music": func(state *lua.LState) int {
+			application.MusicManager().RegisterMusicFromFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.theme(nom, fichier)
+		"theme": func(state *lua.LState) int {
+			application.ThemeManager().RegisterThemeFromFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.spriteSheet(nom, fichier Aseprite)
+		"spriteSheet": func(state *lua.LState) int {
+			application.SpriteSheetManager().RegisterSpriteSheetFromAsepriteFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.tileMap(nom, fichier Tiled ".tmx", ".tmj" ou ".json")
+		"tileMap": func(state *lua.LState) int {
+			application.TileMapManager().RegisterTileMapFromFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.particleEffect(nom, fichier JSON)
+		"particleEffect": func(state *lua.LState) int {
+			application.ParticleEffectManager().RegisterParticleEffectFromFile(state.CheckString(1), resolve(state, 2))
+			return 0
+		},
+		// assets.loadAll() charge toutes les ressources enregistrées
+		"loadAll": func(state *lua.LState) int {
+			for _, load := range []func() error{
+				application.TextureManager().LoadAll,
+				application.FontManager().LoadAll,
+				application.SoundManager().LoadAll,
+				application.MusicManager().LoadAll,
+				application.ThemeManager().LoadAll,
+				application.SpriteSheetManager().LoadAll,
+				application.TileMapManager().LoadAll,
+				application.ParticleEffectManager().LoadAll,
+			} {
+				if err := load(); err != nil {
+					state.RaiseError("%v", err)
+				}
+			}
+			return 0
+		},
+		// assets.playSound(nom, [volume])
+		"playSound": func(state *lua.LState) int {
+			sound, err := application.SoundManager().Get(state.CheckString(1))
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			if state.GetTop() >= 2 {
+				sound.SetVolume(state.CheckInt(2))
+			}
+			sound.Play()
+			return 0
+		},
+		// assets.playMusic(nom, [volume])
+		"playMusic": func(state *lua.LState) int {
+			music, err := application.MusicManager().Get(state.CheckString(1))
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			if state.GetTop() >= 2 {
+				music.SetVolume(state.CheckInt(2))
+			}
+			music.Play()
+			return 0
+		},
+		// assets.pauseMusic(nom)
+		"pauseMusic": func(state *lua.LState) int {
+			music, err := application.MusicManager().Get(state.CheckString(1))
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			music.Pause()
+			return 0
+		},
+		// assets.stopMusic(nom)
+		"stopMusic": func(state *lua.LState) int {
+			music, err := application.MusicManager().Get(state.CheckString(1))
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			music.Stop()
+			return 0
+		},
+		// assets.read(fichier) => contenu d'un fichier du bac à sable
+		"read": func(state *lua.LState) int {
+			content, err := runtime.sandbox.ReadFile(state.CheckString(1))
+			if err != nil {
+				state.RaiseError("%v", fmt.Errorf("failed to read asset\n - %w", err))
+			}
+			state.Push(lua.LString(content))
+			return 1
+		},
+	})
+}
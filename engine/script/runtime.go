@@ -0,0 +1,201 @@
+package script
+
+import (
+	"fmt"
+	lua "github.com/yuin/gopher-lua"
+	"log/slog"
+	"ogl46/engine"
+	"ogl46/engine/actions"
+	"ogl46/engine/scene"
+	"os"
+	"strings"
+	"time"
+)
+
+// Runtime est un interpréteur Lua exposant le moteur aux scripts
+//
+// Modules disponibles dans les scripts : "app" (application, clavier, souris), "assets" (gestionnaires de ressources,
+// textures, polices, sons, musiques), "scene" (scènes 2D et composants), "actions" (moteur d'actions) et "stage"
+// (scènes dessinées par le stage).
+type Runtime struct {
+	application *engine.Application
+	sandbox     *Sandbox
+	state       *lua.LState
+	// actionEngine exécute les actions lancées par les scripts
+	actionEngine actions.ActionEngine
+	// registry contient les types de composants et les fonctions des scènes déclaratives chargées par les scripts
+	registry *scene.SceneRegistry
+	// scenes sont les scènes traitées et dessinées par le stage
+	scenes []*scene.Scene2d
+	// userData associe les objets du moteur à leur valeur Lua (un même objet a toujours la même valeur tant qu'il est utilisé)
+	//
+	//	Les scènes sont mémorisées par stage.addScene. Les valeurs sont oubliées quand l'objet n'est plus utilisé
+	//	par le moteur : action terminée, composant enlevé de sa scène, scène enlevée du stage.
+	userData map[any]*lua.LUserData
+	// files contient la date de modification des fichiers chargés (pour le rechargement à chaud)
+	files map[string]time.Time
+}
+
+// NewRuntime construit un interpréteur Lua dont l'accès aux fichiers est limité au bac à sable
+func NewRuntime(application *engine.Application, sandbox *Sandbox) *Runtime {
+	runtime := &Runtime{
+		application: application,
+		sandbox:     sandbox,
+		state:       lua.NewState(lua.Options{SkipOpenLibs: true}),
+		registry:    scene.NewSceneRegistry(),
+		scenes:      make([]*scene.Scene2d, 0),
+		userData:    make(map[any]*lua.LUserData),
+		files:       make(map[string]time.Time),
+	}
+	sandbox.openLibraries(runtime.state, runtime.watchFile)
+	runtime.registerAppModule()
+	runtime.registerAssetsModule()
+	runtime.registerSceneModule()
+	runtime.registerActionsModule()
+	runtime.registerStageModule()
+	return runtime
+}
+
+// State retourne l'interpréteur Lua (pour ajouter des fonctions propres au jeu)
+func (runtime *Runtime) State() *lua.LState {
+	return runtime.state
+}
+
+// ActionEngine retourne le moteur d'actions des scripts
+func (runtime *Runtime) ActionEngine() *actions.ActionEngine {
+	return &runtime.actionEngine
+}
+
+// Registry retourne le registre des scènes déclaratives (pour ajouter des types de composants propres au jeu)
+func (runtime *Runtime) Registry() *scene.SceneRegistry {
+	return runtime.registry
+}
+
+// Scenes retourne les scènes ajoutées par les scripts
+func (runtime *Runtime) Scenes() []*scene.Scene2d {
+	return runtime.scenes
+}
+
+// DoFile exécute un fichier de script du bac à sable
+func (runtime *Runtime) DoFile(name string) error {
+	path, err := runtime.sandbox.Resolve(name)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s' script file\n - %w", name, err)
+	}
+	runtime.watchFile(path)
+	function, err := runtime.state.Load(strings.NewReader(string(content)), name)
+	if err != nil {
+		return fmt.Errorf("failed to load '%s' script file\n - %w", name, err)
+	}
+	runtime.state.Push(function)
+	if err := runtime.state.PCall(0, 0, nil); err != nil {
+		return fmt.Errorf("failed to execute '%s' script file\n - %w", name, err)
+	}
+	return nil
+}
+
+// DoString exécute un script
+func (runtime *Runtime) DoString(source string) error {
+	if err := runtime.state.DoString(source); err != nil {
+		return fmt.Errorf("failed to execute script\n - %w", err)
+	}
+	return nil
+}
+
+// CallGlobal appelle une fonction globale du script si elle existe (retourne faux si elle n'existe pas)
+func (runtime *Runtime) CallGlobal(name string, arguments ...lua.LValue) (bool, error) {
+	function, ok := runtime.state.GetGlobal(name).(*lua.LFunction)
+	if !ok {
+		return false, nil
+	}
+	if err := runtime.state.CallByParam(lua.P{Fn: function, NRet: 0, Protect: true}, arguments...); err != nil {
+		return true, fmt.Errorf("failed to call '%s' script function\n - %w", name, err)
+	}
+	return true, nil
+}
+
+// Changed indique si un des fichiers chargés a été modifié (ou supprimé) depuis son chargement
+func (runtime *Runtime) Changed() bool {
+	for path, modTime := range runtime.files {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// acknowledgeChanges met à jour la date de modification des fichiers chargés (les modifications sont ignorées)
+func (runtime *Runtime) acknowledgeChanges() {
+	for path := range runtime.files {
+		if info, err := os.Stat(path); err == nil {
+			runtime.files[path] = info.ModTime()
+		} else {
+			delete(runtime.files, path)
+		}
+	}
+}
+
+// Close annule les actions des scripts, libère les scènes du stage et ferme l'interpréteur
+func (runtime *Runtime) Close() {
+	runtime.actionEngine.Clear()
+	for _, scene2d := range runtime.scenes {
+		scene2d.Release()
+	}
+	runtime.scenes = nil
+	runtime.userData = make(map[any]*lua.LUserData)
+	runtime.state.Close()
+}
+
+// watchFile mémorise la date de modification d'un fichier chargé
+func (runtime *Runtime) watchFile(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		slog.Warn("cannot watch script file", "path", path, "error", err)
+		return
+	}
+	runtime.files[path] = info.ModTime()
+}
+
+// toUserData retourne la valeur Lua d'un objet du moteur (avec la métatable indiquée)
+func (runtime *Runtime) toUserData(value any, metatableName string) *lua.LUserData {
+	if userData, found := runtime.userData[value]; found {
+		return userData
+	}
+	userData := runtime.newUserData(value, metatableName)
+	runtime.userData[value] = userData
+	return userData
+}
+
+// newUserData retourne une nouvelle valeur Lua d'un objet du moteur, sans la mémoriser (objet qui n'est pas encore utilisé par le moteur)
+func (runtime *Runtime) newUserData(value any, metatableName string) *lua.LUserData {
+	userData := runtime.state.NewUserData()
+	userData.Value = value
+	runtime.state.SetMetatable(userData, runtime.state.GetTypeMetatable(metatableName))
+	return userData
+}
+
+// forgetUserData oublie la valeur Lua d'un objet du moteur qui n'est plus utilisé
+func (runtime *Runtime) forgetUserData(value any) {
+	delete(runtime.userData, value)
+}
+
+// forgetEndedActions oublie les valeurs Lua des poignées d'actions terminées
+func (runtime *Runtime) forgetEndedActions() {
+	for value := range runtime.userData {
+		if handle, ok := value.(*actions.ActionHandle); ok && handle.IsEnd() {
+			delete(runtime.userData, value)
+		}
+	}
+}
+
+// callFunction appelle une fonction Lua (les erreurs sont tracées)
+func (runtime *Runtime) callFunction(function *lua.LFunction, arguments ...lua.LValue) {
+	if err := runtime.state.CallByParam(lua.P{Fn: function, NRet: 0, Protect: true}, arguments...); err != nil {
+		slog.Error("script callback failed", "error", err)
+	}
+}
@@ -0,0 +1,164 @@
+package script
+
+import (
+	"fmt"
+	lua "github.com/yuin/gopher-lua"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sandbox limite l'accès au système de fichiers des scripts à un répertoire racine
+type Sandbox struct {
+	// root est le chemin absolu du répertoire racine des scripts
+	root string
+}
+
+// NewSandbox construit un bac à sable limité au répertoire indiqué
+func NewSandbox(root string) (*Sandbox, error) {
+	absoluteRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve sandbox root '%s'\n - %w", root, err)
+	}
+	if evaluatedRoot, err := filepath.EvalSymlinks(absoluteRoot); err == nil {
+		absoluteRoot = evaluatedRoot
+	}
+	info, err := os.Stat(absoluteRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sandbox root '%s'\n - %w", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("sandbox root '%s' is not a directory", root)
+	}
+	return &Sandbox{root: absoluteRoot}, nil
+}
+
+// Root retourne le répertoire racine du bac à sable
+func (sandbox *Sandbox) Root() string {
+	return sandbox.root
+}
+
+// Resolve retourne le chemin d'un fichier du bac à sable (chemin relatif à la racine)
+// et refuse les chemins qui sortent du bac à sable.
+func (sandbox *Sandbox) Resolve(name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("access to '%s' is denied (absolute path)", name)
+	}
+	path := filepath.Join(sandbox.root, filepath.Clean(filepath.FromSlash(name)))
+	if evaluatedPath, err := filepath.EvalSymlinks(path); err == nil {
+		path = evaluatedPath
+	}
+	relative, err := filepath.Rel(sandbox.root, path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("access to '%s' is denied (outside of sandbox)", name)
+	}
+	return path, nil
+}
+
+// ReadFile lit un fichier du bac à sable
+func (sandbox *Sandbox) ReadFile(name string) ([]byte, error) {
+	path, err := sandbox.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' script file\n - %w", name, err)
+	}
+	return content, nil
+}
+
+// openLibraries ouvre les bibliothèques Lua sans accès au système de fichiers ni au système d'exploitation
+func (sandbox *Sandbox) openLibraries(state *lua.LState, onFileLoaded func(path string)) {
+	for _, library := range []struct {
+		name     string
+		function lua.LGFunction
+	}{
+		{lua.LoadLibName, lua.OpenPackage},
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+		{lua.CoroutineLibName, lua.OpenCoroutine},
+		{lua.OsLibName, lua.OpenOs},
+	} {
+		state.Push(state.NewFunction(library.function))
+		state.Push(lua.LString(library.name))
+		state.Call(1, 0)
+	}
+
+	// Ne garder que les fonctions "os" sans effet de bord
+	osModule := state.GetGlobal(lua.OsLibName).(*lua.LTable)
+	for _, name := range []string{"execute", "exit", "getenv", "remove", "rename", "setenv", "setlocale", "tmpname"} {
+		osModule.RawSetString(name, lua.LNil)
+	}
+
+	// Chargement de fichiers limité au bac à sable
+	loadFile := func(name string) (*lua.LFunction, error) {
+		path, err := sandbox.Resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' script file\n - %w", name, err)
+		}
+		function, err := state.Load(strings.NewReader(string(content)), name)
+		if err != nil {
+			return nil, err
+		}
+		if onFileLoaded != nil {
+			onFileLoaded(path)
+		}
+		return function, nil
+	}
+	state.SetGlobal("loadfile", state.NewFunction(func(state *lua.LState) int {
+		function, err := loadFile(state.CheckString(1))
+		if err != nil {
+			state.Push(lua.LNil)
+			state.Push(lua.LString(err.Error()))
+			return 2
+		}
+		state.Push(function)
+		return 1
+	}))
+	state.SetGlobal("dofile", state.NewFunction(func(state *lua.LState) int {
+		function, err := loadFile(state.CheckString(1))
+		if err != nil {
+			state.RaiseError("%v", err)
+		}
+		top := state.GetTop()
+		state.Push(function)
+		state.Call(0, lua.MultRet)
+		return state.GetTop() - top
+	}))
+
+	// "require" : modules préchargés puis fichiers du bac à sable ("a.b" => "a/b.lua" ou "a/b/init.lua")
+	packageModule := state.GetGlobal(lua.LoadLibName).(*lua.LTable)
+	packageModule.RawSetString("path", lua.LString("?.lua;?/init.lua"))
+	packageModule.RawSetString("cpath", lua.LString(""))
+	loaders := packageModule.RawGetString("loaders").(*lua.LTable)
+	loaders.RawSetInt(2, state.NewFunction(func(state *lua.LState) int {
+		name := strings.ReplaceAll(state.CheckString(1), ".", "/")
+		messages := make([]string, 0, 2)
+		for _, candidate := range []string{name + ".lua", name + "/init.lua"} {
+			path, err := sandbox.Resolve(candidate)
+			if err != nil {
+				messages = append(messages, err.Error())
+				continue
+			}
+			if _, err := os.Stat(path); err != nil {
+				messages = append(messages, fmt.Sprintf("no file '%s'", candidate))
+				continue
+			}
+			function, err := loadFile(candidate)
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			state.Push(function)
+			return 1
+		}
+		state.Push(lua.LString("\n\t" + strings.Join(messages, "\n\t")))
+		return 1
+	}))
+}
@@ -0,0 +1,59 @@
+package script
+
+import (
+	lua "github.com/yuin/gopher-lua"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func buildSandbox(t *testing.T) *Sandbox {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "lib", "module.lua"), []byte("return { value = 42 }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sandbox, err := NewSandbox(root)
+	if err != nil {
+		t.Fatalf("NewSandbox() error=%v", err)
+	}
+	return sandbox
+}
+
+func TestSandbox_Resolve(t *testing.T) {
+	sandbox := buildSandbox(t)
+	for _, name := range []string{"/etc/passwd", "../secret.lua", "lib/../../secret.lua"} {
+		if _, err := sandbox.Resolve(name); err == nil {
+			t.Fatalf("Resolve(%q) error=nil, want access denied", name)
+		}
+	}
+	path, err := sandbox.Resolve("lib/module.lua")
+	if err != nil || path != filepath.Join(sandbox.Root(), "lib", "module.lua") {
+		t.Fatalf("Resolve(\"lib/module.lua\") = %v, %v", path, err)
+	}
+}
+
+func TestSandbox_OpenLibraries(t *testing.T) {
+	sandbox := buildSandbox(t)
+	state := lua.NewState(lua.Options{SkipOpenLibs: true})
+	defer state.Close()
+	loaded := make([]string, 0)
+	sandbox.openLibraries(state, func(path string) {
+		loaded = append(loaded, path)
+	})
+	err := state.DoString(`
+		assert(require("lib.module").value == 42)
+		assert(io == nil and os.execute == nil and os.remove == nil)
+		assert(not pcall(dofile, "../secret.lua"))
+		assert(loadfile("/etc/passwd") == nil)
+		assert(not pcall(require, "missing"))
+	`)
+	if err != nil {
+		t.Fatalf("DoString() error=%v", err)
+	}
+	if len(loaded) != 1 {
+		t.Fatalf("openLibraries() loaded=%v, want lib/module.lua", loaded)
+	}
+}
@@ -0,0 +1,220 @@
+package script
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	lua "github.com/yuin/gopher-lua"
+	"ogl46/engine"
+	"ogl46/engine/graphic"
+	"ogl46/engine/input"
+	"ogl46/engine/scene"
+)
+
+const (
+	sceneMetatable     = "ogl46.scene"
+	componentMetatable = "ogl46.component"
+)
+
+// registerSceneModule enregistre le module "scene" (scènes 2D, composants et fonctions des scènes déclaratives)
+func (runtime *Runtime) registerSceneModule() {
+	application := runtime.application
+	state := runtime.state
+
+	sceneMethods := state.SetFuncs(state.NewTable(), map[string]lua.LGFunction{
+		// scène:component(nom) => composant (nil si absent)
+		"component": func(state *lua.LState) int {
+			component := checkScene(state, 1).ComponentByName(state.CheckString(2))
+			if component == nil {
+				state.Push(lua.LNil)
+			} else {
+				state.Push(runtime.toUserData(component, componentMetatable))
+			}
+			return 1
+		},
+		// scène:remove(composant)
+		"remove": func(state *lua.LState) int {
+			component := checkComponent(state, 2)
+			checkScene(state, 1).RemoveComponent(component)
+			runtime.forgetUserData(component)
+			return 0
+		},
+		// scène:dimension() => largeur, hauteur
+		"dimension": func(state *lua.LState) int {
+			dimension := checkScene(state, 1).Dimension()
+			state.Push(lua.LNumber(dimension.X()))
+			state.Push(lua.LNumber(dimension.Y()))
+			return 2
+		},
+		// scène:useTheme(nom)
+		"useTheme": func(state *lua.LState) int {
+			if err := checkScene(state, 1).UseTheme(application, state.CheckString(2)); err != nil {
+				state.RaiseError("%v", err)
+			}
+			return 0
+		},
+	})
+	sceneMetatableValue := state.NewTypeMetatable(sceneMetatable)
+	state.SetField(sceneMetatableValue, "__index", sceneMethods)
+
+	componentMethods := state.SetFuncs(state.NewTable(), map[string]lua.LGFunction{
+		// composant:name() => nom du composant
+		"name": func(state *lua.LState) int {
+			if named, ok := checkComponent(state, 1).(interface{ Name() string }); ok {
+				state.Push(lua.LString(named.Name()))
+			} else {
+				state.Push(lua.LString(""))
+			}
+			return 1
+		},
+		// composant:box() => x, y, largeur, hauteur
+		"box": func(state *lua.LState) int {
+			box := checkComponent(state, 1).Box()
+			state.Push(lua.LNumber(box.X()))
+			state.Push(lua.LNumber(box.Y()))
+			state.Push(lua.LNumber(box.Width()))
+			state.Push(lua.LNumber(box.Height()))
+			return 4
+		},
+		// composant:setBox(x, y, largeur, hauteur)
+		"setBox": func(state *lua.LState) int {
+			checkComponent(state, 1).SetBox(graphic.Rectangle{
+				float32(state.CheckNumber(2)), float32(state.CheckNumber(3)),
+				float32(state.CheckNumber(4)), float32(state.CheckNumber(5)),
+			})
+			return 0
+		},
+		// composant:position() => x, y
+		"position": func(state *lua.LState) int {
+			box := checkComponent(state, 1).Box()
+			state.Push(lua.LNumber(box.X()))
+			state.Push(lua.LNumber(box.Y()))
+			return 2
+		},
+		// composant:setPosition(x, y)
+		"setPosition": func(state *lua.LState) int {
+			checkComponent(state, 1).Box().SetPos(mgl32.Vec2{float32(state.CheckNumber(2)), float32(state.CheckNumber(3))})
+			return 0
+		},
+		"visible": func(state *lua.LState) int {
+			state.Push(lua.LBool(checkComponent(state, 1).Visible()))
+			return 1
+		},
+		"setVisible": func(state *lua.LState) int {
+			checkComponent(state, 1).SetVisible(state.CheckBool(2))
+			return 0
+		},
+		// composant:text() => texte (composants texte uniquement)
+		"text": func(state *lua.LState) int {
+			textComponent, ok := checkComponent(state, 1).(interface{ Text() string })
+			if !ok {
+				state.ArgError(1, "component has no text")
+			}
+			state.Push(lua.LString(textComponent.Text()))
+			return 1
+		},
+		// composant:setText(texte) (composants texte uniquement)
+		"setText": func(state *lua.LState) int {
+			textComponent, ok := checkComponent(state, 1).(interface{ SetText(string) })
+			if !ok {
+				state.ArgError(1, "component has no text")
+			}
+			textComponent.SetText(state.CheckString(2))
+			return 0
+		},
+		// composant:setColor(r, v, b, [a]) (composants colorés uniquement)
+		"setColor": func(state *lua.LState) int {
+			colorComponent, ok := checkComponent(state, 1).(interface{ SetColor(graphic.Color) })
+			if !ok {
+				state.ArgError(1, "component has no color")
+			}
+			colorComponent.SetColor(graphic.CreateColorRVBA(
+				float32(state.CheckNumber(2)), float32(state.CheckNumber(3)),
+				float32(state.CheckNumber(4)), float32(state.OptNumber(5, 1)),
+			))
+			return 0
+		},
+	})
+	componentMetatableValue := state.NewTypeMetatable(componentMetatable)
+	state.SetField(componentMetatableValue, "__index", componentMethods)
+
+	runtime.registerModule("scene", map[string]lua.LGFunction{
+		// scene.new(largeur, hauteur) => scène vide
+		"new": func(state *lua.LState) int {
+			scene2d := scene.BuildScene2d(float32(state.CheckNumber(1)), float32(state.CheckNumber(2)))
+			state.Push(runtime.newUserData(scene2d, sceneMetatable))
+			return 1
+		},
+		// scene.load(fichier) => scène décrite par un fichier JSON du bac à sable
+		// (les fonctions utilisées par la scène doivent être enregistrées avant le chargement)
+		"load": func(state *lua.LState) int {
+			name := state.CheckString(1)
+			path, err := runtime.sandbox.Resolve(name)
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			content, err := runtime.sandbox.ReadFile(name)
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			scene2d, err := scene.LoadScene2dFromBytes(application, runtime.registry, content)
+			if err != nil {
+				state.RaiseError("%v", err)
+			}
+			runtime.watchFile(path)
+			state.Push(runtime.newUserData(scene2d, sceneMetatable))
+			return 1
+		},
+		// scene.onMouseButton(nom, fonction(scène, composant, évènement, x, y))
+		"onMouseButton": func(state *lua.LState) int {
+			function := state.CheckFunction(2)
+			runtime.registry.RegisterOnMouseButton(state.CheckString(1), func(application *engine.Application, scene2d *scene.Scene2d, component scene.Component, event *input.MouseButtonEvent, position mgl32.Vec2, timer *engine.Timer) {
+				runtime.callComponentFunction(function, scene2d, component, event, position)
+			})
+			return 0
+		},
+		// scene.onMouseMove(nom, fonction(scène, composant, évènement, x, y))
+		"onMouseMove": func(state *lua.LState) int {
+			function := state.CheckFunction(2)
+			runtime.registry.RegisterOnMouseMove(state.CheckString(1), func(application *engine.Application, scene2d *scene.Scene2d, component scene.Component, event *input.MouseMoveEvent, position mgl32.Vec2, timer *engine.Timer) {
+				runtime.callComponentFunction(function, scene2d, component, event, position)
+			})
+			return 0
+		},
+		// scene.onMouseScroll(nom, fonction(scène, composant, évènement, x, y))
+		"onMouseScroll": func(state *lua.LState) int {
+			function := state.CheckFunction(2)
+			runtime.registry.RegisterOnMouseScroll(state.CheckString(1), func(application *engine.Application, scene2d *scene.Scene2d, component scene.Component, event *input.MouseScrollEvent, position mgl32.Vec2, timer *engine.Timer) {
+				runtime.callComponentFunction(function, scene2d, component, event, position)
+			})
+			return 0
+		},
+	})
+}
+
+// callComponentFunction appelle une fonction Lua de traitement d'un évènement sur un composant
+func (runtime *Runtime) callComponentFunction(function *lua.LFunction, scene2d *scene.Scene2d, component scene.Component, event input.Event, position mgl32.Vec2) {
+	runtime.callFunction(function,
+		runtime.toUserData(scene2d, sceneMetatable),
+		runtime.toUserData(component, componentMetatable),
+		eventToTable(runtime.state, event),
+		lua.LNumber(position.X()),
+		lua.LNumber(position.Y()),
+	)
+}
+
+// checkScene vérifie que l'argument est une scène
+func checkScene(state *lua.LState, position int) *scene.Scene2d {
+	if scene2d, ok := state.CheckUserData(position).Value.(*scene.Scene2d); ok {
+		return scene2d
+	}
+	state.ArgError(position, "scene expected")
+	return nil
+}
+
+// checkComponent vérifie que l'argument est un composant
+func checkComponent(state *lua.LState, position int) scene.Component {
+	if component, ok := state.CheckUserData(position).Value.(scene.Component); ok {
+		return component
+	}
+	state.ArgError(position, "component expected")
+	return nil
+}
@@ -0,0 +1,215 @@
+package script
+
+import (
+	"fmt"
+	lua "github.com/yuin/gopher-lua"
+	"log/slog"
+	"ogl46/engine"
+	"ogl46/engine/input"
+	"ogl46/engine/scene"
+	"slices"
+)
+
+// DEFAULT_RELOAD_INTERVAL_IN_MS est l'intervalle par défaut de vérification des fichiers modifiés
+const DEFAULT_RELOAD_INTERVAL_IN_MS = 1000
+
+// ScriptStage est un stage dont le comportement est écrit en Lua.
+//
+// Le script principal peut définir les fonctions globales suivantes (toutes optionnelles) :
+// initialize(), release(), execute(elapsedInMs), display(elapsedInMs) et event(évènement).
+// Les scènes ajoutées par stage.addScene(scène) sont traitées, dessinées et reçoivent les évènements.
+// Elles sont libérées quand elles sont enlevées du stage, au rechargement du script et à la libération du stage.
+//
+// Quand le rechargement à chaud est actif, le script est rechargé dès qu'un fichier chargé
+// (script, module ou scène) est modifié. Le nouveau script est initialisé avant de remplacer l'ancien :
+// en cas d'erreur, l'ancien script continue à fonctionner.
+type ScriptStage struct {
+	sandbox  *Sandbox
+	mainFile string
+	runtime  *Runtime
+	// setup permet d'enrichir chaque nouvel interpréteur (fonctions et types de composants propres au jeu)
+	setup func(runtime *Runtime)
+
+	hotReload bool
+	// reloadIntervalInMs est l'intervalle de vérification des fichiers modifiés
+	reloadIntervalInMs int64
+	// sinceCheckInMs est le temps écoulé depuis la dernière vérification
+	sinceCheckInMs int64
+}
+
+// NewScriptStage construit un stage exécutant le script principal indiqué (chemin relatif au bac à sable)
+func NewScriptStage(sandbox *Sandbox, mainFile string) *ScriptStage {
+	return &ScriptStage{
+		sandbox:            sandbox,
+		mainFile:           mainFile,
+		reloadIntervalInMs: DEFAULT_RELOAD_INTERVAL_IN_MS,
+	}
+}
+
+// Runtime retourne l'interpréteur en cours (nil si le stage n'est pas initialisé)
+func (stage *ScriptStage) Runtime() *Runtime {
+	return stage.runtime
+}
+
+// SetSetup indique la fonction appelée sur chaque nouvel interpréteur avant l'exécution du script principal
+func (stage *ScriptStage) SetSetup(setup func(runtime *Runtime)) {
+	stage.setup = setup
+}
+
+// HotReload indique si le rechargement à chaud est actif
+func (stage *ScriptStage) HotReload() bool {
+	return stage.hotReload
+}
+
+// SetHotReload active ou désactive le rechargement à chaud
+func (stage *ScriptStage) SetHotReload(hotReload bool) {
+	stage.hotReload = hotReload
+}
+
+// SetReloadInterval modifie l'intervalle de vérification des fichiers modifiés
+func (stage *ScriptStage) SetReloadInterval(reloadIntervalInMs int64) {
+	stage.reloadIntervalInMs = max(reloadIntervalInMs, 0)
+}
+
+func (stage *ScriptStage) Initialize(app *engine.Application) error {
+	runtime, err := stage.load(app)
+	if err != nil {
+		return err
+	}
+	stage.runtime = runtime
+	return nil
+}
+
+func (stage *ScriptStage) Release(app *engine.Application) {
+	if stage.runtime != nil {
+		stage.unload(stage.runtime)
+		stage.runtime = nil
+	}
+}
+
+func (stage *ScriptStage) Display(app *engine.Application, timer *engine.Timer) {
+	if stage.runtime == nil {
+		return
+	}
+	for _, scene2d := range stage.runtime.scenes {
+		scene2d.Draw(app, timer)
+	}
+	stage.callGlobal("display", lua.LNumber(timer.ElapsedTime()))
+}
+
+func (stage *ScriptStage) Execute(app *engine.Application, timer *engine.Timer) {
+	if stage.hotReload {
+		stage.sinceCheckInMs += timer.ElapsedTime()
+		if stage.sinceCheckInMs >= stage.reloadIntervalInMs {
+			stage.sinceCheckInMs = 0
+			stage.reloadIfChanged(app)
+		}
+	}
+	if stage.runtime == nil {
+		return
+	}
+	stage.runtime.actionEngine.Execute(timer)
+	stage.runtime.forgetEndedActions()
+	for _, scene2d := range stage.runtime.scenes {
+		scene2d.Execute(app, timer)
+	}
+	stage.callGlobal("execute", lua.LNumber(timer.ElapsedTime()))
+}
+
+func (stage *ScriptStage) ProcessEvent(app *engine.Application, event input.Event, timer *engine.Timer) {
+	if stage.runtime == nil {
+		return
+	}
+	for _, scene2d := range stage.runtime.scenes {
+		scene2d.ProcessEvent(app, event, timer)
+	}
+	stage.callGlobal("event", eventToTable(stage.runtime.state, event))
+}
+
+// Reload recharge le script principal (l'ancien script est conservé en cas d'erreur)
+func (stage *ScriptStage) Reload(app *engine.Application) error {
+	runtime, err := stage.load(app)
+	if err != nil {
+		return err
+	}
+	if stage.runtime != nil {
+		stage.unload(stage.runtime)
+	}
+	stage.runtime = runtime
+	slog.Info("script reloaded", "file", stage.mainFile)
+	return nil
+}
+
+// reloadIfChanged recharge le script si un des fichiers chargés a été modifié
+func (stage *ScriptStage) reloadIfChanged(app *engine.Application) {
+	if stage.runtime == nil || !stage.runtime.Changed() {
+		return
+	}
+	if err := stage.Reload(app); err != nil {
+		slog.Error("failed to reload script", "file", stage.mainFile, "error", err)
+		// Ne pas réessayer tant que les fichiers ne sont pas à nouveau modifiés
+		stage.runtime.acknowledgeChanges()
+	}
+}
+
+// load construit un nouvel interpréteur, exécute le script principal et appelle sa fonction "initialize"
+func (stage *ScriptStage) load(app *engine.Application) (*Runtime, error) {
+	runtime := NewRuntime(app, stage.sandbox)
+	if stage.setup != nil {
+		stage.setup(runtime)
+	}
+	if err := runtime.DoFile(stage.mainFile); err != nil {
+		runtime.Close()
+		return nil, fmt.Errorf("failed to load script stage\n - %w", err)
+	}
+	if _, err := runtime.CallGlobal("initialize"); err != nil {
+		runtime.Close()
+		return nil, fmt.Errorf("failed to initialize script stage\n - %w", err)
+	}
+	return runtime, nil
+}
+
+// unload appelle la fonction "release" du script et ferme son interpréteur
+func (stage *ScriptStage) unload(runtime *Runtime) {
+	if _, err := runtime.CallGlobal("release"); err != nil {
+		slog.Error("failed to release script stage", "file", stage.mainFile, "error", err)
+	}
+	runtime.Close()
+}
+
+// callGlobal appelle une fonction globale du script en cours (les erreurs sont tracées)
+func (stage *ScriptStage) callGlobal(name string, arguments ...lua.LValue) {
+	if _, err := stage.runtime.CallGlobal(name, arguments...); err != nil {
+		slog.Error("script function failed", "file", stage.mainFile, "error", err)
+	}
+}
+
+// registerStageModule enregistre le module "stage" (scènes traitées et dessinées par le stage)
+func (runtime *Runtime) registerStageModule() {
+	runtime.registerModule("stage", map[string]lua.LGFunction{
+		// stage.addScene(scène)
+		"addScene": func(state *lua.LState) int {
+			scene2d := checkScene(state, 1)
+			if !slices.Contains(runtime.scenes, scene2d) {
+				runtime.scenes = append(runtime.scenes, scene2d)
+			}
+			// Une scène enlevée puis ajoutée à nouveau garde la même valeur Lua
+			runtime.userData[scene2d] = state.CheckUserData(1)
+			return 0
+		},
+		// stage.removeScene(scène) enlève la scène et libère ses ressources graphiques
+		"removeScene": func(state *lua.LState) int {
+			scene2d := checkScene(state, 1)
+			runtime.scenes = slices.DeleteFunc(runtime.scenes, func(current *scene.Scene2d) bool {
+				return current == scene2d
+			})
+			// Le framebuffer de la résolution virtuelle est recréé si la scène est ajoutée à nouveau
+			scene2d.Release()
+			runtime.forgetUserData(scene2d)
+			for _, component := range scene2d.Components() {
+				runtime.forgetUserData(component)
+			}
+			return 0
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package script
+
+import (
+	"ogl46/engine"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildScriptStage retourne un stage initialisé exécutant le script principal indiqué (avec des fichiers annexes)
+func buildScriptStage(t *testing.T, mainScript string, files map[string]string) *ScriptStage {
+	root := t.TempDir()
+	files["main.lua"] = mainScript
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sandbox, err := NewSandbox(root)
+	if err != nil {
+		t.Fatalf("NewSandbox() error=%v", err)
+	}
+	stage := NewScriptStage(sandbox, "main.lua")
+	if err := stage.Initialize(nil); err != nil {
+		t.Fatalf("Initialize() error=%v", err)
+	}
+	t.Cleanup(func() { stage.Release(nil) })
+	return stage
+}
+
+func TestScriptStage_RemoveComponent(t *testing.T) {
+	stage := buildScriptStage(t, `
+		function initialize()
+			menu = scene.load("menu.json")
+			stage.addScene(menu)
+			menu:remove(menu:component("quit"))
+		end
+	`, map[string]string{
+		"menu.json": `{"width": 320, "height": 200, "components": [
+			{"type": "panel", "name": "play", "style": "button"},
+			{"type": "panel", "name": "quit", "style": "button"}
+		]}`,
+	})
+	timer := engine.BuildTimerAt(0)
+	for frame := 0; frame < 2; frame++ {
+		timer = timer.NextTimerAt(timer.CurrentTime() + 16)
+		stage.Execute(nil, &timer)
+	}
+	err := stage.Runtime().DoString(`
+		assert(menu:component("quit") == nil)
+		assert(menu:component("play"):name() == "play")
+	`)
+	if err != nil {
+		t.Fatalf("DoString() error=%v", err)
+	}
+}
+
+func TestScriptStage_ForgetUserData(t *testing.T) {
+	stage := buildScriptStage(t, `
+		function initialize()
+			menu = scene.load("menu.json")
+			stage.addScene(menu)
+			play = menu:component("play")
+			handle = actions.run(actions.wait(10))
+		end
+	`, map[string]string{
+		"menu.json": `{"width": 320, "height": 200, "components": [{"type": "panel", "name": "play", "style": "button"}]}`,
+	})
+	runtime := stage.Runtime()
+	if len(runtime.userData) != 3 {
+		t.Fatalf("userData contains %d values, want scene, component and action handle", len(runtime.userData))
+	}
+	timer := engine.BuildTimerAt(0)
+	timer = timer.NextTimerAt(timer.CurrentTime() + 20)
+	stage.Execute(nil, &timer)
+	if err := runtime.DoString(`
+		assert(handle:isEnd())
+		assert(menu:component("play") == play)
+		stage.removeScene(menu)
+	`); err != nil {
+		t.Fatalf("DoString() error=%v", err)
+	}
+	if len(runtime.userData) != 0 {
+		t.Fatalf("userData contains %d values after action end and scene removal, want 0", len(runtime.userData))
+	}
+	// Une scène ajoutée à nouveau garde la même valeur Lua
+	if err := runtime.DoString(`
+		stage.addScene(menu)
+		menu:remove(menu:component("play"))
+	`); err != nil {
+		t.Fatalf("DoString() error=%v", err)
+	}
+	if userData := runtime.userData[runtime.Scenes()[0]]; len(runtime.userData) != 1 || userData != runtime.state.GetGlobal("menu") {
+		t.Fatalf("userData = %v after scene added again, want only menu scene", runtime.userData)
+	}
+}
@@ -21,6 +21,15 @@ func BuildTimer() Timer {
 	}
 }
 
+// BuildTimerAt construit un timer à partir du temps indiqué (en millisecondes)
+func BuildTimerAt(currentTime int64) Timer {
+	return Timer{
+		currentTime:  currentTime,
+		previousTime: currentTime,
+		elapsedTime:  0,
+	}
+}
+
 // NextTimer construit un timer à partir d'un ancien timer
 func (timer *Timer) NextTimer() Timer {
 	currentTime := time.Now().UnixMilli()
@@ -31,6 +40,15 @@ func (timer *Timer) NextTimer() Timer {
 	}
 }
 
+// NextTimerAt construit un timer à partir d'un ancien timer et du temps courant indiqué (en millisecondes)
+func (timer *Timer) NextTimerAt(currentTime int64) Timer {
+	return Timer{
+		currentTime:  currentTime,
+		previousTime: timer.currentTime,
+		elapsedTime:  currentTime - timer.currentTime,
+	}
+}
+
 // CurrentTime retourne le temps courant en millisecondes
 func (timer *Timer) CurrentTime() int64 {
 	return timer.currentTime